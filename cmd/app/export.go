@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/codec"
+)
+
+// runExport implements `goprod export`, which writes a Markdown vault to a
+// directory. Bookmarks are read from a running server, or from a file produced
+// by GET /export when -in is given.
+func runExport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	dir := fs.String("dir", "", "directory to write the vault to (required)")
+	layout := fs.String("layout", "bookmark", "one note per `bookmark` or per `tag`")
+	server := fs.String("server", "http://localhost:8080", "base URL of the goprod server to export from")
+	in := fs.String("in", "", "read bookmarks from an export file (json, ndjson, csv or xbel) instead of a server")
+	tag := fs.String("tag", "", "only export bookmarks with this tag")
+	query := fs.String("q", "", "only export bookmarks matching this text")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dir == "" {
+		fs.Usage()
+		return errors.New("-dir is required")
+	}
+
+	var vaultLayout codec.VaultLayout
+	switch *layout {
+	case "bookmark":
+		vaultLayout = codec.VaultPerBookmark
+	case "tag":
+		vaultLayout = codec.VaultPerTag
+	default:
+		return fmt.Errorf("unknown layout %q, want bookmark or tag", *layout)
+	}
+
+	var (
+		src io.ReadCloser
+		dec codec.Decoder
+		err error
+	)
+	if *in != "" {
+		src, dec, err = openExportFile(*in)
+	} else {
+		src, dec, err = fetchExport(ctx, *server, *tag, *query)
+	}
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	filter := domain.BookmarkFilter{Tag: *tag, Query: *query}
+	enc := codec.NewVaultEncoder(codec.DirSink{Dir: *dir}, vaultLayout)
+	count := 0
+	for {
+		b, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		// Files may hold more than the filters select; the server applies them itself.
+		if *in != "" && !filter.Matches(b) {
+			continue
+		}
+		if err := enc.Encode(b); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	slog.Info("Exported bookmarks", "count", count, "dir", *dir)
+	return nil
+}
+
+func openExportFile(path string) (io.ReadCloser, codec.Decoder, error) {
+	name := strings.TrimPrefix(filepath.Ext(path), ".")
+	format, ok := codec.Lookup(name)
+	if !ok || format.NewDecoder == nil {
+		return nil, nil, fmt.Errorf("cannot read %s: unsupported extension %q", path, name)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, format.NewDecoder(f), nil
+}
+
+func fetchExport(ctx context.Context, server, tag, query string) (io.ReadCloser, codec.Decoder, error) {
+	u, err := url.Parse(strings.TrimSuffix(server, "/") + "/export")
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid -server: %w", err)
+	}
+	q := u.Query()
+	q.Set("format", "ndjson")
+	if tag != "" {
+		q.Set("tag", tag)
+	}
+	if query != "" {
+		q.Set("q", query)
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, nil, fmt.Errorf("export from %s failed: %s: %s", server, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return resp.Body, codec.NewNDJSONDecoder(resp.Body), nil
+}
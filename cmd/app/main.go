@@ -2,58 +2,544 @@ package main
 
 import (
 	"context"
-	"fmt"
-	"log"
+	"database/sql"
+	"errors"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/archive"
+	"github.com/etsrc/goprod/internal/infra/blob"
 	"github.com/etsrc/goprod/internal/infra/config"
+	"github.com/etsrc/goprod/internal/infra/enrich"
+	"github.com/etsrc/goprod/internal/infra/eventbus"
+	"github.com/etsrc/goprod/internal/infra/favicon"
+	"github.com/etsrc/goprod/internal/infra/linkcheck"
+	"github.com/etsrc/goprod/internal/infra/logging"
+	"github.com/etsrc/goprod/internal/infra/metrics"
+	"github.com/etsrc/goprod/internal/infra/outbound"
+	"github.com/etsrc/goprod/internal/infra/persistence/filestore"
 	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/etsrc/goprod/internal/infra/persistence/postgres"
+	"github.com/etsrc/goprod/internal/infra/search"
+	"github.com/etsrc/goprod/internal/infra/thumbnail"
+	"github.com/etsrc/goprod/internal/infra/tracing"
 	"github.com/etsrc/goprod/internal/infra/transport/rest"
 	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/infra/webhook"
 	"github.com/etsrc/goprod/internal/service"
+	_ "github.com/lib/pq"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(context.Background(), os.Args[2:]); err != nil {
+			fatal("Export failed", err)
+		}
+		return
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("failed to load config: %v", err)
+		fatal("Failed to load config", err)
+	}
+	logger, err := logging.New(os.Stdout, logging.Options{Format: cfg.LogFormat, Level: cfg.LogLevel})
+	if err != nil {
+		fatal("Failed to configure logging", err)
+	}
+	slog.SetDefault(logger)
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter:    cfg.TracingExporter,
+		Endpoint:    cfg.TracingOTLPEndpoint,
+		Insecure:    cfg.TracingOTLPInsecure,
+		SampleRatio: cfg.TracingSampleRatio,
+		ServiceName: "goprod",
+		Stdout:      os.Stderr,
+	})
+	if err != nil {
+		fatal("Failed to configure tracing", err)
 	}
 
+	// Every write to the repository keeps the search index's metadata
+	// current; page content is indexed by the content service. Every write
+	// also stores its events in the repository's outbox, from which the
+	// relay publishes them on the bus.
+	searchIndex := search.NewIndex()
+	events := eventbus.New()
+	store := persistence.NewInMemoryBookmarkRepository()
+	relay := service.NewOutboxRelay(store, events, service.OutboxOptions{
+		PollInterval: cfg.OutboxPollInterval,
+		BatchSize:    cfg.OutboxBatchSize,
+	})
+	// Storage is timed beneath the search index, so that the timings are
+	// the store's and its outbox's alone.
+	registry := metrics.NewRegistry()
+	repoMetrics := metrics.NewRepositoryMetrics(registry)
+	registry.MustRegister(metrics.NewBookmarkCollector(store))
 	//lint:ignore SA1019
-	bookmarkRepo := persistence.NewInMemoryBookmarkRepository()
+	bookmarkRepo := service.WithSearchIndex(repoMetrics.WithBookmarkRepository(service.WithOutbox(store, relay)), searchIndex)
 	bookmarkService := service.NewBookmarkService(bookmarkRepo)
-	handler := rest.NewBookmarkHandler(bookmarkService)
+
+	// Every request to a bookmarked site goes through this client.
+	outboundClient, err := outbound.NewClient(outbound.Options{
+		Allow:           cfg.OutboundAllow,
+		Proxy:           cfg.OutboundProxy,
+		MaxBodyBytes:    cfg.OutboundMaxBytes,
+		MaxRedirects:    cfg.OutboundMaxRedirects,
+		MaxConnsPerHost: cfg.OutboundMaxConnsPerHost,
+		HostDelay:       cfg.OutboundHostDelay,
+	})
+	if err != nil {
+		fatal("Failed to create outbound client", err)
+	}
+
+	// The inspector reads both page metadata and readable text, sharing one
+	// robots.txt cache.
+	inspector := enrich.NewInspector(enrich.Options{
+		Client:          outboundClient,
+		UserAgent:       cfg.EnrichUserAgent,
+		Timeout:         cfg.EnrichTimeout,
+		MaxBytes:        cfg.EnrichMaxBytes,
+		MaxContentBytes: cfg.ContentMaxBytes,
+		RespectRobots:   cfg.EnrichRespectRobots,
+	})
+
+	var enrichService service.EnrichmentService
+	if cfg.EnrichEnabled {
+		enrichService = service.NewEnrichmentService(bookmarkRepo, inspector, service.EnrichmentOptions{
+			Workers:     cfg.EnrichWorkers,
+			QueueSize:   cfg.EnrichQueueSize,
+			MaxAttempts: cfg.EnrichMaxAttempts,
+			Backoff:     cfg.EnrichRetryBackoff,
+		})
+		bookmarkService = service.WithEnrichment(bookmarkService, enrichService)
+	}
+
+	var contentService service.ContentService
+	if cfg.ContentEnabled {
+		contentService = service.NewContentService(bookmarkRepo, inspector, searchIndex, service.ContentOptions{
+			Workers:   cfg.ContentWorkers,
+			QueueSize: cfg.ContentQueueSize,
+		})
+		bookmarkService = service.WithContentExtraction(bookmarkService, contentService)
+	}
+
+	var archiveService service.ArchiveService
+	if cfg.ArchiveDir != "" {
+		archiver, err := archive.NewArchiver(archive.Options{
+			Dir:       cfg.ArchiveDir,
+			Client:    outboundClient,
+			UserAgent: cfg.ArchiveUserAgent,
+			Timeout:   cfg.ArchiveTimeout,
+			MaxAssets: cfg.ArchiveMaxAssets,
+			MaxBytes:  cfg.ArchiveMaxBytes,
+		})
+		if err != nil {
+			fatal("Failed to open archive directory", err)
+		}
+		archiveService = service.NewArchiveService(bookmarkRepo, archiver, service.ArchiveOptions{
+			Workers:   cfg.ArchiveWorkers,
+			QueueSize: cfg.ArchiveQueueSize,
+		})
+		bookmarkService = service.WithArchiving(bookmarkService, archiveService, domain.ArchivePolicy{Tags: cfg.ArchiveTags})
+	}
+
+	blobStore, err := newBlobStore(cfg)
+	if err != nil {
+		fatal("Failed to open blob store", err)
+	}
+
+	// Attachments need somewhere to keep their content. Garbage collection
+	// asks every referrer of blobs which ones are still in use.
+	var attachmentService service.AttachmentService
+	var blobGCService service.BlobGCService
+	if blobStore != nil {
+		attachmentRepo := persistence.NewInMemoryAttachmentRepository()
+		attachmentService = service.NewAttachmentService(bookmarkRepo, attachmentRepo, blobStore, thumbnail.New(thumbnail.Options{
+			MaxSize:  cfg.AttachmentThumbnailSize,
+			MaxBytes: cfg.AttachmentMaxBytes,
+		}), service.AttachmentOptions{
+			MaxBytes:     cfg.AttachmentMaxBytes,
+			QuotaBytes:   cfg.AttachmentQuotaBytes,
+			TotalBytes:   cfg.AttachmentTotalBytes,
+			AllowedTypes: cfg.AttachmentAllowedTypes,
+		})
+		bookmarkService = service.WithAttachments(bookmarkService, attachmentService)
+		blobGCService = service.NewBlobGCService(blobStore, []domain.BlobReferrer{attachmentRepo}, service.BlobGCOptions{
+			Interval: cfg.BlobGCInterval,
+			Grace:    cfg.BlobGCGrace,
+		})
+	}
+
+	var faviconService service.FaviconService
+	if cfg.FaviconEnabled {
+		faviconStore, err := newFaviconStore(cfg)
+		if err != nil {
+			fatal("Failed to open favicon cache", err)
+		}
+		faviconService = service.NewFaviconService(favicon.NewFetcher(favicon.Options{
+			Client:    outboundClient,
+			UserAgent: cfg.FaviconUserAgent,
+			Timeout:   cfg.FaviconTimeout,
+			MaxBytes:  cfg.FaviconMaxBytes,
+		}), faviconStore, service.FaviconOptions{
+			TTL:        cfg.FaviconTTL,
+			RetryAfter: cfg.FaviconRetryAfter,
+		})
+	}
+
+	// Webhooks get their own client: their URLs are set by API users rather
+	// than found in bookmarks, and they have their own allowlist.
+	var webhookService service.WebhookService
+	if cfg.WebhooksEnabled {
+		webhookClient, err := outbound.NewClient(outbound.Options{
+			Allow:           cfg.WebhookAllow,
+			Proxy:           cfg.OutboundProxy,
+			MaxBodyBytes:    64 << 10,
+			MaxConnsPerHost: cfg.WebhookWorkers,
+		})
+		if err != nil {
+			fatal("Failed to create webhook client", err)
+		}
+		webhookService = service.NewWebhookService(
+			persistence.NewInMemoryWebhookRepository(),
+			persistence.NewInMemoryWebhookDeliveryRepository(),
+			webhook.NewSender(webhook.Options{Client: webhookClient, Timeout: cfg.WebhookTimeout}),
+			service.WebhookOptions{
+				Workers:       cfg.WebhookWorkers,
+				MaxAttempts:   cfg.WebhookMaxAttempts,
+				RetryDelay:    cfg.WebhookRetryDelay,
+				MaxRetryDelay: cfg.WebhookMaxRetryDelay,
+				DisableAfter:  cfg.WebhookDisableAfter,
+				History:       cfg.WebhookHistory,
+			})
+		events.SubscribeAsync("webhooks", webhookService.Handle, eventbus.AsyncOptions{})
+	}
+
+	var streamService service.StreamService
+	if cfg.StreamEnabled {
+		streamService = service.NewStreamService(service.StreamOptions{
+			ReplaySize:   cfg.StreamReplaySize,
+			ClientBuffer: cfg.StreamClientBuffer,
+		})
+		// One worker keeps the stream in the order changes were made.
+		events.SubscribeAsync("stream", streamService.Handle, eventbus.AsyncOptions{})
+	}
+	streamGrants := make(map[string]service.StreamGrant, len(cfg.StreamTokens))
+	for token, tags := range cfg.StreamTokens {
+		streamGrants[token] = service.StreamGrant{Tags: tags}
+	}
+	streamHandler := rest.NewStreamHandler(streamService, rest.StreamOptions{
+		Tokens:    streamGrants,
+		Heartbeat: cfg.StreamHeartbeat,
+	})
+
+	// Revisions are numbered in the order changes were made, so they are
+	// recorded by a synchronous subscriber.
+	var revisionService service.RevisionService
+	if cfg.RevisionsEnabled {
+		revisionService = service.NewRevisionService(bookmarkRepo, persistence.NewInMemoryRevisionRepository(), service.RevisionOptions{
+			Keep:          cfg.RevisionsKeep,
+			MaxAge:        cfg.RevisionsMaxAge,
+			PruneInterval: cfg.RevisionsPruneInterval,
+		})
+		events.Subscribe("revisions", revisionService.Handle)
+		bookmarkService = service.WithRevisions(bookmarkService, revisionService)
+	}
+
+	// The trash is emptied through the decorated service, so that purged
+	// bookmarks lose their attachments and archives.
+	trashService := service.NewTrashService(bookmarkService, service.TrashOptions{
+		Retention: cfg.TrashRetention,
+		Interval:  cfg.TrashPurgeInterval,
+	})
+
+	// The change log must see events in the order they were published, so
+	// it is a synchronous subscriber.
+	var syncService service.SyncService
+	if cfg.SyncEnabled {
+		syncService = service.NewSyncService(bookmarkRepo, bookmarkService, persistence.NewInMemoryChangeLog(), service.SyncOptions{
+			PageSize:     cfg.SyncPageSize,
+			MaxPush:      cfg.SyncMaxPush,
+			TombstoneTTL: cfg.SyncTombstoneTTL,
+		})
+		events.Subscribe("sync", syncService.Handle)
+	}
+
+	// The link health report works from stored results, so the service is
+	// built even when scheduled checking is off.
+	linkCheckService := service.NewLinkCheckService(bookmarkRepo, linkcheck.NewChecker(linkcheck.Options{
+		Client:    outboundClient,
+		UserAgent: cfg.LinkCheckUserAgent,
+		Timeout:   cfg.LinkCheckTimeout,
+	}), service.LinkCheckOptions{
+		Interval:                 cfg.LinkCheckInterval,
+		Workers:                  cfg.LinkCheckWorkers,
+		BrokenAfter:              cfg.LinkCheckBrokenAfter,
+		FollowPermanentRedirects: cfg.LinkCheckFollowRedirects,
+	})
+
+	importJobs, err := newImportJobRepository(cfg)
+	if err != nil {
+		fatal("Failed to open import job store", err)
+	}
+	importService := service.NewImportService(bookmarkRepo, importJobs, service.ImportOptions{
+		BatchSize: cfg.ImportBatchSize,
+		Workers:   cfg.ImportWorkers,
+	})
+
+	// Every component the server cannot do without is registered, so that
+	// the server stops being ready when one fails.
+	healthService := service.NewHealthService(service.HealthOptions{Timeout: cfg.HealthCheckTimeout})
+	healthService.Register("bookmark repository", store)
+
+	var auditService service.AuditService
+	if cfg.AuditEnabled {
+		auditLog, err := newAuditLog(cfg)
+		if err != nil {
+			fatal("Failed to open audit log", err)
+		}
+		if checker, ok := auditLog.(domain.HealthChecker); ok {
+			healthService.Register("audit log", checker)
+		}
+		auditService = service.NewAuditService(auditLog, service.AuditOptions{})
+	}
+	auditHandler := rest.NewAuditHandler(auditService, rest.AuditOptions{
+		AdminTokens:    cfg.AdminTokens,
+		TrustedProxies: cfg.TrustedProxies,
+	})
+
+	handler := &rest.Server{
+		BookmarkHandler: rest.NewBookmarkHandler(bookmarkService),
+		ImportHandler:   rest.NewImportHandler(importService, cfg.ImportMaxBodyBytes),
+		ExportHandler:   rest.NewExportHandler(bookmarkService),
+		FeedHandler: rest.NewFeedHandler(bookmarkService, rest.FeedOptions{
+			PrivateTags: cfg.FeedPrivateTags,
+			Tokens:      cfg.FeedTokens,
+			Limit:       cfg.FeedLimit,
+			MaxLimit:    cfg.FeedMaxLimit,
+			BaseURL:     cfg.PublicURL,
+		}),
+		LinkHealthHandler: rest.NewLinkHealthHandler(linkCheckService),
+		ArchiveHandler:    rest.NewArchiveHandler(archiveService),
+		SearchHandler:     rest.NewSearchHandler(service.NewSearchService(bookmarkRepo, searchIndex)),
+		AttachmentHandler: rest.NewAttachmentHandler(attachmentService),
+		FaviconHandler:    rest.NewFaviconHandler(faviconService),
+		WebhookHandler:    rest.NewWebhookHandler(webhookService),
+		StreamHandler:     streamHandler,
+		SyncHandler:       rest.NewSyncHandler(syncService),
+		TrashHandler:      rest.NewTrashHandler(bookmarkService),
+		RevisionHandler:   rest.NewRevisionHandler(revisionService),
+		AuditHandler:      auditHandler,
+	}
 
 	mux := http.NewServeMux()
-	gen.HandlerFromMux(handler, mux)
+	httpMetrics := rest.NewHTTPMetrics(registry)
+	gen.HandlerWithOptions(handler, gen.StdHTTPServerOptions{
+		BaseRouter: httpMetrics.Router(mux),
+		// The last middleware runs first: requests are timed whole, and
+		// audited once logging has given them an ID.
+		Middlewares: []gen.MiddlewareFunc{auditHandler.Record, httpMetrics.Middleware},
+	})
+	if cfg.MetricsEnabled {
+		mux.Handle("GET /metrics", metrics.Handler(registry))
+	}
+	healthHandler := rest.NewHealthHandler(healthService)
+	mux.HandleFunc("GET /livez", healthHandler.Live)
+	mux.HandleFunc("GET /readyz", healthHandler.Ready)
+	mux.HandleFunc("GET /healthz", healthHandler.Health)
 
 	server := &http.Server{
-		Addr:              cfg.HTTPAddr,
-		Handler:           mux,
+		Addr: cfg.HTTPAddr,
+		// Every request, the probes and /metrics included, is traced,
+		// authenticated and logged from before it is routed, so that the
+		// trace ID, actor and request ID are on every line logged for it.
+		Handler:           rest.TraceRequests(rest.Authenticate(cfg.AdminTokens)(rest.IdentifyActor(rest.LogRequests(mux)))),
 		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ErrorLog:          slog.NewLogLogger(logger.Handler(), slog.LevelWarn),
+	}
+	server.RegisterOnShutdown(streamHandler.Shutdown)
+
+	// Background workers run until workersCtx is canceled during shutdown.
+	// The changes they make are attributed to "system".
+	workersCtx, stopWorkers := context.WithCancel(domain.WithActor(context.Background(), "system"))
+	var workers sync.WaitGroup
+	workers.Go(func() {
+		if err := events.Run(workersCtx); err != nil {
+			slog.Error("Worker stopped", "worker", "event bus", "error", err)
+		}
+	})
+	workers.Go(func() {
+		if err := relay.Run(workersCtx); err != nil {
+			slog.Error("Worker stopped", "worker", "outbox relay", "error", err)
+		}
+	})
+	workers.Go(func() {
+		if err := importService.Run(workersCtx); err != nil {
+			slog.Error("Worker stopped", "worker", "import", "error", err)
+		}
+	})
+	workers.Go(func() {
+		if err := trashService.Run(workersCtx); err != nil {
+			slog.Error("Worker stopped", "worker", "trash", "error", err)
+		}
+	})
+	if enrichService != nil {
+		workers.Go(func() {
+			if err := enrichService.Run(workersCtx); err != nil {
+				slog.Error("Worker stopped", "worker", "enrichment", "error", err)
+			}
+		})
+	}
+	if contentService != nil {
+		workers.Go(func() {
+			if err := contentService.Run(workersCtx); err != nil {
+				slog.Error("Worker stopped", "worker", "content", "error", err)
+			}
+		})
+	}
+	if archiveService != nil {
+		workers.Go(func() {
+			if err := archiveService.Run(workersCtx); err != nil {
+				slog.Error("Worker stopped", "worker", "archive", "error", err)
+			}
+		})
+	}
+	if blobGCService != nil {
+		workers.Go(func() {
+			if err := blobGCService.Run(workersCtx); err != nil {
+				slog.Error("Worker stopped", "worker", "blob gc", "error", err)
+			}
+		})
+	}
+	if webhookService != nil {
+		workers.Go(func() {
+			if err := webhookService.Run(workersCtx); err != nil {
+				slog.Error("Worker stopped", "worker", "webhook", "error", err)
+			}
+		})
+	}
+	if syncService != nil {
+		workers.Go(func() {
+			if err := syncService.Run(workersCtx); err != nil {
+				slog.Error("Worker stopped", "worker", "sync", "error", err)
+			}
+		})
+	}
+	if revisionService != nil {
+		workers.Go(func() {
+			if err := revisionService.Run(workersCtx); err != nil {
+				slog.Error("Worker stopped", "worker", "revision", "error", err)
+			}
+		})
+	}
+	if cfg.LinkCheckEnabled {
+		workers.Go(func() {
+			if err := linkCheckService.Run(workersCtx); err != nil {
+				slog.Error("Worker stopped", "worker", "link check", "error", err)
+			}
+		})
 	}
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
 	go func() {
-		fmt.Printf("🚀 Server starting on http://localhost%s\n", cfg.HTTPAddr)
+		slog.Info("Server starting", "addr", cfg.HTTPAddr)
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("could not listen on %s: %v\n", server.Addr, err)
+			fatal("Could not listen", err)
 		}
 	}()
 
 	<-stop
-	fmt.Println("\nRestoring peace and quiet... (Shutting down)")
+	// Requests keep being served while /readyz fails, until load balancers
+	// have stopped sending them.
+	healthService.Drain()
+	slog.Info("Draining", "delay", cfg.ShutdownDrainDelay.String())
+	time.Sleep(cfg.ShutdownDrainDelay)
+
+	slog.Info("Shutting down")
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 
 	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		fatal("Server forced to shutdown", err)
 	}
 
-	fmt.Println("✅ Server exited properly")
+	stopWorkers()
+	workers.Wait()
+
+	// Spans still buffered are sent before exiting.
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Warn("Failed to flush traces", "error", err)
+	}
+
+	slog.Info("Server exited")
+}
+
+// fatal logs err and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// newImportJobRepository keeps import jobs on disk when a state directory is
+// configured so that they resume after a restart.
+func newImportJobRepository(cfg *config.Config) (domain.ImportJobRepository, error) {
+	if cfg.ImportStateDir == "" {
+		return persistence.NewInMemoryImportJobRepository(), nil
+	}
+	return filestore.NewImportJobRepository(cfg.ImportStateDir)
+}
+
+// newAuditLog keeps the audit log in Postgres when a database is configured,
+// so that it outlives the process.
+func newAuditLog(cfg *config.Config) (domain.AuditLog, error) {
+	if cfg.AuditDatabaseURL == "" {
+		return persistence.NewInMemoryAuditLog(), nil
+	}
+	db, err := sql.Open("postgres", cfg.AuditDatabaseURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+	return postgres.NewAuditLog(db), nil
+}
+
+// newFaviconStore caches icons on disk when a directory is configured so that
+// they are not fetched again after a restart.
+func newFaviconStore(cfg *config.Config) (domain.FaviconStore, error) {
+	if cfg.FaviconDir == "" {
+		return persistence.NewInMemoryFaviconStore(), nil
+	}
+	return filestore.NewFaviconStore(cfg.FaviconDir)
+}
+
+// newBlobStore opens the configured blob store, or returns nil when none is
+// configured.
+func newBlobStore(cfg *config.Config) (domain.BlobStore, error) {
+	switch {
+	case cfg.BlobDir != "" && cfg.BlobS3Bucket != "":
+		return nil, errors.New("set either BLOB_DIR or BLOB_S3_BUCKET, not both")
+	case cfg.BlobDir != "":
+		return blob.NewFileStore(cfg.BlobDir)
+	case cfg.BlobS3Bucket != "":
+		return blob.NewS3Store(blob.S3Options{
+			Endpoint:        cfg.BlobS3Endpoint,
+			Region:          cfg.BlobS3Region,
+			Bucket:          cfg.BlobS3Bucket,
+			Prefix:          cfg.BlobS3Prefix,
+			AccessKeyID:     cfg.BlobS3AccessKeyID,
+			SecretAccessKey: cfg.BlobS3SecretAccessKey,
+			PathStyle:       cfg.BlobS3PathStyle,
+		})
+	}
+	return nil, nil
 }
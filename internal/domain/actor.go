@@ -0,0 +1,33 @@
+package domain
+
+import "context"
+
+type actorKey struct{}
+
+// WithActor returns a context in which changes are attributed to actor, such
+// as a user name or "system" for background work.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns who ctx's changes are attributed to, or "" when unknown.
+func ActorFrom(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context for a request that proved, with a
+// credential, to be principal. Unlike an actor, which callers merely name,
+// a principal can be trusted.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom returns who ctx's request authenticated as, or "" when it
+// presented no valid credential.
+func PrincipalFrom(ctx context.Context) string {
+	principal, _ := ctx.Value(principalKey{}).(string)
+	return principal
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// PageArchiver captures a bookmark's page with its assets and reads the
+// captured resources back.
+type PageArchiver interface {
+	// Capture replaces the archive of bookmark id with a fresh capture of url.
+	Capture(ctx context.Context, id, url string) (*ArchiveInfo, error)
+	// Resource returns one captured resource by its original URL, or the page
+	// itself when url is empty. It fails with ErrArchiveNotFound.
+	Resource(ctx context.Context, id, url string) (*ArchivedResource, error)
+	Delete(ctx context.Context, id string) error
+}
+
+var ErrArchiveNotFound = errors.New("archive not found")
+
+// ArchiveInfo describes the latest capture of a bookmark's page.
+type ArchiveInfo struct {
+	CapturedAt time.Time `json:"captured_at"`
+	// Resources counts the page and the assets captured with it.
+	Resources int   `json:"resources"`
+	Size      int64 `json:"size"` // bytes on disk
+	// Error says why the latest capture failed; an earlier capture, if any,
+	// is kept.
+	Error string `json:"error,omitempty"`
+}
+
+// ArchivedResource is one response as it was captured.
+type ArchivedResource struct {
+	URL         string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// ArchiveMode is a bookmark's own archiving preference, which overrides the
+// tag policy.
+type ArchiveMode string
+
+const (
+	ArchiveDefault ArchiveMode = ""
+	ArchiveAlways  ArchiveMode = "always"
+	ArchiveNever   ArchiveMode = "never"
+)
+
+// ArchivePolicy decides which new bookmarks are archived automatically.
+type ArchivePolicy struct {
+	// Tags archives bookmarks carrying any of these tags; "*" matches all.
+	Tags []string
+}
+
+// Applies reports whether b should be archived when it is created.
+func (p ArchivePolicy) Applies(b *Bookmark) bool {
+	switch b.ArchiveMode {
+	case ArchiveAlways:
+		return true
+	case ArchiveNever:
+		return false
+	}
+	if slices.Contains(p.Tags, "*") {
+		return true
+	}
+	for _, tag := range b.Tags {
+		if slices.Contains(p.Tags, tag) {
+			return true
+		}
+	}
+	return false
+}
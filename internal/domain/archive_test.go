@@ -0,0 +1,32 @@
+package domain
+
+import "testing"
+
+func TestArchivePolicy_Applies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy ArchivePolicy
+		b      *Bookmark
+		want   bool
+	}{
+		{"No Policy", ArchivePolicy{}, &Bookmark{Tags: []string{"go"}}, false},
+		{"Tag Matches", ArchivePolicy{Tags: []string{"reference"}}, &Bookmark{Tags: []string{"go", "reference"}}, true},
+		{"Tag Does Not Match", ArchivePolicy{Tags: []string{"reference"}}, &Bookmark{Tags: []string{"go"}}, false},
+		{"Wildcard", ArchivePolicy{Tags: []string{"*"}}, &Bookmark{}, true},
+		{"Bookmark Always", ArchivePolicy{}, &Bookmark{ArchiveMode: ArchiveAlways}, true},
+		{"Bookmark Never Beats Tag", ArchivePolicy{Tags: []string{"*"}}, &Bookmark{ArchiveMode: ArchiveNever}, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.policy.Applies(tt.b); got != tt.want {
+				t.Errorf("Applies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"image"
+	"io"
+	"time"
+)
+
+// Attachment is a file kept with a bookmark, such as the PDF of a paper. Its
+// content is a blob in the BlobStore.
+type Attachment struct {
+	ID          string    `json:"id"`
+	BookmarkID  string    `json:"bookmark_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	BlobKey     string    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+	// Owner is the principal who uploaded the attachment, or "anonymous".
+	// Its size counts against their quota.
+	Owner string `json:"owner"`
+
+	// Thumbnail is a small preview of an image, nil for other files.
+	Thumbnail *Thumbnail `json:"thumbnail,omitempty"`
+}
+
+type Thumbnail struct {
+	BlobKey     string `json:"-"`
+	ContentType string `json:"content_type"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+}
+
+// Clone returns a deep copy of a.
+func (a *Attachment) Clone() *Attachment {
+	c := *a
+	if a.Thumbnail != nil {
+		thumb := *a.Thumbnail
+		c.Thumbnail = &thumb
+	}
+	return &c
+}
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, a *Attachment) error
+	// GetByID fails with ErrAttachmentNotFound unless the attachment belongs
+	// to the bookmark.
+	GetByID(ctx context.Context, bookmarkID, id string) (*Attachment, error)
+	// ListByBookmark returns a bookmark's attachments, oldest first.
+	ListByBookmark(ctx context.Context, bookmarkID string) ([]*Attachment, error)
+	Delete(ctx context.Context, bookmarkID, id string) error
+	// DeleteByBookmark removes all of a bookmark's attachments.
+	DeleteByBookmark(ctx context.Context, bookmarkID string) error
+	// SizeByOwner is the sum of the sizes of an owner's attachments.
+	SizeByOwner(ctx context.Context, owner string) (int64, error)
+	// TotalSize is the sum of the sizes of all attachments.
+	TotalSize(ctx context.Context) (int64, error)
+	// Attachments refer to their content and thumbnail blobs.
+	BlobReferrer
+}
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+	ErrQuotaExceeded      = errors.New("attachment quota exceeded")
+	ErrContentTypeDenied  = errors.New("content type is not allowed")
+)
+
+// Thumbnailer makes previews of images.
+type Thumbnailer interface {
+	// Thumbnail writes a preview of the image read from r to w, fitting
+	// within the thumbnailer's bounds, and returns the preview's content type
+	// and size. It fails with ErrNotAnImage when r holds no image it can
+	// decode.
+	Thumbnail(ctx context.Context, w io.Writer, r io.Reader) (contentType string, size image.Point, err error)
+}
+
+var ErrNotAnImage = errors.New("not a supported image")
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuditOutcome is how an audited operation ended.
+type AuditOutcome string
+
+const (
+	AuditSuccess AuditOutcome = "success"
+	AuditFailure AuditOutcome = "failure"
+	// AuditDenied operations were refused for lack of valid credentials.
+	AuditDenied AuditOutcome = "denied"
+)
+
+// AuditEntry records who did what, when and from where, and how it ended.
+type AuditEntry struct {
+	// Seq numbers the entries from 1 without gaps. The log sets it.
+	Seq int64     `json:"seq"`
+	At  time.Time `json:"at"`
+	// Actor is who the request authenticated as. AssertedActor is who it
+	// claimed to be, unverified, and is never to be taken for Actor.
+	Actor         string       `json:"actor"`
+	AssertedActor string       `json:"asserted_actor,omitempty"`
+	Action        string       `json:"action"`
+	Target        string       `json:"target"`
+	RequestID     string       `json:"request_id,omitempty"`
+	SourceIP      string       `json:"source_ip"`
+	UserAgent     string       `json:"user_agent,omitempty"`
+	Outcome       AuditOutcome `json:"outcome"`
+	Status        int          `json:"status"`
+	// PrevHash is the Hash of the entry before, and Hash covers this entry
+	// and PrevHash, so that changing or removing an entry breaks the chain.
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+}
+
+// Seal chains e to the entry before it, whose Hash is prev.
+func (e *AuditEntry) Seal(prev string) {
+	e.PrevHash = prev
+	e.Hash = e.Digest()
+}
+
+// Digest hashes every field of e but Hash. At is hashed to the microsecond,
+// the precision SQL databases keep.
+func (e *AuditEntry) Digest() string {
+	fields := []string{
+		strconv.FormatInt(e.Seq, 10),
+		e.At.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+		e.Actor,
+		e.AssertedActor,
+		e.Action,
+		e.Target,
+		e.RequestID,
+		e.SourceIP,
+		e.UserAgent,
+		string(e.Outcome),
+		strconv.Itoa(e.Status),
+		e.PrevHash,
+	}
+	for i, f := range fields {
+		fields[i] = strconv.Quote(f)
+	}
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditFilter selects audit entries. Zero fields match every entry.
+type AuditFilter struct {
+	Actor         string
+	AssertedActor string
+	Action        string
+	Target        string
+	Outcome       AuditOutcome
+	Since         time.Time // entries at or after
+	Until         time.Time // entries before
+	// Before is a Seq: only older entries match. It pages back through the
+	// log.
+	Before int64
+	Limit  int
+}
+
+// Matches reports whether e passes every condition of f but Limit.
+func (f AuditFilter) Matches(e *AuditEntry) bool {
+	return (f.Actor == "" || e.Actor == f.Actor) &&
+		(f.AssertedActor == "" || e.AssertedActor == f.AssertedActor) &&
+		(f.Action == "" || e.Action == f.Action) &&
+		(f.Target == "" || e.Target == f.Target) &&
+		(f.Outcome == "" || e.Outcome == f.Outcome) &&
+		(f.Since.IsZero() || !e.At.Before(f.Since)) &&
+		(f.Until.IsZero() || e.At.Before(f.Until)) &&
+		(f.Before == 0 || e.Seq < f.Before)
+}
+
+// AuditPage is a page of audit entries, newest first.
+type AuditPage struct {
+	Entries []*AuditEntry `json:"entries"`
+	// Next is passed as before to get the following page, or 0 on the last
+	// page.
+	Next int64 `json:"next,omitempty"`
+}
+
+// AuditVerification is the result of checking the audit log's hash chain.
+type AuditVerification struct {
+	Valid   bool `json:"valid"`
+	Checked int  `json:"checked"`
+	// BrokenAt is the Seq of the first entry that does not fit the chain:
+	// it was changed, or entries before it were removed.
+	BrokenAt int64 `json:"broken_at,omitempty"`
+}
+
+// AuditLog stores audit entries. Entries are only ever appended.
+type AuditLog interface {
+	// Append stores e as the newest entry, setting its Seq and sealing it to
+	// the entry before. Appends are atomic with respect to each other.
+	Append(ctx context.Context, e *AuditEntry) error
+	// Query returns the entries matching filter, newest first, at most
+	// filter.Limit of them.
+	Query(ctx context.Context, filter AuditFilter) ([]*AuditEntry, error)
+	// Range returns up to limit entries after the one with Seq after, oldest
+	// first.
+	Range(ctx context.Context, after int64, limit int) ([]*AuditEntry, error)
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// BlobStore keeps binary content under the SHA-256 of its bytes, so the same
+// content is stored once however often it is put.
+type BlobStore interface {
+	// Put stores everything read from r and returns its key. Putting content
+	// that is already stored refreshes its modification time.
+	Put(ctx context.Context, r io.Reader) (BlobInfo, error)
+	// Get streams a whole blob. The caller closes it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// GetRange streams length bytes of a blob from offset, or up to its end
+	// when length is negative; a range running past the end is cut short. It
+	// fails with ErrInvalidRange unless offset is within the blob and length
+	// is not zero.
+	GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	Stat(ctx context.Context, key string) (BlobInfo, error)
+	// Delete removes a blob. Deleting a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+	// Walk calls fn for every stored blob, in no particular order. It stops
+	// at the first error fn returns.
+	Walk(ctx context.Context, fn func(BlobInfo) error) error
+}
+
+var (
+	ErrBlobNotFound   = errors.New("blob not found")
+	ErrInvalidBlobKey = errors.New("invalid blob key")
+	ErrInvalidRange   = errors.New("range not satisfiable")
+)
+
+type BlobInfo struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// ValidBlobKey reports whether key is a hex SHA-256 digest, as BlobStore keys
+// are. Adapters check it before using a key in a path or URL.
+func ValidBlobKey(key string) bool {
+	if len(key) != 64 {
+		return false
+	}
+	for _, c := range key {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// BlobReferrer is implemented by whatever records blob keys, such as
+// attachments, so that garbage collection knows which blobs are in use.
+type BlobReferrer interface {
+	// ReferencedBlobs calls fn with the key of every blob in use.
+	ReferencedBlobs(ctx context.Context, fn func(key string) error) error
+}
+
+// BlobGCRun summarizes one garbage collection pass over a BlobStore.
+type BlobGCRun struct {
+	Scanned    int   `json:"scanned"`
+	Deleted    int   `json:"deleted"`
+	FreedBytes int64 `json:"freed_bytes"`
+}
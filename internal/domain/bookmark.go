@@ -3,20 +3,48 @@ package domain
 import (
 	"context"
 	"errors"
+	"maps"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 )
 
 type BookmarkRepository interface {
 	Create(ctx context.Context, b *Bookmark) error
+	CreateBatch(ctx context.Context, bs []*Bookmark) error
 	GetByID(ctx context.Context, id string) (*Bookmark, error)
 	GetAll(ctx context.Context) ([]*Bookmark, error)
+	// Walk calls fn for every bookmark matching filter, oldest first, without
+	// building the full result set. It stops at the first error fn returns.
+	Walk(ctx context.Context, filter BookmarkFilter, fn func(*Bookmark) error) error
+	// Update replaces a stored bookmark. Callers pass a modified Clone rather
+	// than mutating the value they read, which other readers may still hold.
+	// Create and CreateBatch set Version to 1, and Update to one more than
+	// the stored bookmark's.
+	Update(ctx context.Context, b *Bookmark) error
+	// CompareAndUpdate is Update for a bookmark whose stored Version is
+	// still version, failing with ErrVersionConflict when someone else
+	// wrote it since it was read.
+	CompareAndUpdate(ctx context.Context, b *Bookmark, version int64) error
+	// Delete moves a bookmark to the trash. It is kept with DeletedAt set,
+	// and every other method but the trash ones acts as if it were gone.
 	Delete(ctx context.Context, id string) error
+	// CompareAndDelete is Delete for a bookmark whose stored Version is
+	// still version, failing with ErrVersionConflict otherwise.
+	CompareAndDelete(ctx context.Context, id string, version int64) error
+	// Trash lists the deleted bookmarks, most recently deleted first.
+	Trash(ctx context.Context) ([]*Bookmark, error)
+	// Restore takes a bookmark out of the trash, as the next version.
+	Restore(ctx context.Context, id string) error
+	// Purge removes a bookmark in the trash for good.
+	Purge(ctx context.Context, id string) error
 }
 
 var (
 	ErrBookmarkNotFound = errors.New("bookmark not found")
+	ErrBookmarkExists   = errors.New("bookmark already exists")
+	ErrVersionConflict  = errors.New("bookmark has changed since it was read")
 	ErrInvalidURL       = errors.New("the provided URL is invalid")
 	ErrTitleTooShort    = errors.New("title must be at least 3 characters")
 )
@@ -29,8 +57,42 @@ type Bookmark struct {
 	Tags        []string  `json:"tags"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	// Version counts the writes to the bookmark. The repository sets it.
+	Version int64 `json:"version,omitempty"`
+
+	// Metadata holds facts about the page that have no field of their own,
+	// keyed by the Meta* constants.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Health is the result of the latest link check, nil before the first.
+	Health *LinkHealth `json:"health,omitempty"`
+
+	// ArchiveMode overrides the tag policy for archiving this bookmark, and
+	// Archive describes its latest capture.
+	ArchiveMode ArchiveMode  `json:"archive_mode,omitempty"`
+	Archive     *ArchiveInfo `json:"archive,omitempty"`
+
+	// Content describes the readable text last extracted from the page.
+	Content *ContentInfo `json:"content,omitempty"`
+
+	// DeletedAt is set while the bookmark is in the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
+// Well-known Metadata keys. OpenGraph and Twitter card fields found while
+// enriching are stored under their own names, e.g. "og:type".
+const (
+	MetaAuthor    = "author"
+	MetaPublisher = "publisher"
+	MetaCanonical = "canonical"
+	MetaLanguage  = "lang"
+	MetaImage     = "image"
+	// MetaEnrichedAt records when page metadata was last fetched, and
+	// MetaEnrichError why the last attempt failed.
+	MetaEnrichedAt  = "enriched_at"
+	MetaEnrichError = "enrich_error"
+)
+
 func NewBookmark(url, title, description string, tags []string) *Bookmark {
 	return &Bookmark{
 		URL:         url,
@@ -42,6 +104,86 @@ func NewBookmark(url, title, description string, tags []string) *Bookmark {
 	}
 }
 
+// Clone returns a deep copy of b.
+func (b *Bookmark) Clone() *Bookmark {
+	c := *b
+	c.Tags = slices.Clone(b.Tags)
+	c.Metadata = maps.Clone(b.Metadata)
+	if b.Health != nil {
+		health := *b.Health
+		c.Health = &health
+	}
+	if b.Archive != nil {
+		archive := *b.Archive
+		c.Archive = &archive
+	}
+	if b.Content != nil {
+		content := *b.Content
+		c.Content = &content
+	}
+	if b.DeletedAt != nil {
+		deletedAt := *b.DeletedAt
+		c.DeletedAt = &deletedAt
+	}
+	return &c
+}
+
+// HasPlaceholderTitle reports whether the title says nothing the URL does not,
+// as when a bookmark is saved with its address pasted as the title.
+func (b *Bookmark) HasPlaceholderTitle() bool {
+	title := strings.TrimSpace(b.Title)
+	if title == "" || title == b.URL {
+		return true
+	}
+	u, err := url.Parse(b.URL)
+	if err != nil {
+		return false
+	}
+	bare := strings.TrimSuffix(u.Host+u.RequestURI(), "/")
+	return strings.TrimSuffix(title, "/") == bare || title == u.Hostname()
+}
+
+// BookmarkFilter narrows listings and exports. The zero value matches everything.
+type BookmarkFilter struct {
+	IDs    []string   // only these bookmarks
+	Tag    string     // exact tag match
+	Query  string     // case-insensitive substring of the title, URL or description
+	Health LinkStatus // status of the latest link check
+	Host   string     // host name of the URL, case-insensitive
+	// ExcludeTags leaves out bookmarks with any of these tags.
+	ExcludeTags []string
+}
+
+func (f BookmarkFilter) Matches(b *Bookmark) bool {
+	if len(f.IDs) > 0 && !slices.Contains(f.IDs, b.ID) {
+		return false
+	}
+	if f.Tag != "" && !slices.Contains(b.Tags, f.Tag) {
+		return false
+	}
+	if slices.ContainsFunc(b.Tags, func(t string) bool { return slices.Contains(f.ExcludeTags, t) }) {
+		return false
+	}
+	if f.Health != "" && b.LinkStatus() != f.Health {
+		return false
+	}
+	if f.Host != "" {
+		u, err := url.Parse(b.URL)
+		if err != nil || !strings.EqualFold(u.Hostname(), f.Host) {
+			return false
+		}
+	}
+	if f.Query != "" {
+		q := strings.ToLower(f.Query)
+		if !strings.Contains(strings.ToLower(b.Title), q) &&
+			!strings.Contains(strings.ToLower(b.URL), q) &&
+			!strings.Contains(strings.ToLower(b.Description), q) {
+			return false
+		}
+	}
+	return true
+}
+
 func (b *Bookmark) Validate() error {
 	if b.Title == "" || len(strings.TrimSpace(b.Title)) < 3 {
 		return ErrTitleTooShort
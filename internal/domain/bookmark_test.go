@@ -143,3 +143,64 @@ func TestBookmark_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestBookmark_HasPlaceholderTitle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		url   string
+		title string
+		want  bool
+	}{
+		{name: "Empty", url: "https://go.dev/doc", title: "  ", want: true},
+		{name: "Full URL", url: "https://go.dev/doc", title: "https://go.dev/doc", want: true},
+		{name: "URL Without Scheme", url: "https://go.dev/doc/", title: "go.dev/doc", want: true},
+		{name: "Host Only", url: "https://go.dev/doc", title: "go.dev", want: true},
+		{name: "Real Title", url: "https://go.dev/doc", title: "Documentation", want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b := Bookmark{URL: tt.url, Title: tt.title}
+			if got := b.HasPlaceholderTitle(); got != tt.want {
+				t.Errorf("HasPlaceholderTitle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBookmarkFilter_Matches(t *testing.T) {
+	t.Parallel()
+
+	b := &Bookmark{ID: "b1", URL: "https://Go.dev:443/doc", Title: "Documentation", Tags: []string{"go", "docs"}}
+	tests := []struct {
+		name   string
+		filter BookmarkFilter
+		want   bool
+	}{
+		{name: "Empty", filter: BookmarkFilter{}, want: true},
+		{name: "Tag", filter: BookmarkFilter{Tag: "docs"}, want: true},
+		{name: "Other Tag", filter: BookmarkFilter{Tag: "rust"}, want: false},
+		{name: "Excluded Tag", filter: BookmarkFilter{ExcludeTags: []string{"private", "docs"}}, want: false},
+		{name: "Other Excluded Tag", filter: BookmarkFilter{ExcludeTags: []string{"private"}}, want: true},
+		{name: "Host Ignores Case And Port", filter: BookmarkFilter{Host: "go.DEV"}, want: true},
+		{name: "Other Host", filter: BookmarkFilter{Host: "pkg.go.dev"}, want: false},
+		{name: "Query", filter: BookmarkFilter{Query: "document"}, want: true},
+		{name: "All Must Match", filter: BookmarkFilter{Tag: "go", Host: "example.com"}, want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.filter.Matches(b); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ContentExtractor fetches the page behind a URL and extracts its readable
+// text, leaving out navigation, sidebars and other boilerplate.
+type ContentExtractor interface {
+	Extract(ctx context.Context, url string) (*PageContent, error)
+}
+
+// PageContent is the main content of a page as plain text, with paragraphs
+// separated by blank lines.
+type PageContent struct {
+	Text      string
+	WordCount int
+}
+
+// WordsPerMinute is the reading speed reading times are estimated at.
+const WordsPerMinute = 230
+
+// ReadingMinutes estimates how long a text of words words takes to read,
+// rounded up to whole minutes.
+func ReadingMinutes(words int) int {
+	return (words + WordsPerMinute - 1) / WordsPerMinute
+}
+
+// ContentInfo describes the text last extracted from a bookmark's page. The
+// text itself lives in the SearchIndex.
+type ContentInfo struct {
+	WordCount      int       `json:"word_count"`
+	ReadingMinutes int       `json:"reading_minutes"`
+	ExtractedAt    time.Time `json:"extracted_at"`
+	// Error says why the latest extraction failed; earlier text, if any, is
+	// still indexed.
+	Error string `json:"error,omitempty"`
+}
+
+// SearchIndex answers full-text queries over bookmarks. A bookmark's metadata
+// and its page content are indexed separately, so either can be replaced
+// without touching the other.
+type SearchIndex interface {
+	// IndexMetadata replaces the title, URL, description and tags indexed
+	// for b.
+	IndexMetadata(ctx context.Context, b *Bookmark) error
+	// IndexContent replaces the page text indexed for bookmark id.
+	IndexContent(ctx context.Context, id, text string) error
+	// Remove drops everything indexed for bookmark id.
+	Remove(ctx context.Context, id string) error
+	// Search returns up to limit bookmarks matching every word of query, best
+	// first.
+	Search(ctx context.Context, query string, limit int) ([]SearchHit, error)
+}
+
+// Fields a SearchHit can match in.
+const (
+	FieldTitle       = "title"
+	FieldURL         = "url"
+	FieldDescription = "description"
+	FieldTags        = "tags"
+	FieldContent     = "content"
+)
+
+type SearchHit struct {
+	ID    string
+	Score float64
+	// Snippets show where the query matched, one per matching field.
+	Snippets []Snippet
+}
+
+// Snippet is an excerpt of a field as HTML-escaped text, with every matched
+// word wrapped in <mark></mark>.
+type Snippet struct {
+	Field string `json:"field"`
+	Text  string `json:"text"`
+}
+
+// SearchResult is a matching bookmark with the snippets showing why it
+// matched.
+type SearchResult struct {
+	Bookmark *Bookmark `json:"bookmark"`
+	Score    float64   `json:"score"`
+	Snippets []Snippet `json:"snippets"`
+}
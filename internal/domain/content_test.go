@@ -0,0 +1,24 @@
+package domain
+
+import "testing"
+
+func TestReadingMinutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		words int
+		want  int
+	}{
+		{0, 0},
+		{1, 1},
+		{230, 1},
+		{231, 2},
+		{2300, 10},
+	}
+
+	for _, tt := range tests {
+		if got := ReadingMinutes(tt.words); got != tt.want {
+			t.Errorf("ReadingMinutes(%d) = %d, want %d", tt.words, got, tt.want)
+		}
+	}
+}
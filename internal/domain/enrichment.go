@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+// PageInspector reads the metadata of the page behind a URL.
+type PageInspector interface {
+	Inspect(ctx context.Context, url string) (*PageMetadata, error)
+}
+
+// ErrPageUnavailable wraps failures that retrying will not fix, such as a 404,
+// a non-HTML response or a robots.txt rule.
+var ErrPageUnavailable = errors.New("page unavailable")
+
+// PageMetadata is what a page says about itself in its <head>.
+type PageMetadata struct {
+	Title       string
+	Description string
+	Author      string
+	SiteName    string
+	Image       string
+	Canonical   string
+	Language    string
+	// Properties holds every OpenGraph ("og:*") and Twitter card
+	// ("twitter:*") field, keyed by its property name.
+	Properties map[string]string
+}
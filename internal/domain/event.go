@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// EventType names a kind of event, as "<aggregate>.<change>".
+type EventType string
+
+const (
+	EventBookmarkCreated  EventType = "bookmark.created"
+	EventBookmarkUpdated  EventType = "bookmark.updated"
+	EventBookmarkDeleted  EventType = "bookmark.deleted"
+	EventBookmarkRestored EventType = "bookmark.restored"
+	EventTagAdded         EventType = "bookmark.tag_added"
+	EventTagRemoved       EventType = "bookmark.tag_removed"
+)
+
+// EventTypes lists every event type, in the order above.
+var EventTypes = []EventType{
+	EventBookmarkCreated,
+	EventBookmarkUpdated,
+	EventBookmarkDeleted,
+	EventBookmarkRestored,
+	EventTagAdded,
+	EventTagRemoved,
+}
+
+// Event is something that happened to an aggregate, such as a bookmark.
+// Events are values: subscribers must not modify them.
+type Event interface {
+	EventID() string
+	EventType() EventType
+	// AggregateID identifies what changed. Events for one aggregate are
+	// delivered to each subscriber in the order they were published.
+	AggregateID() string
+	OccurredAt() time.Time
+	// EventActor is who made the change, or "" when unknown.
+	EventActor() string
+}
+
+// EventMeta carries what every event has. Event types embed it.
+type EventMeta struct {
+	ID string    `json:"id"`
+	At time.Time `json:"occurred_at"`
+	// Actor is who made the change, as found by ActorFrom.
+	Actor string `json:"actor,omitempty"`
+}
+
+func (m EventMeta) EventID() string       { return m.ID }
+func (m EventMeta) OccurredAt() time.Time { return m.At }
+func (m EventMeta) EventActor() string    { return m.Actor }
+
+// BookmarkCreated holds the bookmark as stored.
+type BookmarkCreated struct {
+	EventMeta
+	Bookmark *Bookmark `json:"bookmark"`
+}
+
+func (e BookmarkCreated) EventType() EventType { return EventBookmarkCreated }
+func (e BookmarkCreated) AggregateID() string  { return e.Bookmark.ID }
+
+// BookmarkUpdated holds the bookmark before and after the change. Before is
+// nil when it could not be read.
+type BookmarkUpdated struct {
+	EventMeta
+	Before   *Bookmark `json:"before,omitempty"`
+	Bookmark *Bookmark `json:"bookmark"`
+}
+
+func (e BookmarkUpdated) EventType() EventType { return EventBookmarkUpdated }
+func (e BookmarkUpdated) AggregateID() string  { return e.Bookmark.ID }
+
+// BookmarkDeleted holds the bookmark as it was before it was moved to the
+// trash, or only its ID when it could not be read.
+type BookmarkDeleted struct {
+	EventMeta
+	Bookmark *Bookmark `json:"bookmark"`
+}
+
+func (e BookmarkDeleted) EventType() EventType { return EventBookmarkDeleted }
+func (e BookmarkDeleted) AggregateID() string  { return e.Bookmark.ID }
+
+// BookmarkRestored holds the bookmark as taken out of the trash. To
+// subscribers it is as if the bookmark were created again.
+type BookmarkRestored struct {
+	EventMeta
+	Bookmark *Bookmark `json:"bookmark"`
+}
+
+func (e BookmarkRestored) EventType() EventType { return EventBookmarkRestored }
+func (e BookmarkRestored) AggregateID() string  { return e.Bookmark.ID }
+
+// TagAdded and TagRemoved follow a BookmarkUpdated that changed the
+// bookmark's tags, one per tag. A bookmark's tags at creation and deletion
+// are in BookmarkCreated and BookmarkDeleted.
+type TagAdded struct {
+	EventMeta
+	BookmarkID string `json:"bookmark_id"`
+	Tag        string `json:"tag"`
+}
+
+func (e TagAdded) EventType() EventType { return EventTagAdded }
+func (e TagAdded) AggregateID() string  { return e.BookmarkID }
+
+type TagRemoved struct {
+	EventMeta
+	BookmarkID string `json:"bookmark_id"`
+	Tag        string `json:"tag"`
+}
+
+func (e TagRemoved) EventType() EventType { return EventTagRemoved }
+func (e TagRemoved) AggregateID() string  { return e.BookmarkID }
+
+// UnmarshalEvent decodes an event of type t from its JSON encoding, for
+// stores and transports that keep events as JSON.
+func UnmarshalEvent(t EventType, data []byte) (Event, error) {
+	switch t {
+	case EventBookmarkCreated:
+		return unmarshalEvent[BookmarkCreated](data)
+	case EventBookmarkUpdated:
+		return unmarshalEvent[BookmarkUpdated](data)
+	case EventBookmarkDeleted:
+		return unmarshalEvent[BookmarkDeleted](data)
+	case EventBookmarkRestored:
+		return unmarshalEvent[BookmarkRestored](data)
+	case EventTagAdded:
+		return unmarshalEvent[TagAdded](data)
+	case EventTagRemoved:
+		return unmarshalEvent[TagRemoved](data)
+	}
+	return nil, fmt.Errorf("unknown event type %q", t)
+}
+
+func unmarshalEvent[E Event](data []byte) (Event, error) {
+	var e E
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// DiffTags returns the tags in after but not before, and those in before but
+// not after, each in the order they appear.
+func DiffTags(before, after []string) (added, removed []string) {
+	for _, t := range after {
+		if !slices.Contains(before, t) {
+			added = append(added, t)
+		}
+	}
+	for _, t := range before {
+		if !slices.Contains(after, t) {
+			removed = append(removed, t)
+		}
+	}
+	return added, removed
+}
+
+// EventPublisher delivers events to whoever subscribed to them. Publish
+// returns the errors of the subscribers that handle events before it
+// returns, so that the caller can deliver the events again; every event is
+// still offered to every subscriber. Subscribers handling events later
+// handle their own errors.
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...Event) error
+}
+
+// EventHandler reacts to an event.
+type EventHandler func(ctx context.Context, e Event) error
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnmarshalEvent(t *testing.T) {
+	t.Parallel()
+
+	meta := EventMeta{ID: "e1", At: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	events := []Event{
+		BookmarkCreated{EventMeta: meta, Bookmark: &Bookmark{ID: "b1", URL: "https://go.dev"}},
+		BookmarkUpdated{EventMeta: meta, Before: &Bookmark{ID: "b1"}, Bookmark: &Bookmark{ID: "b1", Title: "Go"}},
+		BookmarkDeleted{EventMeta: meta, Bookmark: &Bookmark{ID: "b1"}},
+		TagAdded{EventMeta: meta, BookmarkID: "b1", Tag: "go"},
+		TagRemoved{EventMeta: meta, BookmarkID: "b1", Tag: "go"},
+	}
+
+	for _, want := range events {
+		t.Run(string(want.EventType()), func(t *testing.T) {
+			t.Parallel()
+
+			data, err := json.Marshal(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := UnmarshalEvent(want.EventType(), data)
+			if err != nil {
+				t.Fatalf("UnmarshalEvent() error = %v", err)
+			}
+			if got.EventID() != "e1" || got.AggregateID() != "b1" || !got.OccurredAt().Equal(meta.At) {
+				t.Errorf("UnmarshalEvent() = %+v, want %+v", got, want)
+			}
+			if reflect.TypeOf(got) != reflect.TypeOf(want) {
+				t.Errorf("UnmarshalEvent() type = %T, want %T", got, want)
+			}
+		})
+	}
+
+	if _, err := UnmarshalEvent("bookmark.renamed", []byte("{}")); err == nil {
+		t.Error("UnmarshalEvent() of unknown type succeeded")
+	}
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"time"
+)
+
+// FaviconSizes are the sizes, in pixels, at which icons are stored and
+// served, smallest first.
+var FaviconSizes = []int{16, 32, 64, 128}
+
+// FaviconSize is the smallest of FaviconSizes at least size, or the largest
+// when size exceeds them all.
+func FaviconSize(size int) int {
+	for _, s := range FaviconSizes {
+		if s >= size {
+			return s
+		}
+	}
+	return FaviconSizes[len(FaviconSizes)-1]
+}
+
+// Favicon is what is known about a host's icon.
+type Favicon struct {
+	Host string
+	// Icons holds a PNG for each of FaviconSizes. It is empty when the host
+	// has no usable icon.
+	Icons map[int][]byte
+	// FetchedAt is when Icons were fetched, and CheckedAt when the latest
+	// attempt was made. Error says why that attempt failed; Icons then still
+	// holds the result of the last success.
+	FetchedAt time.Time
+	CheckedAt time.Time
+	Error     string
+}
+
+// Clone returns a copy of f. The PNGs themselves are shared, as they are
+// never modified.
+func (f *Favicon) Clone() *Favicon {
+	c := *f
+	c.Icons = maps.Clone(f.Icons)
+	return &c
+}
+
+// FaviconSource finds and renders sites' icons.
+type FaviconSource interface {
+	// Fetch finds host's icon and renders it as a PNG at each of
+	// FaviconSizes. It fails with ErrFaviconNotFound when the site has no
+	// icon it can decode.
+	Fetch(ctx context.Context, host string) (map[int][]byte, error)
+	// Fallback renders a generated icon for host as a PNG of the given size.
+	// The same host always gets the same icon.
+	Fallback(host string, size int) []byte
+}
+
+// FaviconStore caches icons by host.
+type FaviconStore interface {
+	// Get fails with ErrFaviconNotFound when host has never been stored.
+	Get(ctx context.Context, host string) (*Favicon, error)
+	Put(ctx context.Context, f *Favicon) error
+}
+
+var (
+	ErrFaviconNotFound = errors.New("favicon not found")
+	ErrInvalidHost     = errors.New("invalid host name")
+)
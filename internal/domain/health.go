@@ -0,0 +1,35 @@
+package domain
+
+import "context"
+
+// HealthChecker is a component the application cannot serve without, such
+// as a repository. It is healthy when CheckHealth returns nil in time.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+type HealthStatus string
+
+const (
+	HealthUp   HealthStatus = "up"
+	HealthDown HealthStatus = "down"
+)
+
+// ComponentHealth is the outcome of checking one component.
+type ComponentHealth struct {
+	Name      string       `json:"name"`
+	Status    HealthStatus `json:"status"`
+	LatencyMS float64      `json:"latency_ms"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// HealthReport is the outcome of checking every component.
+type HealthReport struct {
+	// Status is HealthUp when every component is.
+	Status HealthStatus `json:"status"`
+	// Ready is true when Status is HealthUp and the application is not
+	// shutting down.
+	Ready      bool              `json:"ready"`
+	Draining   bool              `json:"draining"`
+	Components []ComponentHealth `json:"components"`
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *ImportJob, items []*Bookmark) error
+	GetByID(ctx context.Context, id string) (*ImportJob, error)
+	Update(ctx context.Context, job *ImportJob) error
+	Items(ctx context.Context, id string, offset, limit int) ([]*Bookmark, error)
+	ListUnfinished(ctx context.Context) ([]*ImportJob, error)
+}
+
+var (
+	ErrImportJobNotFound = errors.New("import job not found")
+	ErrImportJobFinished = errors.New("import job has already finished")
+	ErrImportJobCanceled = errors.New("import job was canceled")
+	ErrImportEmpty       = errors.New("import must contain at least one bookmark")
+)
+
+// MaxImportErrors caps how many per-item errors are kept on a job so that a
+// badly formatted file cannot grow the job record without bound.
+const MaxImportErrors = 100
+
+type ImportJobStatus string
+
+const (
+	ImportJobPending   ImportJobStatus = "pending"
+	ImportJobRunning   ImportJobStatus = "running"
+	ImportJobCompleted ImportJobStatus = "completed"
+	ImportJobFailed    ImportJobStatus = "failed"
+	ImportJobCanceled  ImportJobStatus = "canceled"
+)
+
+type ImportItemError struct {
+	Index int    `json:"index"`
+	URL   string `json:"url"`
+	Error string `json:"error"`
+}
+
+// ImportJob tracks a background import. Processed is the checkpoint: every item
+// before that offset has been written (or rejected) and is never replayed.
+type ImportJob struct {
+	ID         string            `json:"id"`
+	Status     ImportJobStatus   `json:"status"`
+	Total      int               `json:"total"`
+	Processed  int               `json:"processed"`
+	Imported   int               `json:"imported"`
+	Failed     int               `json:"failed"`
+	Errors     []ImportItemError `json:"errors"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+	FinishedAt *time.Time        `json:"finished_at,omitempty"`
+}
+
+func NewImportJob(id string, total int) *ImportJob {
+	now := time.Now()
+	return &ImportJob{
+		ID:        id,
+		Status:    ImportJobPending,
+		Total:     total,
+		Errors:    []ImportItemError{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Finished reports whether the job has reached a terminal status.
+func (j *ImportJob) Finished() bool {
+	switch j.Status {
+	case ImportJobCompleted, ImportJobFailed, ImportJobCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// RecordError counts a rejected item, keeping at most MaxImportErrors details.
+func (j *ImportJob) RecordError(index int, url string, err error) {
+	j.Failed++
+	if len(j.Errors) < MaxImportErrors {
+		j.Errors = append(j.Errors, ImportItemError{Index: index, URL: url, Error: err.Error()})
+	}
+}
+
+// Finish moves the job to a terminal status.
+func (j *ImportJob) Finish(status ImportJobStatus) {
+	now := time.Now()
+	j.Status = status
+	j.UpdatedAt = now
+	j.FinishedAt = &now
+}
+
+// Clone returns a copy that can be handed out while a worker keeps mutating j.
+func (j *ImportJob) Clone() *ImportJob {
+	c := *j
+	c.Errors = make([]ImportItemError, len(j.Errors))
+	copy(c.Errors, j.Errors)
+	if j.FinishedAt != nil {
+		t := *j.FinishedAt
+		c.FinishedAt = &t
+	}
+	return &c
+}
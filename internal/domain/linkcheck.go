@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// LinkChecker requests the page behind a URL to see whether it still exists.
+type LinkChecker interface {
+	Check(ctx context.Context, url string) LinkCheck
+}
+
+// LinkCheck is the outcome of one request. Err is set when no response
+// arrived at all, such as for a DNS failure or a refused connection.
+type LinkCheck struct {
+	StatusCode int
+	// FinalURL is where redirects ended; empty without a response.
+	FinalURL string
+	// PermanentRedirect is true when every redirect on the way to FinalURL
+	// was a 301 or 308.
+	PermanentRedirect bool
+	Latency           time.Duration
+	Err               error
+}
+
+// OK reports whether the link works: a response arrived and it was not a
+// client or server error.
+func (c LinkCheck) OK() bool {
+	return c.Err == nil && c.StatusCode > 0 && c.StatusCode < 400
+}
+
+// Inconclusive reports whether the check says nothing about the link, as when
+// the site rate-limits the checker.
+func (c LinkCheck) Inconclusive() bool {
+	return c.Err == nil && c.StatusCode == 429
+}
+
+type LinkStatus string
+
+const (
+	LinkUnchecked  LinkStatus = "unchecked"
+	LinkOK         LinkStatus = "ok"
+	LinkRedirected LinkStatus = "redirected"
+	// LinkFailing links failed their latest checks, but not yet often enough
+	// in a row to count as broken.
+	LinkFailing LinkStatus = "failing"
+	LinkBroken  LinkStatus = "broken"
+)
+
+// ValidLinkStatus reports whether s names a LinkStatus.
+func ValidLinkStatus(s string) bool {
+	switch LinkStatus(s) {
+	case LinkUnchecked, LinkOK, LinkRedirected, LinkFailing, LinkBroken:
+		return true
+	}
+	return false
+}
+
+// LinkHealth records the latest check of a bookmark's URL.
+type LinkHealth struct {
+	Status     LinkStatus `json:"status"`
+	StatusCode int        `json:"status_code,omitempty"`
+	FinalURL   string     `json:"final_url,omitempty"`
+	LatencyMS  int64      `json:"latency_ms"`
+	Error      string     `json:"error,omitempty"`
+	// ConsecutiveFailures counts failed checks since the last success.
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	CheckedAt           time.Time `json:"checked_at"`
+	// PreviousURL is the URL the bookmark had before a permanent redirect
+	// replaced it.
+	PreviousURL string `json:"previous_url,omitempty"`
+}
+
+// LinkStatus is the status of the bookmark's link, LinkUnchecked if it has
+// never been checked.
+func (b *Bookmark) LinkStatus() LinkStatus {
+	if b.Health == nil {
+		return LinkUnchecked
+	}
+	return b.Health.Status
+}
+
+// LinkHealthReport summarizes link health across all bookmarks.
+type LinkHealthReport struct {
+	Total    int                `json:"total"`
+	ByStatus map[LinkStatus]int `json:"by_status"`
+	// ByStatusCode counts bookmarks by the HTTP status of their latest check;
+	// "error" counts checks that got no response.
+	ByStatusCode map[string]int `json:"by_status_code"`
+	// Broken lists the broken bookmarks, longest failing first.
+	Broken  []*Bookmark   `json:"broken"`
+	LastRun *LinkCheckRun `json:"last_run,omitempty"`
+}
+
+// LinkCheckRun describes one pass of the link checker over all bookmarks.
+type LinkCheckRun struct {
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Checked    int        `json:"checked"`
+	Failed     int        `json:"failed"`
+	// Moved counts bookmarks whose URL was replaced after a permanent redirect.
+	Moved int `json:"moved"`
+}
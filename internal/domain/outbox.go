@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+)
+
+// BookmarkTx is a repository whose writes are part of a transaction, and
+// which records the events those writes should publish.
+type BookmarkTx interface {
+	BookmarkRepository
+	// Record adds events to the outbox, to be stored with the writes.
+	Record(events ...Event)
+}
+
+// OutboxRecord is an event stored in an outbox, waiting to be dispatched.
+type OutboxRecord struct {
+	// Seq orders records in the order they were stored.
+	Seq   int64
+	Event Event
+}
+
+// Outbox holds events that have been stored but not yet dispatched.
+type Outbox interface {
+	// Pending returns up to limit records, lowest Seq first.
+	Pending(ctx context.Context, limit int) ([]OutboxRecord, error)
+	// Ack removes dispatched records by their event IDs. Unknown IDs are
+	// ignored.
+	Ack(ctx context.Context, eventIDs ...string) error
+}
+
+// AtomicBookmarkRepository is implemented by repositories that can store
+// changes to bookmarks together with the events describing them, so that no
+// change is stored without its events or the other way round.
+type AtomicBookmarkRepository interface {
+	BookmarkRepository
+	Outbox
+	// Atomically calls fn and stores the writes made through tx, and the
+	// events recorded on it, when fn returns nil; when fn fails, nothing is
+	// stored and its error is returned. fn must not use the repository
+	// other than through tx.
+	Atomically(ctx context.Context, fn func(tx BookmarkTx) error) error
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// RevisionChange is what a revision did to its bookmark.
+type RevisionChange string
+
+const (
+	RevisionCreated  RevisionChange = "created"
+	RevisionUpdated  RevisionChange = "updated"
+	RevisionDeleted  RevisionChange = "deleted"
+	RevisionRestored RevisionChange = "restored"
+)
+
+// Revision is a bookmark as one change left it. Revisions are never modified
+// once stored.
+type Revision struct {
+	BookmarkID string `json:"bookmark_id"`
+	// Number counts the bookmark's revisions from 1. The repository sets it.
+	Number int64          `json:"number"`
+	Change RevisionChange `json:"change"`
+	Actor  string         `json:"actor,omitempty"`
+	At     time.Time      `json:"at"`
+	// Fields names the RevisionFields that differ from the revision before,
+	// or all of them for a created bookmark.
+	Fields   []string  `json:"fields"`
+	Bookmark *Bookmark `json:"bookmark"`
+	// EventID is the event the revision was made from, which keeps an event
+	// delivered twice from making two revisions.
+	EventID string `json:"-"`
+}
+
+func (r *Revision) Clone() *Revision {
+	c := *r
+	c.Fields = slices.Clone(r.Fields)
+	c.Bookmark = r.Bookmark.Clone()
+	return &c
+}
+
+// RevisionFields are the fields revisions track and revert restores, by
+// their JSON names. The others are kept up to date by the application.
+var RevisionFields = []string{"url", "title", "description", "tags", "archive_mode"}
+
+// FieldChange is one field that differs between two bookmarks.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// RevisionDiff is what changed from one revision of a bookmark to another.
+type RevisionDiff struct {
+	BookmarkID string        `json:"bookmark_id"`
+	From       int64         `json:"from"`
+	To         int64         `json:"to"`
+	Changes    []FieldChange `json:"changes"`
+}
+
+// DiffBookmarks returns the RevisionFields that differ between from and to,
+// in the order of RevisionFields.
+func DiffBookmarks(from, to *Bookmark) []FieldChange {
+	changes := []FieldChange{}
+	add := func(field string, a, b any, same bool) {
+		if !same {
+			changes = append(changes, FieldChange{Field: field, From: a, To: b})
+		}
+	}
+	add("url", from.URL, to.URL, from.URL == to.URL)
+	add("title", from.Title, to.Title, from.Title == to.Title)
+	add("description", from.Description, to.Description, from.Description == to.Description)
+	add("tags", from.Tags, to.Tags, slices.Equal(from.Tags, to.Tags))
+	add("archive_mode", from.ArchiveMode, to.ArchiveMode, from.ArchiveMode == to.ArchiveMode)
+	return changes
+}
+
+// RevisionRepository stores the revisions of bookmarks.
+type RevisionRepository interface {
+	// Add stores r as its bookmark's next revision and sets its Number. A
+	// revision with the EventID of one already stored is ignored.
+	Add(ctx context.Context, r *Revision) error
+	// List returns a bookmark's revisions, newest first.
+	List(ctx context.Context, bookmarkID string) ([]*Revision, error)
+	Get(ctx context.Context, bookmarkID string, number int64) (*Revision, error)
+	// Prune drops revisions made before the given time that are not among
+	// the keep newest of their bookmark, and reports how many it dropped.
+	// The newest revision of a bookmark is always kept.
+	Prune(ctx context.Context, keep int, before time.Time) (int, error)
+	DeleteByBookmark(ctx context.Context, bookmarkID string) error
+}
+
+var ErrRevisionNotFound = errors.New("revision not found")
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Change records that a bookmark was written or deleted, in the order
+// clients that sync should apply it.
+type Change struct {
+	Seq        int64
+	BookmarkID string
+	Deleted    bool
+	At         time.Time
+}
+
+// ChangeLog orders bookmark changes for clients that keep a local copy and
+// only pull what changed. Only the latest change to each bookmark is needed,
+// so a log may drop the ones it supersedes.
+type ChangeLog interface {
+	// Epoch identifies the log's sequence. It changes when the log starts
+	// over, which invalidates every position in the old one.
+	Epoch(ctx context.Context) (string, error)
+	// Append records changes, giving each the next sequence number.
+	Append(ctx context.Context, changes ...Change) error
+	// Since returns the latest change to each bookmark changed after seq,
+	// in sequence order, at most limit of them. It fails with
+	// ErrChangesExpired when changes after seq may have been pruned.
+	Since(ctx context.Context, seq int64, limit int) ([]Change, error)
+	// Latest returns the sequence number of the last change, or 0.
+	Latest(ctx context.Context) (int64, error)
+	// Prune drops deletions recorded before the given time, and reports how
+	// many it dropped.
+	Prune(ctx context.Context, before time.Time) (int, error)
+}
+
+var (
+	ErrChangesExpired = errors.New("changes since then are no longer available")
+	ErrInvalidSync    = errors.New("invalid sync request")
+)
+
+// Tombstone tells a syncing client that a bookmark was deleted.
+type Tombstone struct {
+	ID        string    `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// SyncPage is what changed since a sync token.
+type SyncPage struct {
+	// Token is passed as since in the next pull.
+	Token string `json:"token"`
+	// Full means Upserts holds every bookmark, and replaces the local copy.
+	Full bool `json:"full"`
+	// More means further changes are waiting; pull again with Token.
+	More       bool        `json:"more"`
+	Upserts    []*Bookmark `json:"upserts"`
+	Tombstones []Tombstone `json:"tombstones"`
+}
+
+// SyncChange is a change a client made to its local copy, pushed to be
+// applied here.
+type SyncChange struct {
+	ID string
+	// BaseVersion is the version the change was made to, or 0 for a
+	// bookmark the client created.
+	BaseVersion int64
+	// UpdatedAt is when the client made the change.
+	UpdatedAt   time.Time
+	Deleted     bool
+	URL         string
+	Title       string
+	Description string
+	Tags        []string
+}
+
+type SyncStatus string
+
+const (
+	// SyncApplied changes have been stored.
+	SyncApplied SyncStatus = "applied"
+	// SyncConflict changes were made to a version that has since been
+	// changed by someone else.
+	SyncConflict SyncStatus = "conflict"
+	// SyncInvalid changes cannot be stored, e.g. for lack of a title.
+	SyncInvalid SyncStatus = "invalid"
+	// SyncFailed changes could not be stored for reasons of the server's,
+	// and may be pushed again.
+	SyncFailed SyncStatus = "failed"
+)
+
+// SyncResult says what became of one pushed change.
+type SyncResult struct {
+	ID     string     `json:"id"`
+	Status SyncStatus `json:"status"`
+	Error  string     `json:"error,omitempty"`
+	// Bookmark is the stored bookmark: as changed when applied, or the
+	// version that conflicted. It is absent when there is none.
+	Bookmark *Bookmark `json:"bookmark,omitempty"`
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Webhook subscribes a URL to events. Each matching event is POSTed to it as
+// JSON, signed with Secret.
+type Webhook struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Events filters which events are sent, by exact type ("bookmark.created")
+	// or prefix ("bookmark.*"). Empty sends every event.
+	Events []string `json:"events"`
+	Secret string   `json:"-"`
+	Active bool     `json:"active"`
+	// Failures counts failed delivery attempts since the last success. The
+	// webhook is disabled when it reaches the configured limit.
+	Failures       int        `json:"failures"`
+	DisabledAt     *time.Time `json:"disabled_at,omitempty"`
+	DisabledReason string     `json:"disabled_reason,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Clone returns a deep copy of w.
+func (w *Webhook) Clone() *Webhook {
+	c := *w
+	c.Events = slices.Clone(w.Events)
+	if w.DisabledAt != nil {
+		at := *w.DisabledAt
+		c.DisabledAt = &at
+	}
+	return &c
+}
+
+// Accepts reports whether events of type t are sent to w.
+func (w *Webhook) Accepts(t EventType) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, pattern := range w.Events {
+		if pattern == "*" || pattern == string(t) {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(string(t), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	// DeliveryPending is waiting for its first attempt or a retry.
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+	DeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	// DeliveryFailed has run out of attempts, or its webhook was disabled.
+	DeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event sent to one webhook, with every attempt made
+// to send it. Payload is kept so that a redelivery sends the same body.
+type WebhookDelivery struct {
+	ID        string                `json:"id"`
+	WebhookID string                `json:"webhook_id"`
+	EventID   string                `json:"event_id"`
+	EventType EventType             `json:"event_type"`
+	Payload   []byte                `json:"-"`
+	Status    WebhookDeliveryStatus `json:"status"`
+	Attempts  []WebhookAttempt      `json:"attempts"`
+	// NextAttemptAt is when a pending delivery is due.
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	// RedeliveryOf is the ID of the delivery this one repeats.
+	RedeliveryOf string    `json:"redelivery_of,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// WebhookAttempt is the outcome of one request. StatusCode is zero when no
+// response was received.
+type WebhookAttempt struct {
+	At         time.Time `json:"at"`
+	StatusCode int       `json:"status_code,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+	// Response is the start of the response body.
+	Response string `json:"response,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// OK reports whether the receiver accepted the delivery.
+func (a WebhookAttempt) OK() bool {
+	return a.Error == "" && a.StatusCode >= 200 && a.StatusCode < 300
+}
+
+// Clone returns a deep copy of d.
+func (d *WebhookDelivery) Clone() *WebhookDelivery {
+	c := *d
+	c.Attempts = slices.Clone(d.Attempts)
+	if d.NextAttemptAt != nil {
+		at := *d.NextAttemptAt
+		c.NextAttemptAt = &at
+	}
+	return &c
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, w *Webhook) error
+	GetByID(ctx context.Context, id string) (*Webhook, error)
+	// List returns every webhook, oldest first.
+	List(ctx context.Context) ([]*Webhook, error)
+	Update(ctx context.Context, w *Webhook) error
+	Delete(ctx context.Context, id string) error
+}
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, d *WebhookDelivery) error
+	// GetByID fails with ErrWebhookDeliveryNotFound unless the delivery
+	// belongs to the webhook.
+	GetByID(ctx context.Context, webhookID, id string) (*WebhookDelivery, error)
+	// ListByWebhook returns a webhook's deliveries, newest first.
+	ListByWebhook(ctx context.Context, webhookID string) ([]*WebhookDelivery, error)
+	Update(ctx context.Context, d *WebhookDelivery) error
+	// Due returns up to limit pending deliveries whose next attempt is not
+	// after now, earliest first.
+	Due(ctx context.Context, now time.Time, limit int) ([]*WebhookDelivery, error)
+	// Trim deletes a webhook's deliveries other than the newest keep, except
+	// those still pending.
+	Trim(ctx context.Context, webhookID string, keep int) error
+	DeleteByWebhook(ctx context.Context, webhookID string) error
+}
+
+// WebhookRequest is what a WebhookSender sends: Payload POSTed to URL and
+// signed with Secret.
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	DeliveryID string
+	EventType  EventType
+	Payload    []byte
+}
+
+// WebhookSender makes delivery attempts.
+type WebhookSender interface {
+	// Send makes one attempt and describes its outcome. It never retries.
+	Send(ctx context.Context, req WebhookRequest) WebhookAttempt
+}
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhook          = errors.New("invalid webhook")
+	ErrWebhookDisabled         = errors.New("webhook is disabled")
+)
@@ -0,0 +1,359 @@
+// Package archive captures bookmarked pages, with the same-origin assets they
+// load, into WARC files and serves them back.
+//
+// Each bookmark's capture is a gzipped WARC file named after the bookmark ID,
+// with a JSON index next to it that locates each response record, so a single
+// asset can be read without decompressing the whole file.
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/outbound"
+)
+
+const DefaultUserAgent = "goprod-archiver/1.0 (+https://github.com/etsrc/goprod)"
+
+type Options struct {
+	// Dir holds the archives. It is created if missing.
+	Dir string
+	// Client sends every request. Defaults to an outbound client with
+	// default limits.
+	Client    *http.Client
+	UserAgent string
+	// Timeout bounds one capture, the page and all its assets.
+	Timeout time.Duration
+	// MaxAssets caps the assets captured with a page.
+	MaxAssets int
+	// MaxBytes caps the payload of a capture. Assets past it are skipped; a
+	// page larger than it fails.
+	MaxBytes int64
+}
+
+// Archiver implements domain.PageArchiver with WARC files on disk.
+type Archiver struct {
+	opts Options
+
+	mu      sync.Mutex
+	indexes map[string]*index // by bookmark ID
+}
+
+// index is the sidecar file of a capture.
+type index struct {
+	Page       string            `json:"page"` // final URL of the page
+	CapturedAt time.Time         `json:"captured_at"`
+	Records    map[string]record `json:"records"` // response records by URL
+}
+
+var _ domain.PageArchiver = (*Archiver)(nil)
+
+func NewArchiver(opts Options) (*Archiver, error) {
+	if opts.Client == nil {
+		// Without a proxy NewClient cannot fail.
+		opts.Client, _ = outbound.NewClient(outbound.Options{})
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = DefaultUserAgent
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = time.Minute
+	}
+	if opts.MaxAssets <= 0 {
+		opts.MaxAssets = 50
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = 20 << 20
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("archive.NewArchiver: %w", err)
+	}
+	return &Archiver{opts: opts, indexes: make(map[string]*index)}, nil
+}
+
+// Capture fetches rawURL and, if it is HTML, the same-origin images,
+// stylesheets, scripts and fonts it loads, including those referenced from its
+// stylesheets. The new archive replaces the old one only once it is complete.
+func (a *Archiver) Capture(ctx context.Context, id, rawURL string) (*domain.ArchiveInfo, error) {
+	warcPath, indexPath, err := a.paths(id)
+	if err != nil {
+		return nil, fmt.Errorf("archive.Capture: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, a.opts.Timeout)
+	defer cancel()
+
+	tmp, err := os.CreateTemp(a.opts.Dir, id+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("archive.Capture: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	now := time.Now()
+	c := &capture{archiver: a, ww: &warcWriter{w: tmp}, date: now, budget: a.opts.MaxBytes}
+	if err := c.ww.writeInfo(now); err != nil {
+		return nil, fmt.Errorf("archive.Capture: %w", err)
+	}
+	idx, err := c.run(ctx, rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("archive.Capture: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("archive.Capture: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := writeJSONFile(indexPath+".tmp", idx); err != nil {
+		return nil, fmt.Errorf("archive.Capture: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), warcPath); err != nil {
+		return nil, fmt.Errorf("archive.Capture: %w", err)
+	}
+	if err := os.Rename(indexPath+".tmp", indexPath); err != nil {
+		return nil, fmt.Errorf("archive.Capture: %w", err)
+	}
+	a.indexes[id] = idx
+
+	return &domain.ArchiveInfo{
+		CapturedAt: now,
+		Resources:  c.resources,
+		Size:       c.ww.offset,
+	}, nil
+}
+
+func (a *Archiver) Resource(_ context.Context, id, rawURL string) (*domain.ArchivedResource, error) {
+	warcPath, _, err := a.paths(id)
+	if err != nil {
+		return nil, fmt.Errorf("archive.Resource: %w", err)
+	}
+
+	// Hold the lock while reading so that a new capture cannot replace the
+	// file between looking up the index and reading the record.
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	idx, err := a.index(id)
+	if err != nil {
+		return nil, fmt.Errorf("archive.Resource: %w", err)
+	}
+	if rawURL == "" {
+		rawURL = idx.Page
+	}
+	rec, ok := idx.Records[rawURL]
+	if !ok {
+		return nil, fmt.Errorf("archive.Resource: %w", domain.ErrArchiveNotFound)
+	}
+
+	f, err := os.Open(warcPath)
+	if err != nil {
+		return nil, fmt.Errorf("archive.Resource: %w", err)
+	}
+	defer f.Close()
+	resp, body, err := readResponse(f, rec)
+	if err != nil {
+		return nil, fmt.Errorf("archive.Resource: %w", err)
+	}
+	return &domain.ArchivedResource{
+		URL:         rawURL,
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        body,
+	}, nil
+}
+
+func (a *Archiver) Delete(_ context.Context, id string) error {
+	warcPath, indexPath, err := a.paths(id)
+	if err != nil {
+		return fmt.Errorf("archive.Delete: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.indexes, id)
+	for _, path := range []string{indexPath, warcPath} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("archive.Delete: %w", err)
+		}
+	}
+	return nil
+}
+
+// index returns the cached index of id, reading it from disk on first use.
+// Callers hold a.mu.
+func (a *Archiver) index(id string) (*index, error) {
+	if idx, ok := a.indexes[id]; ok {
+		return idx, nil
+	}
+	_, indexPath, err := a.paths(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(indexPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, domain.ErrArchiveNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var idx index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, err
+	}
+	a.indexes[id] = &idx
+	return &idx, nil
+}
+
+// paths maps an ID to its files, rejecting IDs that would escape the archive
+// directory.
+func (a *Archiver) paths(id string) (warcPath, indexPath string, err error) {
+	if id == "" || !filepath.IsLocal(id) || filepath.Base(id) != id {
+		return "", "", domain.ErrArchiveNotFound
+	}
+	base := filepath.Join(a.opts.Dir, id)
+	return base + ".warc.gz", base + ".index.json", nil
+}
+
+// capture is the state of one Capture call.
+type capture struct {
+	archiver *Archiver
+	ww       *warcWriter
+	date     time.Time
+	budget   int64 // payload bytes left
+
+	resources int
+}
+
+func (c *capture) run(ctx context.Context, rawURL string) (*index, error) {
+	resp, body, err := c.fetch(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("%w: status %d", domain.ErrPageUnavailable, resp.StatusCode)
+	}
+
+	pageURL := resp.Request.URL
+	idx := &index{Page: pageURL.String(), CapturedAt: c.date, Records: make(map[string]record)}
+	if err := c.write(idx, resp, body); err != nil {
+		return nil, err
+	}
+	idx.Records[rawURL] = idx.Records[idx.Page]
+	if !isMedia(resp, "text/html", "application/xhtml+xml") {
+		return idx, nil
+	}
+
+	// Breadth-first over the page's assets and what its stylesheets load.
+	queue := pageAssets(body, pageURL)
+	seen := map[string]bool{idx.Page: true, rawURL: true}
+	assets := 0
+	for len(queue) > 0 && assets < c.archiver.opts.MaxAssets && c.budget > 0 {
+		target := queue[0]
+		queue = queue[1:]
+		if seen[target] {
+			continue
+		}
+		seen[target] = true
+		assets++
+
+		resp, body, err := c.fetch(ctx, target)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			continue // a missing asset does not spoil the capture
+		}
+		final := resp.Request.URL.String()
+		if resp.StatusCode >= 400 || !sameOrigin(pageURL, final) {
+			continue
+		}
+		if err := c.write(idx, resp, body); err != nil {
+			return nil, err
+		}
+		// Record the asset under the URL the page uses as well.
+		if final != target {
+			idx.Records[target] = idx.Records[final]
+		}
+		if isMedia(resp, "text/css") {
+			for _, ref := range cssRefs(body) {
+				if abs, ok := resolveRef(resp.Request.URL, ref); ok && sameOrigin(pageURL, abs) {
+					queue = append(queue, abs)
+				}
+			}
+		}
+	}
+	return idx, nil
+}
+
+// fetch GETs target, reading at most the remaining budget. A body that does
+// not fit is an error.
+func (c *capture) fetch(ctx context.Context, target string) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", domain.ErrPageUnavailable, err)
+	}
+	req.Header.Set("User-Agent", c.archiver.opts.UserAgent)
+	resp, err := c.archiver.opts.Client.Do(req)
+	if errors.Is(err, outbound.ErrBlockedAddress) || errors.Is(err, outbound.ErrTooManyRedirects) {
+		return nil, nil, fmt.Errorf("%w: %v", domain.ErrPageUnavailable, err)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.budget+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if int64(len(body)) > c.budget {
+		return nil, nil, fmt.Errorf("%w: %s exceeds the archive size limit", domain.ErrPageUnavailable, target)
+	}
+	c.budget -= int64(len(body))
+	return resp, body, nil
+}
+
+func (c *capture) write(idx *index, resp *http.Response, body []byte) error {
+	rec, err := c.ww.writeExchange(resp, body, c.date)
+	if err != nil {
+		return err
+	}
+	idx.Records[resp.Request.URL.String()] = rec
+	c.resources++
+	return nil
+}
+
+func isMedia(resp *http.Response, types ...string) bool {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	for _, t := range types {
+		if mediaType == t {
+			return true
+		}
+	}
+	return false
+}
+
+func writeJSONFile(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ArchiveURL is the address an archived asset is served from, relative to
+// the page served at /bookmarks/{id}/archive.
+func ArchiveURL(asset string) string {
+	return "archive?url=" + url.QueryEscape(asset)
+}
@@ -0,0 +1,152 @@
+package archive
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSite(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/post", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<html><head><title>Post</title>
+			<link rel="stylesheet" href="/css/site.css">
+			<link rel="canonical" href="/post">
+			</head><body>
+			<img src="img/cover.png" srcset="img/cover.png 1x, img/cover@2x.png 2x">
+			<img src="https://cdn.example.net/ad.png">
+			<img src="/missing.png">
+			<a href="/about">About</a>
+			</body></html>`))
+	})
+	mux.HandleFunc("/old-post", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/post", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/css/site.css", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/css")
+		w.Write([]byte(`@font-face { src: url("../fonts/body.woff2"); }`))
+	})
+	for _, path := range []string{"/img/cover.png", "/img/cover@2x.png", "/fonts/body.woff2"} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write([]byte("binary " + path))
+		})
+	}
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "gone", http.StatusGone)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestArchiver_Capture(t *testing.T) {
+	t.Parallel()
+
+	srv := newSite(t)
+	dir := t.TempDir()
+	archiver, err := NewArchiver(Options{Dir: dir, Client: srv.Client()})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	info, err := archiver.Capture(ctx, "b1", srv.URL+"/old-post")
+	require.NoError(t, err)
+	assert.Equal(t, 5, info.Resources, "page, stylesheet, font and two images")
+	assert.Positive(t, info.Size)
+
+	page, err := archiver.Resource(ctx, "b1", "")
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL+"/post", page.URL)
+	assert.Equal(t, "text/html; charset=utf-8", page.ContentType)
+	assert.Contains(t, string(page.Body), "<title>Post</title>")
+
+	font, err := archiver.Resource(ctx, "b1", srv.URL+"/fonts/body.woff2")
+	require.NoError(t, err, "assets of stylesheets are captured")
+	assert.Equal(t, "binary /fonts/body.woff2", string(font.Body))
+
+	_, err = archiver.Resource(ctx, "b1", srv.URL+"/about")
+	assert.ErrorIs(t, err, domain.ErrArchiveNotFound, "links are not followed")
+	_, err = archiver.Resource(ctx, "b1", "https://cdn.example.net/ad.png")
+	assert.ErrorIs(t, err, domain.ErrArchiveNotFound, "other origins are not captured")
+
+	// The file is a valid multi-member gzip of WARC records.
+	f, err := os.Open(filepath.Join(dir, "b1.warc.gz"))
+	require.NoError(t, err)
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	warc, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(warc), "WARC/1.1\r\nWARC-Type: warcinfo\r\n"))
+	assert.Equal(t, 5, strings.Count(string(warc), "WARC-Type: response\r\n"))
+	assert.Equal(t, 5, strings.Count(string(warc), "WARC-Type: request\r\n"))
+
+	// A fresh Archiver reads the index from disk.
+	reopened, err := NewArchiver(Options{Dir: dir})
+	require.NoError(t, err)
+	css, err := reopened.Resource(ctx, "b1", srv.URL+"/css/site.css")
+	require.NoError(t, err)
+	assert.Equal(t, "text/css", css.ContentType)
+
+	require.NoError(t, archiver.Delete(ctx, "b1"))
+	_, err = archiver.Resource(ctx, "b1", "")
+	assert.ErrorIs(t, err, domain.ErrArchiveNotFound)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestArchiver_Limits(t *testing.T) {
+	t.Parallel()
+
+	srv := newSite(t)
+	ctx := context.Background()
+
+	t.Run("Max Assets", func(t *testing.T) {
+		archiver, err := NewArchiver(Options{Dir: t.TempDir(), Client: srv.Client(), MaxAssets: 1})
+		require.NoError(t, err)
+		info, err := archiver.Capture(ctx, "b1", srv.URL+"/post")
+		require.NoError(t, err)
+		assert.Equal(t, 2, info.Resources)
+	})
+
+	t.Run("Page Larger Than Max Bytes", func(t *testing.T) {
+		archiver, err := NewArchiver(Options{Dir: t.TempDir(), Client: srv.Client(), MaxBytes: 64})
+		require.NoError(t, err)
+		_, err = archiver.Capture(ctx, "b1", srv.URL+"/post")
+		assert.ErrorIs(t, err, domain.ErrPageUnavailable)
+	})
+
+	t.Run("Failed Capture Keeps Previous", func(t *testing.T) {
+		archiver, err := NewArchiver(Options{Dir: t.TempDir(), Client: srv.Client()})
+		require.NoError(t, err)
+		_, err = archiver.Capture(ctx, "b1", srv.URL+"/post")
+		require.NoError(t, err)
+		_, err = archiver.Capture(ctx, "b1", srv.URL+"/gone")
+		assert.ErrorIs(t, err, domain.ErrPageUnavailable)
+
+		page, err := archiver.Resource(ctx, "b1", "")
+		require.NoError(t, err)
+		assert.Equal(t, srv.URL+"/post", page.URL)
+	})
+
+	t.Run("ID Outside Directory", func(t *testing.T) {
+		archiver, err := NewArchiver(Options{Dir: t.TempDir(), Client: srv.Client()})
+		require.NoError(t, err)
+		_, err = archiver.Resource(ctx, "../b1", "")
+		assert.ErrorIs(t, err, domain.ErrArchiveNotFound)
+	})
+}
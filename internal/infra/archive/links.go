@@ -0,0 +1,328 @@
+package archive
+
+import (
+	"bytes"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// refKind says what a URL in a page is used for.
+type refKind int
+
+const (
+	refNone  refKind = iota
+	refAsset         // loaded to render the page: images, stylesheets, scripts
+	refLink          // navigated to: anchors, forms, frames
+)
+
+// attrRef classifies attribute key of element tag.
+func attrRef(tag, key string, attrs []html.Attribute) refKind {
+	switch key {
+	case "src":
+		switch tag {
+		case "img", "source", "script", "video", "audio", "track", "embed", "input":
+			return refAsset
+		case "iframe", "frame":
+			return refLink
+		}
+	case "srcset":
+		if tag == "img" || tag == "source" {
+			return refAsset
+		}
+	case "poster":
+		if tag == "video" {
+			return refAsset
+		}
+	case "data":
+		if tag == "object" {
+			return refAsset
+		}
+	case "href":
+		switch tag {
+		case "link":
+			if isAssetLink(attrs) {
+				return refAsset
+			}
+			return refLink
+		case "a", "area":
+			return refLink
+		}
+	case "action":
+		if tag == "form" {
+			return refLink
+		}
+	}
+	return refNone
+}
+
+// isAssetLink reports whether a <link> loads something the page needs.
+func isAssetLink(attrs []html.Attribute) bool {
+	for _, a := range attrs {
+		if a.Key != "rel" {
+			continue
+		}
+		for _, rel := range strings.Fields(strings.ToLower(a.Val)) {
+			switch rel {
+			case "stylesheet", "icon", "apple-touch-icon", "preload", "modulepreload":
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var (
+	cssURL    = regexp.MustCompile(`url\(\s*(?:"([^"]*)"|'([^']*)'|([^'")\s]+))\s*\)`)
+	cssImport = regexp.MustCompile(`@import\s+(?:"([^"]*)"|'([^']*)')`)
+)
+
+// cssRefs returns the URLs a stylesheet refers to, as written.
+func cssRefs(css []byte) []string {
+	var refs []string
+	for _, re := range []*regexp.Regexp{cssURL, cssImport} {
+		for _, m := range re.FindAllSubmatch(css, -1) {
+			for _, g := range m[1:] {
+				if len(g) > 0 {
+					refs = append(refs, string(g))
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// rewriteCSSRefs replaces every URL in css with what mapRef returns for it.
+func rewriteCSSRefs(css []byte, mapRef func(string) string) []byte {
+	replace := func(re *regexp.Regexp, format string) {
+		css = re.ReplaceAllFunc(css, func(m []byte) []byte {
+			sub := re.FindSubmatch(m)
+			for _, g := range sub[1:] {
+				if len(g) > 0 {
+					return []byte(strings.Replace(format, "%s", cssQuote(mapRef(string(g))), 1))
+				}
+			}
+			return m
+		})
+	}
+	replace(cssURL, "url(%s)")
+	replace(cssImport, "@import %s")
+	return css
+}
+
+func cssQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\a `).Replace(s) + `"`
+}
+
+// srcsetURLs splits a srcset value into its URLs and descriptors.
+func srcsetURLs(srcset string) (urls, descriptors []string) {
+	for _, candidate := range strings.Split(srcset, ",") {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+		urls = append(urls, fields[0])
+		descriptors = append(descriptors, strings.Join(fields[1:], " "))
+	}
+	return urls, descriptors
+}
+
+// resolveRef makes ref absolute against base. Only http(s) URLs are
+// returned; fragments are dropped since they do not change the resource.
+func resolveRef(base *url.URL, ref string) (string, bool) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "#") {
+		return "", false
+	}
+	u, err := base.Parse(ref)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", false
+	}
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String(), true
+}
+
+func sameOrigin(a *url.URL, raw string) bool {
+	b, err := url.Parse(raw)
+	return err == nil && strings.EqualFold(a.Scheme, b.Scheme) && strings.EqualFold(a.Host, b.Host)
+}
+
+// pageAssets lists the same-origin assets an HTML page loads, in the order
+// they appear, resolved against pageURL and any <base> element.
+func pageAssets(page []byte, pageURL *url.URL) []string {
+	base := pageURL
+	var assets []string
+	add := func(ref string) {
+		if abs, ok := resolveRef(base, ref); ok && sameOrigin(pageURL, abs) {
+			assets = append(assets, abs)
+		}
+	}
+
+	z := html.NewTokenizer(bytes.NewReader(page))
+	inStyle := false
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return assets
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			inStyle = tok.Data == "style"
+			for _, a := range tok.Attr {
+				switch {
+				case tok.Data == "base" && a.Key == "href":
+					if u, err := pageURL.Parse(a.Val); err == nil {
+						base = u
+					}
+				case a.Key == "style":
+					for _, ref := range cssRefs([]byte(a.Val)) {
+						add(ref)
+					}
+				case attrRef(tok.Data, a.Key, tok.Attr) == refAsset:
+					if a.Key == "srcset" {
+						urls, _ := srcsetURLs(a.Val)
+						for _, ref := range urls {
+							add(ref)
+						}
+						continue
+					}
+					add(a.Val)
+				}
+			}
+		case html.EndTagToken:
+			inStyle = false
+		case html.TextToken:
+			if inStyle {
+				for _, ref := range cssRefs(z.Text()) {
+					add(ref)
+				}
+			}
+		}
+	}
+}
+
+// RewriteHTML prepares an archived page for display. Same-origin assets are
+// pointed at archiveURL, which maps an asset's original absolute URL to the
+// address it is served from; every other URL is made absolute so that it
+// leads to the live web. <base> elements are dropped because they would
+// redirect the rewritten URLs, and integrity attributes because rewritten
+// stylesheets no longer match them.
+func RewriteHTML(page []byte, pageURL string, archiveURL func(string) string) []byte {
+	origin, err := url.Parse(pageURL)
+	if err != nil {
+		return page
+	}
+	base := origin
+	mapRef := func(kind refKind, ref string) string {
+		abs, ok := resolveRef(base, ref)
+		switch {
+		case !ok:
+			return ref
+		case kind == refAsset && sameOrigin(origin, abs):
+			return archiveURL(abs)
+		default:
+			return abs
+		}
+	}
+
+	var out bytes.Buffer
+	z := html.NewTokenizer(bytes.NewReader(page))
+	inStyle := false
+	for {
+		tt := z.Next()
+		raw := z.Raw()
+		switch tt {
+		case html.ErrorToken:
+			return out.Bytes()
+		case html.StartTagToken, html.SelfClosingTagToken:
+			raw = bytes.Clone(raw)
+			tok := z.Token()
+			inStyle = tt == html.StartTagToken && tok.Data == "style"
+			if tok.Data == "base" {
+				for _, a := range tok.Attr {
+					if u, err := origin.Parse(a.Val); err == nil && a.Key == "href" {
+						base = u
+					}
+				}
+				continue
+			}
+			if rewritten, ok := rewriteAttrs(&tok, mapRef); ok {
+				out.WriteString(rewritten)
+				continue
+			}
+			out.Write(raw)
+		case html.EndTagToken:
+			inStyle = false
+			out.Write(raw)
+		case html.TextToken:
+			if inStyle {
+				out.Write(rewriteCSSRefs(bytes.Clone(raw), func(ref string) string {
+					return mapRef(refAsset, ref)
+				}))
+				continue
+			}
+			out.Write(raw)
+		default:
+			out.Write(raw)
+		}
+	}
+}
+
+// rewriteAttrs maps the URLs in tok's attributes and returns the rewritten
+// tag, or false when nothing needed changing.
+func rewriteAttrs(tok *html.Token, mapRef func(refKind, string) string) (string, bool) {
+	changed := false
+	attrs := tok.Attr[:0:0]
+	for _, a := range tok.Attr {
+		val := a.Val
+		switch kind := attrRef(tok.Data, a.Key, tok.Attr); {
+		case a.Key == "integrity":
+			changed = true
+			continue
+		case a.Key == "style":
+			val = string(rewriteCSSRefs([]byte(a.Val), func(ref string) string {
+				return mapRef(refAsset, ref)
+			}))
+		case a.Key == "srcset" && kind == refAsset:
+			urls, descriptors := srcsetURLs(a.Val)
+			parts := make([]string, len(urls))
+			for i, ref := range urls {
+				parts[i] = strings.TrimSpace(mapRef(kind, ref) + " " + descriptors[i])
+			}
+			val = strings.Join(parts, ", ")
+		case kind != refNone:
+			val = mapRef(kind, a.Val)
+		}
+		if val != a.Val {
+			changed = true
+		}
+		attrs = append(attrs, html.Attribute{Namespace: a.Namespace, Key: a.Key, Val: val})
+	}
+	if !changed {
+		return "", false
+	}
+	tok.Attr = attrs
+	return tok.String(), true
+}
+
+// RewriteCSS points the same-origin URLs in an archived stylesheet at
+// archiveURL and makes the others absolute.
+func RewriteCSS(css []byte, cssURL string, archiveURL func(string) string) []byte {
+	base, err := url.Parse(cssURL)
+	if err != nil {
+		return css
+	}
+	return rewriteCSSRefs(css, func(ref string) string {
+		abs, ok := resolveRef(base, ref)
+		switch {
+		case !ok:
+			return ref
+		case sameOrigin(base, abs):
+			return archiveURL(abs)
+		default:
+			return abs
+		}
+	})
+}
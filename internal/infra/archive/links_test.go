@@ -0,0 +1,114 @@
+package archive
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageAssets(t *testing.T) {
+	t.Parallel()
+
+	page := `<html><head>
+		<link rel="stylesheet" href="/a.css">
+		<link rel="alternate" href="/feed.xml">
+		<style>body { background: url('bg.png') }</style>
+		<script src="app.js"></script>
+		</head><body style="background-image: url(&quot;/hero.jpg&quot;)">
+		<img srcset="small.png 480w, large.png 800w">
+		<img src="data:image/png;base64,AAAA">
+		<img src="//other.example/x.png">
+		<a href="/next">next</a>
+		</body></html>`
+	base, _ := url.Parse("https://example.com/blog/post")
+
+	assert.Equal(t, []string{
+		"https://example.com/a.css",
+		"https://example.com/blog/bg.png",
+		"https://example.com/blog/app.js",
+		"https://example.com/hero.jpg",
+		"https://example.com/blog/small.png",
+		"https://example.com/blog/large.png",
+	}, pageAssets([]byte(page), base))
+}
+
+func TestRewriteHTML(t *testing.T) {
+	t.Parallel()
+
+	archived := func(u string) string { return "archive?url=" + u }
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "Same-Origin Asset",
+			in:   `<img src="/a.png" alt="A">`,
+			want: `<img src="archive?url=https://example.com/a.png" alt="A">`,
+		},
+		{
+			name: "Other Origin Asset Made Absolute",
+			in:   `<img src="//cdn.example.net/a.png">`,
+			want: `<img src="https://cdn.example.net/a.png">`,
+		},
+		{
+			name: "Link Leads To Live Web",
+			in:   `<a href="../about">About</a>`,
+			want: `<a href="https://example.com/about">About</a>`,
+		},
+		{
+			name: "Srcset",
+			in:   `<img srcset="s.png 1x, l.png 2x">`,
+			want: `<img srcset="archive?url=https://example.com/blog/s.png 1x, archive?url=https://example.com/blog/l.png 2x">`,
+		},
+		{
+			name: "Stylesheet Loses Integrity",
+			in:   `<link rel="stylesheet" href="/a.css" integrity="sha384-abc">`,
+			want: `<link rel="stylesheet" href="archive?url=https://example.com/a.css">`,
+		},
+		{
+			name: "Inline Style",
+			in:   `<style>h1 { background: url(/h.png) }</style>`,
+			want: `<style>h1 { background: url("archive?url=https://example.com/h.png") }</style>`,
+		},
+		{
+			name: "Base Dropped And Applied",
+			in:   `<base href="https://example.com/docs/"><img src="x.png">`,
+			want: `<img src="archive?url=https://example.com/docs/x.png">`,
+		},
+		{
+			name: "Untouched Markup Kept Byte For Byte",
+			in:   `<p CLASS=intro>Fish &amp; chips<!-- note --></p><script>if (a < b) {}</script>`,
+			want: `<p CLASS=intro>Fish &amp; chips<!-- note --></p><script>if (a < b) {}</script>`,
+		},
+		{
+			name: "Fragment And Mailto Kept",
+			in:   `<a href="#top">Top</a><a href="mailto:me@example.com">Mail</a>`,
+			want: `<a href="#top">Top</a><a href="mailto:me@example.com">Mail</a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := RewriteHTML([]byte(tt.in), "https://example.com/blog/post", archived)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestRewriteCSS(t *testing.T) {
+	t.Parallel()
+
+	css := `@import "print.css"; .a { background: url(../img/a.png) } .b { background: url('https://cdn.example.net/b.png') } .c { mask: url(#m) }`
+	got := string(RewriteCSS([]byte(css), "https://example.com/css/site.css", ArchiveURL))
+
+	assert.Contains(t, got, `@import "archive?url=`+url.QueryEscape("https://example.com/css/print.css")+`"`)
+	assert.Contains(t, got, `url("archive?url=`+url.QueryEscape("https://example.com/img/a.png")+`")`)
+	assert.Contains(t, got, `url("https://cdn.example.net/b.png")`)
+	assert.True(t, strings.HasSuffix(got, `.c { mask: url("#m") }`))
+}
@@ -0,0 +1,156 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/base32"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// warcWriter writes WARC/1.1 records, each in its own gzip member so that a
+// record can be read on its own from its offset, as replay tools expect of
+// .warc.gz files.
+type warcWriter struct {
+	w      io.Writer
+	offset int64
+}
+
+// record locates one record in the file.
+type record struct {
+	Offset int64 `json:"offset"`
+	Length int64 `json:"length"`
+}
+
+func (ww *warcWriter) writeRecord(headers [][2]string, block []byte) (record, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	fmt.Fprint(zw, "WARC/1.1\r\n")
+	for _, h := range headers {
+		fmt.Fprintf(zw, "%s: %s\r\n", h[0], h[1])
+	}
+	fmt.Fprintf(zw, "Content-Length: %d\r\n\r\n", len(block))
+	zw.Write(block)
+	fmt.Fprint(zw, "\r\n\r\n")
+	if err := zw.Close(); err != nil {
+		return record{}, err
+	}
+
+	rec := record{Offset: ww.offset, Length: int64(buf.Len())}
+	n, err := ww.w.Write(buf.Bytes())
+	ww.offset += int64(n)
+	return rec, err
+}
+
+func (ww *warcWriter) writeInfo(date time.Time) error {
+	block := "software: goprod\r\nformat: WARC File Format 1.1\r\n"
+	_, err := ww.writeRecord([][2]string{
+		{"WARC-Type", "warcinfo"},
+		{"WARC-Record-ID", recordID()},
+		{"WARC-Date", date.UTC().Format(time.RFC3339)},
+		{"Content-Type", "application/warc-fields"},
+	}, []byte(block))
+	return err
+}
+
+// writeExchange writes a request record and the response record for it. body
+// is the payload as received, after any content encoding was removed.
+func (ww *warcWriter) writeExchange(resp *http.Response, body []byte, date time.Time) (record, error) {
+	target := resp.Request.URL.String()
+	warcDate := date.UTC().Format(time.RFC3339)
+	responseID := recordID()
+
+	var req bytes.Buffer
+	fmt.Fprintf(&req, "%s %s HTTP/1.1\r\nHost: %s\r\n", resp.Request.Method, resp.Request.URL.RequestURI(), resp.Request.URL.Host)
+	resp.Request.Header.Write(&req)
+	req.WriteString("\r\n")
+	_, err := ww.writeRecord([][2]string{
+		{"WARC-Type", "request"},
+		{"WARC-Record-ID", recordID()},
+		{"WARC-Date", warcDate},
+		{"WARC-Target-URI", target},
+		{"WARC-Concurrent-To", responseID},
+		{"Content-Type", "application/http;msgtype=request"},
+	}, req.Bytes())
+	if err != nil {
+		return record{}, err
+	}
+
+	header := resp.Header.Clone()
+	header.Del("Content-Encoding")
+	header.Del("Transfer-Encoding")
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	var block bytes.Buffer
+	fmt.Fprintf(&block, "HTTP/1.1 %s\r\n", resp.Status)
+	header.Write(&block)
+	block.WriteString("\r\n")
+	block.Write(body)
+
+	return ww.writeRecord([][2]string{
+		{"WARC-Type", "response"},
+		{"WARC-Record-ID", responseID},
+		{"WARC-Date", warcDate},
+		{"WARC-Target-URI", target},
+		{"WARC-Payload-Digest", digest(body)},
+		{"WARC-Block-Digest", digest(block.Bytes())},
+		{"Content-Type", "application/http;msgtype=response"},
+	}, block.Bytes())
+}
+
+// readResponse reads the response record at rec from r.
+func readResponse(r io.ReaderAt, rec record) (*http.Response, []byte, error) {
+	zr, err := gzip.NewReader(io.NewSectionReader(r, rec.Offset, rec.Length))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer zr.Close()
+
+	tp := textproto.NewReader(bufio.NewReader(zr))
+	version, err := tp.ReadLine()
+	if err != nil {
+		return nil, nil, err
+	}
+	if version != "WARC/1.1" && version != "WARC/1.0" {
+		return nil, nil, fmt.Errorf("not a WARC record: %q", version)
+	}
+	headers, err := tp.ReadMIMEHeader()
+	if err != nil {
+		return nil, nil, err
+	}
+	if t := headers.Get("WARC-Type"); t != "response" {
+		return nil, nil, fmt.Errorf("WARC record is a %s, not a response", t)
+	}
+	length, err := strconv.ParseInt(headers.Get("Content-Length"), 10, 64)
+	if err != nil {
+		return nil, nil, fmt.Errorf("WARC record length: %w", err)
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(io.LimitReader(tp.R, length)), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, body, nil
+}
+
+func recordID() string {
+	return "<urn:uuid:" + uuid.NewString() + ">"
+}
+
+// digest is the SHA-1 digest in the base32 form WARC tools use.
+func digest(b []byte) string {
+	sum := sha1.Sum(b)
+	return "sha1:" + base32.StdEncoding.EncodeToString(sum[:])
+}
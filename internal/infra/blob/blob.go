@@ -0,0 +1,56 @@
+// Package blob implements domain.BlobStore on a local directory and on any
+// service that speaks the S3 API.
+package blob
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// copyHashed copies r to dst and returns the hex SHA-256 of what it copied,
+// which is the content's key.
+func copyHashed(ctx context.Context, dst io.Writer, r io.Reader) (key string, size int64, err error) {
+	h := sha256.New()
+	size, err = io.Copy(io.MultiWriter(dst, h), &ctxReader{ctx: ctx, r: r})
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// ctxReader stops a long copy once ctx is done.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+func checkKey(key string) error {
+	if !domain.ValidBlobKey(key) {
+		return fmt.Errorf("%w: %q", domain.ErrInvalidBlobKey, key)
+	}
+	return nil
+}
+
+// checkRange validates a GetRange request against a blob of the given size
+// and returns how many bytes it covers.
+func checkRange(size, offset, length int64) (int64, error) {
+	if offset < 0 || offset >= size || length == 0 {
+		return 0, fmt.Errorf("%w: offset %d, length %d of %d bytes", domain.ErrInvalidRange, offset, length, size)
+	}
+	if length < 0 || offset+length > size {
+		length = size - offset
+	}
+	return length, nil
+}
@@ -0,0 +1,174 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// tmpDir holds content being put until its key is known. It sits inside the
+// store's directory so that the final rename stays on one file system.
+const tmpDir = "tmp"
+
+// FileStore implements domain.BlobStore in a local directory. Blobs are fanned
+// out over two levels of subdirectories by the first characters of their key,
+// e.g. 9f/86/9f86d081…, to keep directories small.
+type FileStore struct {
+	dir string
+}
+
+var _ domain.BlobStore = (*FileStore)(nil)
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Join(dir, tmpDir), 0o750); err != nil {
+		return nil, fmt.Errorf("blob.NewFileStore: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.dir, key[:2], key[2:4], key)
+}
+
+func (s *FileStore) Put(ctx context.Context, r io.Reader) (domain.BlobInfo, error) {
+	tmp, err := os.CreateTemp(filepath.Join(s.dir, tmpDir), "put-*")
+	if err != nil {
+		return domain.BlobInfo{}, fmt.Errorf("blob.Put: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	key, size, err := copyHashed(ctx, tmp, r)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return domain.BlobInfo{}, fmt.Errorf("blob.Put: %w", err)
+	}
+
+	final := s.path(key)
+	now := time.Now()
+	if _, err := os.Stat(final); err == nil {
+		// Already stored. Touching it keeps garbage collection from taking
+		// it before the caller records its reference.
+		if err := os.Chtimes(final, now, now); err != nil {
+			return domain.BlobInfo{}, fmt.Errorf("blob.Put: %w", err)
+		}
+		return domain.BlobInfo{Key: key, Size: size, ModTime: now}, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(final), 0o750); err != nil {
+		return domain.BlobInfo{}, fmt.Errorf("blob.Put: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), final); err != nil {
+		return domain.BlobInfo{}, fmt.Errorf("blob.Put: %w", err)
+	}
+	return s.Stat(ctx, key)
+}
+
+func (s *FileStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	f, err := s.open(key)
+	if err != nil {
+		return nil, fmt.Errorf("blob.Get: %w", err)
+	}
+	return f, nil
+}
+
+func (s *FileStore) GetRange(_ context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	f, err := s.open(key)
+	if err != nil {
+		return nil, fmt.Errorf("blob.GetRange: %w", err)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("blob.GetRange: %w", err)
+	}
+	n, err := checkRange(fi.Size(), offset, length)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("blob.GetRange: %w", err)
+	}
+	return &sectionReadCloser{SectionReader: io.NewSectionReader(f, offset, n), Closer: f}, nil
+}
+
+type sectionReadCloser struct {
+	*io.SectionReader
+	io.Closer
+}
+
+func (s *FileStore) open(key string) (*os.File, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, domain.ErrBlobNotFound
+	}
+	return f, err
+}
+
+func (s *FileStore) Stat(_ context.Context, key string) (domain.BlobInfo, error) {
+	if err := checkKey(key); err != nil {
+		return domain.BlobInfo{}, fmt.Errorf("blob.Stat: %w", err)
+	}
+	fi, err := os.Stat(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return domain.BlobInfo{}, fmt.Errorf("blob.Stat: %w", domain.ErrBlobNotFound)
+	}
+	if err != nil {
+		return domain.BlobInfo{}, fmt.Errorf("blob.Stat: %w", err)
+	}
+	return domain.BlobInfo{Key: key, Size: fi.Size(), ModTime: fi.ModTime()}, nil
+}
+
+func (s *FileStore) Delete(_ context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return fmt.Errorf("blob.Delete: %w", err)
+	}
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("blob.Delete: %w", err)
+	}
+	return nil
+}
+
+func (s *FileStore) Walk(ctx context.Context, fn func(domain.BlobInfo) error) error {
+	err := filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == filepath.Join(s.dir, tmpDir) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !domain.ValidBlobKey(d.Name()) {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		fi, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			// Deleted since the directory was read.
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return fn(domain.BlobInfo{Key: d.Name(), Size: fi.Size(), ModTime: fi.ModTime()})
+	})
+	if err != nil {
+		return fmt.Errorf("blob.Walk: %w", err)
+	}
+	return nil
+}
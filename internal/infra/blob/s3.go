@@ -0,0 +1,340 @@
+package blob
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+type S3Options struct {
+	// Endpoint is the service's base URL, e.g.
+	// https://s3.eu-west-1.amazonaws.com or http://localhost:9000.
+	Endpoint string
+	Region   string
+	Bucket   string
+	// Prefix is put before every key, so that one bucket can hold several
+	// stores, e.g. "goprod/".
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PathStyle puts the bucket in the URL path rather than the host name,
+	// as MinIO and most other S3-compatible services expect.
+	PathStyle bool
+	// Client sends every request. Defaults to http.DefaultClient.
+	Client *http.Client
+	// TempDir is where Put spools content while hashing it, since the key
+	// must be known before the upload starts. Defaults to os.TempDir().
+	TempDir string
+}
+
+// S3Store implements domain.BlobStore on a bucket of any service that speaks
+// the S3 API. Objects are named Prefix followed by the blob key. Single
+// uploads limit blobs to 5 GiB.
+type S3Store struct {
+	opts     S3Options
+	endpoint *url.URL
+	signer   signer
+	now      func() time.Time
+}
+
+var _ domain.BlobStore = (*S3Store)(nil)
+
+func NewS3Store(opts S3Options) (*S3Store, error) {
+	endpoint, err := url.Parse(opts.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("blob.NewS3Store: invalid endpoint %q", opts.Endpoint)
+	}
+	if opts.Bucket == "" {
+		return nil, errors.New("blob.NewS3Store: bucket is required")
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	return &S3Store{
+		opts:     opts,
+		endpoint: endpoint,
+		signer:   signer{accessKeyID: opts.AccessKeyID, secretAccessKey: opts.SecretAccessKey, region: opts.Region},
+		now:      time.Now,
+	}, nil
+}
+
+// s3Error is an error response from the service.
+type s3Error struct {
+	StatusCode int
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e *s3Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("s3: status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("s3: status %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+func (s *S3Store) Put(ctx context.Context, r io.Reader) (domain.BlobInfo, error) {
+	tmp, err := os.CreateTemp(s.opts.TempDir, "blob-*")
+	if err != nil {
+		return domain.BlobInfo{}, fmt.Errorf("blob.Put: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	key, size, err := copyHashed(ctx, tmp, r)
+	if err != nil {
+		return domain.BlobInfo{}, fmt.Errorf("blob.Put: %w", err)
+	}
+
+	if _, err := s.Stat(ctx, key); err == nil {
+		// Already stored. Copying it onto itself refreshes its modification
+		// time, which keeps garbage collection from taking it before the
+		// caller records its reference.
+		if err := s.touch(ctx, key); err != nil {
+			return domain.BlobInfo{}, fmt.Errorf("blob.Put: %w", err)
+		}
+		return domain.BlobInfo{Key: key, Size: size, ModTime: s.now()}, nil
+	} else if !errors.Is(err, domain.ErrBlobNotFound) {
+		return domain.BlobInfo{}, fmt.Errorf("blob.Put: %w", err)
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return domain.BlobInfo{}, fmt.Errorf("blob.Put: %w", err)
+	}
+	req, err := s.newRequest(ctx, http.MethodPut, s.objectPath(key), nil, io.NopCloser(tmp))
+	if err != nil {
+		return domain.BlobInfo{}, fmt.Errorf("blob.Put: %w", err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	// The key is the SHA-256 of the body, which is what signing needs.
+	resp, err := s.do(req, key)
+	if err != nil {
+		return domain.BlobInfo{}, fmt.Errorf("blob.Put: %w", err)
+	}
+	resp.Body.Close()
+	return domain.BlobInfo{Key: key, Size: size, ModTime: s.now()}, nil
+}
+
+func (s *S3Store) touch(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodPut, s.objectPath(key), nil, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Amz-Copy-Source", uriEncode("/"+s.opts.Bucket+"/"+s.opts.Prefix+key, false))
+	req.Header.Set("X-Amz-Metadata-Directive", "REPLACE")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := s.do(req, emptySHA256)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// A copy can fail after the 200 has been sent, in which case the body is
+	// an error document.
+	return readCopyResult(resp)
+}
+
+func readCopyResult(resp *http.Response) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return err
+	}
+	var e s3Error
+	if xml.Unmarshal(body, &e) == nil && e.Code != "" {
+		e.StatusCode = resp.StatusCode
+		return &e
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	body, err := s.get(ctx, key, "")
+	if err != nil {
+		return nil, fmt.Errorf("blob.Get: %w", err)
+	}
+	return body, nil
+}
+
+func (s *S3Store) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	if offset < 0 || length == 0 {
+		return nil, fmt.Errorf("blob.GetRange: %w: offset %d, length %d", domain.ErrInvalidRange, offset, length)
+	}
+	byteRange := fmt.Sprintf("bytes=%d-", offset)
+	if length > 0 {
+		byteRange += strconv.FormatInt(offset+length-1, 10)
+	}
+	body, err := s.get(ctx, key, byteRange)
+	if err != nil {
+		return nil, fmt.Errorf("blob.GetRange: %w", err)
+	}
+	return body, nil
+}
+
+func (s *S3Store) get(ctx context.Context, key, byteRange string) (io.ReadCloser, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	req, err := s.newRequest(ctx, http.MethodGet, s.objectPath(key), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if byteRange != "" {
+		req.Header.Set("Range", byteRange)
+	}
+	resp, err := s.do(req, emptySHA256)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *S3Store) Stat(ctx context.Context, key string) (domain.BlobInfo, error) {
+	if err := checkKey(key); err != nil {
+		return domain.BlobInfo{}, fmt.Errorf("blob.Stat: %w", err)
+	}
+	req, err := s.newRequest(ctx, http.MethodHead, s.objectPath(key), nil, nil)
+	if err != nil {
+		return domain.BlobInfo{}, fmt.Errorf("blob.Stat: %w", err)
+	}
+	resp, err := s.do(req, emptySHA256)
+	if err != nil {
+		return domain.BlobInfo{}, fmt.Errorf("blob.Stat: %w", err)
+	}
+	resp.Body.Close()
+	modTime, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return domain.BlobInfo{Key: key, Size: resp.ContentLength, ModTime: modTime}, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return fmt.Errorf("blob.Delete: %w", err)
+	}
+	req, err := s.newRequest(ctx, http.MethodDelete, s.objectPath(key), nil, nil)
+	if err != nil {
+		return fmt.Errorf("blob.Delete: %w", err)
+	}
+	resp, err := s.do(req, emptySHA256)
+	if errors.Is(err, domain.ErrBlobNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("blob.Delete: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// listResult is a page of ListObjectsV2.
+type listResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *S3Store) Walk(ctx context.Context, fn func(domain.BlobInfo) error) error {
+	query := url.Values{"list-type": {"2"}, "prefix": {s.opts.Prefix}}
+	for {
+		req, err := s.newRequest(ctx, http.MethodGet, s.bucketPath(), query, nil)
+		if err != nil {
+			return fmt.Errorf("blob.Walk: %w", err)
+		}
+		resp, err := s.do(req, emptySHA256)
+		if err != nil {
+			return fmt.Errorf("blob.Walk: %w", err)
+		}
+		var page listResult
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("blob.Walk: %w", err)
+		}
+
+		for _, obj := range page.Contents {
+			key := strings.TrimPrefix(obj.Key, s.opts.Prefix)
+			if !domain.ValidBlobKey(key) {
+				continue
+			}
+			if err := fn(domain.BlobInfo{Key: key, Size: obj.Size, ModTime: obj.LastModified}); err != nil {
+				return fmt.Errorf("blob.Walk: %w", err)
+			}
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return nil
+		}
+		query.Set("continuation-token", page.NextContinuationToken)
+	}
+}
+
+// bucketPath and objectPath are unescaped URL paths; with virtual-hosted
+// addressing the bucket is in the host name instead.
+func (s *S3Store) bucketPath() string {
+	if s.opts.PathStyle {
+		return "/" + s.opts.Bucket + "/"
+	}
+	return "/"
+}
+
+func (s *S3Store) objectPath(key string) string {
+	return s.bucketPath() + s.opts.Prefix + key
+}
+
+func (s *S3Store) newRequest(ctx context.Context, method, path string, query url.Values, body io.ReadCloser) (*http.Request, error) {
+	u := *s.endpoint
+	if !s.opts.PathStyle {
+		u.Host = s.opts.Bucket + "." + u.Host
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	u.RawPath = uriEncode(u.Path, false)
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Body = body
+	}
+	return req, nil
+}
+
+// do signs and sends req, turning error responses into errors: 404 into
+// domain.ErrBlobNotFound and 416 into domain.ErrInvalidRange.
+func (s *S3Store) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	s.signer.sign(req, payloadHash, s.now())
+	resp, err := s.opts.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return nil, domain.ErrBlobNotFound
+	case http.StatusRequestedRangeNotSatisfiable:
+		return nil, domain.ErrInvalidRange
+	}
+	e := &s3Error{StatusCode: resp.StatusCode}
+	if body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10)); err == nil {
+		xml.Unmarshal(body, e)
+	}
+	return nil, e
+}
@@ -0,0 +1,164 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func keyOf(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// readAll returns a function that reads what Get or GetRange returned.
+func readAll(t *testing.T) func(io.ReadCloser, error) string {
+	return func(rc io.ReadCloser, err error) string {
+		t.Helper()
+
+		require.NoError(t, err)
+		defer rc.Close()
+		b, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		return string(b)
+	}
+}
+
+// testStore runs the behavior every domain.BlobStore shares. age makes a blob
+// look as if it was last put an hour ago.
+func testStore(t *testing.T, store domain.BlobStore, age func(key string)) {
+	ctx := context.Background()
+	const content = "The quick brown fox jumps over the lazy dog"
+	key := keyOf(content)
+
+	info, err := store.Put(ctx, strings.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, key, info.Key)
+	assert.Equal(t, int64(len(content)), info.Size)
+
+	t.Run("Get", func(t *testing.T) {
+		assert.Equal(t, content, readAll(t)(store.Get(ctx, key)))
+	})
+
+	t.Run("Stat", func(t *testing.T) {
+		info, err := store.Stat(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(content)), info.Size)
+		assert.WithinDuration(t, time.Now(), info.ModTime, time.Minute)
+	})
+
+	t.Run("Ranges", func(t *testing.T) {
+		assert.Equal(t, "quick", readAll(t)(store.GetRange(ctx, key, 4, 5)))
+		assert.Equal(t, "lazy dog", readAll(t)(store.GetRange(ctx, key, 35, -1)))
+		assert.Equal(t, "dog", readAll(t)(store.GetRange(ctx, key, 40, 100)), "cut short at the end")
+
+		for _, r := range [][2]int64{{int64(len(content)), 1}, {-1, 5}, {0, 0}} {
+			_, err := store.GetRange(ctx, key, r[0], r[1])
+			assert.ErrorIs(t, err, domain.ErrInvalidRange, "offset %d, length %d", r[0], r[1])
+		}
+	})
+
+	t.Run("Missing And Invalid Keys", func(t *testing.T) {
+		missing := keyOf("nothing")
+		_, err := store.Get(ctx, missing)
+		assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+		_, err = store.Stat(ctx, missing)
+		assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+		_, err = store.GetRange(ctx, missing, 0, 1)
+		assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+
+		for _, bad := range []string{"", "../../etc/passwd", strings.ToUpper(key), key[:63]} {
+			_, err := store.Get(ctx, bad)
+			assert.ErrorIs(t, err, domain.ErrInvalidBlobKey, bad)
+			assert.ErrorIs(t, store.Delete(ctx, bad), domain.ErrInvalidBlobKey, bad)
+		}
+	})
+
+	t.Run("Put Again Refreshes", func(t *testing.T) {
+		age(key)
+		old, err := store.Stat(ctx, key)
+		require.NoError(t, err)
+		require.True(t, old.ModTime.Before(time.Now().Add(-30*time.Minute)))
+
+		_, err = store.Put(ctx, strings.NewReader(content))
+		require.NoError(t, err)
+		info, err := store.Stat(ctx, key)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), info.ModTime, time.Minute)
+	})
+
+	t.Run("Large Blob", func(t *testing.T) {
+		big := bytes.Repeat([]byte("0123456789abcdef"), 1<<16)
+		info, err := store.Put(ctx, bytes.NewReader(big))
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(big)), info.Size)
+		assert.Equal(t, string(big[1<<19:1<<19+16]), readAll(t)(store.GetRange(ctx, info.Key, 1<<19, 16)))
+	})
+
+	t.Run("Walk And Delete", func(t *testing.T) {
+		other, err := store.Put(ctx, strings.NewReader("another blob"))
+		require.NoError(t, err)
+
+		var keys []string
+		require.NoError(t, store.Walk(ctx, func(info domain.BlobInfo) error {
+			keys = append(keys, info.Key)
+			return nil
+		}))
+		assert.Contains(t, keys, key)
+		assert.Contains(t, keys, other.Key)
+
+		require.NoError(t, store.Delete(ctx, other.Key))
+		require.NoError(t, store.Delete(ctx, other.Key), "deleting twice is fine")
+		_, err = store.Stat(ctx, other.Key)
+		assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+
+		keys = nil
+		require.NoError(t, store.Walk(ctx, func(info domain.BlobInfo) error {
+			keys = append(keys, info.Key)
+			return nil
+		}))
+		assert.False(t, slices.Contains(keys, other.Key))
+	})
+
+	t.Run("Canceled Put", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := store.Put(canceled, strings.NewReader("never stored"))
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = store.Stat(ctx, keyOf("never stored"))
+		assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+	})
+}
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	testStore(t, store, func(key string) {
+		old := time.Now().Add(-time.Hour)
+		require.NoError(t, os.Chtimes(store.path(key), old, old))
+	})
+
+	// Content is fanned out by key, and nothing is left behind in tmp.
+	key := keyOf("The quick brown fox jumps over the lazy dog")
+	_, err = os.Stat(filepath.Join(dir, key[:2], key[2:4], key))
+	assert.NoError(t, err)
+	entries, err := os.ReadDir(filepath.Join(dir, tmpDir))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,214 @@
+package codec
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// CitationKey derives a BibTeX-style key from what is known about a bookmark:
+// the author's last name (or the site's host when there is no author), the
+// year it was saved and the first significant word of the title, e.g.
+// "pike2024concurrency" or "godev2024effective". The key only changes when
+// those inputs change.
+func CitationKey(b *domain.Bookmark) string {
+	var who string
+	if author := b.Metadata[domain.MetaAuthor]; author != "" {
+		fields := strings.Fields(strings.Split(author, ",")[0])
+		if len(fields) > 0 {
+			who = fields[len(fields)-1]
+		}
+	}
+	if who == "" {
+		if u, err := url.Parse(b.URL); err == nil {
+			who = strings.TrimPrefix(u.Hostname(), "www.")
+		}
+	}
+
+	var word string
+	for _, w := range strings.Fields(b.Title) {
+		if w = keyPart(w); w != "" && !stopWords[w] {
+			word = w
+			break
+		}
+	}
+
+	key := keyPart(who)
+	if !b.CreatedAt.IsZero() {
+		key += strconv.Itoa(b.CreatedAt.Year())
+	}
+	key += word
+	if key == "" {
+		key = "bookmark"
+	}
+	return key
+}
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "of": true, "on": true, "in": true,
+	"and": true, "for": true, "to": true, "with": true, "from": true, "about": true,
+}
+
+// keyPart lower-cases s and keeps only ASCII letters and digits, the
+// characters every BibTeX tool accepts in a key.
+func keyPart(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// citationKeys hands out keys that are unique within one document by
+// appending a, b, c... to repeats, as reference managers do.
+type citationKeys map[string]int
+
+func (k citationKeys) next(b *domain.Bookmark) string {
+	key := CitationKey(b)
+	n := k[key]
+	k[key] = n + 1
+	if n == 0 {
+		return key
+	}
+	return key + suffix(n)
+}
+
+// suffix maps 1 to "a", 26 to "z", 27 to "aa" and so on.
+func suffix(n int) string {
+	var s []byte
+	for n > 0 {
+		n--
+		s = append([]byte{byte('a' + n%26)}, s...)
+		n /= 26
+	}
+	return string(s)
+}
+
+// bibtexEncoder writes biblatex @online entries. The publisher is written as
+// organization, the field @online defines for the body behind a web page.
+type bibtexEncoder struct {
+	w    io.Writer
+	keys citationKeys
+}
+
+func NewBibTeXEncoder(w io.Writer) Encoder {
+	return &bibtexEncoder{w: w, keys: citationKeys{}}
+}
+
+func (e *bibtexEncoder) Encode(b *domain.Bookmark) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "@online{%s,\n", e.keys.next(b))
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&sb, "  %-12s = {%s},\n", name, value)
+		}
+	}
+	field("title", escapeBibTeX(b.Title))
+	field("author", escapeBibTeX(b.Metadata[domain.MetaAuthor]))
+	field("organization", escapeBibTeX(b.Metadata[domain.MetaPublisher]))
+	field("url", strings.NewReplacer("{", "%7B", "}", "%7D").Replace(b.URL))
+	if !b.CreatedAt.IsZero() {
+		field("urldate", b.CreatedAt.Format("2006-01-02"))
+	}
+	field("abstract", escapeBibTeX(b.Description))
+	field("keywords", escapeBibTeX(strings.Join(b.Tags, ", ")))
+	sb.WriteString("}\n\n")
+
+	_, err := io.WriteString(e.w, sb.String())
+	return err
+}
+
+func (e *bibtexEncoder) Close() error { return nil }
+
+// escapeBibTeX protects the characters LaTeX treats specially. Braces are
+// escaped too, so a value can never close its field early.
+func escapeBibTeX(s string) string {
+	return strings.NewReplacer(
+		`\`, `\textbackslash{}`,
+		`{`, `\{`,
+		`}`, `\}`,
+		`&`, `\&`,
+		`%`, `\%`,
+		`$`, `\$`,
+		`#`, `\#`,
+		`_`, `\_`,
+		`^`, `\^{}`,
+		`~`, `\~{}`,
+		"\n", " ",
+	).Replace(s)
+}
+
+// cslItem is the subset of CSL-JSON (https://citeproc-js.readthedocs.io)
+// that bookmarks can fill.
+type cslItem struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	Title     string      `json:"title"`
+	URL       string      `json:"URL"`
+	Accessed  *cslDate    `json:"accessed,omitempty"`
+	Author    []cslName   `json:"author,omitempty"`
+	Publisher string      `json:"publisher,omitempty"`
+	Abstract  string      `json:"abstract,omitempty"`
+	Keyword   string      `json:"keyword,omitempty"`
+	Custom    *cslCustoms `json:"custom,omitempty"`
+}
+
+type cslDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+// cslName holds authors as literal names: splitting into given and family
+// names goes wrong too often for free-form page metadata.
+type cslName struct {
+	Literal string `json:"literal"`
+}
+
+type cslCustoms struct {
+	BookmarkID string `json:"bookmark_id"`
+}
+
+// cslEncoder writes a CSL-JSON array of "webpage" items.
+type cslEncoder struct {
+	json *jsonEncoder
+	keys citationKeys
+}
+
+func NewCSLJSONEncoder(w io.Writer) Encoder {
+	return &cslEncoder{json: &jsonEncoder{w: w}, keys: citationKeys{}}
+}
+
+func (e *cslEncoder) Encode(b *domain.Bookmark) error {
+	item := cslItem{
+		ID:        e.keys.next(b),
+		Type:      "webpage",
+		Title:     b.Title,
+		URL:       b.URL,
+		Publisher: b.Metadata[domain.MetaPublisher],
+		Abstract:  b.Description,
+		Keyword:   strings.Join(b.Tags, ", "),
+		Custom:    &cslCustoms{BookmarkID: b.ID},
+	}
+	if !b.CreatedAt.IsZero() {
+		y, m, d := b.CreatedAt.Date()
+		item.Accessed = &cslDate{DateParts: [][]int{{y, int(m), d}}}
+	}
+	if author := b.Metadata[domain.MetaAuthor]; author != "" {
+		item.Author = []cslName{{Literal: author}}
+	}
+
+	if err := e.json.write(item); err != nil {
+		return fmt.Errorf("codec.csl-json: %w", err)
+	}
+	return nil
+}
+
+func (e *cslEncoder) Close() error {
+	return e.json.Close()
+}
@@ -0,0 +1,106 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCitationKey(t *testing.T) {
+	t.Parallel()
+
+	saved := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		b    domain.Bookmark
+		want string
+	}{
+		{
+			name: "Author Last Name",
+			b:    domain.Bookmark{URL: "https://go.dev/blog/pipelines", Title: "Go Concurrency Patterns: Pipelines", CreatedAt: saved, Metadata: map[string]string{domain.MetaAuthor: "Sameer Ajmani"}},
+			want: "ajmani2024go",
+		},
+		{
+			name: "Host Without Author",
+			b:    domain.Bookmark{URL: "https://www.example.com/a", Title: "The Art of Testing", CreatedAt: saved},
+			want: "examplecom2024art",
+		},
+		{
+			name: "Accents And Punctuation Dropped",
+			b:    domain.Bookmark{URL: "https://example.com", Title: "¿Qué? Überblick", CreatedAt: saved, Metadata: map[string]string{domain.MetaAuthor: "O'Brien, Jo"}},
+			want: "obrien2024qu",
+		},
+		{
+			name: "Nothing Known",
+			b:    domain.Bookmark{Title: "—"},
+			want: "bookmark",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, CitationKey(&tt.b))
+		})
+	}
+}
+
+func TestBibTeXEncoder(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	enc := NewBibTeXEncoder(&buf)
+	b := sampleBookmarks()[0]
+	require.NoError(t, enc.Encode(b))
+	require.NoError(t, enc.Encode(b))
+	require.NoError(t, enc.Close())
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "@online{authors2024effective,\n"), out)
+	assert.Contains(t, out, "@online{authors2024effectivea,\n", "repeated keys get a suffix")
+	assert.Contains(t, out, "  title        = {Effective Go, \"the\" guide},\n")
+	assert.Contains(t, out, "  author       = {The Go Authors},\n")
+	assert.Contains(t, out, "  organization = {Google},\n")
+	assert.Contains(t, out, "  url          = {https://go.dev/doc/effective_go?x=1&y=2},\n")
+	assert.Contains(t, out, "  urldate      = {2024-03-01},\n")
+	assert.Contains(t, out, "  abstract     = {Line one line two <b>\\& co</b>},\n")
+	assert.Contains(t, out, "  keywords     = {go, docs, reference, with space},\n")
+}
+
+func TestEscapeBibTeX(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `50\% of \{x\} \& \$y\_z \#1 \textbackslash{}n`, escapeBibTeX(`50% of {x} & $y_z #1 \n`))
+}
+
+func TestCSLJSONEncoder(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	enc := NewCSLJSONEncoder(&buf)
+	for _, b := range sampleBookmarks() {
+		require.NoError(t, enc.Encode(b))
+	}
+	require.NoError(t, enc.Close())
+
+	var items []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &items))
+	require.Len(t, items, 2)
+
+	assert.Equal(t, "authors2024effective", items[0]["id"])
+	assert.Equal(t, "webpage", items[0]["type"])
+	assert.Equal(t, "https://go.dev/doc/effective_go?x=1&y=2", items[0]["URL"])
+	assert.Equal(t, []any{map[string]any{"literal": "The Go Authors"}}, items[0]["author"])
+	assert.Equal(t, "Google", items[0]["publisher"])
+	assert.Equal(t, map[string]any{"date-parts": []any{[]any{2024.0, 3.0, 1.0}}}, items[0]["accessed"])
+
+	assert.Equal(t, "examplecom2024example", items[1]["id"])
+	assert.NotContains(t, items[1], "author")
+}
@@ -0,0 +1,146 @@
+// Package codec converts bookmarks to and from the file formats used by export
+// and import. Every encoder writes one bookmark at a time so that large
+// collections can be streamed straight from the repository to the client.
+//
+// The schema of each format is documented in docs/Export.md.
+package codec
+
+import (
+	"io"
+	"mime"
+	"slices"
+	"strings"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// Encoder writes bookmarks in a single format. Close must be called once after
+// the last bookmark to terminate the document; it does not close the
+// underlying writer.
+type Encoder interface {
+	Encode(b *domain.Bookmark) error
+	Close() error
+}
+
+// Decoder reads bookmarks one at a time and returns io.EOF after the last one.
+type Decoder interface {
+	Decode() (*domain.Bookmark, error)
+}
+
+type Format struct {
+	Name        string
+	ContentType string
+	Extension   string
+	NewEncoder  func(w io.Writer) Encoder
+	// NewDecoder is nil for formats that cannot be imported back.
+	NewDecoder func(r io.Reader) Decoder
+}
+
+var formats = []Format{
+	{
+		Name:        "json",
+		ContentType: "application/json",
+		Extension:   "json",
+		NewEncoder:  NewJSONEncoder,
+		NewDecoder:  NewJSONDecoder,
+	},
+	{
+		Name:        "ndjson",
+		ContentType: "application/x-ndjson",
+		Extension:   "ndjson",
+		NewEncoder:  NewNDJSONEncoder,
+		NewDecoder:  NewNDJSONDecoder,
+	},
+	{
+		Name:        "csv",
+		ContentType: "text/csv",
+		Extension:   "csv",
+		NewEncoder:  NewCSVEncoder,
+		NewDecoder:  NewCSVDecoder,
+	},
+	{
+		Name:        "xbel",
+		ContentType: "application/xbel+xml",
+		Extension:   "xbel",
+		NewEncoder:  NewXBELEncoder,
+		NewDecoder:  NewXBELDecoder,
+	},
+	{
+		Name:        "markdown",
+		ContentType: "application/zip",
+		Extension:   "zip",
+		NewEncoder:  NewMarkdownZipEncoder,
+	},
+	{
+		Name:        "markdown-by-tag",
+		ContentType: "application/zip",
+		Extension:   "zip",
+		NewEncoder:  NewMarkdownByTagZipEncoder,
+	},
+	{
+		Name:        "bibtex",
+		ContentType: "application/x-bibtex",
+		Extension:   "bib",
+		NewEncoder:  NewBibTeXEncoder,
+	},
+	{
+		Name:        "csl-json",
+		ContentType: "application/vnd.citationstyles.csl+json",
+		Extension:   "json",
+		NewEncoder:  NewCSLJSONEncoder,
+	},
+}
+
+// Lookup returns the format registered under name.
+func Lookup(name string) (Format, bool) {
+	i := slices.IndexFunc(formats, func(f Format) bool { return f.Name == name })
+	if i < 0 {
+		return Format{}, false
+	}
+	return formats[i], true
+}
+
+// ForContentType returns the importable format for a Content-Type header
+// value. Parameters such as charset are ignored.
+func ForContentType(contentType string) (Format, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return Format{}, false
+	}
+	switch mediaType {
+	case "application/ndjson", "application/jsonl":
+		mediaType = "application/x-ndjson"
+	case "application/xml", "text/xml":
+		mediaType = "application/xbel+xml"
+	}
+	for _, f := range formats {
+		if f.ContentType == mediaType && f.NewDecoder != nil {
+			return f, true
+		}
+	}
+	return Format{}, false
+}
+
+// Names lists the registered format names, e.g. for error messages.
+func Names() string {
+	names := make([]string, 0, len(formats))
+	for _, f := range formats {
+		names = append(names, f.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// DecodeAll drains d.
+func DecodeAll(d Decoder) ([]*domain.Bookmark, error) {
+	var bookmarks []*domain.Bookmark
+	for {
+		b, err := d.Decode()
+		if err == io.EOF {
+			return bookmarks, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		bookmarks = append(bookmarks, b)
+	}
+}
@@ -0,0 +1,145 @@
+package codec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleBookmarks() []*domain.Bookmark {
+	created := time.Date(2024, 3, 1, 12, 30, 0, 123456789, time.UTC)
+	return []*domain.Bookmark{
+		{
+			ID:          "0b6c1f9e-6a43-4f57-9a3f-0d0b8f2b1c11",
+			URL:         "https://go.dev/doc/effective_go?x=1&y=2",
+			Title:       `Effective Go, "the" guide`,
+			Description: "Line one\nline two <b>& co</b>",
+			Tags:        []string{"go", "docs, reference", "with space"},
+			CreatedAt:   created,
+			UpdatedAt:   created.Add(time.Hour),
+			Metadata:    map[string]string{domain.MetaAuthor: "The Go Authors", domain.MetaPublisher: "Google"},
+			ArchiveMode: domain.ArchiveAlways,
+		},
+		{
+			ID:          "1c7d2a0f-7b54-4068-8b40-1e1c903c2d22",
+			URL:         "https://example.com",
+			Title:       "Example",
+			CreatedAt:   created.Add(time.Minute),
+			UpdatedAt:   created.Add(time.Minute),
+			ArchiveMode: domain.ArchiveNever,
+		},
+	}
+}
+
+func TestFormats_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range formats {
+		f := f
+		if f.NewDecoder == nil {
+			continue
+		}
+		t.Run(f.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			enc := f.NewEncoder(&buf)
+			for _, b := range sampleBookmarks() {
+				require.NoError(t, enc.Encode(b))
+			}
+			require.NoError(t, enc.Close())
+
+			got, err := DecodeAll(f.NewDecoder(&buf))
+			require.NoError(t, err)
+			require.Len(t, got, 2)
+
+			for i, want := range sampleBookmarks() {
+				assert.Equal(t, want.ID, got[i].ID)
+				assert.Equal(t, want.URL, got[i].URL)
+				assert.Equal(t, want.Title, got[i].Title)
+				assert.Equal(t, want.Description, got[i].Description)
+				assert.ElementsMatch(t, want.Tags, got[i].Tags)
+				assert.Equal(t, want.Metadata, got[i].Metadata)
+				assert.Equal(t, want.ArchiveMode, got[i].ArchiveMode)
+				assert.True(t, want.CreatedAt.Equal(got[i].CreatedAt), "created_at %v != %v", want.CreatedAt, got[i].CreatedAt)
+				assert.True(t, want.UpdatedAt.Equal(got[i].UpdatedAt), "updated_at %v != %v", want.UpdatedAt, got[i].UpdatedAt)
+			}
+		})
+	}
+}
+
+func TestFormats_Empty(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range formats {
+		f := f
+		if f.NewDecoder == nil {
+			continue
+		}
+		t.Run(f.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			enc := f.NewEncoder(&buf)
+			require.NoError(t, enc.Close())
+
+			got, err := DecodeAll(f.NewDecoder(&buf))
+			require.NoError(t, err)
+			assert.Empty(t, got)
+		})
+	}
+}
+
+func TestForContentType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		contentType string
+		want        string
+		wantOK      bool
+	}{
+		{contentType: "application/json; charset=utf-8", want: "json", wantOK: true},
+		{contentType: "application/x-ndjson", want: "ndjson", wantOK: true},
+		{contentType: "application/jsonl", want: "ndjson", wantOK: true},
+		{contentType: "text/csv", want: "csv", wantOK: true},
+		{contentType: "application/xml", want: "xbel", wantOK: true},
+		{contentType: "text/plain", wantOK: false},
+		{contentType: "not a media type;;", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.contentType, func(t *testing.T) {
+			t.Parallel()
+
+			f, ok := ForContentType(tt.contentType)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, f.Name)
+		})
+	}
+}
+
+func TestXBELDecoder_NestedFolders(t *testing.T) {
+	t.Parallel()
+
+	doc := `<?xml version="1.0"?>
+<xbel version="1.0">
+  <folder><title>Dev</title>
+    <bookmark href="https://go.dev"><title>Go</title></bookmark>
+    <folder><title>Deeper</title>
+      <bookmark href="https://example.com"><title>Example</title><desc>notes</desc></bookmark>
+    </folder>
+  </folder>
+</xbel>`
+
+	got, err := DecodeAll(NewXBELDecoder(strings.NewReader(doc)))
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "https://go.dev", got[0].URL)
+	assert.Equal(t, "notes", got[1].Description)
+}
@@ -0,0 +1,154 @@
+package codec
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+var csvHeader = []string{"id", "url", "title", "description", "tags", "created_at", "updated_at", "metadata", "archive_mode"}
+
+// csvEncoder writes RFC 4180 CSV. Tags and metadata are stored as JSON in a
+// single cell so that values containing commas or spaces survive a round trip.
+type csvEncoder struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func NewCSVEncoder(w io.Writer) Encoder {
+	return &csvEncoder{w: csv.NewWriter(w)}
+}
+
+func (e *csvEncoder) Encode(b *domain.Bookmark) error {
+	if err := e.header(); err != nil {
+		return err
+	}
+	tags, err := json.Marshal(tagsOrEmpty(b.Tags))
+	if err != nil {
+		return fmt.Errorf("codec.csv: %w", err)
+	}
+	var metadata []byte
+	if len(b.Metadata) > 0 {
+		if metadata, err = json.Marshal(b.Metadata); err != nil {
+			return fmt.Errorf("codec.csv: %w", err)
+		}
+	}
+	return e.w.Write([]string{
+		b.ID,
+		b.URL,
+		b.Title,
+		b.Description,
+		string(tags),
+		formatTime(b.CreatedAt),
+		formatTime(b.UpdatedAt),
+		string(metadata),
+		string(b.ArchiveMode),
+	})
+}
+
+func (e *csvEncoder) Close() error {
+	if err := e.header(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) header() error {
+	if e.wroteHeader {
+		return nil
+	}
+	e.wroteHeader = true
+	return e.w.Write(csvHeader)
+}
+
+// csvDecoder maps columns by header name, so column order does not matter and
+// unknown columns are ignored.
+type csvDecoder struct {
+	r       *csv.Reader
+	columns map[string]int
+}
+
+func NewCSVDecoder(r io.Reader) Decoder {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	return &csvDecoder{r: cr}
+}
+
+func (d *csvDecoder) Decode() (*domain.Bookmark, error) {
+	if d.columns == nil {
+		header, err := d.r.Read()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, fmt.Errorf("codec.csv: %w", err)
+		}
+		d.columns = make(map[string]int, len(header))
+		for i, name := range header {
+			d.columns[name] = i
+		}
+		if _, ok := d.columns["url"]; !ok {
+			return nil, fmt.Errorf("codec.csv: header must contain a url column")
+		}
+	}
+
+	record, err := d.r.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("codec.csv: %w", err)
+	}
+	field := func(name string) string {
+		if i, ok := d.columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	line, _ := d.r.FieldPos(0)
+	b := &domain.Bookmark{
+		ID:          field("id"),
+		URL:         field("url"),
+		Title:       field("title"),
+		Description: field("description"),
+		ArchiveMode: domain.ArchiveMode(field("archive_mode")),
+	}
+	if tags := field("tags"); tags != "" {
+		if err := json.Unmarshal([]byte(tags), &b.Tags); err != nil {
+			return nil, fmt.Errorf("codec.csv: line %d: tags: %w", line, err)
+		}
+	}
+	if metadata := field("metadata"); metadata != "" {
+		if err := json.Unmarshal([]byte(metadata), &b.Metadata); err != nil {
+			return nil, fmt.Errorf("codec.csv: line %d: metadata: %w", line, err)
+		}
+	}
+	if b.CreatedAt, err = parseTime(field("created_at")); err != nil {
+		return nil, fmt.Errorf("codec.csv: line %d: created_at: %w", line, err)
+	}
+	if b.UpdatedAt, err = parseTime(field("updated_at")); err != nil {
+		return nil, fmt.Errorf("codec.csv: line %d: updated_at: %w", line, err)
+	}
+	return b, nil
+}
+
+// parseTime accepts RFC 3339 with optional fractional seconds; empty is zero.
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
@@ -0,0 +1,137 @@
+package codec
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// jsonEncoder writes a single JSON array, emitting the brackets itself so that
+// the array never has to exist in memory.
+type jsonEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func NewJSONEncoder(w io.Writer) Encoder {
+	return &jsonEncoder{w: w}
+}
+
+func (e *jsonEncoder) Encode(b *domain.Bookmark) error {
+	if err := e.write(b); err != nil {
+		return fmt.Errorf("codec.json: %w", err)
+	}
+	return nil
+}
+
+// write appends v to the array. Other JSON-array formats share it.
+func (e *jsonEncoder) write(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	sep := ",\n"
+	if e.count == 0 {
+		sep = "[\n"
+	}
+	e.count++
+	if _, err := io.WriteString(e.w, sep); err != nil {
+		return err
+	}
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonEncoder) Close() error {
+	end := "\n]\n"
+	if e.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
+
+type jsonDecoder struct {
+	dec     *json.Decoder
+	started bool
+}
+
+func NewJSONDecoder(r io.Reader) Decoder {
+	return &jsonDecoder{dec: json.NewDecoder(r)}
+}
+
+func (d *jsonDecoder) Decode() (*domain.Bookmark, error) {
+	if !d.started {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("codec.json: %w", err)
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return nil, errors.New("codec.json: expected an array of bookmarks")
+		}
+		d.started = true
+	}
+	if !d.dec.More() {
+		if _, err := d.dec.Token(); err != nil {
+			return nil, fmt.Errorf("codec.json: %w", err)
+		}
+		return nil, io.EOF
+	}
+	var b domain.Bookmark
+	if err := d.dec.Decode(&b); err != nil {
+		return nil, fmt.Errorf("codec.json: %w", err)
+	}
+	return &b, nil
+}
+
+// ndjsonEncoder writes one JSON object per line.
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func NewNDJSONEncoder(w io.Writer) Encoder {
+	return &ndjsonEncoder{enc: json.NewEncoder(w)}
+}
+
+func (e *ndjsonEncoder) Encode(b *domain.Bookmark) error {
+	if err := e.enc.Encode(b); err != nil {
+		return fmt.Errorf("codec.ndjson: %w", err)
+	}
+	return nil
+}
+
+func (e *ndjsonEncoder) Close() error { return nil }
+
+type ndjsonDecoder struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func NewNDJSONDecoder(r io.Reader) Decoder {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	return &ndjsonDecoder{scanner: s}
+}
+
+func (d *ndjsonDecoder) Decode() (*domain.Bookmark, error) {
+	for d.scanner.Scan() {
+		d.line++
+		line := d.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var b domain.Bookmark
+		if err := json.Unmarshal(line, &b); err != nil {
+			return nil, fmt.Errorf("codec.ndjson: line %d: %w", d.line, err)
+		}
+		return &b, nil
+	}
+	if err := d.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("codec.ndjson: %w", err)
+	}
+	return nil, io.EOF
+}
@@ -0,0 +1,354 @@
+package codec
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// VaultLayout decides how bookmarks are spread over Markdown files.
+type VaultLayout int
+
+const (
+	// VaultPerBookmark writes bookmarks/<title>-<id>.md for every bookmark and a
+	// tags/<tag>.md index note per tag.
+	VaultPerBookmark VaultLayout = iota
+	// VaultPerTag writes one <tag>.md per tag holding all of its bookmarks.
+	// Bookmarks without tags go to untagged.md.
+	VaultPerTag
+)
+
+const untaggedNote = "untagged"
+
+// FileSink receives the files of a vault. Names use forward slashes.
+type FileSink interface {
+	Create(name string) (io.WriteCloser, error)
+}
+
+// ZipSink writes vault files into a zip archive.
+type ZipSink struct {
+	zw *zip.Writer
+}
+
+func NewZipSink(w io.Writer) *ZipSink {
+	return &ZipSink{zw: zip.NewWriter(w)}
+}
+
+// Create adds a compressed entry. The modification time is left at the zip
+// epoch so that archives of the same bookmarks are byte-for-byte identical.
+func (s *ZipSink) Create(name string) (io.WriteCloser, error) {
+	w, err := s.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+	if err != nil {
+		return nil, err
+	}
+	return nopWriteCloser{w}, nil
+}
+
+func (s *ZipSink) Close() error {
+	return s.zw.Close()
+}
+
+// DirSink writes vault files below a directory, overwriting earlier exports.
+type DirSink struct {
+	Dir string
+}
+
+func (s DirSink) Create(name string) (io.WriteCloser, error) {
+	if !filepath.IsLocal(filepath.FromSlash(name)) {
+		return nil, fmt.Errorf("codec.markdown: refusing to write outside the vault: %s", name)
+	}
+	p := filepath.Join(s.Dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return nil, err
+	}
+	return os.Create(p)
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+// VaultEncoder renders bookmarks as an Obsidian-style vault: Markdown notes
+// with YAML front matter, linked to each other through [[wiki-links]] on tags.
+// File names depend only on the bookmark or tag they hold, so exporting the
+// same data twice produces identical files.
+type VaultEncoder struct {
+	sink   FileSink
+	layout VaultLayout
+
+	tagged  map[string][]vaultEntry    // tag -> bookmarks carrying it
+	related map[string]map[string]bool // tag -> tags seen alongside it
+}
+
+type vaultEntry struct {
+	note  string // per-bookmark note name, without extension
+	title string
+	body  string // rendered section, VaultPerTag only
+}
+
+func NewVaultEncoder(sink FileSink, layout VaultLayout) *VaultEncoder {
+	return &VaultEncoder{
+		sink:    sink,
+		layout:  layout,
+		tagged:  make(map[string][]vaultEntry),
+		related: make(map[string]map[string]bool),
+	}
+}
+
+func (e *VaultEncoder) Encode(b *domain.Bookmark) error {
+	tags := b.Tags
+	if len(tags) == 0 && e.layout == VaultPerTag {
+		tags = []string{untaggedNote}
+	}
+	for _, t := range tags {
+		if e.related[t] == nil {
+			e.related[t] = make(map[string]bool)
+		}
+		for _, other := range tags {
+			if other != t {
+				e.related[t][other] = true
+			}
+		}
+	}
+
+	if e.layout == VaultPerTag {
+		body := renderTagSection(b)
+		for _, t := range tags {
+			e.tagged[t] = append(e.tagged[t], vaultEntry{title: b.Title, body: body})
+		}
+		return nil
+	}
+
+	note := "bookmarks/" + bookmarkNoteName(b)
+	for _, t := range tags {
+		e.tagged[t] = append(e.tagged[t], vaultEntry{note: note, title: b.Title})
+	}
+	return e.writeFile(note+".md", renderBookmarkNote(b))
+}
+
+// Close writes the tag notes, which can only be rendered once every bookmark
+// has been seen. It closes the sink if the sink needs closing.
+func (e *VaultEncoder) Close() error {
+	tags := make([]string, 0, len(e.tagged))
+	for t := range e.tagged {
+		tags = append(tags, t)
+	}
+	slices.Sort(tags)
+
+	for _, t := range tags {
+		var name, content string
+		if e.layout == VaultPerTag {
+			name = tagNoteName(t)
+			content = e.renderTagPage(t, "")
+		} else {
+			name = "tags/" + tagNoteName(t)
+			content = e.renderTagPage(t, "tags/")
+		}
+		if err := e.writeFile(name+".md", content); err != nil {
+			return err
+		}
+	}
+
+	if c, ok := e.sink.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (e *VaultEncoder) writeFile(name, content string) error {
+	f, err := e.sink.Create(name)
+	if err != nil {
+		return fmt.Errorf("codec.markdown: %w", err)
+	}
+	if _, err := io.WriteString(f, content); err != nil {
+		f.Close()
+		return fmt.Errorf("codec.markdown: %w", err)
+	}
+	return f.Close()
+}
+
+func renderBookmarkNote(b *domain.Bookmark) string {
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	fmt.Fprintf(&sb, "id: %s\n", yamlString(b.ID))
+	fmt.Fprintf(&sb, "title: %s\n", yamlString(b.Title))
+	fmt.Fprintf(&sb, "url: %s\n", yamlString(b.URL))
+	fmt.Fprintf(&sb, "tags: %s\n", yamlList(b.Tags))
+	fmt.Fprintf(&sb, "created: %s\n", yamlTime(b.CreatedAt))
+	fmt.Fprintf(&sb, "updated: %s\n", yamlTime(b.UpdatedAt))
+	sb.WriteString("---\n\n")
+	fmt.Fprintf(&sb, "# [%s](%s)\n", escapeMarkdown(b.Title), b.URL)
+	if b.Description != "" {
+		fmt.Fprintf(&sb, "\n%s\n", strings.TrimSpace(b.Description))
+	}
+	if len(b.Tags) > 0 {
+		fmt.Fprintf(&sb, "\nTags: %s\n", tagLinks(b.Tags, "tags/"))
+	}
+	return sb.String()
+}
+
+func renderTagSection(b *domain.Bookmark) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## [%s](%s)\n\n", escapeMarkdown(b.Title), b.URL)
+	fmt.Fprintf(&sb, "- created: %s\n", yamlTime(b.CreatedAt))
+	fmt.Fprintf(&sb, "- updated: %s\n", yamlTime(b.UpdatedAt))
+	if len(b.Tags) > 0 {
+		fmt.Fprintf(&sb, "- tags: %s\n", tagLinks(b.Tags, ""))
+	}
+	if b.Description != "" {
+		fmt.Fprintf(&sb, "\n%s\n", strings.TrimSpace(b.Description))
+	}
+	return sb.String()
+}
+
+// renderTagPage lists the bookmarks of tag t and links the tags that appear
+// alongside it. prefix is the folder tag notes live in.
+func (e *VaultEncoder) renderTagPage(t, prefix string) string {
+	related := make([]string, 0, len(e.related[t]))
+	for other := range e.related[t] {
+		related = append(related, other)
+	}
+	slices.Sort(related)
+
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	fmt.Fprintf(&sb, "tag: %s\n", yamlString(t))
+	fmt.Fprintf(&sb, "count: %d\n", len(e.tagged[t]))
+	fmt.Fprintf(&sb, "related: %s\n", yamlList(related))
+	sb.WriteString("---\n\n")
+	fmt.Fprintf(&sb, "# %s\n", escapeMarkdown(t))
+	if len(related) > 0 {
+		fmt.Fprintf(&sb, "\nRelated: %s\n", tagLinks(related, prefix))
+	}
+	for _, entry := range e.tagged[t] {
+		if e.layout == VaultPerTag {
+			fmt.Fprintf(&sb, "\n%s", entry.body)
+			continue
+		}
+		fmt.Fprintf(&sb, "\n- [[%s|%s]]", entry.note, wikiAlias(entry.title))
+	}
+	if e.layout == VaultPerBookmark {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func tagLinks(tags []string, prefix string) string {
+	links := make([]string, 0, len(tags))
+	for _, t := range tags {
+		links = append(links, fmt.Sprintf("[[%s%s|%s]]", prefix, tagNoteName(t), wikiAlias(t)))
+	}
+	return strings.Join(links, " ")
+}
+
+// bookmarkNoteName combines a readable slug of the title with the start of the
+// ID, so renaming a bookmark renames its note but two bookmarks never collide.
+func bookmarkNoteName(b *domain.Bookmark) string {
+	id := b.ID
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	slug := slugify(b.Title, 60)
+	if slug == "" {
+		return id
+	}
+	return slug + "-" + id
+}
+
+// tagNoteName keeps tags that are already safe file names as they are, so the
+// note matches what Obsidian shows. Others are slugged and suffixed with a
+// hash of the original tag to keep distinct tags in distinct files.
+func tagNoteName(tag string) string {
+	if isSafeFileName(tag) {
+		return tag
+	}
+	h := fnv.New32a()
+	h.Write([]byte(tag))
+	return fmt.Sprintf("%s-%06x", slugify(tag, 40), h.Sum32()&0xffffff)
+}
+
+func isSafeFileName(s string) bool {
+	if s == "" || s == "." || s == ".." || strings.HasPrefix(s, ".") {
+		return false
+	}
+	return !strings.ContainsFunc(s, func(r rune) bool {
+		return strings.ContainsRune(`/\:*?"<>|#^[]`, r) || unicode.IsControl(r) || unicode.IsSpace(r)
+	})
+}
+
+// slugify lower-cases s and joins its letters and digits with hyphens.
+func slugify(s string, maxLen int) string {
+	var sb strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if hyphen && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			hyphen = false
+			sb.WriteRune(r)
+			continue
+		}
+		hyphen = true
+	}
+	slug := sb.String()
+	for len(slug) > maxLen {
+		// Cut on a rune boundary, then drop a dangling hyphen.
+		_, size := utf8.DecodeLastRuneInString(slug)
+		slug = strings.TrimSuffix(slug[:len(slug)-size], "-")
+	}
+	return slug
+}
+
+// yamlString quotes s as a JSON string, which YAML accepts as a double-quoted
+// scalar.
+func yamlString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+func yamlList(items []string) string {
+	if items == nil {
+		items = []string{}
+	}
+	data, _ := json.Marshal(items)
+	return string(data)
+}
+
+func yamlTime(t time.Time) string {
+	if t.IsZero() {
+		return `""`
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// escapeMarkdown keeps titles from closing the link text they are placed in.
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `[`, `\[`, `]`, `\]`).Replace(s)
+}
+
+// wikiAlias strips characters that would end a [[link|alias]] early.
+func wikiAlias(s string) string {
+	return strings.NewReplacer("|", "-", "]]", "] ]", "\n", " ").Replace(s)
+}
+
+// NewMarkdownZipEncoder writes a per-bookmark vault as a zip archive.
+func NewMarkdownZipEncoder(w io.Writer) Encoder {
+	return NewVaultEncoder(NewZipSink(w), VaultPerBookmark)
+}
+
+// NewMarkdownByTagZipEncoder writes a per-tag vault as a zip archive.
+func NewMarkdownByTagZipEncoder(w io.Writer) Encoder {
+	return NewVaultEncoder(NewZipSink(w), VaultPerTag)
+}
@@ -0,0 +1,137 @@
+package codec
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memSink collects vault files in memory.
+type memSink map[string]*bytes.Buffer
+
+func (s memSink) Create(name string) (io.WriteCloser, error) {
+	buf := &bytes.Buffer{}
+	s[name] = buf
+	return nopWriteCloser{buf}, nil
+}
+
+func (s memSink) names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func encodeVault(t *testing.T, layout VaultLayout) memSink {
+	t.Helper()
+
+	sink := memSink{}
+	enc := NewVaultEncoder(sink, layout)
+	for _, b := range sampleBookmarks() {
+		require.NoError(t, enc.Encode(b))
+	}
+	require.NoError(t, enc.Close())
+	return sink
+}
+
+func TestVaultEncoder_PerBookmark(t *testing.T) {
+	t.Parallel()
+
+	sink := encodeVault(t, VaultPerBookmark)
+
+	names := sink.names()
+	require.Len(t, names, 5)
+	assert.Equal(t, "bookmarks/effective-go-the-guide-0b6c1f9e.md", names[0])
+	assert.Equal(t, "bookmarks/example-1c7d2a0f.md", names[1])
+	assert.True(t, strings.HasPrefix(names[2], "tags/docs-reference-"), names[2])
+	assert.Equal(t, "tags/go.md", names[3])
+	assert.True(t, strings.HasPrefix(names[4], "tags/with-space-"), names[4])
+
+	note := sink["bookmarks/effective-go-the-guide-0b6c1f9e.md"].String()
+	assert.True(t, strings.HasPrefix(note, "---\nid: \"0b6c1f9e-6a43-4f57-9a3f-0d0b8f2b1c11\"\n"), note)
+	assert.Contains(t, note, "url: \"https://go.dev/doc/effective_go?x=1\\u0026y=2\"\n")
+	assert.Contains(t, note, "tags: [\"go\",\"docs, reference\",\"with space\"]\n")
+	assert.Contains(t, note, "created: 2024-03-01T12:30:00Z\n")
+	assert.Contains(t, note, "\nLine one\nline two <b>& co</b>\n")
+	assert.Contains(t, note, "[[tags/go|go]]")
+
+	tagNote := sink["tags/go.md"].String()
+	assert.Contains(t, tagNote, "count: 1\n")
+	assert.Contains(t, tagNote, "- [[bookmarks/effective-go-the-guide-0b6c1f9e|Effective Go, \"the\" guide]]")
+	assert.Contains(t, tagNote, "Related: [[tags/docs-reference-")
+}
+
+func TestVaultEncoder_PerTag(t *testing.T) {
+	t.Parallel()
+
+	sink := encodeVault(t, VaultPerTag)
+
+	assert.Contains(t, sink.names(), "go.md")
+	assert.Contains(t, sink.names(), "untagged.md")
+
+	goNote := sink["go.md"].String()
+	assert.Contains(t, goNote, "## [Effective Go, \"the\" guide](https://go.dev/doc/effective_go?x=1&y=2)")
+	assert.Contains(t, goNote, "- tags: [[go|go]]")
+	assert.Contains(t, sink["untagged.md"].String(), "## [Example](https://example.com)")
+}
+
+func TestMarkdownZip_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	export := func() []byte {
+		var buf bytes.Buffer
+		enc := NewMarkdownZipEncoder(&buf)
+		for _, b := range sampleBookmarks() {
+			require.NoError(t, enc.Encode(b))
+		}
+		require.NoError(t, enc.Close())
+		return buf.Bytes()
+	}
+
+	first, second := export(), export()
+	assert.Equal(t, first, second)
+
+	zr, err := zip.NewReader(bytes.NewReader(first), int64(len(first)))
+	require.NoError(t, err)
+	assert.Len(t, zr.File, 5)
+}
+
+func TestTagNoteName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tag        string
+		wantPrefix string
+		wantExact  bool
+	}{
+		{tag: "go", wantPrefix: "go", wantExact: true},
+		{tag: "Café", wantPrefix: "Café", wantExact: true},
+		{tag: "a/b", wantPrefix: "a-b-"},
+		{tag: "../etc", wantPrefix: "etc-"},
+		{tag: "#todo", wantPrefix: "todo-"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.tag, func(t *testing.T) {
+			t.Parallel()
+
+			got := tagNoteName(tt.tag)
+			if tt.wantExact {
+				assert.Equal(t, tt.wantPrefix, got)
+				return
+			}
+			assert.True(t, strings.HasPrefix(got, tt.wantPrefix), got)
+			assert.NotContains(t, got, "/")
+			assert.Equal(t, got, tagNoteName(tt.tag), "names must be stable")
+		})
+	}
+}
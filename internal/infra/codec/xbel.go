@@ -0,0 +1,171 @@
+package codec
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+const xbelHeader = xml.Header +
+	`<!DOCTYPE xbel PUBLIC "+//IDN python.org//DTD XML Bookmark Exchange Language 1.0//EN//XML" "http://pyxml.sourceforge.net/topics/dtds/xbel.dtd">` + "\n" +
+	`<xbel version="1.0">` + "\n"
+
+// xbelMetadataOwner marks the <metadata> block that carries fields XBEL has no
+// element for. Other readers ignore it.
+const xbelMetadataOwner = "https://github.com/etsrc/goprod"
+
+type xbelBookmark struct {
+	XMLName  xml.Name  `xml:"bookmark"`
+	Href     string    `xml:"href,attr"`
+	ID       string    `xml:"id,attr,omitempty"`
+	Added    string    `xml:"added,attr,omitempty"`
+	Modified string    `xml:"modified,attr,omitempty"`
+	Title    string    `xml:"title"`
+	Desc     string    `xml:"desc,omitempty"`
+	Info     *xbelInfo `xml:"info,omitempty"`
+}
+
+type xbelInfo struct {
+	Metadata []xbelMetadata `xml:"metadata"`
+}
+
+type xbelMetadata struct {
+	Owner       string     `xml:"owner,attr"`
+	ArchiveMode string     `xml:"archive-mode,attr,omitempty"`
+	Tags        []string   `xml:"tags>tag"`
+	Meta        []xbelMeta `xml:"meta"`
+}
+
+type xbelMeta struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+// xbelEncoder writes a flat XBEL 1.0 document: one <bookmark> per bookmark,
+// with tags, page metadata and the archive mode kept in an <info><metadata>
+// block owned by goprod.
+type xbelEncoder struct {
+	w       io.Writer
+	enc     *xml.Encoder
+	started bool
+}
+
+func NewXBELEncoder(w io.Writer) Encoder {
+	enc := xml.NewEncoder(w)
+	enc.Indent("  ", "  ")
+	return &xbelEncoder{w: w, enc: enc}
+}
+
+func (e *xbelEncoder) Encode(b *domain.Bookmark) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	xb := xbelBookmark{
+		Href:     b.URL,
+		ID:       b.ID,
+		Added:    formatTime(b.CreatedAt),
+		Modified: formatTime(b.UpdatedAt),
+		Title:    b.Title,
+		Desc:     b.Description,
+	}
+	if len(b.Tags) > 0 || len(b.Metadata) > 0 || b.ArchiveMode != domain.ArchiveDefault {
+		md := xbelMetadata{Owner: xbelMetadataOwner, ArchiveMode: string(b.ArchiveMode), Tags: b.Tags}
+		for _, name := range slices.Sorted(maps.Keys(b.Metadata)) {
+			md.Meta = append(md.Meta, xbelMeta{Name: name, Value: b.Metadata[name]})
+		}
+		xb.Info = &xbelInfo{Metadata: []xbelMetadata{md}}
+	}
+	if err := e.enc.Encode(xb); err != nil {
+		return fmt.Errorf("codec.xbel: %w", err)
+	}
+	_, err := io.WriteString(e.w, "\n")
+	return err
+}
+
+func (e *xbelEncoder) Close() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(e.w, "</xbel>\n")
+	return err
+}
+
+func (e *xbelEncoder) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	_, err := io.WriteString(e.w, xbelHeader)
+	return err
+}
+
+// xbelDecoder reads every <bookmark> in the document, including those nested in
+// folders, which are flattened.
+type xbelDecoder struct {
+	dec *xml.Decoder
+}
+
+func NewXBELDecoder(r io.Reader) Decoder {
+	return &xbelDecoder{dec: xml.NewDecoder(r)}
+}
+
+func (d *xbelDecoder) Decode() (*domain.Bookmark, error) {
+	for {
+		tok, err := d.dec.Token()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, fmt.Errorf("codec.xbel: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "bookmark" {
+			continue
+		}
+
+		var xb xbelBookmark
+		if err := d.dec.DecodeElement(&xb, &start); err != nil {
+			return nil, fmt.Errorf("codec.xbel: %w", err)
+		}
+		b := &domain.Bookmark{
+			ID:          xb.ID,
+			URL:         xb.Href,
+			Title:       xb.Title,
+			Description: xb.Desc,
+		}
+		if b.CreatedAt, err = parseTime(xb.Added); err != nil {
+			return nil, fmt.Errorf("codec.xbel: added: %w", err)
+		}
+		if b.UpdatedAt, err = parseTime(xb.Modified); err != nil {
+			return nil, fmt.Errorf("codec.xbel: modified: %w", err)
+		}
+		if xb.Info != nil {
+			for _, m := range xb.Info.Metadata {
+				if m.Owner != xbelMetadataOwner {
+					continue
+				}
+				b.Tags = m.Tags
+				b.ArchiveMode = domain.ArchiveMode(m.ArchiveMode)
+				for _, meta := range m.Meta {
+					if b.Metadata == nil {
+						b.Metadata = make(map[string]string, len(m.Meta))
+					}
+					b.Metadata[meta.Name] = meta.Value
+				}
+			}
+		}
+		return b, nil
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
-	"log"
+	"log/slog"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -12,39 +14,522 @@ type Config struct {
 	HTTPAddr          string
 	ReadHeaderTimeout time.Duration
 	ShutdownTimeout   time.Duration
+	// ShutdownDrainDelay is how long /readyz fails before the server stops
+	// taking requests, for load balancers to notice.
+	ShutdownDrainDelay time.Duration
+	// HealthCheckTimeout bounds the check of each component.
+	HealthCheckTimeout time.Duration
+
+	// LogFormat is "json" or "text"; LogLevel is debug, info, warn or error.
+	LogFormat string
+	LogLevel  string
+
+	// MetricsEnabled serves the Prometheus metrics at /metrics.
+	MetricsEnabled bool
+
+	// TracingExporter is "otlp", "stdout" or "none". OTLP spans go over HTTP
+	// to TracingOTLPEndpoint, or to the endpoint the standard
+	// OTEL_EXPORTER_OTLP_* variables name when it is empty.
+	// TracingSampleRatio is the share of new traces kept, from 0 to 1;
+	// traces started by a caller follow the caller's decision.
+	TracingExporter     string
+	TracingOTLPEndpoint string
+	TracingOTLPInsecure bool
+	TracingSampleRatio  float64
+
+	// ImportStateDir keeps import jobs on disk so they resume after a restart.
+	// Empty keeps them in memory.
+	ImportStateDir     string
+	ImportBatchSize    int
+	ImportWorkers      int
+	ImportMaxBodyBytes int64
+
+	// PublicURL is the address clients reach the server at, used for links in
+	// feeds. Empty derives it from each request.
+	PublicURL string
+	// FeedPrivateTags keeps bookmarks with these tags out of feeds unless the
+	// request's token grants them. FeedTokens maps feed tokens to the private
+	// tags they grant, none meaning all of them.
+	FeedPrivateTags []string
+	FeedTokens      map[string][]string
+	FeedLimit       int
+	FeedMaxLimit    int
+
+	// Enrichment fetches each new bookmark's page to fill in its title,
+	// description and metadata.
+	EnrichEnabled       bool
+	EnrichWorkers       int
+	EnrichQueueSize     int
+	EnrichTimeout       time.Duration
+	EnrichMaxBytes      int64
+	EnrichMaxAttempts   int
+	EnrichRetryBackoff  time.Duration
+	EnrichUserAgent     string
+	EnrichRespectRobots bool
+
+	// Content extraction fetches each new bookmark's page for its readable
+	// text and indexes it for search, sharing the enrichment fetch settings.
+	ContentEnabled   bool
+	ContentWorkers   int
+	ContentQueueSize int
+	ContentMaxBytes  int64
+
+	// Link checking requests every bookmark's URL each interval and records
+	// whether it still works.
+	LinkCheckEnabled         bool
+	LinkCheckInterval        time.Duration
+	LinkCheckWorkers         int
+	LinkCheckTimeout         time.Duration
+	LinkCheckBrokenAfter     int
+	LinkCheckFollowRedirects bool
+	LinkCheckUserAgent       string
+
+	// ArchiveDir enables archiving pages as WARC files in that directory.
+	// ArchiveTags lists tags whose new bookmarks are archived; "*" archives
+	// every new bookmark.
+	ArchiveDir       string
+	ArchiveTags      []string
+	ArchiveWorkers   int
+	ArchiveQueueSize int
+	ArchiveTimeout   time.Duration
+	ArchiveMaxAssets int
+	ArchiveMaxBytes  int64
+	ArchiveUserAgent string
+
+	// Blob storage holds attachments. BlobDir selects a local directory and
+	// BlobS3Bucket a bucket of an S3-compatible service; with neither,
+	// attachments are disabled.
+	BlobDir               string
+	BlobS3Endpoint        string
+	BlobS3Region          string
+	BlobS3Bucket          string
+	BlobS3Prefix          string
+	BlobS3AccessKeyID     string
+	BlobS3SecretAccessKey string
+	BlobS3PathStyle       bool
+	BlobGCInterval        time.Duration
+	BlobGCGrace           time.Duration
+
+	// AttachmentAllowedTypes lists the media types that may be attached, with
+	// "image/*" style wildcards; empty allows all.
+	AttachmentMaxBytes      int64
+	AttachmentQuotaBytes    int64
+	AttachmentTotalBytes    int64
+	AttachmentAllowedTypes  []string
+	AttachmentThumbnailSize int
+
+	// Favicons serves sites' icons at /favicons/{host}. FaviconDir caches them
+	// on disk; empty keeps them in memory.
+	FaviconEnabled    bool
+	FaviconDir        string
+	FaviconTTL        time.Duration
+	FaviconRetryAfter time.Duration
+	FaviconTimeout    time.Duration
+	FaviconMaxBytes   int64
+	FaviconUserAgent  string
+
+	// The outbox relay publishes stored events when notified of a write, and
+	// otherwise looks for leftovers every OutboxPollInterval.
+	OutboxPollInterval time.Duration
+	OutboxBatchSize    int
+
+	// Webhooks POST events to subscribed URLs. WebhookAllow lists private
+	// ranges, addresses and host names they may be sent to, like
+	// OutboundAllow does for bookmarked sites.
+	WebhooksEnabled      bool
+	WebhookAllow         []string
+	WebhookTimeout       time.Duration
+	WebhookWorkers       int
+	WebhookMaxAttempts   int
+	WebhookRetryDelay    time.Duration
+	WebhookMaxRetryDelay time.Duration
+	WebhookDisableAfter  int
+	WebhookHistory       int
+
+	// The event stream at /events/stream. StreamTokens maps access tokens to
+	// the tags their holders may see, none meaning every bookmark; empty
+	// leaves the stream open to anyone.
+	StreamEnabled      bool
+	StreamTokens       map[string][]string
+	StreamReplaySize   int
+	StreamClientBuffer int
+	StreamHeartbeat    time.Duration
+
+	// Delta sync at /sync. SyncTombstoneTTL is how long deletions are kept;
+	// clients that have not synced for longer must pull everything again.
+	SyncEnabled      bool
+	SyncPageSize     int
+	SyncMaxPush      int
+	SyncTombstoneTTL time.Duration
+
+	// Deleted bookmarks stay in the trash for TrashRetention before they are
+	// purged.
+	TrashRetention     time.Duration
+	TrashPurgeInterval time.Duration
+
+	// A revision of a bookmark is kept while it is one of the RevisionsKeep
+	// newest or younger than RevisionsMaxAge.
+	RevisionsEnabled       bool
+	RevisionsKeep          int
+	RevisionsMaxAge        time.Duration
+	RevisionsPruneInterval time.Duration
+
+	// The audit log is kept in memory unless AuditDatabaseURL names a
+	// Postgres database. AdminTokens may read it; with none, no one may.
+	// Requests bearing one are the admin's. Behind TrustedProxies reverse
+	// proxies, the client address is taken from X-Forwarded-For.
+	AuditEnabled     bool
+	AuditDatabaseURL string
+	AdminTokens      []string
+	TrustedProxies   int
+
+	// Outbound settings apply to every request made to a bookmarked site.
+	// OutboundAllow lists ranges, addresses and host names that may be
+	// reached even though they are private.
+	OutboundProxy           string
+	OutboundAllow           []string
+	OutboundMaxBytes        int64
+	OutboundMaxRedirects    int
+	OutboundMaxConnsPerHost int
+	OutboundHostDelay       time.Duration
 }
 
 func Load() (*Config, error) {
 	// Load .env file, but don't fail if it's missing (e.g. in production or docker)
 	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found")
+		slog.Info("No .env file found")
 	}
 
 	cfg := &Config{
-		HTTPAddr:          ":8080",
-		ReadHeaderTimeout: 10 * time.Second,
-		ShutdownTimeout:   10 * time.Second,
+		HTTPAddr:            ":8080",
+		ReadHeaderTimeout:   10 * time.Second,
+		ShutdownTimeout:     10 * time.Second,
+		ShutdownDrainDelay:  5 * time.Second,
+		HealthCheckTimeout:  2 * time.Second,
+		LogFormat:           "json",
+		LogLevel:            "info",
+		MetricsEnabled:      true,
+		TracingExporter:     "none",
+		TracingSampleRatio:  1,
+		ImportBatchSize:     500,
+		ImportWorkers:       2,
+		ImportMaxBodyBytes:  64 << 20,
+		FeedLimit:           50,
+		FeedMaxLimit:        500,
+		EnrichEnabled:       true,
+		EnrichWorkers:       4,
+		EnrichQueueSize:     1000,
+		EnrichTimeout:       15 * time.Second,
+		EnrichMaxBytes:      1 << 20,
+		EnrichMaxAttempts:   3,
+		EnrichRetryBackoff:  5 * time.Second,
+		EnrichRespectRobots: true,
+
+		ContentEnabled:   true,
+		ContentWorkers:   2,
+		ContentQueueSize: 1000,
+		ContentMaxBytes:  5 << 20,
+
+		LinkCheckEnabled:     true,
+		LinkCheckInterval:    24 * time.Hour,
+		LinkCheckWorkers:     4,
+		LinkCheckTimeout:     15 * time.Second,
+		LinkCheckBrokenAfter: 3,
+
+		ArchiveWorkers:   2,
+		ArchiveQueueSize: 100,
+		ArchiveTimeout:   time.Minute,
+		ArchiveMaxAssets: 50,
+		ArchiveMaxBytes:  20 << 20,
+
+		BlobS3Endpoint: "https://s3.amazonaws.com",
+		BlobGCInterval: 24 * time.Hour,
+		BlobGCGrace:    time.Hour,
+
+		AttachmentMaxBytes:      25 << 20,
+		AttachmentQuotaBytes:    1 << 30,
+		AttachmentTotalBytes:    10 << 30,
+		AttachmentThumbnailSize: 256,
+
+		FaviconEnabled:    true,
+		FaviconTTL:        7 * 24 * time.Hour,
+		FaviconRetryAfter: 24 * time.Hour,
+		FaviconTimeout:    10 * time.Second,
+		FaviconMaxBytes:   256 << 10,
+
+		OutboxPollInterval: time.Second,
+		OutboxBatchSize:    100,
+
+		WebhooksEnabled:      true,
+		WebhookTimeout:       10 * time.Second,
+		WebhookWorkers:       4,
+		WebhookMaxAttempts:   8,
+		WebhookRetryDelay:    30 * time.Second,
+		WebhookMaxRetryDelay: time.Hour,
+		WebhookDisableAfter:  20,
+		WebhookHistory:       100,
+
+		StreamEnabled:      true,
+		StreamReplaySize:   1000,
+		StreamClientBuffer: 64,
+		StreamHeartbeat:    15 * time.Second,
+
+		SyncEnabled:      true,
+		SyncPageSize:     500,
+		SyncMaxPush:      500,
+		SyncTombstoneTTL: 30 * 24 * time.Hour,
+
+		TrashRetention:     30 * 24 * time.Hour,
+		TrashPurgeInterval: time.Hour,
+
+		RevisionsEnabled:       true,
+		RevisionsKeep:          50,
+		RevisionsPruneInterval: time.Hour,
+
+		AuditEnabled: true,
+
+		OutboundMaxBytes:        10 << 20,
+		OutboundMaxRedirects:    10,
+		OutboundMaxConnsPerHost: 2,
+		OutboundHostDelay:       time.Second,
 	}
 
 	if addr := os.Getenv("HTTP_ADDR"); addr != "" {
 		cfg.HTTPAddr = addr
 	}
 
-	if val := os.Getenv("HTTP_READ_HEADER_TIMEOUT"); val != "" {
-		if d, err := time.ParseDuration(val); err == nil {
-			cfg.ReadHeaderTimeout = d
-		} else {
-			log.Printf("Invalid HTTP_READ_HEADER_TIMEOUT %q, using default", val)
-		}
+	durationVar(&cfg.ReadHeaderTimeout, "HTTP_READ_HEADER_TIMEOUT")
+	durationVar(&cfg.ShutdownTimeout, "HTTP_SHUTDOWN_TIMEOUT")
+	durationVar(&cfg.ShutdownDrainDelay, "HTTP_SHUTDOWN_DRAIN_DELAY")
+	durationVar(&cfg.HealthCheckTimeout, "HEALTH_CHECK_TIMEOUT")
+	if format := os.Getenv("LOG_FORMAT"); format != "" {
+		cfg.LogFormat = format
 	}
+	if level := os.Getenv("LOG_LEVEL"); level != "" {
+		cfg.LogLevel = level
+	}
+	boolVar(&cfg.MetricsEnabled, "METRICS_ENABLED")
+	if exporter := os.Getenv("TRACING_EXPORTER"); exporter != "" {
+		cfg.TracingExporter = exporter
+	}
+	cfg.TracingOTLPEndpoint = os.Getenv("TRACING_OTLP_ENDPOINT")
+	boolVar(&cfg.TracingOTLPInsecure, "TRACING_OTLP_INSECURE")
+	ratioVar(&cfg.TracingSampleRatio, "TRACING_SAMPLE_RATIO")
 
-	if val := os.Getenv("HTTP_SHUTDOWN_TIMEOUT"); val != "" {
-		if d, err := time.ParseDuration(val); err == nil {
-			cfg.ShutdownTimeout = d
-		} else {
-			log.Printf("Invalid HTTP_SHUTDOWN_TIMEOUT %q, using default", val)
-		}
+	cfg.ImportStateDir = os.Getenv("IMPORT_STATE_DIR")
+	intVar(&cfg.ImportBatchSize, "IMPORT_BATCH_SIZE")
+	intVar(&cfg.ImportWorkers, "IMPORT_WORKERS")
+	int64Var(&cfg.ImportMaxBodyBytes, "IMPORT_MAX_BODY_BYTES")
+
+	cfg.PublicURL = os.Getenv("PUBLIC_URL")
+	listVar(&cfg.FeedPrivateTags, "FEED_PRIVATE_TAGS")
+	grantsVar(&cfg.FeedTokens, "FEED_TOKENS")
+	intVar(&cfg.FeedLimit, "FEED_LIMIT")
+	intVar(&cfg.FeedMaxLimit, "FEED_MAX_LIMIT")
+
+	boolVar(&cfg.EnrichEnabled, "ENRICH_ENABLED")
+	intVar(&cfg.EnrichWorkers, "ENRICH_WORKERS")
+	intVar(&cfg.EnrichQueueSize, "ENRICH_QUEUE_SIZE")
+	durationVar(&cfg.EnrichTimeout, "ENRICH_TIMEOUT")
+	int64Var(&cfg.EnrichMaxBytes, "ENRICH_MAX_BYTES")
+	intVar(&cfg.EnrichMaxAttempts, "ENRICH_MAX_ATTEMPTS")
+	durationVar(&cfg.EnrichRetryBackoff, "ENRICH_RETRY_BACKOFF")
+	cfg.EnrichUserAgent = os.Getenv("ENRICH_USER_AGENT")
+	boolVar(&cfg.EnrichRespectRobots, "ENRICH_RESPECT_ROBOTS")
+
+	boolVar(&cfg.ContentEnabled, "CONTENT_ENABLED")
+	intVar(&cfg.ContentWorkers, "CONTENT_WORKERS")
+	intVar(&cfg.ContentQueueSize, "CONTENT_QUEUE_SIZE")
+	int64Var(&cfg.ContentMaxBytes, "CONTENT_MAX_BYTES")
+
+	boolVar(&cfg.LinkCheckEnabled, "LINKCHECK_ENABLED")
+	durationVar(&cfg.LinkCheckInterval, "LINKCHECK_INTERVAL")
+	intVar(&cfg.LinkCheckWorkers, "LINKCHECK_WORKERS")
+	durationVar(&cfg.LinkCheckTimeout, "LINKCHECK_TIMEOUT")
+	intVar(&cfg.LinkCheckBrokenAfter, "LINKCHECK_BROKEN_AFTER")
+	boolVar(&cfg.LinkCheckFollowRedirects, "LINKCHECK_FOLLOW_REDIRECTS")
+	cfg.LinkCheckUserAgent = os.Getenv("LINKCHECK_USER_AGENT")
+
+	cfg.ArchiveDir = os.Getenv("ARCHIVE_DIR")
+	listVar(&cfg.ArchiveTags, "ARCHIVE_TAGS")
+	intVar(&cfg.ArchiveWorkers, "ARCHIVE_WORKERS")
+	intVar(&cfg.ArchiveQueueSize, "ARCHIVE_QUEUE_SIZE")
+	durationVar(&cfg.ArchiveTimeout, "ARCHIVE_TIMEOUT")
+	intVar(&cfg.ArchiveMaxAssets, "ARCHIVE_MAX_ASSETS")
+	int64Var(&cfg.ArchiveMaxBytes, "ARCHIVE_MAX_BYTES")
+	cfg.ArchiveUserAgent = os.Getenv("ARCHIVE_USER_AGENT")
+
+	cfg.BlobDir = os.Getenv("BLOB_DIR")
+	if endpoint := os.Getenv("BLOB_S3_ENDPOINT"); endpoint != "" {
+		cfg.BlobS3Endpoint = endpoint
 	}
+	cfg.BlobS3Region = os.Getenv("BLOB_S3_REGION")
+	cfg.BlobS3Bucket = os.Getenv("BLOB_S3_BUCKET")
+	cfg.BlobS3Prefix = os.Getenv("BLOB_S3_PREFIX")
+	cfg.BlobS3AccessKeyID = os.Getenv("BLOB_S3_ACCESS_KEY_ID")
+	cfg.BlobS3SecretAccessKey = os.Getenv("BLOB_S3_SECRET_ACCESS_KEY")
+	boolVar(&cfg.BlobS3PathStyle, "BLOB_S3_PATH_STYLE")
+	durationVar(&cfg.BlobGCInterval, "BLOB_GC_INTERVAL")
+	durationVar(&cfg.BlobGCGrace, "BLOB_GC_GRACE")
+
+	int64Var(&cfg.AttachmentMaxBytes, "ATTACHMENT_MAX_BYTES")
+	int64Var(&cfg.AttachmentQuotaBytes, "ATTACHMENT_QUOTA_BYTES")
+	int64Var(&cfg.AttachmentTotalBytes, "ATTACHMENT_TOTAL_BYTES")
+	listVar(&cfg.AttachmentAllowedTypes, "ATTACHMENT_ALLOWED_TYPES")
+	intVar(&cfg.AttachmentThumbnailSize, "ATTACHMENT_THUMBNAIL_SIZE")
+
+	boolVar(&cfg.FaviconEnabled, "FAVICON_ENABLED")
+	cfg.FaviconDir = os.Getenv("FAVICON_DIR")
+	durationVar(&cfg.FaviconTTL, "FAVICON_TTL")
+	durationVar(&cfg.FaviconRetryAfter, "FAVICON_RETRY_AFTER")
+	durationVar(&cfg.FaviconTimeout, "FAVICON_TIMEOUT")
+	int64Var(&cfg.FaviconMaxBytes, "FAVICON_MAX_BYTES")
+	cfg.FaviconUserAgent = os.Getenv("FAVICON_USER_AGENT")
+
+	durationVar(&cfg.OutboxPollInterval, "OUTBOX_POLL_INTERVAL")
+	intVar(&cfg.OutboxBatchSize, "OUTBOX_BATCH_SIZE")
+
+	boolVar(&cfg.WebhooksEnabled, "WEBHOOKS_ENABLED")
+	listVar(&cfg.WebhookAllow, "WEBHOOK_ALLOW")
+	durationVar(&cfg.WebhookTimeout, "WEBHOOK_TIMEOUT")
+	intVar(&cfg.WebhookWorkers, "WEBHOOK_WORKERS")
+	intVar(&cfg.WebhookMaxAttempts, "WEBHOOK_MAX_ATTEMPTS")
+	durationVar(&cfg.WebhookRetryDelay, "WEBHOOK_RETRY_DELAY")
+	durationVar(&cfg.WebhookMaxRetryDelay, "WEBHOOK_MAX_RETRY_DELAY")
+	intVar(&cfg.WebhookDisableAfter, "WEBHOOK_DISABLE_AFTER")
+	intVar(&cfg.WebhookHistory, "WEBHOOK_HISTORY")
+
+	boolVar(&cfg.StreamEnabled, "STREAM_ENABLED")
+	grantsVar(&cfg.StreamTokens, "STREAM_TOKENS")
+	intVar(&cfg.StreamReplaySize, "STREAM_REPLAY_SIZE")
+	intVar(&cfg.StreamClientBuffer, "STREAM_CLIENT_BUFFER")
+	durationVar(&cfg.StreamHeartbeat, "STREAM_HEARTBEAT")
+	boolVar(&cfg.SyncEnabled, "SYNC_ENABLED")
+	intVar(&cfg.SyncPageSize, "SYNC_PAGE_SIZE")
+	intVar(&cfg.SyncMaxPush, "SYNC_MAX_PUSH")
+	durationVar(&cfg.SyncTombstoneTTL, "SYNC_TOMBSTONE_TTL")
+	durationVar(&cfg.TrashRetention, "TRASH_RETENTION")
+	durationVar(&cfg.TrashPurgeInterval, "TRASH_PURGE_INTERVAL")
+	boolVar(&cfg.RevisionsEnabled, "REVISIONS_ENABLED")
+	intVar(&cfg.RevisionsKeep, "REVISIONS_KEEP")
+	durationVar(&cfg.RevisionsMaxAge, "REVISIONS_MAX_AGE")
+	durationVar(&cfg.RevisionsPruneInterval, "REVISIONS_PRUNE_INTERVAL")
+	boolVar(&cfg.AuditEnabled, "AUDIT_ENABLED")
+	cfg.AuditDatabaseURL = os.Getenv("AUDIT_DATABASE_URL")
+	listVar(&cfg.AdminTokens, "ADMIN_TOKENS")
+	intVar(&cfg.TrustedProxies, "TRUSTED_PROXIES")
+
+	cfg.OutboundProxy = os.Getenv("OUTBOUND_PROXY")
+	listVar(&cfg.OutboundAllow, "OUTBOUND_ALLOW")
+	int64Var(&cfg.OutboundMaxBytes, "OUTBOUND_MAX_BYTES")
+	intVar(&cfg.OutboundMaxRedirects, "OUTBOUND_MAX_REDIRECTS")
+	intVar(&cfg.OutboundMaxConnsPerHost, "OUTBOUND_MAX_CONNS_PER_HOST")
+	durationVar(&cfg.OutboundHostDelay, "OUTBOUND_HOST_DELAY")
 
 	return cfg, nil
 }
+
+// durationVar overrides *dst with the duration in the named variable, keeping
+// the default when it is unset or malformed.
+func durationVar(dst *time.Duration, name string) {
+	val := os.Getenv(name)
+	if val == "" {
+		return
+	}
+	if d, err := time.ParseDuration(val); err == nil {
+		*dst = d
+	} else {
+		slog.Warn("Invalid setting, using default", "name", name, "value", val)
+	}
+}
+
+// intVar overrides *dst with the positive integer in the named variable.
+func intVar(dst *int, name string) {
+	val := os.Getenv(name)
+	if val == "" {
+		return
+	}
+	if n, err := strconv.Atoi(val); err == nil && n > 0 {
+		*dst = n
+	} else {
+		slog.Warn("Invalid setting, using default", "name", name, "value", val)
+	}
+}
+
+// int64Var overrides *dst with the positive integer in the named variable.
+func int64Var(dst *int64, name string) {
+	val := os.Getenv(name)
+	if val == "" {
+		return
+	}
+	if n, err := strconv.ParseInt(val, 10, 64); err == nil && n > 0 {
+		*dst = n
+	} else {
+		slog.Warn("Invalid setting, using default", "name", name, "value", val)
+	}
+}
+
+// boolVar overrides *dst with the boolean in the named variable.
+func boolVar(dst *bool, name string) {
+	val := os.Getenv(name)
+	if val == "" {
+		return
+	}
+	if b, err := strconv.ParseBool(val); err == nil {
+		*dst = b
+	} else {
+		slog.Warn("Invalid setting, using default", "name", name, "value", val)
+	}
+}
+
+// ratioVar overrides *dst with the number from 0 to 1 in the named variable.
+func ratioVar(dst *float64, name string) {
+	val := os.Getenv(name)
+	if val == "" {
+		return
+	}
+	if f, err := strconv.ParseFloat(val, 64); err == nil && f >= 0 && f <= 1 {
+		*dst = f
+	} else {
+		slog.Warn("Invalid setting, using default", "name", name, "value", val)
+	}
+}
+
+// listVar overrides *dst with the comma-separated values in the named
+// variable, dropping empty entries.
+func listVar(dst *[]string, name string) {
+	val := os.Getenv(name)
+	if val == "" {
+		return
+	}
+	var list []string
+	for _, item := range strings.Split(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	*dst = list
+}
+
+// grantsVar overrides *dst with the comma-separated entries in the named
+// variable, each a token optionally followed by a colon and the
+// "|"-separated tags it grants, e.g. "t0k3n,team-t0k3n:work|go".
+func grantsVar(dst *map[string][]string, name string) {
+	var entries []string
+	listVar(&entries, name)
+	if entries == nil {
+		return
+	}
+	grants := make(map[string][]string, len(entries))
+	for _, entry := range entries {
+		token, tags, _ := strings.Cut(entry, ":")
+		var list []string
+		for tag := range strings.SplitSeq(tags, "|") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				list = append(list, tag)
+			}
+		}
+		grants[strings.TrimSpace(token)] = list
+	}
+	*dst = grants
+}
@@ -0,0 +1,145 @@
+package enrich
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// maxValueLen caps every extracted value, in runes, so a hostile page cannot
+// bloat the bookmark.
+const maxValueLen = 1000
+
+// parseHead reads the metadata in an HTML document's <head>. It stops at the
+// first sign of the body, so the rest of the page is never read.
+func parseHead(r io.Reader) *domain.PageMetadata {
+	page := &domain.PageMetadata{Properties: map[string]string{}}
+	z := html.NewTokenizer(r)
+	inTitle := false
+	var title strings.Builder
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return finish(page, title.String())
+		case html.TextToken:
+			if inTitle {
+				title.Write(z.Text())
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.Title:
+				inTitle = false
+			case atom.Head:
+				return finish(page, title.String())
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			tag := atom.Lookup(name)
+			var attrs map[string]string
+			if hasAttr {
+				attrs = readAttrs(z)
+			}
+			switch tag {
+			case atom.Html:
+				page.Language = attrs["lang"]
+			case atom.Title:
+				inTitle = title.Len() == 0
+			case atom.Meta:
+				readMeta(page, attrs)
+			case atom.Link:
+				if hasToken(attrs["rel"], "canonical") && page.Canonical == "" {
+					page.Canonical = attrs["href"]
+				}
+			case atom.Body:
+				return finish(page, title.String())
+			}
+		}
+	}
+}
+
+func readAttrs(z *html.Tokenizer) map[string]string {
+	attrs := map[string]string{}
+	for {
+		key, val, more := z.TagAttr()
+		attrs[strings.ToLower(string(key))] = string(val)
+		if !more {
+			return attrs
+		}
+	}
+}
+
+func readMeta(page *domain.PageMetadata, attrs map[string]string) {
+	content := clean(attrs["content"])
+	if content == "" {
+		return
+	}
+	if strings.EqualFold(attrs["http-equiv"], "content-language") && page.Language == "" {
+		page.Language = content
+		return
+	}
+
+	// OpenGraph uses property=, Twitter cards use name=, and many sites mix
+	// them up, so both are read for both.
+	key := strings.ToLower(attrs["property"])
+	if key == "" {
+		key = strings.ToLower(attrs["name"])
+	}
+	switch {
+	case key == "description":
+		setOnce(&page.Description, content)
+	case key == "author", key == "article:author":
+		setOnce(&page.Author, content)
+	case strings.HasPrefix(key, "og:"), strings.HasPrefix(key, "twitter:"):
+		if _, ok := page.Properties[key]; !ok {
+			page.Properties[key] = content
+		}
+	}
+}
+
+// finish fills the main fields from OpenGraph and Twitter properties where the
+// plain HTML left them empty.
+func finish(page *domain.PageMetadata, title string) *domain.PageMetadata {
+	page.Title = clean(title)
+	p := page.Properties
+	setOnce(&page.Title, p["og:title"], p["twitter:title"])
+	setOnce(&page.Description, p["og:description"], p["twitter:description"])
+	setOnce(&page.SiteName, p["og:site_name"])
+	setOnce(&page.Image, p["og:image"], p["og:image:url"], p["twitter:image"])
+	page.Canonical = strings.TrimSpace(page.Canonical)
+	page.Language = clean(page.Language)
+	return page
+}
+
+func setOnce(dst *string, values ...string) {
+	for _, v := range values {
+		if *dst != "" {
+			return
+		}
+		*dst = v
+	}
+}
+
+// clean collapses whitespace and truncates s to maxValueLen runes.
+func clean(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= maxValueLen {
+		return s
+	}
+	return string([]rune(s)[:maxValueLen])
+}
+
+func hasToken(list, token string) bool {
+	for _, t := range strings.Fields(list) {
+		if strings.EqualFold(t, token) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package enrich
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHead(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		doc   string
+		check func(t *testing.T, doc string)
+	}{
+		{
+			name: "Plain HTML",
+			doc: `<!doctype html><html lang="en-GB"><head>
+				<title>  Effective
+				Go </title>
+				<meta name="description" content="Tips for writing clear, idiomatic Go code.">
+				<meta name="author" content="The Go Authors">
+				<link rel="canonical" href="/doc/effective_go">
+				</head><body><title>not this</title></body></html>`,
+			check: func(t *testing.T, doc string) {
+				page := parseHead(strings.NewReader(doc))
+				assert.Equal(t, "Effective Go", page.Title)
+				assert.Equal(t, "Tips for writing clear, idiomatic Go code.", page.Description)
+				assert.Equal(t, "The Go Authors", page.Author)
+				assert.Equal(t, "/doc/effective_go", page.Canonical)
+				assert.Equal(t, "en-GB", page.Language)
+				assert.Empty(t, page.Properties)
+			},
+		},
+		{
+			name: "OpenGraph And Twitter Fallbacks",
+			doc: `<html><head>
+				<meta property="og:title" content="OG title">
+				<meta property="og:site_name" content="Example News">
+				<meta name="twitter:description" content="Card description">
+				<meta name="twitter:image" content="https://cdn.example.com/card.png">
+				<meta property="og:type" content="article">
+				<meta http-equiv="Content-Language" content="de">
+				<link rel="alternate canonical" href="https://example.com/a">
+				</head></html>`,
+			check: func(t *testing.T, doc string) {
+				page := parseHead(strings.NewReader(doc))
+				assert.Equal(t, "OG title", page.Title)
+				assert.Equal(t, "Card description", page.Description)
+				assert.Equal(t, "Example News", page.SiteName)
+				assert.Equal(t, "https://cdn.example.com/card.png", page.Image)
+				assert.Equal(t, "https://example.com/a", page.Canonical)
+				assert.Equal(t, "de", page.Language)
+				assert.Equal(t, "article", page.Properties["og:type"])
+			},
+		},
+		{
+			name: "Stops At Body",
+			doc:  `<html><body><meta name="description" content="in body"><title>late</title></body></html>`,
+			check: func(t *testing.T, doc string) {
+				page := parseHead(strings.NewReader(doc))
+				assert.Empty(t, page.Title)
+				assert.Empty(t, page.Description)
+			},
+		},
+		{
+			name: "Long Values Truncated",
+			doc:  `<title>` + strings.Repeat("ä", maxValueLen+10) + `</title>`,
+			check: func(t *testing.T, doc string) {
+				page := parseHead(strings.NewReader(doc))
+				assert.Equal(t, maxValueLen, len([]rune(page.Title)))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.check(t, tt.doc)
+		})
+	}
+}
@@ -0,0 +1,236 @@
+// Package enrich fetches bookmarked pages and reads the metadata in their
+// <head>: title, description, OpenGraph and Twitter card fields, canonical
+// link and language. It also extracts the readable text of their body.
+package enrich
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/outbound"
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	DefaultUserAgent = "goprod/1.0 (+https://github.com/etsrc/goprod)"
+
+	// robotsMaxBytes follows RFC 9309, which lets crawlers stop reading at
+	// 500 KiB.
+	robotsMaxBytes = 500 << 10
+	robotsTTL      = 24 * time.Hour
+)
+
+type Options struct {
+	// Client sends every request. Its redirect policy and transport apply.
+	// Defaults to an outbound client with default limits.
+	Client *http.Client
+	// UserAgent is sent with every request and matched against robots.txt
+	// groups.
+	UserAgent string
+	// Timeout bounds one Inspect or Extract call, including the robots.txt
+	// lookup.
+	Timeout time.Duration
+	// MaxBytes is how much of a page Inspect reads. The <head> is almost
+	// always in the first few kilobytes.
+	MaxBytes int64
+	// MaxContentBytes is how much of a page Extract reads.
+	MaxContentBytes int64
+	// RespectRobots skips pages robots.txt disallows for UserAgent.
+	RespectRobots bool
+}
+
+// Inspector implements domain.PageInspector and domain.ContentExtractor over
+// HTTP.
+type Inspector struct {
+	opts Options
+
+	mu     sync.Mutex
+	robots map[string]robotsEntry // by scheme://host
+}
+
+type robotsEntry struct {
+	rules   *robotsRules
+	expires time.Time
+}
+
+var (
+	_ domain.PageInspector    = (*Inspector)(nil)
+	_ domain.ContentExtractor = (*Inspector)(nil)
+)
+
+func NewInspector(opts Options) *Inspector {
+	if opts.Client == nil {
+		// Without a proxy NewClient cannot fail.
+		opts.Client, _ = outbound.NewClient(outbound.Options{})
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = DefaultUserAgent
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 15 * time.Second
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = 1 << 20
+	}
+	if opts.MaxContentBytes <= 0 {
+		opts.MaxContentBytes = 5 << 20
+	}
+	return &Inspector{opts: opts, robots: make(map[string]robotsEntry)}
+}
+
+// Inspect fetches rawURL and parses its <head>. Errors that retrying cannot fix
+// wrap domain.ErrPageUnavailable.
+func (i *Inspector) Inspect(ctx context.Context, rawURL string) (*domain.PageMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.opts.Timeout)
+	defer cancel()
+
+	body, resp, err := i.fetch(ctx, rawURL, i.opts.MaxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("enrich.Inspect: %w", err)
+	}
+	defer resp.Body.Close()
+	page := parseHead(body)
+
+	// Relative links resolve against where the redirects ended.
+	base := resp.Request.URL
+	page.Canonical = resolve(base, page.Canonical)
+	page.Image = resolve(base, page.Image)
+	return page, nil
+}
+
+// fetch requests the HTML page at rawURL, subject to robots.txt when so
+// configured, and returns up to maxBytes of its body decoded to UTF-8. The
+// caller closes resp.Body.
+func (i *Inspector) fetch(ctx context.Context, rawURL string, maxBytes int64) (io.Reader, *http.Response, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, nil, fmt.Errorf("%w: not an http(s) URL", domain.ErrPageUnavailable)
+	}
+
+	if i.opts.RespectRobots {
+		ok, err := i.allowedByRobots(ctx, u)
+		if err != nil {
+			return nil, nil, fmt.Errorf("robots.txt: %w", err)
+		}
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: disallowed by robots.txt", domain.ErrPageUnavailable)
+		}
+	}
+
+	resp, err := i.get(ctx, u.String(), "text/html,application/xhtml+xml;q=0.9,*/*;q=0.1")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := checkStatus(resp.StatusCode); err != nil {
+		resp.Body.Close()
+		return nil, nil, err
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(contentType); contentType != "" &&
+		mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		resp.Body.Close()
+		return nil, nil, fmt.Errorf("%w: content type %q", domain.ErrPageUnavailable, mediaType)
+	}
+
+	body, err := charset.NewReader(io.LimitReader(resp.Body, maxBytes), contentType)
+	if err != nil {
+		resp.Body.Close()
+		return nil, nil, fmt.Errorf("%w: %v", domain.ErrPageUnavailable, err)
+	}
+	return body, resp, nil
+}
+
+func (i *Inspector) get(ctx context.Context, target, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", i.opts.UserAgent)
+	req.Header.Set("Accept", accept)
+	resp, err := i.opts.Client.Do(req)
+	if errors.Is(err, outbound.ErrBlockedAddress) || errors.Is(err, outbound.ErrTooManyRedirects) {
+		return nil, fmt.Errorf("%w: %v", domain.ErrPageUnavailable, err)
+	}
+	return resp, err
+}
+
+// allowedByRobots consults the host's robots.txt, fetched at most once a day.
+// A missing file allows everything; a server error is reported so that the
+// page is retried later rather than fetched against the owner's wishes.
+func (i *Inspector) allowedByRobots(ctx context.Context, u *url.URL) (bool, error) {
+	origin := u.Scheme + "://" + u.Host
+
+	i.mu.Lock()
+	entry, ok := i.robots[origin]
+	i.mu.Unlock()
+
+	if !ok || time.Now().After(entry.expires) {
+		rules, err := i.fetchRobots(ctx, origin)
+		if err != nil {
+			return false, err
+		}
+		entry = robotsEntry{rules: rules, expires: time.Now().Add(robotsTTL)}
+		i.mu.Lock()
+		i.robots[origin] = entry
+		i.mu.Unlock()
+	}
+	return entry.rules.allowed(u.EscapedPath()), nil
+}
+
+func (i *Inspector) fetchRobots(ctx context.Context, origin string) (*robotsRules, error) {
+	resp, err := i.get(ctx, origin+"/robots.txt", "text/plain")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return parseRobots(io.LimitReader(resp.Body, robotsMaxBytes), i.agentToken()), nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return &robotsRules{}, nil
+	default:
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+}
+
+// agentToken is the product name robots.txt groups refer to, e.g. "goprod"
+// for "goprod/1.0 (+https://...)".
+func (i *Inspector) agentToken() string {
+	token, _, _ := strings.Cut(i.opts.UserAgent, "/")
+	return strings.TrimSpace(token)
+}
+
+// checkStatus sorts failed responses into those worth retrying (timeouts,
+// rate limits and server errors) and those that are not.
+func checkStatus(code int) error {
+	switch {
+	case code >= 200 && code < 300:
+		return nil
+	case code == http.StatusRequestTimeout, code == http.StatusTooManyRequests, code >= 500:
+		return fmt.Errorf("status %d", code)
+	default:
+		return fmt.Errorf("%w: status %d", domain.ErrPageUnavailable, code)
+	}
+}
+
+func resolve(base *url.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	u, err := base.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	return u.String()
+}
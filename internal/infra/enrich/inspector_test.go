@@ -0,0 +1,125 @@
+package enrich
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspector_Inspect(t *testing.T) {
+	t.Parallel()
+
+	var robotsFetches atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, _ *http.Request) {
+		robotsFetches.Add(1)
+		w.Write([]byte("User-agent: *\nDisallow: /secret\n"))
+	})
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "testbot/1.0" {
+			http.Error(w, "wrong user agent", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<html lang="en"><head><title>Article</title>
+			<link rel="canonical" href="/article?ref=canonical">
+			<meta property="og:image" content="img/cover.png"></head></html>`))
+	})
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/article", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/latin1", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=iso-8859-1")
+		w.Write([]byte("<title>Caf\xe9</title>"))
+	})
+	mux.HandleFunc("/secret", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("<title>Secret</title>"))
+	})
+	mux.HandleFunc("/file.pdf", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte("%PDF-1.7"))
+	})
+	mux.HandleFunc("/flaky", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "try later", http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/huge", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<head><!--" + strings.Repeat("x", 4096) + "--><title>Too far</title></head>"))
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	inspector := NewInspector(Options{
+		Client:        srv.Client(),
+		UserAgent:     "testbot/1.0",
+		Timeout:       200 * time.Millisecond,
+		MaxBytes:      1024,
+		RespectRobots: true,
+	})
+	ctx := context.Background()
+
+	t.Run("Follows Redirects And Resolves Links", func(t *testing.T) {
+		page, err := inspector.Inspect(ctx, srv.URL+"/old")
+		require.NoError(t, err)
+		assert.Equal(t, "Article", page.Title)
+		assert.Equal(t, "en", page.Language)
+		assert.Equal(t, srv.URL+"/article?ref=canonical", page.Canonical)
+		assert.Equal(t, srv.URL+"/img/cover.png", page.Image)
+	})
+
+	t.Run("Decodes Charset", func(t *testing.T) {
+		page, err := inspector.Inspect(ctx, srv.URL+"/latin1")
+		require.NoError(t, err)
+		assert.Equal(t, "Café", page.Title)
+	})
+
+	t.Run("Size Cap", func(t *testing.T) {
+		page, err := inspector.Inspect(ctx, srv.URL+"/huge")
+		require.NoError(t, err)
+		assert.Empty(t, page.Title)
+	})
+
+	permanent := []string{"/secret", "/file.pdf", "/missing"}
+	for _, path := range permanent {
+		t.Run("Permanent "+path, func(t *testing.T) {
+			_, err := inspector.Inspect(ctx, srv.URL+path)
+			assert.ErrorIs(t, err, domain.ErrPageUnavailable)
+		})
+	}
+
+	transient := []string{"/flaky", "/slow"}
+	for _, path := range transient {
+		t.Run("Transient "+path, func(t *testing.T) {
+			_, err := inspector.Inspect(ctx, srv.URL+path)
+			require.Error(t, err)
+			assert.NotErrorIs(t, err, domain.ErrPageUnavailable)
+		})
+	}
+
+	t.Run("Not HTTP", func(t *testing.T) {
+		_, err := inspector.Inspect(ctx, "ftp://example.com/file")
+		assert.ErrorIs(t, err, domain.ErrPageUnavailable)
+	})
+
+	assert.Equal(t, int32(1), robotsFetches.Load(), "robots.txt is cached per host")
+
+	t.Run("Blocked Address", func(t *testing.T) {
+		// The default client refuses the loopback test server.
+		_, err := NewInspector(Options{}).Inspect(ctx, srv.URL+"/article")
+		assert.ErrorIs(t, err, domain.ErrPageUnavailable)
+	})
+}
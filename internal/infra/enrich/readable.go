@@ -0,0 +1,317 @@
+package enrich
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Extract fetches rawURL and returns the text of its main content. Errors that
+// retrying cannot fix wrap domain.ErrPageUnavailable.
+func (i *Inspector) Extract(ctx context.Context, rawURL string) (*domain.PageContent, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.opts.Timeout)
+	defer cancel()
+
+	body, resp, err := i.fetch(ctx, rawURL, i.opts.MaxContentBytes)
+	if err != nil {
+		return nil, fmt.Errorf("enrich.Extract: %w", err)
+	}
+	defer resp.Body.Close()
+
+	doc, err := html.Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("enrich.Extract: %w: %v", domain.ErrPageUnavailable, err)
+	}
+	text := readableText(doc)
+	return &domain.PageContent{Text: text, WordCount: len(strings.Fields(text))}, nil
+}
+
+// The extraction below follows the approach of Readability: drop what is
+// clearly not content, score the containers of paragraphs by how much prose
+// they hold, and keep the best one along with siblings that look like part of
+// the same article.
+
+// minParagraphLen is how many characters a block needs before it counts as
+// prose rather than a caption, button or menu entry.
+const minParagraphLen = 25
+
+var (
+	// unlikelyClass matches the class and id values of page furniture.
+	unlikelyClass = regexp.MustCompile(`(?i)\b(?:ad|ads|advert|banner|breadcrumbs?|comments?|cookie|footer|masthead|menu|nav|newsletter|popup|promo|related|share|sidebar|social|sponsor|subscribe|widget)\b`)
+	// likelyClass matches the class and id values of content containers and
+	// wins over unlikelyClass.
+	likelyClass = regexp.MustCompile(`(?i)\b(?:article|body|content|entry|main|post|story|text)\b`)
+)
+
+func readableText(doc *html.Node) string {
+	root := findElement(doc, atom.Body)
+	if root == nil {
+		root = doc
+	}
+	prune(root)
+
+	scores := scoreParagraphs(root)
+	var top *html.Node
+	for n, score := range scores {
+		if top == nil || score > scores[top] {
+			top = n
+		}
+	}
+
+	w := &textWriter{}
+	if top == nil {
+		w.render(root)
+		return w.String()
+	}
+
+	// Articles are often split over several containers under one parent, e.g.
+	// around an inline advert.
+	threshold := max(10, scores[top]*0.2)
+	for sibling := range top.Parent.ChildNodes() {
+		switch {
+		case sibling == top:
+			w.render(sibling)
+		case sibling.Type != html.ElementNode:
+		case scores[sibling] >= threshold:
+			w.render(sibling)
+		case sibling.DataAtom == atom.P:
+			if n := textLen(sibling); n > 80 && linkDensity(sibling) < 0.25 {
+				w.render(sibling)
+			}
+		}
+	}
+	return w.String()
+}
+
+// prune removes elements that never hold the main content.
+func prune(n *html.Node) {
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		if c.Type == html.CommentNode || (c.Type == html.ElementNode && unwanted(c)) {
+			n.RemoveChild(c)
+		} else {
+			prune(c)
+		}
+		c = next
+	}
+}
+
+func unwanted(n *html.Node) bool {
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Noscript, atom.Template, atom.Iframe, atom.Svg,
+		atom.Canvas, atom.Form, atom.Button, atom.Input, atom.Select, atom.Textarea,
+		atom.Nav, atom.Aside, atom.Footer:
+		return true
+	case atom.Html, atom.Body, atom.Article, atom.Main:
+		return false
+	}
+	if hasAttr(n, "hidden") || attr(n, "aria-hidden") == "true" {
+		return true
+	}
+	switch attr(n, "role") {
+	case "navigation", "banner", "contentinfo", "complementary", "dialog", "menu", "search":
+		return true
+	}
+	class := attr(n, "class") + " " + attr(n, "id")
+	return unlikelyClass.MatchString(class) && !likelyClass.MatchString(class)
+}
+
+// scoreParagraphs credits every paragraph's parent with its score and its
+// grandparent with half of it, then discounts each container by how much of
+// its text is links.
+func scoreParagraphs(root *html.Node) map[*html.Node]float64 {
+	scores := make(map[*html.Node]float64)
+	add := func(n *html.Node, score float64) {
+		if n == nil || n.Type != html.ElementNode {
+			return
+		}
+		if _, ok := scores[n]; !ok {
+			scores[n] = initialScore(n)
+		}
+		scores[n] += score
+	}
+
+	for n := range root.Descendants() {
+		if !isParagraph(n) {
+			continue
+		}
+		text := innerText(n)
+		length := utf8.RuneCountInString(text)
+		if length < minParagraphLen {
+			continue
+		}
+		score := 1 + float64(strings.Count(text, ",")) + min(float64(length)/100, 3)
+		add(n.Parent, score)
+		if n.Parent != nil {
+			add(n.Parent.Parent, score/2)
+		}
+	}
+
+	for n := range scores {
+		scores[n] *= 1 - linkDensity(n)
+	}
+	return scores
+}
+
+func isParagraph(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	switch n.DataAtom {
+	case atom.P, atom.Pre, atom.Blockquote, atom.Td:
+		return true
+	case atom.Div:
+		// A div holding nothing but text and inline markup is a paragraph in
+		// all but name.
+		for c := range n.Descendants() {
+			if c.Type == html.ElementNode && isBlock(c) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func initialScore(n *html.Node) float64 {
+	var score float64
+	switch n.DataAtom {
+	case atom.Article, atom.Main:
+		score = 10
+	case atom.Div:
+		score = 5
+	case atom.Pre, atom.Td, atom.Blockquote:
+		score = 3
+	case atom.Ol, atom.Ul, atom.Dl, atom.Th:
+		score = -3
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		score = -5
+	}
+	class := attr(n, "class") + " " + attr(n, "id")
+	if likelyClass.MatchString(class) {
+		score += 25
+	}
+	if unlikelyClass.MatchString(class) {
+		score -= 25
+	}
+	return score
+}
+
+// linkDensity is the share of n's text inside links.
+func linkDensity(n *html.Node) float64 {
+	total := textLen(n)
+	if total == 0 {
+		return 0
+	}
+	var links int
+	for c := range n.Descendants() {
+		if c.Type == html.ElementNode && c.DataAtom == atom.A {
+			links += textLen(c)
+		}
+	}
+	return float64(links) / float64(total)
+}
+
+func textLen(n *html.Node) int {
+	return utf8.RuneCountInString(innerText(n))
+}
+
+// innerText joins the text under n with its whitespace collapsed.
+func innerText(n *html.Node) string {
+	var b strings.Builder
+	for c := range n.Descendants() {
+		if c.Type == html.TextNode {
+			b.WriteString(c.Data)
+			b.WriteByte(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func isBlock(n *html.Node) bool {
+	switch n.DataAtom {
+	case atom.Address, atom.Article, atom.Blockquote, atom.Br, atom.Dd, atom.Details,
+		atom.Div, atom.Dl, atom.Dt, atom.Figcaption, atom.Figure, atom.H1, atom.H2,
+		atom.H3, atom.H4, atom.H5, atom.H6, atom.Header, atom.Hr, atom.Li, atom.Main,
+		atom.Ol, atom.P, atom.Pre, atom.Section, atom.Summary, atom.Table, atom.Tr,
+		atom.Ul:
+		return true
+	}
+	return false
+}
+
+// textWriter renders nodes as plain text, one paragraph per block element.
+type textWriter struct {
+	paragraphs []string
+	current    strings.Builder
+}
+
+func (w *textWriter) render(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.current.WriteString(n.Data)
+		return
+	case html.ElementNode:
+		if n.DataAtom == atom.Img {
+			return
+		}
+	}
+
+	block := n.Type == html.ElementNode && isBlock(n)
+	if block {
+		w.endParagraph()
+	}
+	for c := range n.ChildNodes() {
+		w.render(c)
+	}
+	if block {
+		w.endParagraph()
+	} else if n.DataAtom == atom.Td || n.DataAtom == atom.Th {
+		w.current.WriteByte(' ')
+	}
+}
+
+func (w *textWriter) endParagraph() {
+	if p := strings.Join(strings.Fields(w.current.String()), " "); p != "" {
+		w.paragraphs = append(w.paragraphs, p)
+	}
+	w.current.Reset()
+}
+
+func (w *textWriter) String() string {
+	w.endParagraph()
+	return strings.Join(w.paragraphs, "\n\n")
+}
+
+func findElement(n *html.Node, a atom.Atom) *html.Node {
+	for c := range n.Descendants() {
+		if c.Type == html.ElementNode && c.DataAtom == a {
+			return c
+		}
+	}
+	return nil
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasAttr(n *html.Node, key string) bool {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package enrich
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+)
+
+const blogPost = `<!doctype html><html><head><title>Post</title>
+<script>var tracking = "do not index";</script><style>p { color: red }</style></head>
+<body>
+<header class="site-header"><a href="/">Home</a> <a href="/blog">Blog</a></header>
+<nav><ul><li><a href="/a">Archive</a></li><li><a href="/b">About</a></li></ul></nav>
+<div id="main" class="layout">
+  <div class="post-content">
+    <h1>Understanding channels</h1>
+    <p>Channels are the pipes that connect concurrent goroutines, and you can send values into
+    channels from one goroutine and receive those values in another.</p>
+    <div class="ad-slot">Buy our course, now with 20% off, limited time only!</div>
+    <p>By default, sends and receives block until the other side is ready, which lets goroutines
+    synchronize without explicit locks or condition variables.</p>
+    <pre>ch := make(chan int)</pre>
+  </div>
+  <div class="post-content-more">
+    <p>Buffered channels accept a limited number of values without a corresponding receiver,
+    which can smooth out bursts of work between producers and consumers.</p>
+  </div>
+  <aside class="sidebar"><p>Popular posts: one, two, three, four, five, six, seven and eight.</p></aside>
+</div>
+<div class="comments"><p>Great post, thanks a lot, this helped me understand everything!</p></div>
+<footer><p>Copyright 2026, all rights reserved, no part may be reproduced.</p></footer>
+</body></html>`
+
+func TestReadableText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		doc     string
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "Blog Post",
+			doc:  blogPost,
+			want: []string{
+				"Understanding channels",
+				"Channels are the pipes that connect concurrent goroutines",
+				"without explicit locks or condition variables.",
+				"ch := make(chan int)",
+				"Buffered channels accept a limited number of values",
+			},
+			notWant: []string{"tracking", "color: red", "Archive", "Buy our course", "Popular posts", "Great post", "Copyright"},
+		},
+		{
+			name: "No Paragraphs Falls Back To Body",
+			doc:  `<body><nav>Menu</nav><span>Just a short note.</span></body>`,
+			want: []string{"Just a short note."},
+		},
+		{
+			name:    "Hidden Content",
+			doc:     `<body><article><p>Visible paragraph with enough text to count as prose.</p><p hidden>Secret paragraph with enough text to count as prose.</p></article></body>`,
+			want:    []string{"Visible paragraph"},
+			notWant: []string{"Secret"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := html.Parse(strings.NewReader(tt.doc))
+			require.NoError(t, err)
+			text := readableText(doc)
+			for _, s := range tt.want {
+				assert.Contains(t, text, s)
+			}
+			for _, s := range tt.notWant {
+				assert.NotContains(t, text, s)
+			}
+		})
+	}
+}
+
+func TestReadableText_Paragraphs(t *testing.T) {
+	t.Parallel()
+
+	doc, err := html.Parse(strings.NewReader(`<body><article>
+		<h2>Title</h2>
+		<p>First   paragraph,
+		with a <a href="/x">link</a> inside it.</p>
+		<p>Second<br>line.</p>
+	</article></body>`))
+	require.NoError(t, err)
+	assert.Equal(t, "Title\n\nFirst paragraph, with a link inside it.\n\nSecond\n\nline.", readableText(doc))
+}
+
+func TestInspector_Extract(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/post", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(blogPost))
+	})
+	mux.HandleFunc("/file.pdf", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte("%PDF-1.7"))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	inspector := NewInspector(Options{Client: srv.Client()})
+	ctx := context.Background()
+
+	content, err := inspector.Extract(ctx, srv.URL+"/post")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(content.Text, "Understanding channels\n\n"))
+	assert.Equal(t, len(strings.Fields(content.Text)), content.WordCount)
+	assert.Greater(t, content.WordCount, 50)
+
+	_, err = inspector.Extract(ctx, srv.URL+"/file.pdf")
+	assert.ErrorIs(t, err, domain.ErrPageUnavailable)
+}
@@ -0,0 +1,111 @@
+package enrich
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// robotsRules is the group of a robots.txt file that applies to one user agent.
+type robotsRules struct {
+	rules []robotsRule
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+}
+
+// parseRobots reads a robots.txt (RFC 9309) and keeps the rules of the group
+// that names agent most specifically, or of the "*" group when none does.
+func parseRobots(r io.Reader, agent string) *robotsRules {
+	agent = strings.ToLower(agent)
+
+	groups := map[string][]robotsRule{}
+	var current []string
+	inRules := false
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// A user-agent line after rules starts a new group.
+			if inRules {
+				current, inRules = nil, false
+			}
+			ua := strings.ToLower(value)
+			current = append(current, ua)
+			if _, ok := groups[ua]; !ok {
+				groups[ua] = nil
+			}
+		case "allow", "disallow":
+			inRules = true
+			if value == "" {
+				continue // "Disallow:" with no path allows everything
+			}
+			for _, ua := range current {
+				groups[ua] = append(groups[ua], robotsRule{allow: key == "allow", pattern: value})
+			}
+		}
+	}
+
+	best, found := "", false
+	for ua := range groups {
+		if ua != "*" && strings.Contains(agent, ua) && len(ua) > len(best) {
+			best, found = ua, true
+		}
+	}
+	if !found {
+		best = "*"
+	}
+	return &robotsRules{rules: groups[best]}
+}
+
+// allowed applies the longest matching rule to path; on a tie Allow wins.
+func (r *robotsRules) allowed(path string) bool {
+	if path == "/robots.txt" {
+		return true
+	}
+	allow, longest := true, -1
+	for _, rule := range r.rules {
+		if !robotsMatch(rule.pattern, path) {
+			continue
+		}
+		if n := len(rule.pattern); n > longest || (n == longest && rule.allow) {
+			allow, longest = rule.allow, n
+		}
+	}
+	return allow
+}
+
+// robotsMatch matches path against a robots.txt pattern, where "*" stands for
+// any run of characters and a trailing "$" anchors the end.
+func robotsMatch(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	parts := strings.Split(pattern, "*")
+
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	for i, part := range parts[1:] {
+		last := i == len(parts)-2
+		if last && anchored {
+			return strings.HasSuffix(rest, part)
+		}
+		idx := strings.Index(rest, part)
+		if idx < 0 {
+			return false
+		}
+		rest = rest[idx+len(part):]
+	}
+	return !anchored || rest == ""
+}
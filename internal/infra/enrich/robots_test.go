@@ -0,0 +1,50 @@
+package enrich
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRobotsRules(t *testing.T) {
+	t.Parallel()
+
+	const robots = `
+# comments are ignored
+User-agent: *
+Disallow: /private/
+Allow: /private/public-note
+Disallow: /*.pdf$
+
+User-agent: goprod
+User-agent: otherbot
+Disallow: /no-goprod
+Allow: /
+`
+
+	tests := []struct {
+		name  string
+		agent string
+		path  string
+		want  bool
+	}{
+		{name: "Star Group Disallow", agent: "somebot", path: "/private/diary", want: false},
+		{name: "Longer Allow Wins", agent: "somebot", path: "/private/public-note", want: true},
+		{name: "Wildcard With Anchor", agent: "somebot", path: "/files/report.pdf", want: false},
+		{name: "Anchor Does Not Match Longer Path", agent: "somebot", path: "/files/report.pdf.html", want: true},
+		{name: "Named Group Replaces Star", agent: "goprod", path: "/private/diary", want: true},
+		{name: "Named Group Disallow", agent: "goprod", path: "/no-goprod/x", want: false},
+		{name: "Robots File Always Allowed", agent: "somebot", path: "/robots.txt", want: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rules := parseRobots(strings.NewReader(robots), tt.agent)
+			assert.Equal(t, tt.want, rules.allowed(tt.path))
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Package eventbus delivers domain events to subscribers in the same process.
+//
+// Synchronous subscribers run inside Publish, before it returns, in the order
+// they subscribed. Asynchronous subscribers get events through queues served
+// by their own workers, so that a slow one never holds up the write that
+// published the event. Either way, events for one aggregate reach a
+// subscriber in the order they were published, and a subscriber that fails
+// or panics is logged without affecting the others. Publish returns the
+// errors of synchronous subscribers, so that the events can be published
+// again.
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"runtime/debug"
+	"sync"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+type AsyncOptions struct {
+	// Workers is how many events are handled at once. Events for one
+	// aggregate always go to the same worker. Defaults to 1.
+	Workers int
+	// QueueSize is how many events may wait for each worker. Further events
+	// are dropped and logged. Defaults to 1000.
+	QueueSize int
+}
+
+// Bus implements domain.EventPublisher.
+type Bus struct {
+	mu   sync.RWMutex
+	subs []*subscriber
+}
+
+var _ domain.EventPublisher = (*Bus)(nil)
+
+type subscriber struct {
+	name   string
+	handle domain.EventHandler
+	queues []chan queued // one per worker; nil for synchronous subscribers
+}
+
+type queued struct {
+	ctx   context.Context
+	event domain.Event
+}
+
+func New() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers handle to run inside Publish. name identifies the
+// subscriber in logs.
+func (b *Bus) Subscribe(name string, handle domain.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs = append(b.subs, &subscriber{name: name, handle: handle})
+}
+
+// SubscribeAsync registers handle to run on workers started by Run. Async
+// subscribers must be registered before Run is called.
+func (b *Bus) SubscribeAsync(name string, handle domain.EventHandler, opts AsyncOptions) {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 1000
+	}
+	s := &subscriber{name: name, handle: handle, queues: make([]chan queued, opts.Workers)}
+	for i := range s.queues {
+		s.queues[i] = make(chan queued, opts.QueueSize)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs = append(b.subs, s)
+}
+
+// Publish hands events to every subscriber, and returns the errors and
+// panics of the synchronous ones, joined. Asynchronous handlers get a context
+// with ctx's values but not its cancellation, since they usually run after
+// the request that published the event has finished.
+func (b *Bus) Publish(ctx context.Context, events ...domain.Event) error {
+	b.mu.RLock()
+	subs := b.subs
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, e := range events {
+		for _, s := range subs {
+			if s.queues == nil {
+				errs = append(errs, s.call(ctx, e))
+				continue
+			}
+			select {
+			case s.queues[shard(e.AggregateID(), len(s.queues))] <- queued{ctx: context.WithoutCancel(ctx), event: e}:
+			default:
+				domain.LoggerFrom(ctx).Warn("Subscriber queue full, event dropped", "subscriber", s.name, "event_type", e.EventType(), "event_id", e.EventID())
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run serves asynchronous subscribers until ctx is canceled, then handles
+// the events already queued and returns.
+func (b *Bus) Run(ctx context.Context) error {
+	b.mu.RLock()
+	subs := b.subs
+	b.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, s := range subs {
+		for _, q := range s.queues {
+			wg.Go(func() { s.serve(ctx, q) })
+		}
+	}
+	wg.Wait()
+	return nil
+}
+
+func (s *subscriber) serve(ctx context.Context, q chan queued) {
+	for {
+		select {
+		case item := <-q:
+			s.call(item.ctx, item.event)
+		case <-ctx.Done():
+			for {
+				select {
+				case item := <-q:
+					s.call(item.ctx, item.event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// call runs the handler, logging and returning its error or panic.
+func (s *subscriber) call(ctx context.Context, e domain.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			domain.LoggerFrom(ctx).Error("Subscriber panicked", "subscriber", s.name, "event_type", e.EventType(), "event_id", e.EventID(), "panic", r, "stack", string(debug.Stack()))
+			err = fmt.Errorf("subscriber %s panicked: %v", s.name, r)
+		}
+	}()
+	if err := s.handle(ctx, e); err != nil {
+		domain.LoggerFrom(ctx).Error("Subscriber failed", "subscriber", s.name, "event_type", e.EventType(), "event_id", e.EventID(), "error", err)
+		return fmt.Errorf("subscriber %s: %w", s.name, err)
+	}
+	return nil
+}
+
+// shard picks the worker for an aggregate.
+func shard(aggregateID string, n int) int {
+	if n == 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	h.Write([]byte(aggregateID))
+	return int(h.Sum32() % uint32(n))
+}
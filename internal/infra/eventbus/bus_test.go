@@ -0,0 +1,172 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tagAdded(bookmarkID, tag string) domain.Event {
+	return domain.TagAdded{EventMeta: domain.EventMeta{ID: bookmarkID + "/" + tag, At: time.Now()}, BookmarkID: bookmarkID, Tag: tag}
+}
+
+// run starts b's workers and returns a function that stops them and waits
+// for the queued events to be handled.
+func run(t *testing.T, b *Bus) func() {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, b.Run(ctx))
+		close(done)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func TestBus_SyncSubscribers(t *testing.T) {
+	t.Parallel()
+
+	b := New()
+	var got []string
+	errFull := errors.New("disk full")
+	b.Subscribe("first", func(_ context.Context, e domain.Event) error {
+		got = append(got, "first:"+e.EventID())
+		return nil
+	})
+	b.Subscribe("second", func(_ context.Context, e domain.Event) error {
+		got = append(got, "second:"+e.EventID())
+		return errFull
+	})
+	b.SubscribeAsync("async", func(context.Context, domain.Event) error {
+		return errors.New("logged, not returned")
+	}, AsyncOptions{})
+	stop := run(t, b)
+
+	err := b.Publish(context.Background(), tagAdded("a", "go"), tagAdded("a", "db"))
+	stop()
+
+	// Handled before Publish returned, in subscription order, each event
+	// by every subscriber although one of them failed.
+	assert.Equal(t, []string{"first:a/go", "second:a/go", "first:a/db", "second:a/db"}, got)
+	assert.ErrorIs(t, err, errFull)
+	assert.ErrorContains(t, err, "subscriber second")
+	assert.NotContains(t, err.Error(), "logged, not returned")
+}
+
+func TestBus_PanicIsolation(t *testing.T) {
+	t.Parallel()
+
+	b := New()
+	var mu sync.Mutex
+	var inline, async []string
+	b.Subscribe("panics", func(context.Context, domain.Event) error { panic("boom") })
+	b.SubscribeAsync("panics-async", func(context.Context, domain.Event) error { panic("boom") }, AsyncOptions{})
+	b.Subscribe("healthy", func(_ context.Context, e domain.Event) error {
+		inline = append(inline, e.EventID())
+		return nil
+	})
+	b.SubscribeAsync("healthy-async", func(_ context.Context, e domain.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		async = append(async, e.EventID())
+		return nil
+	}, AsyncOptions{})
+	stop := run(t, b)
+
+	err := b.Publish(context.Background(), tagAdded("a", "go"), tagAdded("a", "db"))
+	stop()
+
+	assert.ErrorContains(t, err, "subscriber panics panicked: boom")
+	assert.Equal(t, []string{"a/go", "a/db"}, inline)
+	assert.Equal(t, []string{"a/go", "a/db"}, async)
+}
+
+func TestBus_AsyncOrderedPerAggregate(t *testing.T) {
+	t.Parallel()
+
+	b := New()
+	var mu sync.Mutex
+	got := map[string][]string{}
+	b.SubscribeAsync("recorder", func(_ context.Context, e domain.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		got[e.AggregateID()] = append(got[e.AggregateID()], e.(domain.TagAdded).Tag)
+		return nil
+	}, AsyncOptions{Workers: 4})
+	stop := run(t, b)
+
+	want := map[string][]string{}
+	for i := range 200 {
+		id := fmt.Sprintf("b%d", i%10)
+		tag := fmt.Sprint(i)
+		want[id] = append(want[id], tag)
+		b.Publish(context.Background(), tagAdded(id, tag))
+	}
+	stop()
+
+	assert.Equal(t, want, got)
+}
+
+func TestBus_AsyncDoesNotBlockPublish(t *testing.T) {
+	t.Parallel()
+
+	b := New()
+	release := make(chan struct{})
+	var handled []string
+	b.SubscribeAsync("slow", func(_ context.Context, e domain.Event) error {
+		<-release
+		handled = append(handled, e.EventID())
+		return nil
+	}, AsyncOptions{QueueSize: 1})
+
+	// Without workers the queue fills at once and the rest is dropped.
+	published := make(chan struct{})
+	go func() {
+		b.Publish(context.Background(), tagAdded("a", "1"), tagAdded("a", "2"), tagAdded("a", "3"))
+		close(published)
+	}()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a full queue")
+	}
+
+	close(release)
+	stop := run(t, b)
+	stop()
+	require.Equal(t, []string{"a/1"}, handled)
+}
+
+func TestBus_AsyncContextOutlivesRequest(t *testing.T) {
+	t.Parallel()
+
+	type key struct{}
+	b := New()
+	gotErr := make(chan error, 2)
+	b.SubscribeAsync("ctx", func(ctx context.Context, _ domain.Event) error {
+		if ctx.Value(key{}) != "request-1" {
+			gotErr <- errors.New("value lost")
+		}
+		gotErr <- ctx.Err()
+		return nil
+	}, AsyncOptions{})
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "request-1"))
+	b.Publish(ctx, tagAdded("a", "go"))
+	cancel()
+	stop := run(t, b)
+	stop()
+
+	assert.NoError(t, <-gotErr)
+}
@@ -0,0 +1,87 @@
+package favicon
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+)
+
+// fallbackGrid is the number of cells across a generated icon. Its left half
+// is mirrored to the right, which makes the patterns read as shapes.
+const fallbackGrid = 5
+
+// Fallback draws an identicon: a symmetric pattern of cells, on a light
+// background, in a colour derived from host. Both come from a hash of host,
+// so a host keeps its icon across restarts and instances.
+func (f *Fetcher) Fallback(host string, size int) []byte {
+	sum := sha256.Sum256([]byte(host))
+	fg := hueColor(int(sum[0])<<8 | int(sum[1]))
+	bg := color.NRGBA{0xf0, 0xf0, 0xf0, 0xff}
+
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(img, img.Bounds(), image.NewUniform(bg), image.Point{}, draw.Src)
+
+	// Cells are whole pixels, centred, with a margin of about half a cell.
+	cell := max(size/(fallbackGrid+1), 1)
+	origin := (size - cell*fallbackGrid) / 2
+	half := (fallbackGrid + 1) / 2
+	for row := range fallbackGrid {
+		for col := range half {
+			bit := row*half + col
+			if sum[2+bit/8]>>(bit%8)&1 == 0 {
+				continue
+			}
+			for _, c := range []int{col, fallbackGrid - 1 - col} {
+				r := image.Rect(0, 0, cell, cell).Add(image.Pt(origin+c*cell, origin+row*cell))
+				draw.Draw(img, r, image.NewUniform(fg), image.Point{}, draw.Src)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	// Encoding an in-memory image cannot fail.
+	_ = png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+// hueColor is a mid-saturation colour of the given hue, out of 65536, dark
+// enough to stand out on the light background.
+func hueColor(hue int) color.NRGBA {
+	const s, l = 0.55, 0.5
+	h := float64(hue) / 65536 * 6
+	c := (1 - abs(2*l-1)) * s
+	x := c * (1 - abs(mod2(h)-1))
+	m := l - c/2
+
+	var r, g, b float64
+	switch int(h) {
+	case 0:
+		r, g, b = c, x, 0
+	case 1:
+		r, g, b = x, c, 0
+	case 2:
+		r, g, b = 0, c, x
+	case 3:
+		r, g, b = 0, x, c
+	case 4:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return color.NRGBA{uint8((r + m) * 255), uint8((g + m) * 255), uint8((b + m) * 255), 0xff}
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// mod2 is v modulo 2 for non-negative v.
+func mod2(v float64) float64 {
+	return v - 2*float64(int(v/2))
+}
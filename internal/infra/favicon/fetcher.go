@@ -0,0 +1,231 @@
+// Package favicon finds sites' icons, from the <link rel="icon"> tags of their
+// home page or at /favicon.ico, and renders them as PNGs at standard sizes.
+// It also draws a generated icon for sites that have none.
+package favicon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	_ "image/gif"  // register the GIF decoder
+	_ "image/jpeg" // register the JPEG decoder
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/outbound"
+	"github.com/etsrc/goprod/internal/infra/thumbnail"
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	DefaultUserAgent = "goprod-favicon/1.0 (+https://github.com/etsrc/goprod)"
+
+	// pageMaxBytes is how much of the home page is read looking for <link>
+	// tags, which are in the <head>.
+	pageMaxBytes = 512 << 10
+	// maxCandidates bounds the icons downloaded for one host.
+	maxCandidates = 4
+	// maxPixels refuses icons that declare enormous dimensions before they
+	// are decoded.
+	maxPixels = 4096 * 4096
+)
+
+type Options struct {
+	// Client sends every request. Its redirect policy and transport apply.
+	// Defaults to an outbound client with default limits.
+	Client *http.Client
+	// UserAgent is sent with every request.
+	UserAgent string
+	// Timeout bounds one Fetch, including the home page and every icon tried.
+	Timeout time.Duration
+	// MaxBytes bounds each icon file read.
+	MaxBytes int64
+}
+
+// Fetcher implements domain.FaviconSource over HTTP.
+type Fetcher struct {
+	opts Options
+}
+
+var _ domain.FaviconSource = (*Fetcher)(nil)
+
+func NewFetcher(opts Options) *Fetcher {
+	if opts.Client == nil {
+		// Without a proxy NewClient cannot fail.
+		opts.Client, _ = outbound.NewClient(outbound.Options{})
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = DefaultUserAgent
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = 256 << 10
+	}
+	return &Fetcher{opts: opts}
+}
+
+// Fetch reads the icons the home page declares, largest first, and falls back
+// to /favicon.ico. It keeps the largest icon it can decode, stopping early
+// once one covers the biggest size served.
+func (f *Fetcher) Fetch(ctx context.Context, host string) (map[int][]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.opts.Timeout)
+	defer cancel()
+
+	base, links := f.discover(ctx, host)
+	candidates := append(links, base.ResolveReference(&url.URL{Path: "/favicon.ico"}).String())
+
+	largest := domain.FaviconSizes[len(domain.FaviconSizes)-1]
+	var best image.Image
+	var errs []error
+	seen := make(map[string]bool)
+	for _, c := range candidates {
+		if seen[c] {
+			continue
+		}
+		seen[c] = true
+		if len(seen) > maxCandidates {
+			break
+		}
+
+		img, err := f.download(ctx, c)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c, err))
+			continue
+		}
+		if best == nil || side(img) > side(best) {
+			best = img
+		}
+		if side(best) >= largest {
+			break
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("favicon.Fetch: %w: %v", domain.ErrFaviconNotFound, errors.Join(errs...))
+	}
+
+	icons := make(map[int][]byte, len(domain.FaviconSizes))
+	for _, size := range domain.FaviconSizes {
+		data, err := render(best, size)
+		if err != nil {
+			return nil, fmt.Errorf("favicon.Fetch: %w", err)
+		}
+		icons[size] = data
+	}
+	return icons, nil
+}
+
+// discover reads the icon links of host's home page, over HTTPS and then
+// plain HTTP. Relative links, and /favicon.ico, resolve against where the
+// redirects ended. A page that cannot be read yields no links, and
+// /favicon.ico is still tried.
+func (f *Fetcher) discover(ctx context.Context, host string) (*url.URL, []string) {
+	base := &url.URL{Scheme: "https", Host: host, Path: "/"}
+	for _, scheme := range []string{"https", "http"} {
+		u := &url.URL{Scheme: scheme, Host: host, Path: "/"}
+		resp, err := f.get(ctx, u.String(), "text/html,application/xhtml+xml;q=0.9,*/*;q=0.1")
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		defer resp.Body.Close()
+
+		base = resp.Request.URL
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return base, nil
+		}
+		body, err := charset.NewReader(io.LimitReader(resp.Body, pageMaxBytes), resp.Header.Get("Content-Type"))
+		if err != nil {
+			return base, nil
+		}
+		return base, parseLinks(base, body)
+	}
+	return base, nil
+}
+
+// download fetches and decodes one icon.
+func (f *Fetcher) download(ctx context.Context, target string) (image.Image, error) {
+	resp, err := f.get(ctx, target, "image/*")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, f.opts.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > f.opts.MaxBytes {
+		return nil, fmt.Errorf("more than %d bytes", f.opts.MaxBytes)
+	}
+	return decode(data)
+}
+
+func (f *Fetcher) get(ctx context.Context, target, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", f.opts.UserAgent)
+	req.Header.Set("Accept", accept)
+	return f.opts.Client.Do(req)
+}
+
+// decode reads an ICO, PNG, JPEG or GIF file.
+func decode(data []byte) (image.Image, error) {
+	if isICO(data) {
+		return decodeICO(data)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPixels {
+		return nil, fmt.Errorf("%dx%d pixels", cfg.Width, cfg.Height)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, err
+}
+
+// render scales img to fit a size by size square, centred on a transparent
+// background, and encodes it as a PNG.
+func render(img image.Image, size int) ([]byte, error) {
+	b := img.Bounds()
+	w, h := size, size
+	if b.Dx() > b.Dy() {
+		h = max(size*b.Dy()/b.Dx(), 1)
+	} else if b.Dy() > b.Dx() {
+		w = max(size*b.Dx()/b.Dy(), 1)
+	}
+	scaled := thumbnail.Resize(img, image.Pt(w, h))
+
+	canvas := image.NewNRGBA(image.Rect(0, 0, size, size))
+	offset := image.Pt((size-w)/2, (size-h)/2)
+	draw.Draw(canvas, scaled.Bounds().Add(offset), scaled, image.Point{}, draw.Src)
+
+	var buf bytes.Buffer
+	enc := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := enc.Encode(&buf, canvas); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// side is the shorter side of img, which bounds the icon size it can fill.
+func side(img image.Image) int {
+	return min(img.Bounds().Dx(), img.Bounds().Dy())
+}
@@ -0,0 +1,174 @@
+package favicon
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func solidPNG(t *testing.T, size int, c color.NRGBA) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = c.R, c.G, c.B, c.A
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestFetcher_Fetch(t *testing.T) {
+	t.Parallel()
+
+	red := color.NRGBA{255, 0, 0, 255}
+	green := color.NRGBA{0, 255, 0, 255}
+	blue := color.NRGBA{0, 0, 255, 255}
+	ico := buildICO([]int{16}, []int{24}, buildDIB(16, 24, nil,
+		func(int, int) uint32 { return 0x0000ff }, func(int, int) bool { return false }))
+
+	tests := []struct {
+		name      string
+		files     map[string][]byte
+		wantColor color.NRGBA
+		wantErr   error
+	}{
+		{
+			name: "Largest Declared Icon",
+			files: map[string][]byte{
+				"/": []byte(`<html><head>
+					<link rel="icon" href="/small.png" sizes="16x16">
+					<link rel="apple-touch-icon" href="touch.png">
+					<link rel="icon" href="/logo.svg">
+					</head><body><link rel="icon" href="/ignored.png" sizes="512x512"></body></html>`),
+				"/small.png":   solidPNG(t, 16, red),
+				"/touch.png":   solidPNG(t, 180, green),
+				"/favicon.ico": ico,
+			},
+			wantColor: green,
+		},
+		{
+			name: "Favicon ICO Without Page",
+			files: map[string][]byte{
+				"/favicon.ico": ico,
+			},
+			wantColor: blue,
+		},
+		{
+			name: "Broken Link Falls Back",
+			files: map[string][]byte{
+				"/":            []byte(`<link rel="shortcut icon" href="/missing.png">`),
+				"/favicon.ico": ico,
+			},
+			wantColor: blue,
+		},
+		{
+			name: "No Icon",
+			files: map[string][]byte{
+				"/":            []byte(`<title>Plain</title>`),
+				"/favicon.ico": []byte("not an image"),
+			},
+			wantErr: domain.ErrFaviconNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				data, ok := tt.files[r.URL.Path]
+				if !ok {
+					http.NotFound(w, r)
+					return
+				}
+				if r.URL.Path == "/" {
+					w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				}
+				w.Write(data)
+			}))
+			t.Cleanup(srv.Close)
+
+			f := NewFetcher(Options{Client: srv.Client(), Timeout: 5 * time.Second})
+			icons, err := f.Fetch(context.Background(), strings.TrimPrefix(srv.URL, "http://"))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, icons, len(domain.FaviconSizes))
+			for _, size := range domain.FaviconSizes {
+				img, err := png.Decode(bytes.NewReader(icons[size]))
+				require.NoError(t, err)
+				assert.Equal(t, image.Pt(size, size), img.Bounds().Size())
+				assert.Equal(t, tt.wantColor, color.NRGBAModel.Convert(img.At(size/2, size/2)))
+			}
+		})
+	}
+}
+
+func TestParseLinks(t *testing.T) {
+	t.Parallel()
+
+	page, _ := url.Parse("https://example.com/blog/post")
+	doc := `<head>
+		<link rel="icon" href="a.png">
+		<base href="https://cdn.example.com/static/">
+		<link rel="ICON" type="image/png" href="b.png" sizes="16x16 64x64">
+		<link rel="apple-touch-icon-precomposed" href="/c.png">
+		<link rel="stylesheet" href="style.css">
+		<link rel="icon" href="data:image/png;base64,AAAA">
+		<link rel="icon" href="d.svg?v=2">
+	</head>`
+
+	got := parseLinks(page, strings.NewReader(doc))
+	assert.Equal(t, []string{
+		"https://cdn.example.com/c.png",
+		"https://cdn.example.com/static/b.png",
+		"https://cdn.example.com/static/a.png",
+	}, got)
+}
+
+func TestRender_KeepsAspectRatio(t *testing.T) {
+	t.Parallel()
+
+	wide := image.NewNRGBA(image.Rect(0, 0, 64, 32))
+	for i := 0; i < len(wide.Pix); i += 4 {
+		wide.Pix[i], wide.Pix[i+3] = 255, 255
+	}
+	data, err := render(wide, 16)
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	assert.Equal(t, image.Pt(16, 16), img.Bounds().Size())
+	assert.Equal(t, uint8(0), color.NRGBAModel.Convert(img.At(8, 1)).(color.NRGBA).A, "band above is transparent")
+	assert.Equal(t, color.NRGBA{255, 0, 0, 255}, color.NRGBAModel.Convert(img.At(8, 8)))
+}
+
+func TestFetcher_Fallback(t *testing.T) {
+	t.Parallel()
+
+	f := NewFetcher(Options{})
+	a := f.Fallback("example.com", 32)
+	assert.Equal(t, a, f.Fallback("example.com", 32), "same host, same icon")
+	assert.NotEqual(t, a, f.Fallback("example.org", 32))
+
+	for _, size := range domain.FaviconSizes {
+		img, err := png.Decode(bytes.NewReader(f.Fallback("example.com", size)))
+		require.NoError(t, err)
+		assert.Equal(t, image.Pt(size, size), img.Bounds().Size())
+	}
+}
@@ -0,0 +1,201 @@
+package favicon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"slices"
+)
+
+// An ICO file is a directory of images, each either a PNG or a BMP without
+// its file header. The BMP's height counts twice its rows: its colour rows
+// are followed by a 1-bit transparency mask of the same size.
+
+const (
+	icoHeaderSize = 6
+	icoEntrySize  = 16
+	dibHeaderSize = 40
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+type icoEntry struct {
+	width, bpp   int
+	size, offset uint32
+}
+
+func isICO(data []byte) bool {
+	return len(data) >= icoHeaderSize &&
+		binary.LittleEndian.Uint16(data[0:]) == 0 && // reserved
+		binary.LittleEndian.Uint16(data[2:]) == 1 && // type 1 is icon
+		binary.LittleEndian.Uint16(data[4:]) > 0
+}
+
+// decodeICO decodes the largest image in an ICO file, preferring more colours
+// between images of the same size, and falling back to smaller ones when it
+// cannot be decoded.
+func decodeICO(data []byte) (image.Image, error) {
+	count := int(binary.LittleEndian.Uint16(data[4:]))
+	if len(data) < icoHeaderSize+count*icoEntrySize {
+		return nil, errors.New("ico: truncated directory")
+	}
+
+	entries := make([]icoEntry, count)
+	for i := range entries {
+		e := data[icoHeaderSize+i*icoEntrySize:]
+		width := int(e[0])
+		if width == 0 {
+			width = 256
+		}
+		entries[i] = icoEntry{
+			width:  width,
+			bpp:    int(binary.LittleEndian.Uint16(e[6:])),
+			size:   binary.LittleEndian.Uint32(e[8:]),
+			offset: binary.LittleEndian.Uint32(e[12:]),
+		}
+	}
+	slices.SortStableFunc(entries, func(a, b icoEntry) int {
+		if a.width != b.width {
+			return b.width - a.width
+		}
+		return b.bpp - a.bpp
+	})
+
+	var errs []error
+	for _, e := range entries {
+		end := uint64(e.offset) + uint64(e.size)
+		if end > uint64(len(data)) {
+			errs = append(errs, errors.New("ico: image outside file"))
+			continue
+		}
+		img, err := decodeICOImage(data[e.offset:end])
+		if err == nil {
+			return img, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+func decodeICOImage(data []byte) (image.Image, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return decodeDIB(data)
+	}
+	cfg, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, fmt.Errorf("ico: %dx%d pixels", cfg.Width, cfg.Height)
+	}
+	return png.Decode(bytes.NewReader(data))
+}
+
+// decodeDIB decodes an uncompressed 1, 4, 8, 24 or 32-bit BMP as stored in
+// ICO files. 32-bit images carry their own alpha; the others, and 32-bit
+// images whose alpha is all zero, are made transparent by the mask.
+func decodeDIB(data []byte) (image.Image, error) {
+	if len(data) < dibHeaderSize {
+		return nil, errors.New("ico: truncated bitmap header")
+	}
+	headerSize := binary.LittleEndian.Uint32(data[0:])
+	width := int(int32(binary.LittleEndian.Uint32(data[4:])))
+	height := int(int32(binary.LittleEndian.Uint32(data[8:]))) / 2
+	bpp := int(binary.LittleEndian.Uint16(data[14:]))
+	compression := binary.LittleEndian.Uint32(data[16:])
+	colorsUsed := int(binary.LittleEndian.Uint32(data[32:]))
+
+	if headerSize < dibHeaderSize || uint64(headerSize) > uint64(len(data)) {
+		return nil, errors.New("ico: invalid bitmap header")
+	}
+	if width <= 0 || width > 256 || height <= 0 || height > 256 {
+		return nil, fmt.Errorf("ico: bitmap of %dx%d pixels", width, height)
+	}
+	if compression != 0 {
+		return nil, fmt.Errorf("ico: compressed bitmap (%d)", compression)
+	}
+	offset := int(headerSize)
+
+	var palette []color.NRGBA
+	switch bpp {
+	case 1, 4, 8:
+		if colorsUsed == 0 || colorsUsed > 1<<bpp {
+			colorsUsed = 1 << bpp
+		}
+		if len(data) < offset+colorsUsed*4 {
+			return nil, errors.New("ico: truncated palette")
+		}
+		palette = make([]color.NRGBA, colorsUsed)
+		for i := range palette {
+			p := data[offset+i*4:]
+			palette[i] = color.NRGBA{p[2], p[1], p[0], 0xff}
+		}
+		offset += colorsUsed * 4
+	case 24, 32:
+	default:
+		return nil, fmt.Errorf("ico: %d bits per pixel", bpp)
+	}
+
+	// Rows are padded to four bytes and stored bottom-up.
+	stride := (width*bpp + 31) / 32 * 4
+	maskStride := (width + 31) / 32 * 4
+	if len(data) < offset+stride*height {
+		return nil, errors.New("ico: truncated bitmap")
+	}
+	maskOffset := offset + stride*height
+	hasMask := len(data) >= maskOffset+maskStride*height
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	hasAlpha := false
+	for y := range height {
+		row := data[offset+(height-1-y)*stride:]
+		for x := range width {
+			var c color.NRGBA
+			switch bpp {
+			case 32:
+				c = color.NRGBA{row[x*4+2], row[x*4+1], row[x*4], row[x*4+3]}
+				hasAlpha = hasAlpha || c.A != 0
+			case 24:
+				c = color.NRGBA{row[x*3+2], row[x*3+1], row[x*3], 0xff}
+			default:
+				var index int
+				switch bpp {
+				case 8:
+					index = int(row[x])
+				case 4:
+					index = int(row[x/2]>>(4*(1-x%2))) & 0x0f
+				case 1:
+					index = int(row[x/8]>>(7-x%8)) & 1
+				}
+				if index >= len(palette) {
+					return nil, errors.New("ico: colour index outside palette")
+				}
+				c = palette[index]
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+
+	if bpp == 32 && hasAlpha {
+		return img, nil
+	}
+	for y := range height {
+		var mask []byte
+		if hasMask {
+			mask = data[maskOffset+(height-1-y)*maskStride:]
+		}
+		for x := range width {
+			i := img.PixOffset(x, y) + 3
+			if mask != nil && mask[x/8]>>(7-x%8)&1 == 1 {
+				img.Pix[i] = 0
+			} else {
+				img.Pix[i] = 0xff
+			}
+		}
+	}
+	return img, nil
+}
@@ -0,0 +1,190 @@
+package favicon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildICO packs images, each a PNG or a BMP without its file header, into an
+// ICO file.
+func buildICO(widths []int, bpps []int, images ...[]byte) []byte {
+	var buf bytes.Buffer
+	le := binary.LittleEndian
+	buf.Write(le.AppendUint16(nil, 0))
+	buf.Write(le.AppendUint16(nil, 1))
+	buf.Write(le.AppendUint16(nil, uint16(len(images))))
+
+	offset := icoHeaderSize + icoEntrySize*len(images)
+	for i, img := range images {
+		buf.WriteByte(byte(widths[i])) // 256 wraps to 0 as in real files
+		buf.WriteByte(byte(widths[i]))
+		buf.Write([]byte{0, 0})
+		buf.Write(le.AppendUint16(nil, 1))
+		buf.Write(le.AppendUint16(nil, uint16(bpps[i])))
+		buf.Write(le.AppendUint32(nil, uint32(len(img))))
+		buf.Write(le.AppendUint32(nil, uint32(offset)))
+		offset += len(img)
+	}
+	for _, img := range images {
+		buf.Write(img)
+	}
+	return buf.Bytes()
+}
+
+// buildDIB makes a bitmap of the given size and depth. pixel returns the
+// palette index, or the packed BGRA value, of each pixel; masked pixels are
+// set in the AND mask.
+func buildDIB(size, bpp int, palette []color.NRGBA, pixel func(x, y int) uint32, masked func(x, y int) bool) []byte {
+	le := binary.LittleEndian
+	header := make([]byte, dibHeaderSize)
+	le.PutUint32(header[0:], dibHeaderSize)
+	le.PutUint32(header[4:], uint32(size))
+	le.PutUint32(header[8:], uint32(size*2))
+	le.PutUint16(header[12:], 1)
+	le.PutUint16(header[14:], uint16(bpp))
+	le.PutUint32(header[32:], uint32(len(palette)))
+
+	buf := bytes.NewBuffer(header)
+	for _, c := range palette {
+		buf.Write([]byte{c.B, c.G, c.R, 0})
+	}
+
+	stride := (size*bpp + 31) / 32 * 4
+	for y := size - 1; y >= 0; y-- {
+		row := make([]byte, stride)
+		for x := range size {
+			v := pixel(x, y)
+			switch bpp {
+			case 32:
+				le.PutUint32(row[x*4:], v)
+			case 24:
+				row[x*3], row[x*3+1], row[x*3+2] = byte(v), byte(v>>8), byte(v>>16)
+			case 8:
+				row[x] = byte(v)
+			case 4:
+				row[x/2] |= byte(v) << (4 * (1 - x%2))
+			case 1:
+				row[x/8] |= byte(v) << (7 - x%8)
+			}
+		}
+		buf.Write(row)
+	}
+
+	maskStride := (size + 31) / 32 * 4
+	for y := size - 1; y >= 0; y-- {
+		row := make([]byte, maskStride)
+		for x := range size {
+			if masked(x, y) {
+				row[x/8] |= 1 << (7 - x%8)
+			}
+		}
+		buf.Write(row)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeICO(t *testing.T) {
+	t.Parallel()
+
+	red := color.NRGBA{255, 0, 0, 255}
+	blue := color.NRGBA{0, 0, 255, 255}
+	checker := func(x, y int) uint32 { return uint32((x + y) % 2) }
+	leftHalf := func(x, _ int) bool { return x < 8 }
+	none := func(int, int) bool { return false }
+
+	var pngData bytes.Buffer
+	big := image.NewNRGBA(image.Rect(0, 0, 48, 48))
+	big.SetNRGBA(0, 0, blue)
+	require.NoError(t, png.Encode(&pngData, big))
+
+	tests := []struct {
+		name      string
+		data      []byte
+		wantSize  int
+		wantAt    image.Point
+		wantColor color.NRGBA
+	}{
+		{
+			name:      "1-Bit With Mask",
+			data:      buildICO([]int{16}, []int{1}, buildDIB(16, 1, []color.NRGBA{red, blue}, checker, leftHalf)),
+			wantSize:  16,
+			wantAt:    image.Pt(9, 0),
+			wantColor: blue,
+		},
+		{
+			name:      "Masked Pixel Is Transparent",
+			data:      buildICO([]int{16}, []int{1}, buildDIB(16, 1, []color.NRGBA{red, blue}, checker, leftHalf)),
+			wantSize:  16,
+			wantAt:    image.Pt(1, 0),
+			wantColor: color.NRGBA{0, 0, 255, 0},
+		},
+		{
+			name:      "4-Bit",
+			data:      buildICO([]int{16}, []int{4}, buildDIB(16, 4, []color.NRGBA{red, blue}, checker, none)),
+			wantSize:  16,
+			wantAt:    image.Pt(3, 0),
+			wantColor: blue,
+		},
+		{
+			name: "32-Bit Alpha Ignores Mask",
+			data: buildICO([]int{16}, []int{32}, buildDIB(16, 32, nil,
+				func(x, _ int) uint32 { return 0x80ff0000 }, leftHalf)),
+			wantSize:  16,
+			wantAt:    image.Pt(0, 0),
+			wantColor: color.NRGBA{255, 0, 0, 0x80},
+		},
+		{
+			name: "Largest Entry Wins",
+			data: buildICO([]int{16, 48}, []int{24, 32},
+				buildDIB(16, 24, nil, func(int, int) uint32 { return 0xff0000 }, none),
+				pngData.Bytes()),
+			wantSize:  48,
+			wantAt:    image.Pt(0, 0),
+			wantColor: blue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.True(t, isICO(tt.data))
+			img, err := decodeICO(tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, image.Pt(tt.wantSize, tt.wantSize), img.Bounds().Size())
+			got := color.NRGBAModel.Convert(img.At(tt.wantAt.X, tt.wantAt.Y)).(color.NRGBA)
+			assert.Equal(t, tt.wantColor, got)
+		})
+	}
+}
+
+func TestDecodeICO_Malformed(t *testing.T) {
+	t.Parallel()
+
+	valid := buildICO([]int{16}, []int{24}, buildDIB(16, 24, nil, func(int, int) uint32 { return 0 }, func(int, int) bool { return false }))
+
+	tests := map[string][]byte{
+		"Truncated Directory": valid[:10],
+		"Truncated Bitmap":    valid[:len(valid)-200],
+		"Image Outside File": func() []byte {
+			b := bytes.Clone(valid)
+			binary.LittleEndian.PutUint32(b[icoHeaderSize+12:], 1<<30)
+			return b
+		}(),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := decodeICO(data)
+			assert.Error(t, err)
+		})
+	}
+}
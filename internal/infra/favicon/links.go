@@ -0,0 +1,127 @@
+package favicon
+
+import (
+	"cmp"
+	"io"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// appleTouchSize is what Apple touch icons are assumed to measure when they
+// do not say.
+const appleTouchSize = 180
+
+type link struct {
+	href string
+	size int // declared, 0 when unknown
+}
+
+// parseLinks returns the icons declared in an HTML document's <head>, largest
+// first, resolved against page or the document's <base href>. SVG icons are
+// skipped since they cannot be rasterised here.
+func parseLinks(page *url.URL, r io.Reader) []string {
+	base := page
+	var links []link
+	z := html.NewTokenizer(r)
+
+loop:
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			break loop
+		case html.EndTagToken:
+			if name, _ := z.TagName(); atom.Lookup(name) == atom.Head {
+				break loop
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			tag := atom.Lookup(name)
+			if tag == atom.Body {
+				break loop
+			}
+			if !hasAttr || (tag != atom.Link && tag != atom.Base) {
+				continue
+			}
+			attrs := readAttrs(z)
+			if tag == atom.Base {
+				if u, err := page.Parse(strings.TrimSpace(attrs["href"])); err == nil && attrs["href"] != "" {
+					base = u
+				}
+				continue
+			}
+			if l, ok := iconLink(attrs); ok {
+				links = append(links, l)
+			}
+		}
+	}
+
+	// Links are resolved only now, since <base> may follow them.
+	slices.SortStableFunc(links, func(a, b link) int { return cmp.Compare(b.size, a.size) })
+	var hrefs []string
+	for _, l := range links {
+		u, err := base.Parse(l.href)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+		hrefs = append(hrefs, u.String())
+	}
+	return hrefs
+}
+
+// iconLink reads a <link> tag that declares a raster icon.
+func iconLink(attrs map[string]string) (link, bool) {
+	href := strings.TrimSpace(attrs["href"])
+	if href == "" || strings.EqualFold(attrs["type"], "image/svg+xml") ||
+		strings.EqualFold(path.Ext(strings.SplitN(href, "?", 2)[0]), ".svg") {
+		return link{}, false
+	}
+
+	l := link{href: href, size: largestSize(attrs["sizes"])}
+	rel := strings.Fields(strings.ToLower(attrs["rel"]))
+	switch {
+	case slices.Contains(rel, "icon"):
+	case slices.Contains(rel, "apple-touch-icon"), slices.Contains(rel, "apple-touch-icon-precomposed"):
+		if l.size == 0 {
+			l.size = appleTouchSize
+		}
+	default:
+		return link{}, false
+	}
+	return l, true
+}
+
+// largestSize reads the largest square side in a sizes attribute such as
+// "16x16 32x32". "any" and malformed entries count as unknown.
+func largestSize(sizes string) int {
+	largest := 0
+	for _, s := range strings.Fields(strings.ToLower(sizes)) {
+		w, h, ok := strings.Cut(s, "x")
+		if !ok {
+			continue
+		}
+		width, err1 := strconv.Atoi(w)
+		height, err2 := strconv.Atoi(h)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		largest = max(largest, min(width, height))
+	}
+	return largest
+}
+
+func readAttrs(z *html.Tokenizer) map[string]string {
+	attrs := map[string]string{}
+	for {
+		key, val, more := z.TagAttr()
+		attrs[strings.ToLower(string(key))] = string(val)
+		if !more {
+			return attrs
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  *atomPerson `xml:"author,omitempty"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     *atomPerson    `xml:"author,omitempty"`
+	Summary    string         `xml:"summary,omitempty"`
+	Categories []atomCategory `xml:"category"`
+}
+
+// RenderAtom writes f as an Atom 1.0 document (RFC 4287).
+func RenderAtom(w io.Writer, f *Feed) error {
+	doc := atomFeed{
+		ID:      f.Link,
+		Title:   f.Title,
+		Updated: atomTime(f.Updated()),
+		Links: []atomLink{
+			{Href: f.SelfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: f.Link, Rel: "alternate"},
+		},
+		// RFC 4287 requires an author on the feed unless every entry has one.
+		Author: &atomPerson{Name: "goprod"},
+	}
+
+	for _, b := range f.Items {
+		e := atomEntry{
+			ID:        f.entryID(b),
+			Title:     b.Title,
+			Link:      atomLink{Href: b.URL, Rel: "alternate"},
+			Published: atomTime(b.CreatedAt),
+			Updated:   atomTime(lastChange(b)),
+			Summary:   b.Description,
+		}
+		if author := b.Metadata[domain.MetaAuthor]; author != "" {
+			e.Author = &atomPerson{Name: author}
+		}
+		for _, tag := range b.Tags {
+			e.Categories = append(e.Categories, atomCategory{Term: tag})
+		}
+		doc.Entries = append(doc.Entries, e)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("feed.atom: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// atomTime formats t as an RFC 3339 timestamp. An empty feed has no updates,
+// so the epoch stands in for the required value.
+func atomTime(t time.Time) string {
+	if t.IsZero() {
+		t = time.Unix(0, 0)
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,74 @@
+// Package feed renders bookmarks as Atom 1.0, RSS 2.0 and JSON Feed 1.1
+// documents for feed readers.
+//
+// Rendering is deterministic: the same feed always produces the same bytes,
+// which lets the HTTP layer derive an ETag from the output.
+package feed
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// Feed is one rendered channel of bookmarks.
+type Feed struct {
+	Title string
+	// Link is the human-readable page the feed describes, and SelfURL the
+	// address the feed itself is served from.
+	Link    string
+	SelfURL string
+	// BaseURL prefixes /bookmarks/{id} to build stable entry IDs.
+	BaseURL string
+	// Items are rendered in order, newest first by convention.
+	Items []*domain.Bookmark
+}
+
+// Updated is the latest change to any item, or the zero time for an empty
+// feed.
+func (f *Feed) Updated() time.Time {
+	var latest time.Time
+	for _, b := range f.Items {
+		if t := lastChange(b); t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// entryID is the permanent identifier of a bookmark's entry. It does not
+// depend on the bookmark's URL, which may change.
+func (f *Feed) entryID(b *domain.Bookmark) string {
+	return strings.TrimSuffix(f.BaseURL, "/") + "/bookmarks/" + b.ID
+}
+
+func lastChange(b *domain.Bookmark) time.Time {
+	if b.UpdatedAt.After(b.CreatedAt) {
+		return b.UpdatedAt
+	}
+	return b.CreatedAt
+}
+
+type Format struct {
+	Name        string
+	ContentType string
+	Render      func(w io.Writer, f *Feed) error
+}
+
+var formats = []Format{
+	{Name: "atom", ContentType: "application/atom+xml; charset=utf-8", Render: RenderAtom},
+	{Name: "rss", ContentType: "application/rss+xml; charset=utf-8", Render: RenderRSS},
+	{Name: "json", ContentType: "application/feed+json; charset=utf-8", Render: RenderJSON},
+}
+
+// Lookup returns the format registered under name.
+func Lookup(name string) (Format, bool) {
+	i := slices.IndexFunc(formats, func(f Format) bool { return f.Name == name })
+	if i < 0 {
+		return Format{}, false
+	}
+	return formats[i], true
+}
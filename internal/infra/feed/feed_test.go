@@ -0,0 +1,115 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleFeed() *Feed {
+	created := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	return &Feed{
+		Title:   "goprod: recent bookmarks",
+		Link:    "https://bm.example.org/bookmarks",
+		SelfURL: "https://bm.example.org/feeds/all",
+		BaseURL: "https://bm.example.org",
+		Items: []*domain.Bookmark{
+			{
+				ID:          "2",
+				URL:         "https://go.dev/blog/pipelines?x=1&y=2",
+				Title:       "Pipelines <and> cancellation",
+				Description: "Fan-in & fan-out",
+				Tags:        []string{"go", "concurrency"},
+				CreatedAt:   created.Add(time.Hour),
+				UpdatedAt:   created.Add(2 * time.Hour),
+				Metadata:    map[string]string{domain.MetaAuthor: "Sameer Ajmani"},
+			},
+			{
+				ID:        "1",
+				URL:       "https://example.com",
+				Title:     "Example",
+				CreatedAt: created,
+				UpdatedAt: created,
+			},
+		},
+	}
+}
+
+func TestFeed_Updated(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, time.Date(2024, 3, 1, 14, 30, 0, 0, time.UTC), sampleFeed().Updated())
+	assert.True(t, (&Feed{}).Updated().IsZero())
+}
+
+func TestRenderAtom(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderAtom(&buf, sampleFeed()))
+
+	var doc atomFeed
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "2024-03-01T14:30:00Z", doc.Updated)
+	require.Len(t, doc.Entries, 2)
+
+	e := doc.Entries[0]
+	assert.Equal(t, "https://bm.example.org/bookmarks/2", e.ID)
+	assert.Equal(t, "Pipelines <and> cancellation", e.Title)
+	assert.Equal(t, "https://go.dev/blog/pipelines?x=1&y=2", e.Link.Href)
+	assert.Equal(t, "2024-03-01T13:30:00Z", e.Published)
+	assert.Equal(t, "Sameer Ajmani", e.Author.Name)
+	assert.Equal(t, []atomCategory{{Term: "go"}, {Term: "concurrency"}}, e.Categories)
+	assert.Nil(t, doc.Entries[1].Author)
+}
+
+func TestRenderRSS(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderRSS(&buf, sampleFeed()))
+
+	assert.Contains(t, buf.String(), `<atom:link href="https://bm.example.org/feeds/all" rel="self" type="application/rss+xml"></atom:link>`)
+	assert.Contains(t, buf.String(), `<guid isPermaLink="false">https://bm.example.org/bookmarks/2</guid>`)
+	assert.Contains(t, buf.String(), `<pubDate>Fri, 01 Mar 2024 13:30:00 +0000</pubDate>`)
+	assert.Contains(t, buf.String(), `<dc:creator>Sameer Ajmani</dc:creator>`)
+	assert.Contains(t, buf.String(), `<title>Pipelines &lt;and&gt; cancellation</title>`)
+}
+
+func TestRenderJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderJSON(&buf, sampleFeed()))
+
+	var doc jsonFeed
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "https://jsonfeed.org/version/1.1", doc.Version)
+	assert.Equal(t, "https://bm.example.org/feeds/all", doc.FeedURL)
+	require.Len(t, doc.Items, 2)
+	assert.Equal(t, "Fan-in & fan-out", doc.Items[0].ContentText)
+	assert.Equal(t, []jsonFeedAuthor{{Name: "Sameer Ajmani"}}, doc.Items[0].Authors)
+	assert.Equal(t, "https://example.com", doc.Items[1].ContentText, "items need content, so the URL stands in")
+}
+
+func TestRender_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range formats {
+		f := f
+		t.Run(f.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var first, second bytes.Buffer
+			require.NoError(t, f.Render(&first, sampleFeed()))
+			require.NoError(t, f.Render(&second, sampleFeed()))
+			assert.Equal(t, first.String(), second.String())
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url,omitempty"`
+	FeedURL     string         `json:"feed_url,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	Title         string           `json:"title"`
+	ContentText   string           `json:"content_text"`
+	DatePublished string           `json:"date_published,omitempty"`
+	DateModified  string           `json:"date_modified,omitempty"`
+	Authors       []jsonFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+}
+
+// RenderJSON writes f as a JSON Feed 1.1 document
+// (https://jsonfeed.org/version/1.1). Items need content, so a bookmark
+// without a description uses its URL.
+func RenderJSON(w io.Writer, f *Feed) error {
+	doc := jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		FeedURL:     f.SelfURL,
+		Items:       make([]jsonFeedItem, 0, len(f.Items)),
+	}
+
+	for _, b := range f.Items {
+		item := jsonFeedItem{
+			ID:            f.entryID(b),
+			URL:           b.URL,
+			Title:         b.Title,
+			ContentText:   b.Description,
+			DatePublished: jsonFeedTime(b.CreatedAt),
+			DateModified:  jsonFeedTime(lastChange(b)),
+			Tags:          b.Tags,
+		}
+		if item.ContentText == "" {
+			item.ContentText = b.URL
+		}
+		if author := b.Metadata[domain.MetaAuthor]; author != "" {
+			item.Authors = []jsonFeedAuthor{{Name: author}}
+		}
+		doc.Items = append(doc.Items, item)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("feed.json: %w", err)
+	}
+	return nil
+}
+
+func jsonFeedTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,101 @@
+// Package linkcheck tells whether bookmarked links still work by requesting
+// them over HTTP.
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/outbound"
+)
+
+const DefaultUserAgent = "goprod-linkcheck/1.0 (+https://github.com/etsrc/goprod)"
+
+type Options struct {
+	// Client sends every request. Its redirect policy and transport apply.
+	// Defaults to an outbound client with default limits.
+	Client *http.Client
+	// UserAgent is sent with every request.
+	UserAgent string
+	// Timeout bounds one Check, including a GET after a failed HEAD.
+	Timeout time.Duration
+}
+
+// Checker implements domain.LinkChecker over HTTP.
+type Checker struct {
+	opts Options
+}
+
+var _ domain.LinkChecker = (*Checker)(nil)
+
+func NewChecker(opts Options) *Checker {
+	if opts.Client == nil {
+		// Without a proxy NewClient cannot fail.
+		opts.Client, _ = outbound.NewClient(outbound.Options{})
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = DefaultUserAgent
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 15 * time.Second
+	}
+	return &Checker{opts: opts}
+}
+
+// Check sends a HEAD request and, since many servers answer HEAD wrongly, a
+// GET when HEAD fails with an error status. The GET body is not read.
+func (c *Checker) Check(ctx context.Context, url string) domain.LinkCheck {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	result := c.request(ctx, http.MethodHead, url)
+	if result.Err == nil && result.StatusCode >= 400 {
+		result = c.request(ctx, http.MethodGet, url)
+	}
+	return result
+}
+
+func (c *Checker) request(ctx context.Context, method, url string) domain.LinkCheck {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return domain.LinkCheck{Err: err}
+	}
+	req.Header.Set("User-Agent", c.opts.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.8")
+
+	// Copy the client to record the status of every redirect hop while
+	// keeping its own redirect policy.
+	client := *c.opts.Client
+	redirected, permanent := false, true
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		redirected = true
+		if code := req.Response.StatusCode; code != http.StatusMovedPermanently && code != http.StatusPermanentRedirect {
+			permanent = false
+		}
+		if c.opts.Client.CheckRedirect != nil {
+			return c.opts.Client.CheckRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		return domain.LinkCheck{Latency: latency, Err: err}
+	}
+	resp.Body.Close()
+
+	return domain.LinkCheck{
+		StatusCode:        resp.StatusCode,
+		FinalURL:          resp.Request.URL.String(),
+		PermanentRedirect: redirected && permanent,
+		Latency:           latency,
+	}
+}
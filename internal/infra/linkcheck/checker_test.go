@@ -0,0 +1,99 @@
+package linkcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "testbot/1.0" {
+			http.Error(w, "wrong user agent", http.StatusBadRequest)
+		}
+	})
+	mux.HandleFunc("/no-head", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/moved-again", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/moved-again", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusPermanentRedirect)
+	})
+	mux.HandleFunc("/mixed", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/temporary", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/temporary", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusFound)
+	})
+	mux.HandleFunc("/slow", func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	checker := NewChecker(Options{
+		Client:    srv.Client(),
+		UserAgent: "testbot/1.0",
+		Timeout:   200 * time.Millisecond,
+	})
+
+	tests := []struct {
+		path          string
+		wantCode      int
+		wantFinal     string
+		wantPermanent bool
+		wantErr       bool
+	}{
+		{path: "/ok", wantCode: http.StatusOK, wantFinal: "/ok"},
+		{path: "/no-head", wantCode: http.StatusOK, wantFinal: "/no-head"},
+		{path: "/gone", wantCode: http.StatusGone, wantFinal: "/gone"},
+		{path: "/moved", wantCode: http.StatusOK, wantFinal: "/ok", wantPermanent: true},
+		{path: "/mixed", wantCode: http.StatusOK, wantFinal: "/ok"},
+		{path: "/slow", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			got := checker.Check(context.Background(), srv.URL+tt.path)
+			if tt.wantErr {
+				assert.Error(t, got.Err)
+				assert.False(t, got.OK())
+				return
+			}
+			assert.NoError(t, got.Err)
+			assert.Equal(t, tt.wantCode, got.StatusCode)
+			assert.Equal(t, srv.URL+tt.wantFinal, got.FinalURL)
+			assert.Equal(t, tt.wantPermanent, got.PermanentRedirect)
+			assert.Positive(t, got.Latency)
+		})
+	}
+}
+
+func TestChecker_BlockedAddress(t *testing.T) {
+	t.Parallel()
+
+	// The default client refuses loopback addresses.
+	got := NewChecker(Options{}).Check(context.Background(), "http://127.0.0.1:1/")
+	assert.Error(t, got.Err)
+	assert.False(t, got.OK())
+}
@@ -0,0 +1,34 @@
+// Package logging builds the application's structured logger.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Options configures the logger.
+type Options struct {
+	// Format is "json", one object per line, or "text", key=value pairs.
+	Format string
+	// Level is the least severe level logged: debug, info, warn or error.
+	Level string
+}
+
+// New returns a logger writing to w.
+func New(w io.Writer, opts Options) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(opts.Level)); err != nil {
+		return nil, fmt.Errorf("logging: unknown level %q", opts.Level)
+	}
+	handlerOpts := &slog.HandlerOptions{Level: level}
+
+	switch strings.ToLower(opts.Format) {
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, handlerOpts)), nil
+	case "text":
+		return slog.New(slog.NewTextHandler(w, handlerOpts)), nil
+	}
+	return nil, fmt.Errorf("logging: unknown format %q, want json or text", opts.Format)
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectTimeout bounds the repository reads made for one scrape.
+const collectTimeout = 5 * time.Second
+
+var (
+	bookmarksDesc = prometheus.NewDesc(Namespace+"_bookmarks",
+		"Bookmarks, excluding those in the trash, by the status of their link.", []string{"link_status"}, nil)
+	trashedDesc = prometheus.NewDesc(Namespace+"_bookmarks_trashed",
+		"Bookmarks in the trash.", nil, nil)
+	tagsDesc = prometheus.NewDesc(Namespace+"_tags",
+		"Distinct tags on bookmarks, excluding those in the trash.", nil, nil)
+)
+
+// bookmarkCollector counts the stored bookmarks when scraped, so that the
+// counts are never stale.
+type bookmarkCollector struct {
+	repo domain.BookmarkRepository
+}
+
+// NewBookmarkCollector returns a collector of gauges describing the
+// bookmarks in repo.
+func NewBookmarkCollector(repo domain.BookmarkRepository) prometheus.Collector {
+	return &bookmarkCollector{repo: repo}
+}
+
+func (c *bookmarkCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- bookmarksDesc
+	ch <- trashedDesc
+	ch <- tagsDesc
+}
+
+func (c *bookmarkCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	byStatus := map[domain.LinkStatus]int{
+		domain.LinkUnchecked: 0, domain.LinkOK: 0, domain.LinkRedirected: 0, domain.LinkFailing: 0, domain.LinkBroken: 0,
+	}
+	tags := make(map[string]struct{})
+	err := c.repo.Walk(ctx, domain.BookmarkFilter{}, func(b *domain.Bookmark) error {
+		byStatus[b.LinkStatus()]++
+		for _, t := range b.Tags {
+			tags[t] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Error("Counting bookmarks failed", "error", err)
+		ch <- prometheus.NewInvalidMetric(bookmarksDesc, err)
+		return
+	}
+	for status, n := range byStatus {
+		ch <- prometheus.MustNewConstMetric(bookmarksDesc, prometheus.GaugeValue, float64(n), string(status))
+	}
+	ch <- prometheus.MustNewConstMetric(tagsDesc, prometheus.GaugeValue, float64(len(tags)))
+
+	trash, err := c.repo.Trash(ctx)
+	if err != nil {
+		slog.Error("Counting trashed bookmarks failed", "error", err)
+		ch <- prometheus.NewInvalidMetric(trashedDesc, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(trashedDesc, prometheus.GaugeValue, float64(len(trash)))
+}
@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestBookmarkCollector(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := persistence.NewInMemoryBookmarkRepository()
+	for _, b := range []*domain.Bookmark{
+		{ID: "1", URL: "https://go.dev", Title: "Go", Tags: []string{"go", "docs"}},
+		{ID: "2", URL: "https://pkg.go.dev", Title: "Packages", Tags: []string{"go"}, Health: &domain.LinkHealth{Status: domain.LinkOK}},
+		{ID: "3", URL: "https://example.com", Title: "Example", Tags: []string{"trash"}},
+	} {
+		if err := repo.Create(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := repo.Delete(ctx, "3"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `
+# HELP goprod_bookmarks Bookmarks, excluding those in the trash, by the status of their link.
+# TYPE goprod_bookmarks gauge
+goprod_bookmarks{link_status="broken"} 0
+goprod_bookmarks{link_status="failing"} 0
+goprod_bookmarks{link_status="ok"} 1
+goprod_bookmarks{link_status="redirected"} 0
+goprod_bookmarks{link_status="unchecked"} 1
+# HELP goprod_bookmarks_trashed Bookmarks in the trash.
+# TYPE goprod_bookmarks_trashed gauge
+goprod_bookmarks_trashed 1
+# HELP goprod_tags Distinct tags on bookmarks, excluding those in the trash.
+# TYPE goprod_tags gauge
+goprod_tags 2
+`
+	if err := testutil.CollectAndCompare(NewBookmarkCollector(repo), strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RepositoryMetrics times the operations of the repositories it decorates.
+type RepositoryMetrics struct {
+	duration *prometheus.HistogramVec
+}
+
+// NewRepositoryMetrics registers the repository operation histogram with
+// reg.
+func NewRepositoryMetrics(reg prometheus.Registerer) *RepositoryMetrics {
+	m := &RepositoryMetrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: "repository",
+			Name:      "operation_duration_seconds",
+			Help:      "Time taken by repository operations, by outcome: ok, not_found, conflict or error.",
+			Buckets:   []float64{.0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"repository", "operation", "outcome"}),
+	}
+	reg.MustRegister(m.duration)
+	return m
+}
+
+// observe records an operation that started at start and ended with err.
+func (m *RepositoryMetrics) observe(repository, operation string, start time.Time, err error) {
+	outcome := "ok"
+	switch {
+	case errors.Is(err, domain.ErrBookmarkNotFound):
+		outcome = "not_found"
+	case errors.Is(err, domain.ErrVersionConflict):
+		outcome = "conflict"
+	case err != nil:
+		outcome = "error"
+	}
+	m.duration.WithLabelValues(repository, operation, outcome).Observe(time.Since(start).Seconds())
+}
+
+type instrumentedBookmarkRepository struct {
+	repo    domain.BookmarkRepository
+	metrics *RepositoryMetrics
+}
+
+// WithBookmarkRepository decorates repo so that m times its operations.
+func (m *RepositoryMetrics) WithBookmarkRepository(repo domain.BookmarkRepository) domain.BookmarkRepository {
+	return &instrumentedBookmarkRepository{repo: repo, metrics: m}
+}
+
+func (r *instrumentedBookmarkRepository) observe(operation string, start time.Time, err error) {
+	r.metrics.observe("bookmarks", operation, start, err)
+}
+
+func (r *instrumentedBookmarkRepository) Create(ctx context.Context, b *domain.Bookmark) error {
+	start := time.Now()
+	err := r.repo.Create(ctx, b)
+	r.observe("Create", start, err)
+	return err
+}
+
+func (r *instrumentedBookmarkRepository) CreateBatch(ctx context.Context, bs []*domain.Bookmark) error {
+	start := time.Now()
+	err := r.repo.CreateBatch(ctx, bs)
+	r.observe("CreateBatch", start, err)
+	return err
+}
+
+func (r *instrumentedBookmarkRepository) GetByID(ctx context.Context, id string) (*domain.Bookmark, error) {
+	start := time.Now()
+	v, err := r.repo.GetByID(ctx, id)
+	r.observe("GetByID", start, err)
+	return v, err
+}
+
+func (r *instrumentedBookmarkRepository) GetAll(ctx context.Context) ([]*domain.Bookmark, error) {
+	start := time.Now()
+	v, err := r.repo.GetAll(ctx)
+	r.observe("GetAll", start, err)
+	return v, err
+}
+
+func (r *instrumentedBookmarkRepository) Walk(ctx context.Context, filter domain.BookmarkFilter, fn func(*domain.Bookmark) error) error {
+	start := time.Now()
+	err := r.repo.Walk(ctx, filter, fn)
+	r.observe("Walk", start, err)
+	return err
+}
+
+func (r *instrumentedBookmarkRepository) Update(ctx context.Context, b *domain.Bookmark) error {
+	start := time.Now()
+	err := r.repo.Update(ctx, b)
+	r.observe("Update", start, err)
+	return err
+}
+
+func (r *instrumentedBookmarkRepository) CompareAndUpdate(ctx context.Context, b *domain.Bookmark, version int64) error {
+	start := time.Now()
+	err := r.repo.CompareAndUpdate(ctx, b, version)
+	r.observe("CompareAndUpdate", start, err)
+	return err
+}
+
+func (r *instrumentedBookmarkRepository) Delete(ctx context.Context, id string) error {
+	start := time.Now()
+	err := r.repo.Delete(ctx, id)
+	r.observe("Delete", start, err)
+	return err
+}
+
+func (r *instrumentedBookmarkRepository) CompareAndDelete(ctx context.Context, id string, version int64) error {
+	start := time.Now()
+	err := r.repo.CompareAndDelete(ctx, id, version)
+	r.observe("CompareAndDelete", start, err)
+	return err
+}
+
+func (r *instrumentedBookmarkRepository) Trash(ctx context.Context) ([]*domain.Bookmark, error) {
+	start := time.Now()
+	v, err := r.repo.Trash(ctx)
+	r.observe("Trash", start, err)
+	return v, err
+}
+
+func (r *instrumentedBookmarkRepository) Restore(ctx context.Context, id string) error {
+	start := time.Now()
+	err := r.repo.Restore(ctx, id)
+	r.observe("Restore", start, err)
+	return err
+}
+
+func (r *instrumentedBookmarkRepository) Purge(ctx context.Context, id string) error {
+	start := time.Now()
+	err := r.repo.Purge(ctx, id)
+	r.observe("Purge", start, err)
+	return err
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestRepositoryMetrics(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	reg := prometheus.NewRegistry()
+	repo := NewRepositoryMetrics(reg).WithBookmarkRepository(persistence.NewInMemoryBookmarkRepository())
+
+	b := &domain.Bookmark{ID: "1", URL: "https://go.dev", Title: "Go"}
+	if err := repo.Create(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetByID(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+	repo.GetByID(ctx, "2")
+	repo.Create(ctx, b)
+	repo.CompareAndUpdate(ctx, b.Clone(), 2)
+	repo.CompareAndDelete(ctx, "1", 2)
+
+	tests := []struct {
+		operation string
+		outcome   string
+		want      int
+	}{
+		{operation: "Create", outcome: "ok", want: 1},
+		{operation: "Create", outcome: "error", want: 1},
+		{operation: "GetByID", outcome: "ok", want: 1},
+		{operation: "GetByID", outcome: "not_found", want: 1},
+		{operation: "CompareAndUpdate", outcome: "conflict", want: 1},
+		{operation: "CompareAndDelete", outcome: "conflict", want: 1},
+		{operation: "Update", outcome: "ok", want: 0},
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[[2]string]int)
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			labels := make(map[string]string)
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			counts[[2]string{labels["operation"], labels["outcome"]}] = int(m.GetHistogram().GetSampleCount())
+		}
+	}
+	for _, tt := range tests {
+		if got := counts[[2]string{tt.operation, tt.outcome}]; got != tt.want {
+			t.Errorf("%s %s observations = %d, want %d", tt.operation, tt.outcome, got, tt.want)
+		}
+	}
+	if n := len(counts); n != 6 {
+		t.Errorf("collected %d series, want 6", n)
+	}
+}
@@ -0,0 +1,101 @@
+package outbound
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// blockedPrefixes lists ranges that netip.Addr has no predicate for but that
+// must never be reached from user-supplied URLs.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT, also Alibaba Cloud metadata
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved, including broadcast
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("100::/64"),        // discard
+}
+
+// Prefixes whose addresses embed an IPv4 address in their last four bytes,
+// which is checked as well.
+var (
+	nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
+	sixToFour   = netip.MustParsePrefix("2002::/16")
+)
+
+// policy decides which addresses and hosts may be contacted.
+type policy struct {
+	allowPrefixes []netip.Prefix
+	allowHosts    map[string]bool
+}
+
+// newPolicy sorts allowlist entries into address ranges ("10.0.0.0/8",
+// "192.168.1.5") and host names ("intranet.example.com").
+func newPolicy(allow []string) *policy {
+	p := &policy{allowHosts: make(map[string]bool)}
+	for _, entry := range allow {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			p.allowPrefixes = append(p.allowPrefixes, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(entry); err == nil {
+			p.allowPrefixes = append(p.allowPrefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+		} else {
+			p.allowHosts[strings.ToLower(entry)] = true
+		}
+	}
+	return p
+}
+
+func (p *policy) hostAllowed(host string) bool {
+	return p.allowHosts[strings.ToLower(strings.TrimSuffix(host, "."))]
+}
+
+// addrAllowed reports whether addr is public or explicitly allowlisted.
+func (p *policy) addrAllowed(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range p.allowPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return isPublic(addr)
+}
+
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	if nat64Prefix.Contains(addr) || sixToFour.Contains(addr) {
+		return isPublic(embeddedIPv4(addr))
+	}
+	return true
+}
+
+// embeddedIPv4 extracts the IPv4 address a NAT64 or 6to4 address carries.
+func embeddedIPv4(addr netip.Addr) netip.Addr {
+	b := addr.As16()
+	if sixToFour.Contains(addr) {
+		return netip.AddrFrom4([4]byte{b[2], b[3], b[4], b[5]})
+	}
+	return netip.AddrFrom4([4]byte{b[12], b[13], b[14], b[15]})
+}
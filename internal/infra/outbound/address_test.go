@@ -0,0 +1,77 @@
+package outbound
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestIsPublic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.0.0.1", false},
+		{"172.16.5.4", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false}, // cloud metadata
+		{"100.100.100.200", false}, // Alibaba Cloud metadata
+		{"fd00:ec2::254", false},   // AWS IPv6 metadata
+		{"fe80::1", false},
+		{"0.0.0.0", false},
+		{"255.255.255.255", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"64:ff9b::a9fe:a9fe", false}, // NAT64 of 169.254.169.254
+		{"64:ff9b::5db8:d822", true},  // NAT64 of 93.184.216.34
+		{"2002:7f00:1::", false},      // 6to4 of 127.0.0.1
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.addr, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isPublic(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("isPublic(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	t.Parallel()
+
+	p := newPolicy([]string{"10.1.0.0/16", " 192.168.1.5 ", "Wiki.Internal", ""})
+
+	addrs := map[string]bool{
+		"10.1.2.3":           true,
+		"10.2.0.1":           false,
+		"192.168.1.5":        true,
+		"::ffff:192.168.1.5": true,
+		"192.168.1.6":        false,
+		"8.8.8.8":            true,
+		"169.254.169.254":    false,
+	}
+	for addr, want := range addrs {
+		if got := p.addrAllowed(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("addrAllowed(%s) = %v, want %v", addr, got, want)
+		}
+	}
+
+	hosts := map[string]bool{
+		"wiki.internal":  true,
+		"WIKI.internal.": true,
+		"other.internal": false,
+	}
+	for host, want := range hosts {
+		if got := p.hostAllowed(host); got != want {
+			t.Errorf("hostAllowed(%s) = %v, want %v", host, got, want)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Package outbound builds the HTTP client for every request the server makes
+// to a user-supplied URL. It keeps goprod from being used to reach internal
+// services (SSRF):
+//
+//   - host names are resolved here and the connection goes to a checked
+//     address, so a DNS answer cannot change between check and dial;
+//   - loopback, private, link-local, cloud metadata and other reserved ranges
+//     are refused unless allowlisted;
+//   - every redirect hop goes through the same checks;
+//   - response bodies are capped, and requests to one host are limited in
+//     number and spaced out.
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"time"
+)
+
+var (
+	// ErrBlockedAddress is returned for a URL whose host resolves to an
+	// address outside the allowed ranges.
+	ErrBlockedAddress = errors.New("outbound: address not allowed")
+	// ErrResponseTooLarge is returned while reading a body past MaxBodyBytes.
+	ErrResponseTooLarge = errors.New("outbound: response too large")
+	// ErrTooManyRedirects is returned when a request redirects more than
+	// MaxRedirects times.
+	ErrTooManyRedirects = errors.New("outbound: too many redirects")
+)
+
+type Options struct {
+	// Allow lists address ranges ("10.1.0.0/16"), addresses ("192.168.1.5")
+	// and host names ("wiki.internal") that may be contacted even though they
+	// are not public.
+	Allow []string
+	// Proxy sends every request through this HTTP proxy. The proxy itself is
+	// trusted; target hosts are still resolved and checked before each
+	// request. Empty connects directly.
+	Proxy string
+	// MaxBodyBytes caps every response body. Reads past it fail with
+	// ErrResponseTooLarge.
+	MaxBodyBytes int64
+	// MaxRedirects is the number of redirects followed per request.
+	MaxRedirects int
+	// MaxConnsPerHost limits concurrent requests to one host; further
+	// requests wait.
+	MaxConnsPerHost int
+	// HostDelay is the least time between the starts of two requests to the
+	// same host.
+	HostDelay time.Duration
+	// Timeout bounds a whole request including redirects and reading the
+	// body. Zero leaves it to the request's context.
+	Timeout time.Duration
+	// UserAgent is sent with requests that do not set their own.
+	UserAgent string
+}
+
+// NewClient returns an http.Client that enforces opts. It is safe for
+// concurrent use and meant to be shared by every fetcher in the process.
+func NewClient(opts Options) (*http.Client, error) {
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = 10 << 20
+	}
+	if opts.MaxRedirects <= 0 {
+		opts.MaxRedirects = 10
+	}
+	if opts.MaxConnsPerHost <= 0 {
+		opts.MaxConnsPerHost = 2
+	}
+
+	p := newPolicy(opts.Allow)
+	dialer := &net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}
+	transport := &http.Transport{
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+	}
+
+	var proxyAddr string
+	if opts.Proxy != "" {
+		proxyURL, err := url.Parse(opts.Proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("outbound.NewClient: invalid proxy %q", opts.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+		proxyAddr = canonicalAddr(proxyURL)
+	}
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if addr == proxyAddr {
+			return dialer.DialContext(ctx, network, addr)
+		}
+		return dialChecked(ctx, dialer, p, network, addr)
+	}
+
+	rt := &limitTransport{
+		next:      transport,
+		policy:    p,
+		proxied:   proxyAddr != "",
+		maxBody:   opts.MaxBodyBytes,
+		maxConns:  opts.MaxConnsPerHost,
+		delay:     opts.HostDelay,
+		userAgent: opts.UserAgent,
+		hosts:     make(map[string]*hostState),
+	}
+	return &http.Client{
+		Transport: rt,
+		Timeout:   opts.Timeout,
+		CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+			if len(via) > opts.MaxRedirects {
+				return ErrTooManyRedirects
+			}
+			return nil
+		},
+	}, nil
+}
+
+// dialChecked resolves the host in addr and connects to the first allowed
+// address. Because the connection goes to the address that was checked, a
+// second DNS answer cannot redirect it.
+func dialChecked(ctx context.Context, dialer *net.Dialer, p *policy, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := resolve(ctx, p, host)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, ip := range addrs {
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// resolve looks up host and fails if any of its addresses is not allowed. A
+// name that resolves to both public and internal addresses is refused as a
+// whole rather than trusted for its public half.
+func resolve(ctx context.Context, p *policy, host string) ([]netip.Addr, error) {
+	if ip, err := netip.ParseAddr(host); err == nil {
+		if !p.addrAllowed(ip) {
+			return nil, fmt.Errorf("%w: %s", ErrBlockedAddress, ip)
+		}
+		return []netip.Addr{ip}, nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil, err
+	}
+	if p.hostAllowed(host) {
+		return addrs, nil
+	}
+	for _, ip := range addrs {
+		if !p.addrAllowed(ip) {
+			return nil, fmt.Errorf("%w: %s resolves to %s", ErrBlockedAddress, host, ip)
+		}
+	}
+	return addrs, nil
+}
+
+// canonicalAddr is u's host:port with the scheme's default port filled in.
+func canonicalAddr(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
@@ -0,0 +1,244 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func get(t *testing.T, client *http.Client, target string) (string, error) {
+	t.Helper()
+
+	resp, err := client.Get(target)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	return string(body), err
+}
+
+func TestClient_Addresses(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if to := r.URL.Query().Get("to"); to != "" {
+			http.Redirect(w, r, to, http.StatusFound)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	t.Cleanup(srv.Close)
+	viaName := fmt.Sprintf("http://localhost:%d", srv.Listener.Addr().(*net.TCPAddr).Port)
+
+	tests := []struct {
+		name    string
+		allow   []string
+		target  string
+		wantErr error
+	}{
+		{
+			name:    "Loopback Blocked By Default",
+			target:  srv.URL,
+			wantErr: ErrBlockedAddress,
+		},
+		{
+			name:    "Host Name Resolving To Loopback Blocked",
+			target:  viaName,
+			wantErr: ErrBlockedAddress,
+		},
+		{
+			name:   "Allowlisted Range",
+			allow:  []string{"127.0.0.0/8", "::1"},
+			target: viaName,
+		},
+		{
+			name:   "Allowlisted Host Name",
+			allow:  []string{"localhost"},
+			target: viaName,
+		},
+		{
+			name:    "Redirect To Blocked Address Rechecked",
+			allow:   []string{"localhost"},
+			target:  viaName + "/?to=" + url.QueryEscape(srv.URL+"/"),
+			wantErr: ErrBlockedAddress,
+		},
+		{
+			name:    "Metadata Address",
+			target:  "http://169.254.169.254/latest/meta-data/",
+			wantErr: ErrBlockedAddress,
+		},
+		{
+			name:    "Scheme",
+			target:  "ftp://example.com/file",
+			wantErr: ErrBlockedAddress,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := NewClient(Options{Allow: tt.allow})
+			require.NoError(t, err)
+
+			body, err := get(t, client, tt.target)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "ok", body)
+		})
+	}
+}
+
+func TestClient_Redirects(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/again", http.StatusFound)
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient(Options{Allow: []string{"127.0.0.1"}, MaxRedirects: 3})
+	require.NoError(t, err)
+
+	_, err = get(t, client, srv.URL)
+	assert.ErrorIs(t, err, ErrTooManyRedirects)
+}
+
+func TestClient_MaxBodyBytes(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := 16
+		fmt.Sscan(r.URL.Query().Get("n"), &n)
+		fmt.Fprint(w, strings.Repeat("x", n))
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient(Options{Allow: []string{"127.0.0.1"}, MaxBodyBytes: 16})
+	require.NoError(t, err)
+
+	body, err := get(t, client, srv.URL+"?n=16")
+	require.NoError(t, err, "a body exactly at the cap is fine")
+	assert.Len(t, body, 16)
+
+	_, err = get(t, client, srv.URL+"?n=17")
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+
+	// A prefix of a large body can still be read.
+	resp, err := client.Get(srv.URL + "?n=100000")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	prefix := make([]byte, 8)
+	_, err = io.ReadFull(resp.Body, prefix)
+	assert.NoError(t, err)
+}
+
+func TestClient_PerHostLimits(t *testing.T) {
+	t.Parallel()
+
+	var active, peak atomic.Int32
+	var mu sync.Mutex
+	var starts []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		starts = append(starts, time.Now())
+		mu.Unlock()
+
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	t.Cleanup(srv.Close)
+
+	const delay = 30 * time.Millisecond
+	client, err := NewClient(Options{
+		Allow:           []string{"127.0.0.1"},
+		MaxConnsPerHost: 1,
+		HostDelay:       delay,
+	})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			_, err := get(t, client, srv.URL)
+			assert.NoError(t, err)
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), peak.Load())
+	require.Len(t, starts, 4)
+	for i := 1; i < len(starts); i++ {
+		// Allow for timer granularity.
+		assert.GreaterOrEqual(t, starts[i].Sub(starts[i-1]), delay-5*time.Millisecond)
+	}
+}
+
+func TestClient_WaitHonorsContext(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewClient(Options{Allow: []string{"127.0.0.1"}, HostDelay: time.Hour})
+	require.NoError(t, err)
+
+	_, err = get(t, client, srv.URL)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_Proxy(t *testing.T) {
+	t.Parallel()
+
+	var proxied atomic.Value
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Store(r.URL.String())
+		fmt.Fprint(w, "via proxy")
+	}))
+	t.Cleanup(proxy.Close)
+
+	client, err := NewClient(Options{Proxy: proxy.URL})
+	require.NoError(t, err)
+
+	body, err := get(t, client, "http://93.184.216.34/page")
+	require.NoError(t, err)
+	assert.Equal(t, "via proxy", body)
+	assert.Equal(t, "http://93.184.216.34/page", proxied.Load())
+
+	_, err = get(t, client, "http://10.0.0.1/admin")
+	assert.ErrorIs(t, err, ErrBlockedAddress, "targets are checked even through a proxy")
+
+	_, err = NewClient(Options{Proxy: "not a url"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package outbound
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// limitTransport checks each request before handing it to the real transport,
+// and applies the per-host limits and the body cap. http.Client calls it once
+// per redirect hop, so every hop is checked.
+type limitTransport struct {
+	next      http.RoundTripper
+	policy    *policy
+	proxied   bool
+	maxBody   int64
+	maxConns  int
+	delay     time.Duration
+	userAgent string
+
+	mu    sync.Mutex
+	hosts map[string]*hostState
+}
+
+// hostState tracks one host. Entries are kept for the life of the process,
+// which is fine for the number of distinct hosts a bookmark collection holds.
+type hostState struct {
+	slots chan struct{}
+	next  time.Time // earliest start of the next request
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return nil, fmt.Errorf("%w: scheme %q", ErrBlockedAddress, req.URL.Scheme)
+	}
+	// Through a proxy no dial reaches the target, so check it here. The proxy
+	// resolves the name again, which leaves a small window this cannot close.
+	if t.proxied {
+		if _, err := resolve(req.Context(), t.policy, req.URL.Hostname()); err != nil {
+			return nil, err
+		}
+	}
+	if t.userAgent != "" && req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+
+	host := t.host(strings.ToLower(req.URL.Host))
+	if err := t.acquire(req, host); err != nil {
+		return nil, err
+	}
+	release := sync.OnceFunc(func() { <-host.slots })
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &cappedBody{body: resp.Body, remaining: t.maxBody, release: release}
+	return resp, nil
+}
+
+func (t *limitTransport) host(name string) *hostState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h, ok := t.hosts[name]
+	if !ok {
+		h = &hostState{slots: make(chan struct{}, t.maxConns)}
+		t.hosts[name] = h
+	}
+	return h
+}
+
+// acquire waits for a free slot on the host and then for the politeness delay
+// since the previous request started.
+func (t *limitTransport) acquire(req *http.Request, h *hostState) error {
+	ctx := req.Context()
+	select {
+	case h.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	t.mu.Lock()
+	now := time.Now()
+	start := now
+	if h.next.After(now) {
+		start = h.next
+	}
+	h.next = start.Add(t.delay)
+	t.mu.Unlock()
+
+	if wait := start.Sub(now); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			<-h.slots
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// cappedBody fails reads past the cap instead of truncating silently, and
+// frees the host slot when the body is closed. A declared Content-Length over
+// the cap is not rejected up front: callers that read only a prefix, like the
+// metadata inspector, still work on large pages.
+type cappedBody struct {
+	body      io.ReadCloser
+	remaining int64
+	release   func()
+}
+
+func (b *cappedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// Distinguish a body that ends exactly at the cap from a longer one.
+		var probe [1]byte
+		if n, _ := b.body.Read(probe[:]); n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, io.EOF
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.body.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
+
+func (b *cappedBody) Close() error {
+	b.release()
+	return b.body.Close()
+}
@@ -0,0 +1,114 @@
+package filestore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+const faviconMetaFile = "meta.json"
+
+// FaviconStore keeps each host's icons in their own directory: one <size>.png
+// per size, and meta.json listing them. meta.json is written last, so a host
+// is only seen once all of its PNGs are in place.
+type FaviconStore struct {
+	dir string
+}
+
+type faviconMeta struct {
+	Host      string    `json:"host"`
+	Sizes     []int     `json:"sizes"`
+	FetchedAt time.Time `json:"fetched_at"`
+	CheckedAt time.Time `json:"checked_at"`
+	Error     string    `json:"error,omitempty"`
+}
+
+var _ domain.FaviconStore = (*FaviconStore)(nil)
+
+func NewFaviconStore(dir string) (*FaviconStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("filestore.NewFaviconStore: %w", err)
+	}
+	return &FaviconStore{dir: dir}, nil
+}
+
+func (s *FaviconStore) Get(_ context.Context, host string) (*domain.Favicon, error) {
+	hostDir, err := s.hostDir(host)
+	if err != nil {
+		return nil, fmt.Errorf("filestore.FaviconStore.Get: %w", err)
+	}
+	var meta faviconMeta
+	if err := readJSON(filepath.Join(hostDir, faviconMetaFile), &meta); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("filestore.FaviconStore.Get: %w", domain.ErrFaviconNotFound)
+		}
+		return nil, fmt.Errorf("filestore.FaviconStore.Get: %w", err)
+	}
+
+	f := &domain.Favicon{
+		Host:      host,
+		Icons:     make(map[int][]byte, len(meta.Sizes)),
+		FetchedAt: meta.FetchedAt,
+		CheckedAt: meta.CheckedAt,
+		Error:     meta.Error,
+	}
+	for _, size := range meta.Sizes {
+		data, err := os.ReadFile(filepath.Join(hostDir, iconFile(size)))
+		if err != nil {
+			return nil, fmt.Errorf("filestore.FaviconStore.Get: %w", err)
+		}
+		f.Icons[size] = data
+	}
+	return f, nil
+}
+
+func (s *FaviconStore) Put(_ context.Context, f *domain.Favicon) error {
+	hostDir, err := s.hostDir(f.Host)
+	if err != nil {
+		return fmt.Errorf("filestore.FaviconStore.Put: %w", err)
+	}
+	if err := os.MkdirAll(hostDir, 0o750); err != nil {
+		return fmt.Errorf("filestore.FaviconStore.Put: %w", err)
+	}
+
+	meta := faviconMeta{Host: f.Host, FetchedAt: f.FetchedAt, CheckedAt: f.CheckedAt, Error: f.Error}
+	for _, size := range domain.FaviconSizes {
+		data, ok := f.Icons[size]
+		if !ok {
+			continue
+		}
+		err := writeAtomic(filepath.Join(hostDir, iconFile(size)), func(w io.Writer) error {
+			_, err := io.Copy(w, bytes.NewReader(data))
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("filestore.FaviconStore.Put: %w", err)
+		}
+		meta.Sizes = append(meta.Sizes, size)
+	}
+	if err := writeJSON(filepath.Join(hostDir, faviconMetaFile), meta); err != nil {
+		return fmt.Errorf("filestore.FaviconStore.Put: %w", err)
+	}
+	return nil
+}
+
+// hostDir maps a host to its directory, rejecting names that would escape
+// s.dir. Callers normalize hosts, so this is only a safeguard.
+func (s *FaviconStore) hostDir(host string) (string, error) {
+	if host == "" || host[0] == '.' || !filepath.IsLocal(host) || filepath.Base(host) != host {
+		return "", domain.ErrInvalidHost
+	}
+	return filepath.Join(s.dir, host), nil
+}
+
+func iconFile(size int) string {
+	return strconv.Itoa(size) + ".png"
+}
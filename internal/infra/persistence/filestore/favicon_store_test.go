@@ -0,0 +1,85 @@
+package filestore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+func TestFaviconStore_SurvivesReopen(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	store, err := NewFaviconStore(dir)
+	if err != nil {
+		t.Fatalf("NewFaviconStore() error = %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	icon := &domain.Favicon{
+		Host:      "example.com",
+		Icons:     map[int][]byte{16: []byte("sixteen"), 32: []byte("thirty-two")},
+		FetchedAt: now,
+		CheckedAt: now,
+	}
+	if err := store.Put(ctx, icon); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	// A failed refresh keeps the icons and records why.
+	failed := icon.Clone()
+	failed.CheckedAt = now.Add(time.Hour)
+	failed.Error = "status 503"
+	if err := store.Put(ctx, failed); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	reopened, err := NewFaviconStore(dir)
+	if err != nil {
+		t.Fatalf("NewFaviconStore() error = %v", err)
+	}
+	got, err := reopened.Get(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(got.Icons) != 2 || !bytes.Equal(got.Icons[32], []byte("thirty-two")) {
+		t.Errorf("Get() icons = %v", got.Icons)
+	}
+	if !got.FetchedAt.Equal(now) || !got.CheckedAt.Equal(now.Add(time.Hour)) || got.Error != "status 503" {
+		t.Errorf("Get() = %+v", got)
+	}
+}
+
+func TestFaviconStore_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, err := NewFaviconStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFaviconStore() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		host    string
+		wantErr error
+	}{
+		{"Unknown Host", "example.org", domain.ErrFaviconNotFound},
+		{"Path Traversal", "../etc", domain.ErrInvalidHost},
+		{"Dot", ".", domain.ErrInvalidHost},
+		{"Empty", "", domain.ErrInvalidHost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := store.Get(ctx, tt.host); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Get(%q) error = %v, want %v", tt.host, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Package filestore persists state as files, mostly JSON, on local disk. It is
+// meant for single-node deployments that need data to survive a restart
+// without running a database.
+package filestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+const (
+	jobFile   = "job.json"
+	itemsFile = "items.json"
+)
+
+// ImportJobRepository stores each job in its own directory: job.json holds the
+// checkpoint and is rewritten atomically, items.json holds the payload and is
+// written once.
+type ImportJobRepository struct {
+	dir string
+
+	mu    sync.Mutex
+	items map[string][]*domain.Bookmark // decoded payloads, loaded on first use
+}
+
+func NewImportJobRepository(dir string) (*ImportJobRepository, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("filestore.NewImportJobRepository: %w", err)
+	}
+	return &ImportJobRepository{
+		dir:   dir,
+		items: make(map[string][]*domain.Bookmark),
+	}, nil
+}
+
+func (r *ImportJobRepository) Create(_ context.Context, job *domain.ImportJob, items []*domain.Bookmark) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	jobDir, err := r.jobDir(job.ID)
+	if err != nil {
+		return fmt.Errorf("filestore.ImportJobRepository.Create: %w", err)
+	}
+	if err := os.Mkdir(jobDir, 0o750); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("filestore.ImportJobRepository.Create: job with ID %s already exists", job.ID)
+		}
+		return fmt.Errorf("filestore.ImportJobRepository.Create: %w", err)
+	}
+	// Items go first: a job.json without its payload would be resumed into nothing.
+	if err := writeJSON(filepath.Join(jobDir, itemsFile), items); err != nil {
+		return fmt.Errorf("filestore.ImportJobRepository.Create: %w", err)
+	}
+	if err := writeJSON(filepath.Join(jobDir, jobFile), job); err != nil {
+		return fmt.Errorf("filestore.ImportJobRepository.Create: %w", err)
+	}
+	r.items[job.ID] = items
+	return nil
+}
+
+func (r *ImportJobRepository) GetByID(_ context.Context, id string) (*domain.ImportJob, error) {
+	job, err := r.readJob(id)
+	if err != nil {
+		return nil, fmt.Errorf("filestore.ImportJobRepository.GetByID: %w", err)
+	}
+	return job, nil
+}
+
+func (r *ImportJobRepository) Update(_ context.Context, job *domain.ImportJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	jobDir, err := r.jobDir(job.ID)
+	if err != nil {
+		return fmt.Errorf("filestore.ImportJobRepository.Update: %w", err)
+	}
+	if _, err := os.Stat(filepath.Join(jobDir, jobFile)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return domain.ErrImportJobNotFound
+		}
+		return fmt.Errorf("filestore.ImportJobRepository.Update: %w", err)
+	}
+	if err := writeJSON(filepath.Join(jobDir, jobFile), job); err != nil {
+		return fmt.Errorf("filestore.ImportJobRepository.Update: %w", err)
+	}
+	return nil
+}
+
+func (r *ImportJobRepository) Items(_ context.Context, id string, offset, limit int) ([]*domain.Bookmark, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items, ok := r.items[id]
+	if !ok {
+		jobDir, err := r.jobDir(id)
+		if err != nil {
+			return nil, fmt.Errorf("filestore.ImportJobRepository.Items: %w", err)
+		}
+		if err := readJSON(filepath.Join(jobDir, itemsFile), &items); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, domain.ErrImportJobNotFound
+			}
+			return nil, fmt.Errorf("filestore.ImportJobRepository.Items: %w", err)
+		}
+		r.items[id] = items
+	}
+	if offset >= len(items) {
+		return nil, nil
+	}
+	end := min(offset+limit, len(items))
+	return items[offset:end], nil
+}
+
+func (r *ImportJobRepository) ListUnfinished(_ context.Context) ([]*domain.ImportJob, error) {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return nil, fmt.Errorf("filestore.ImportJobRepository.ListUnfinished: %w", err)
+	}
+
+	var jobs []*domain.ImportJob
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		job, err := r.readJob(e.Name())
+		if errors.Is(err, domain.ErrImportJobNotFound) {
+			// Crashed between creating the directory and writing job.json.
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("filestore.ImportJobRepository.ListUnfinished: %w", err)
+		}
+		if !job.Finished() {
+			jobs = append(jobs, job)
+		}
+	}
+	slices.SortFunc(jobs, func(a, b *domain.ImportJob) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return jobs, nil
+}
+
+func (r *ImportJobRepository) readJob(id string) (*domain.ImportJob, error) {
+	jobDir, err := r.jobDir(id)
+	if err != nil {
+		return nil, err
+	}
+	var job domain.ImportJob
+	if err := readJSON(filepath.Join(jobDir, jobFile), &job); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, domain.ErrImportJobNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// jobDir maps an ID to its directory, rejecting IDs that would escape r.dir.
+func (r *ImportJobRepository) jobDir(id string) (string, error) {
+	if id == "" || !filepath.IsLocal(id) || filepath.Base(id) != id {
+		return "", domain.ErrImportJobNotFound
+	}
+	return filepath.Join(r.dir, id), nil
+}
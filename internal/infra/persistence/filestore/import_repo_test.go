@@ -0,0 +1,101 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+func TestImportJobRepository_SurvivesReopen(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	repo, err := NewImportJobRepository(dir)
+	if err != nil {
+		t.Fatalf("NewImportJobRepository() error = %v", err)
+	}
+
+	items := []*domain.Bookmark{
+		{ID: "a", URL: "https://example.com/a", Title: "First"},
+		{ID: "b", URL: "https://example.com/b", Title: "Second"},
+		{ID: "c", URL: "https://example.com/c", Title: "Third"},
+	}
+	job := domain.NewImportJob("job-1", len(items))
+	if err := repo.Create(ctx, job, items); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	done := domain.NewImportJob("job-2", 1)
+	done.Finish(domain.ImportJobCompleted)
+	if err := repo.Create(ctx, done, items[:1]); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	job.Status = domain.ImportJobRunning
+	job.Processed = 2
+	if err := repo.Update(ctx, job); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// A new repository over the same directory stands in for a restart.
+	reopened, err := NewImportJobRepository(dir)
+	if err != nil {
+		t.Fatalf("NewImportJobRepository() error = %v", err)
+	}
+
+	unfinished, err := reopened.ListUnfinished(ctx)
+	if err != nil {
+		t.Fatalf("ListUnfinished() error = %v", err)
+	}
+	if len(unfinished) != 1 || unfinished[0].ID != "job-1" {
+		t.Fatalf("ListUnfinished() = %v, want only job-1", unfinished)
+	}
+	if unfinished[0].Processed != 2 {
+		t.Errorf("ListUnfinished() checkpoint = %d, want 2", unfinished[0].Processed)
+	}
+
+	rest, err := reopened.Items(ctx, "job-1", 2, 10)
+	if err != nil {
+		t.Fatalf("Items() error = %v", err)
+	}
+	if len(rest) != 1 || rest[0].ID != "c" {
+		t.Errorf("Items() = %v, want only c", rest)
+	}
+}
+
+func TestImportJobRepository_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo, err := NewImportJobRepository(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewImportJobRepository() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		id   string
+	}{
+		{name: "Unknown ID", id: "missing"},
+		{name: "Path Traversal", id: "../etc"},
+		{name: "Empty ID", id: ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := repo.GetByID(ctx, tt.id); !errors.Is(err, domain.ErrImportJobNotFound) {
+				t.Errorf("GetByID() error = %v, want %v", err, domain.ErrImportJobNotFound)
+			}
+			if err := repo.Update(ctx, &domain.ImportJob{ID: tt.id}); !errors.Is(err, domain.ErrImportJobNotFound) {
+				t.Errorf("Update() error = %v, want %v", err, domain.ErrImportJobNotFound)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package persistence
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// InMemoryAttachmentRepository keeps attachment records for the lifetime of
+// the process; their content lives in the blob store.
+type InMemoryAttachmentRepository struct {
+	mu          sync.RWMutex
+	attachments map[string]*domain.Attachment
+}
+
+func NewInMemoryAttachmentRepository() *InMemoryAttachmentRepository {
+	return &InMemoryAttachmentRepository{
+		attachments: make(map[string]*domain.Attachment),
+	}
+}
+
+func (r *InMemoryAttachmentRepository) Create(_ context.Context, a *domain.Attachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.attachments[a.ID]; exists {
+		return fmt.Errorf("persistence.InMemoryAttachmentRepository.Create: attachment with ID %s already exists", a.ID)
+	}
+	r.attachments[a.ID] = a.Clone()
+	return nil
+}
+
+func (r *InMemoryAttachmentRepository) GetByID(_ context.Context, bookmarkID, id string) (*domain.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	a, ok := r.attachments[id]
+	if !ok || a.BookmarkID != bookmarkID {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	return a.Clone(), nil
+}
+
+func (r *InMemoryAttachmentRepository) ListByBookmark(_ context.Context, bookmarkID string) ([]*domain.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := []*domain.Attachment{}
+	for _, a := range r.attachments {
+		if a.BookmarkID == bookmarkID {
+			list = append(list, a.Clone())
+		}
+	}
+	slices.SortFunc(list, func(a, b *domain.Attachment) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return list, nil
+}
+
+func (r *InMemoryAttachmentRepository) Delete(_ context.Context, bookmarkID, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if a, ok := r.attachments[id]; !ok || a.BookmarkID != bookmarkID {
+		return domain.ErrAttachmentNotFound
+	}
+	delete(r.attachments, id)
+	return nil
+}
+
+func (r *InMemoryAttachmentRepository) DeleteByBookmark(_ context.Context, bookmarkID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, a := range r.attachments {
+		if a.BookmarkID == bookmarkID {
+			delete(r.attachments, id)
+		}
+	}
+	return nil
+}
+
+func (r *InMemoryAttachmentRepository) SizeByOwner(_ context.Context, owner string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var total int64
+	for _, a := range r.attachments {
+		if a.Owner == owner {
+			total += a.Size
+		}
+	}
+	return total, nil
+}
+
+func (r *InMemoryAttachmentRepository) TotalSize(_ context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var total int64
+	for _, a := range r.attachments {
+		total += a.Size
+	}
+	return total, nil
+}
+
+// ReferencedBlobs collects the keys before calling fn, so that fn may use the
+// repository.
+func (r *InMemoryAttachmentRepository) ReferencedBlobs(_ context.Context, fn func(key string) error) error {
+	r.mu.RLock()
+	keys := make([]string, 0, len(r.attachments))
+	for _, a := range r.attachments {
+		keys = append(keys, a.BlobKey)
+		if a.Thumbnail != nil {
+			keys = append(keys, a.Thumbnail.BlobKey)
+		}
+	}
+	r.mu.RUnlock()
+
+	for _, key := range keys {
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
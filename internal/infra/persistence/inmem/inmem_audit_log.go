@@ -0,0 +1,58 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// InMemoryAuditLog keeps audit entries for the lifetime of the process.
+type InMemoryAuditLog struct {
+	mu sync.RWMutex
+	// entries holds the log oldest first; entries[i].Seq is i+1.
+	entries []domain.AuditEntry
+}
+
+func NewInMemoryAuditLog() *InMemoryAuditLog {
+	return &InMemoryAuditLog{}
+}
+
+func (l *InMemoryAuditLog) Append(_ context.Context, e *domain.AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var prev string
+	if n := len(l.entries); n > 0 {
+		prev = l.entries[n-1].Hash
+	}
+	e.Seq = int64(len(l.entries)) + 1
+	e.Seal(prev)
+	l.entries = append(l.entries, *e)
+	return nil
+}
+
+func (l *InMemoryAuditLog) Query(_ context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries := []*domain.AuditEntry{}
+	for i := len(l.entries) - 1; i >= 0 && len(entries) < filter.Limit; i-- {
+		if e := l.entries[i]; filter.Matches(&e) {
+			entries = append(entries, &e)
+		}
+	}
+	return entries, nil
+}
+
+func (l *InMemoryAuditLog) Range(_ context.Context, after int64, limit int) ([]*domain.AuditEntry, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries := []*domain.AuditEntry{}
+	for i := int(min(max(after, 0), int64(len(l.entries)))); i < len(l.entries) && len(entries) < limit; i++ {
+		e := l.entries[i]
+		entries = append(entries, &e)
+	}
+	return entries, nil
+}
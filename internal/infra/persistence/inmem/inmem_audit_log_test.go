@@ -0,0 +1,81 @@
+package persistence
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+func auditSeqs(entries []*domain.AuditEntry) []int64 {
+	seqs := make([]int64, len(entries))
+	for i, e := range entries {
+		seqs[i] = e.Seq
+	}
+	return seqs
+}
+
+func TestInMemoryAuditLog(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	l := NewInMemoryAuditLog()
+	for _, actor := range []string{"alice", "bob", "alice", "alice"} {
+		if err := l.Append(ctx, &domain.AuditEntry{Actor: "anonymous", AssertedActor: actor, Outcome: domain.AuditSuccess}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all, err := l.Range(ctx, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := auditSeqs(all), []int64{1, 2, 3, 4}; !slices.Equal(got, want) {
+		t.Fatalf("Range() seqs = %v, want %v", got, want)
+	}
+	for i, e := range all {
+		if i > 0 && e.PrevHash != all[i-1].Hash {
+			t.Errorf("entry %d PrevHash = %q, want %q", e.Seq, e.PrevHash, all[i-1].Hash)
+		}
+		if e.Hash != e.Digest() {
+			t.Errorf("entry %d Hash does not match its digest", e.Seq)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter domain.AuditFilter
+		want   []int64
+	}{
+		{name: "All", filter: domain.AuditFilter{Limit: 10}, want: []int64{4, 3, 2, 1}},
+		{name: "Actor", filter: domain.AuditFilter{Actor: "anonymous", Limit: 10}, want: []int64{4, 3, 2, 1}},
+		{name: "Asserted Actor", filter: domain.AuditFilter{AssertedActor: "alice", Limit: 10}, want: []int64{4, 3, 1}},
+		{name: "Limit", filter: domain.AuditFilter{AssertedActor: "alice", Limit: 2}, want: []int64{4, 3}},
+		{name: "Before", filter: domain.AuditFilter{AssertedActor: "alice", Before: 3, Limit: 10}, want: []int64{1}},
+		{name: "No Match", filter: domain.AuditFilter{Outcome: domain.AuditDenied, Limit: 10}, want: []int64{}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			entries, err := l.Query(ctx, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := auditSeqs(entries); !slices.Equal(got, tt.want) {
+				t.Errorf("Query() seqs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	page, err := l.Range(ctx, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := auditSeqs(page), []int64{3}; !slices.Equal(got, want) {
+		t.Errorf("Range(2, 1) seqs = %v, want %v", got, want)
+	}
+}
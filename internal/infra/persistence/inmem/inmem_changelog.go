@@ -0,0 +1,88 @@
+package persistence
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// InMemoryChangeLog keeps the latest change to each bookmark for the
+// lifetime of the process. Its epoch is new every time it is created, since
+// the sequence starts over.
+type InMemoryChangeLog struct {
+	mu     sync.RWMutex
+	epoch  string
+	seq    int64
+	latest map[string]domain.Change
+	// pruned is the sequence number of the newest change dropped by Prune.
+	pruned int64
+}
+
+func NewInMemoryChangeLog() *InMemoryChangeLog {
+	return &InMemoryChangeLog{
+		epoch:  strconv.FormatInt(time.Now().UnixNano(), 36),
+		latest: make(map[string]domain.Change),
+	}
+}
+
+func (l *InMemoryChangeLog) Epoch(_ context.Context) (string, error) {
+	return l.epoch, nil
+}
+
+func (l *InMemoryChangeLog) Append(_ context.Context, changes ...domain.Change) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, c := range changes {
+		l.seq++
+		c.Seq = l.seq
+		l.latest[c.BookmarkID] = c
+	}
+	return nil
+}
+
+func (l *InMemoryChangeLog) Since(_ context.Context, seq int64, limit int) ([]domain.Change, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if seq < l.pruned || seq > l.seq {
+		return nil, domain.ErrChangesExpired
+	}
+	var changes []domain.Change
+	for _, c := range l.latest {
+		if c.Seq > seq {
+			changes = append(changes, c)
+		}
+	}
+	slices.SortFunc(changes, func(a, b domain.Change) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+	return changes[:min(limit, len(changes))], nil
+}
+
+func (l *InMemoryChangeLog) Latest(_ context.Context) (int64, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.seq, nil
+}
+
+func (l *InMemoryChangeLog) Prune(_ context.Context, before time.Time) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	dropped := 0
+	for id, c := range l.latest {
+		if c.Deleted && c.At.Before(before) {
+			delete(l.latest, id)
+			l.pruned = max(l.pruned, c.Seq)
+			dropped++
+		}
+	}
+	return dropped, nil
+}
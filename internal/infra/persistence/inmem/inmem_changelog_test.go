@@ -0,0 +1,95 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+func changedIDs(changes []domain.Change) []string {
+	ids := make([]string, len(changes))
+	for i, c := range changes {
+		ids[i] = c.BookmarkID
+	}
+	return ids
+}
+
+func TestInMemoryChangeLog_Since(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	log := NewInMemoryChangeLog()
+	now := time.Now()
+	err := log.Append(ctx,
+		domain.Change{BookmarkID: "a", At: now},
+		domain.Change{BookmarkID: "b", At: now},
+		domain.Change{BookmarkID: "a", At: now},
+		domain.Change{BookmarkID: "c", Deleted: true, At: now},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		seq     int64
+		limit   int
+		want    []string
+		wantErr error
+	}{
+		{name: "From The Start", seq: 0, limit: 10, want: []string{"b", "a", "c"}},
+		{name: "Superseded Changes Are Skipped", seq: 2, limit: 10, want: []string{"a", "c"}},
+		{name: "Limit", seq: 0, limit: 2, want: []string{"b", "a"}},
+		{name: "Up To Date", seq: 4, limit: 10, want: []string{}},
+		{name: "Unknown Position", seq: 5, limit: 10, wantErr: domain.ErrChangesExpired},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			changes, err := log.Since(ctx, tt.seq, tt.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Since() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := changedIDs(changes); tt.wantErr == nil && !slices.Equal(got, tt.want) {
+				t.Errorf("Since() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInMemoryChangeLog_Prune(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	log := NewInMemoryChangeLog()
+	old := time.Now().Add(-48 * time.Hour)
+	log.Append(ctx,
+		domain.Change{BookmarkID: "kept", At: old},
+		domain.Change{BookmarkID: "gone", Deleted: true, At: old},
+		domain.Change{BookmarkID: "recent", Deleted: true, At: time.Now()},
+	)
+
+	dropped, err := log.Prune(ctx, time.Now().Add(-24*time.Hour))
+	if err != nil || dropped != 1 {
+		t.Fatalf("Prune() = %d, %v, want 1 dropped", dropped, err)
+	}
+
+	// A client that had seen "kept" but not the deletion of "gone" missed it.
+	if _, err := log.Since(ctx, 1, 10); !errors.Is(err, domain.ErrChangesExpired) {
+		t.Errorf("Since() before the pruned change error = %v, want %v", err, domain.ErrChangesExpired)
+	}
+	changes, err := log.Since(ctx, 2, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := changedIDs(changes); !slices.Equal(got, []string{"recent"}) {
+		t.Errorf("Since() after the pruned change = %v, want [recent]", got)
+	}
+}
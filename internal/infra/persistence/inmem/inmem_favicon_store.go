@@ -0,0 +1,39 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// InMemoryFaviconStore keeps icons for the lifetime of the process, so every
+// host's icon is fetched again after a restart.
+type InMemoryFaviconStore struct {
+	mu    sync.RWMutex
+	icons map[string]*domain.Favicon
+}
+
+func NewInMemoryFaviconStore() *InMemoryFaviconStore {
+	return &InMemoryFaviconStore{icons: make(map[string]*domain.Favicon)}
+}
+
+func (s *InMemoryFaviconStore) Get(_ context.Context, host string) (*domain.Favicon, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	f, ok := s.icons[host]
+	if !ok {
+		return nil, fmt.Errorf("persistence.InMemoryFaviconStore.Get: %w", domain.ErrFaviconNotFound)
+	}
+	return f.Clone(), nil
+}
+
+func (s *InMemoryFaviconStore) Put(_ context.Context, f *domain.Favicon) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.icons[f.Host] = f.Clone()
+	return nil
+}
@@ -0,0 +1,90 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// InMemoryImportJobRepository keeps import jobs for the lifetime of the process.
+// Use a durable adapter when jobs must survive a restart.
+type InMemoryImportJobRepository struct {
+	mu    sync.RWMutex
+	jobs  map[string]*domain.ImportJob
+	items map[string][]*domain.Bookmark
+}
+
+func NewInMemoryImportJobRepository() *InMemoryImportJobRepository {
+	return &InMemoryImportJobRepository{
+		jobs:  make(map[string]*domain.ImportJob),
+		items: make(map[string][]*domain.Bookmark),
+	}
+}
+
+func (r *InMemoryImportJobRepository) Create(_ context.Context, job *domain.ImportJob, items []*domain.Bookmark) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.jobs[job.ID]; exists {
+		return fmt.Errorf("persistence.InMemoryImportJobRepository.Create: job with ID %s already exists", job.ID)
+	}
+	r.jobs[job.ID] = job.Clone()
+	r.items[job.ID] = items
+	return nil
+}
+
+func (r *InMemoryImportJobRepository) GetByID(_ context.Context, id string) (*domain.ImportJob, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, domain.ErrImportJobNotFound
+	}
+	return job.Clone(), nil
+}
+
+func (r *InMemoryImportJobRepository) Update(_ context.Context, job *domain.ImportJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.jobs[job.ID]; !ok {
+		return domain.ErrImportJobNotFound
+	}
+	r.jobs[job.ID] = job.Clone()
+	return nil
+}
+
+func (r *InMemoryImportJobRepository) Items(_ context.Context, id string, offset, limit int) ([]*domain.Bookmark, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items, ok := r.items[id]
+	if !ok {
+		return nil, domain.ErrImportJobNotFound
+	}
+	if offset >= len(items) {
+		return nil, nil
+	}
+	end := min(offset+limit, len(items))
+	return items[offset:end], nil
+}
+
+func (r *InMemoryImportJobRepository) ListUnfinished(_ context.Context) ([]*domain.ImportJob, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var jobs []*domain.ImportJob
+	for _, job := range r.jobs {
+		if !job.Finished() {
+			jobs = append(jobs, job.Clone())
+		}
+	}
+	slices.SortFunc(jobs, func(a, b *domain.ImportJob) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return jobs, nil
+}
@@ -0,0 +1,219 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+var _ domain.AtomicBookmarkRepository = (*InMemoryBookmarkRepository)(nil)
+
+// Atomically holds the write lock while fn runs. Writes through tx are kept
+// aside and applied, with the recorded events, only when fn succeeds.
+func (r *InMemoryBookmarkRepository) Atomically(ctx context.Context, fn func(tx domain.BookmarkTx) error) error {
+	span := startSpan(ctx, "InMemoryBookmarkRepository.Atomically")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx := &inMemoryTx{base: r.bookmarks, written: make(map[string]*domain.Bookmark)}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	for id, b := range tx.written {
+		if b == nil {
+			delete(r.bookmarks, id)
+		} else {
+			r.bookmarks[id] = b
+		}
+	}
+	for _, e := range tx.events {
+		r.lastSeq++
+		r.outbox = append(r.outbox, domain.OutboxRecord{Seq: r.lastSeq, Event: e})
+	}
+	return nil
+}
+
+func (r *InMemoryBookmarkRepository) Pending(_ context.Context, limit int) ([]domain.OutboxRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	n := min(limit, len(r.outbox))
+	pending := make([]domain.OutboxRecord, n)
+	copy(pending, r.outbox[:n])
+	return pending, nil
+}
+
+func (r *InMemoryBookmarkRepository) Ack(_ context.Context, eventIDs ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	acked := make(map[string]bool, len(eventIDs))
+	for _, id := range eventIDs {
+		acked[id] = true
+	}
+	kept := r.outbox[:0]
+	for _, rec := range r.outbox {
+		if !acked[rec.Event.EventID()] {
+			kept = append(kept, rec)
+		}
+	}
+	clear(r.outbox[len(kept):])
+	r.outbox = kept
+	return nil
+}
+
+// inMemoryTx reads through its own writes to the repository's bookmarks,
+// which the caller of Atomically keeps locked.
+type inMemoryTx struct {
+	base    map[string]*domain.Bookmark
+	written map[string]*domain.Bookmark // nil marks a purge
+	events  []domain.Event
+}
+
+func (tx *inMemoryTx) Record(events ...domain.Event) {
+	tx.events = append(tx.events, events...)
+}
+
+func (tx *inMemoryTx) get(id string) (*domain.Bookmark, bool) {
+	if b, ok := tx.written[id]; ok {
+		return b, b != nil
+	}
+	b, ok := tx.base[id]
+	return b, ok
+}
+
+// live is get for bookmarks outside the trash.
+func (tx *inMemoryTx) live(id string) (*domain.Bookmark, bool) {
+	b, ok := tx.get(id)
+	return b, ok && b.DeletedAt == nil
+}
+
+func (tx *inMemoryTx) Create(_ context.Context, b *domain.Bookmark) error {
+	if _, exists := tx.get(b.ID); exists {
+		return fmt.Errorf("persistence.InMemoryBookmarkRepository.Create: bookmark with ID %s already exists", b.ID)
+	}
+	b.Version = 1
+	tx.written[b.ID] = b
+	return nil
+}
+
+func (tx *inMemoryTx) CreateBatch(_ context.Context, bs []*domain.Bookmark) error {
+	seen := make(map[string]struct{}, len(bs))
+	for _, b := range bs {
+		if _, exists := tx.get(b.ID); exists {
+			return fmt.Errorf("persistence.InMemoryBookmarkRepository.CreateBatch: %w: %s", domain.ErrBookmarkExists, b.ID)
+		}
+		if _, dup := seen[b.ID]; dup {
+			return fmt.Errorf("persistence.InMemoryBookmarkRepository.CreateBatch: %w: %s", domain.ErrBookmarkExists, b.ID)
+		}
+		seen[b.ID] = struct{}{}
+	}
+	for _, b := range bs {
+		b.Version = 1
+		tx.written[b.ID] = b
+	}
+	return nil
+}
+
+func (tx *inMemoryTx) GetByID(_ context.Context, id string) (*domain.Bookmark, error) {
+	b, ok := tx.live(id)
+	if !ok {
+		return nil, domain.ErrBookmarkNotFound
+	}
+	return b, nil
+}
+
+func (tx *inMemoryTx) GetAll(_ context.Context) ([]*domain.Bookmark, error) {
+	return tx.matching(domain.BookmarkFilter{}), nil
+}
+
+func (tx *inMemoryTx) Walk(ctx context.Context, filter domain.BookmarkFilter, fn func(*domain.Bookmark) error) error {
+	return walkSorted(ctx, tx.matching(filter), fn)
+}
+
+func (tx *inMemoryTx) matching(filter domain.BookmarkFilter) []*domain.Bookmark {
+	var matched []*domain.Bookmark
+	for id, b := range tx.base {
+		if _, ok := tx.written[id]; !ok && b.DeletedAt == nil && filter.Matches(b) {
+			matched = append(matched, b)
+		}
+	}
+	for _, b := range tx.written {
+		if b != nil && b.DeletedAt == nil && filter.Matches(b) {
+			matched = append(matched, b)
+		}
+	}
+	return matched
+}
+
+func (tx *inMemoryTx) Update(_ context.Context, b *domain.Bookmark) error {
+	stored, ok := tx.live(b.ID)
+	if !ok {
+		return domain.ErrBookmarkNotFound
+	}
+	b.Version = stored.Version + 1
+	tx.written[b.ID] = b
+	return nil
+}
+
+func (tx *inMemoryTx) CompareAndUpdate(_ context.Context, b *domain.Bookmark, version int64) error {
+	stored, ok := tx.live(b.ID)
+	if !ok {
+		return domain.ErrBookmarkNotFound
+	}
+	if stored.Version != version {
+		return domain.ErrVersionConflict
+	}
+	b.Version = stored.Version + 1
+	tx.written[b.ID] = b
+	return nil
+}
+
+func (tx *inMemoryTx) Delete(_ context.Context, id string) error {
+	stored, _ := tx.get(id)
+	trashed, err := trash(stored)
+	if err != nil {
+		return err
+	}
+	tx.written[id] = trashed
+	return nil
+}
+
+func (tx *inMemoryTx) CompareAndDelete(_ context.Context, id string, version int64) error {
+	stored, _ := tx.get(id)
+	trashed, err := trash(stored)
+	if err != nil {
+		return err
+	}
+	if trashed.Version != version {
+		return domain.ErrVersionConflict
+	}
+	tx.written[id] = trashed
+	return nil
+}
+
+func (tx *inMemoryTx) Trash(_ context.Context) ([]*domain.Bookmark, error) {
+	return sortTrash(tx.base, tx.written), nil
+}
+
+func (tx *inMemoryTx) Restore(_ context.Context, id string) error {
+	stored, _ := tx.get(id)
+	restored, err := restore(stored)
+	if err != nil {
+		return err
+	}
+	tx.written[id] = restored
+	return nil
+}
+
+func (tx *inMemoryTx) Purge(_ context.Context, id string) error {
+	if b, ok := tx.get(id); !ok || b.DeletedAt == nil {
+		return domain.ErrBookmarkNotFound
+	}
+	tx.written[id] = nil
+	return nil
+}
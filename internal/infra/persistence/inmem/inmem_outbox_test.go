@@ -0,0 +1,115 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+func testEvent(id, bookmarkID string) domain.Event {
+	return domain.BookmarkDeleted{
+		EventMeta: domain.EventMeta{ID: id, At: time.Now()},
+		Bookmark:  &domain.Bookmark{ID: bookmarkID},
+	}
+}
+
+func TestInMemoryBookmarkRepository_Atomically(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := newTestRepo()
+	if err := repo.Create(ctx, &domain.Bookmark{ID: "kept", URL: "https://example.com/kept"}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := repo.Atomically(ctx, func(tx domain.BookmarkTx) error {
+		if err := tx.Create(ctx, &domain.Bookmark{ID: "new", URL: "https://example.com/new"}); err != nil {
+			return err
+		}
+		if err := tx.Delete(ctx, "kept"); err != nil {
+			return err
+		}
+		// Reads see the transaction's own writes.
+		if _, err := tx.GetByID(ctx, "new"); err != nil {
+			return err
+		}
+		if _, err := tx.GetByID(ctx, "kept"); !errors.Is(err, domain.ErrBookmarkNotFound) {
+			t.Errorf("GetByID() of deleted bookmark error = %v, want %v", err, domain.ErrBookmarkNotFound)
+		}
+		tx.Record(testEvent("e1", "new"), testEvent("e2", "kept"))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Atomically() error = %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "new"); err != nil {
+		t.Errorf("GetByID(new) error = %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "kept"); !errors.Is(err, domain.ErrBookmarkNotFound) {
+		t.Errorf("GetByID(kept) error = %v, want %v", err, domain.ErrBookmarkNotFound)
+	}
+
+	failed := errors.New("failed")
+	err = repo.Atomically(ctx, func(tx domain.BookmarkTx) error {
+		if err := tx.Delete(ctx, "new"); err != nil {
+			return err
+		}
+		tx.Record(testEvent("e3", "new"))
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("Atomically() error = %v, want %v", err, failed)
+	}
+	if _, err := repo.GetByID(ctx, "new"); err != nil {
+		t.Errorf("GetByID(new) after rollback error = %v", err)
+	}
+
+	pending, err := repo.Pending(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("Pending() returned %d records, want 2", len(pending))
+	}
+	for i, want := range []string{"e1", "e2"} {
+		if got := pending[i].Event.EventID(); got != want {
+			t.Errorf("Pending()[%d] = %s, want %s", i, got, want)
+		}
+	}
+	if pending[0].Seq >= pending[1].Seq {
+		t.Errorf("Pending() Seq not increasing: %d, %d", pending[0].Seq, pending[1].Seq)
+	}
+}
+
+func TestInMemoryBookmarkRepository_Ack(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := newTestRepo()
+	err := repo.Atomically(ctx, func(tx domain.BookmarkTx) error {
+		tx.Record(testEvent("e1", "a"), testEvent("e2", "a"), testEvent("e3", "b"))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.Ack(ctx, "e1", "e3", "unknown"); err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+	pending, err := repo.Pending(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].Event.EventID() != "e2" {
+		t.Errorf("Pending() after Ack = %v, want only e2", pending)
+	}
+
+	pending, _ = repo.Pending(ctx, 0)
+	if len(pending) != 0 {
+		t.Errorf("Pending(0) returned %d records, want 0", len(pending))
+	}
+}
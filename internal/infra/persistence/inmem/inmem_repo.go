@@ -3,14 +3,23 @@ package persistence
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/etsrc/goprod/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type InMemoryBookmarkRepository struct {
-	mu        sync.RWMutex
+	mu sync.RWMutex
+	// bookmarks includes those in the trash, which have DeletedAt set.
 	bookmarks map[string]*domain.Bookmark
+
+	// outbox holds events recorded by Atomically until they are acked.
+	outbox  []domain.OutboxRecord
+	lastSeq int64
 }
 
 func NewInMemoryBookmarkRepository() *InMemoryBookmarkRepository {
@@ -19,7 +28,10 @@ func NewInMemoryBookmarkRepository() *InMemoryBookmarkRepository {
 	}
 }
 
-func (r *InMemoryBookmarkRepository) Create(_ context.Context, b *domain.Bookmark) error {
+func (r *InMemoryBookmarkRepository) Create(ctx context.Context, b *domain.Bookmark) error {
+	span := startSpan(ctx, "InMemoryBookmarkRepository.Create")
+	defer span.End()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -27,39 +39,264 @@ func (r *InMemoryBookmarkRepository) Create(_ context.Context, b *domain.Bookmar
 		// Given the architecture, the service layer generates UUIDs, so this shouldn't happen.
 		return fmt.Errorf("persistence.InMemoryBookmarkRepository.Create: bookmark with ID %s already exists", b.ID)
 	}
+	b.Version = 1
 	r.bookmarks[b.ID] = b
 	return nil
 }
 
-func (r *InMemoryBookmarkRepository) GetByID(_ context.Context, id string) (*domain.Bookmark, error) {
+// CreateBatch inserts all bookmarks under a single lock. The batch is applied
+// all-or-nothing: if any ID is already taken nothing is written.
+func (r *InMemoryBookmarkRepository) CreateBatch(ctx context.Context, bs []*domain.Bookmark) error {
+	span := startSpan(ctx, "InMemoryBookmarkRepository.CreateBatch")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[string]struct{}, len(bs))
+	for _, b := range bs {
+		if _, exists := r.bookmarks[b.ID]; exists {
+			return fmt.Errorf("persistence.InMemoryBookmarkRepository.CreateBatch: %w: %s", domain.ErrBookmarkExists, b.ID)
+		}
+		if _, dup := seen[b.ID]; dup {
+			return fmt.Errorf("persistence.InMemoryBookmarkRepository.CreateBatch: %w: %s", domain.ErrBookmarkExists, b.ID)
+		}
+		seen[b.ID] = struct{}{}
+	}
+	for _, b := range bs {
+		b.Version = 1
+		r.bookmarks[b.ID] = b
+	}
+	return nil
+}
+
+func (r *InMemoryBookmarkRepository) GetByID(ctx context.Context, id string) (*domain.Bookmark, error) {
+	span := startSpan(ctx, "InMemoryBookmarkRepository.GetByID", attribute.String("bookmark.id", id))
+	defer span.End()
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	bookmark, ok := r.bookmarks[id]
-	if !ok {
+	if !ok || bookmark.DeletedAt != nil {
 		return nil, domain.ErrBookmarkNotFound
 	}
 	return bookmark, nil
 }
 
-func (r *InMemoryBookmarkRepository) GetAll(_ context.Context) ([]*domain.Bookmark, error) {
+func (r *InMemoryBookmarkRepository) GetAll(ctx context.Context) ([]*domain.Bookmark, error) {
+	span := startSpan(ctx, "InMemoryBookmarkRepository.GetAll")
+	defer span.End()
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	allBookmarks := make([]*domain.Bookmark, 0, len(r.bookmarks))
 	for _, bookmark := range r.bookmarks {
-		allBookmarks = append(allBookmarks, bookmark)
+		if bookmark.DeletedAt == nil {
+			allBookmarks = append(allBookmarks, bookmark)
+		}
 	}
 	return allBookmarks, nil
 }
 
-func (r *InMemoryBookmarkRepository) Delete(_ context.Context, id string) error {
+// Walk snapshots the matching bookmarks and releases the lock before calling
+// fn, so a slow consumer such as an export download never blocks writers.
+func (r *InMemoryBookmarkRepository) Walk(ctx context.Context, filter domain.BookmarkFilter, fn func(*domain.Bookmark) error) error {
+	span := startSpan(ctx, "InMemoryBookmarkRepository.Walk")
+	defer span.End()
+
+	r.mu.RLock()
+	matched := make([]*domain.Bookmark, 0, len(r.bookmarks))
+	for _, bookmark := range r.bookmarks {
+		if bookmark.DeletedAt == nil && filter.Matches(bookmark) {
+			matched = append(matched, bookmark)
+		}
+	}
+	r.mu.RUnlock()
+
+	return walkSorted(ctx, matched, fn)
+}
+
+// walkSorted calls fn for bookmarks oldest first.
+func walkSorted(ctx context.Context, bookmarks []*domain.Bookmark, fn func(*domain.Bookmark) error) error {
+	slices.SortFunc(bookmarks, func(a, b *domain.Bookmark) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	for _, bookmark := range bookmarks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(bookmark); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *InMemoryBookmarkRepository) Update(ctx context.Context, b *domain.Bookmark) error {
+	span := startSpan(ctx, "InMemoryBookmarkRepository.Update")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.bookmarks[b.ID]
+	if !ok || stored.DeletedAt != nil {
+		return domain.ErrBookmarkNotFound
+	}
+	b.Version = stored.Version + 1
+	r.bookmarks[b.ID] = b
+	return nil
+}
+
+func (r *InMemoryBookmarkRepository) CompareAndUpdate(ctx context.Context, b *domain.Bookmark, version int64) error {
+	span := startSpan(ctx, "InMemoryBookmarkRepository.CompareAndUpdate")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.bookmarks[b.ID]
+	if !ok || stored.DeletedAt != nil {
+		return domain.ErrBookmarkNotFound
+	}
+	if stored.Version != version {
+		return domain.ErrVersionConflict
+	}
+	b.Version = stored.Version + 1
+	r.bookmarks[b.ID] = b
+	return nil
+}
+
+func (r *InMemoryBookmarkRepository) Delete(ctx context.Context, id string) error {
+	span := startSpan(ctx, "InMemoryBookmarkRepository.Delete", attribute.String("bookmark.id", id))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	trashed, err := trash(r.bookmarks[id])
+	if err != nil {
+		return err
+	}
+	r.bookmarks[id] = trashed
+	return nil
+}
+
+func (r *InMemoryBookmarkRepository) CompareAndDelete(ctx context.Context, id string, version int64) error {
+	span := startSpan(ctx, "InMemoryBookmarkRepository.CompareAndDelete", attribute.String("bookmark.id", id))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	trashed, err := trash(r.bookmarks[id])
+	if err != nil {
+		return err
+	}
+	if trashed.Version != version {
+		return domain.ErrVersionConflict
+	}
+	r.bookmarks[id] = trashed
+	return nil
+}
+
+func (r *InMemoryBookmarkRepository) Trash(ctx context.Context) ([]*domain.Bookmark, error) {
+	span := startSpan(ctx, "InMemoryBookmarkRepository.Trash")
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return sortTrash(r.bookmarks, nil), nil
+}
+
+func (r *InMemoryBookmarkRepository) Restore(ctx context.Context, id string) error {
+	span := startSpan(ctx, "InMemoryBookmarkRepository.Restore", attribute.String("bookmark.id", id))
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	restored, err := restore(r.bookmarks[id])
+	if err != nil {
+		return err
+	}
+	r.bookmarks[id] = restored
+	return nil
+}
+
+func (r *InMemoryBookmarkRepository) Purge(ctx context.Context, id string) error {
+	span := startSpan(ctx, "InMemoryBookmarkRepository.Purge", attribute.String("bookmark.id", id))
+	defer span.End()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, ok := r.bookmarks[id]; !ok {
+	if b, ok := r.bookmarks[id]; !ok || b.DeletedAt == nil {
 		return domain.ErrBookmarkNotFound
 	}
 	delete(r.bookmarks, id)
 	return nil
 }
+
+// trash returns a copy of stored moved to the trash. Stored bookmarks are
+// replaced rather than modified, since readers may hold them.
+func trash(stored *domain.Bookmark) (*domain.Bookmark, error) {
+	if stored == nil || stored.DeletedAt != nil {
+		return nil, domain.ErrBookmarkNotFound
+	}
+	trashed := stored.Clone()
+	now := time.Now()
+	trashed.DeletedAt = &now
+	return trashed, nil
+}
+
+// restore returns a copy of stored taken out of the trash.
+func restore(stored *domain.Bookmark) (*domain.Bookmark, error) {
+	if stored == nil || stored.DeletedAt == nil {
+		return nil, domain.ErrBookmarkNotFound
+	}
+	restored := stored.Clone()
+	restored.DeletedAt = nil
+	restored.Version++
+	return restored, nil
+}
+
+var _ domain.HealthChecker = (*InMemoryBookmarkRepository)(nil)
+
+// CheckHealth waits for the read lock, so a write that holds the lock for
+// too long shows up as a failed check.
+func (r *InMemoryBookmarkRepository) CheckHealth(_ context.Context) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return nil
+}
+
+// sortTrash returns the trashed bookmarks in base, as overlaid by written,
+// most recently deleted first. A nil bookmark in written has been purged.
+func sortTrash(base, written map[string]*domain.Bookmark) []*domain.Bookmark {
+	list := []*domain.Bookmark{}
+	for id, b := range base {
+		if _, ok := written[id]; !ok && b.DeletedAt != nil {
+			list = append(list, b)
+		}
+	}
+	for _, b := range written {
+		if b != nil && b.DeletedAt != nil {
+			list = append(list, b)
+		}
+	}
+	slices.SortFunc(list, func(a, b *domain.Bookmark) int {
+		if c := b.DeletedAt.Compare(*a.DeletedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return list
+}
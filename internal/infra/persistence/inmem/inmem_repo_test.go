@@ -3,6 +3,7 @@ package persistence
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
 	"time"
 
@@ -336,3 +337,226 @@ func TestInMemoryBookmarkRepository_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestInMemoryBookmarkRepository_Walk(t *testing.T) {
+	t.Parallel()
+
+	base := time.Now()
+	bookmarks := []*domain.Bookmark{
+		{ID: "id-3", URL: "https://go.dev", Title: "Go", Tags: []string{"lang"}, CreatedAt: base.Add(2 * time.Minute)},
+		{ID: "id-1", URL: "https://example.com", Title: "Example", CreatedAt: base},
+		{ID: "id-2", URL: "https://rust-lang.org", Title: "Rust", Description: "systems LANGUAGE", Tags: []string{"lang"}, CreatedAt: base.Add(time.Minute),
+			Health: &domain.LinkHealth{Status: domain.LinkBroken, StatusCode: 404, ConsecutiveFailures: 3}},
+	}
+
+	tests := []struct {
+		name    string
+		filter  domain.BookmarkFilter
+		wantIDs []string
+	}{
+		{
+			name:    "No Filter Returns All Oldest First",
+			filter:  domain.BookmarkFilter{},
+			wantIDs: []string{"id-1", "id-2", "id-3"},
+		},
+		{
+			name:    "Filter By Tag",
+			filter:  domain.BookmarkFilter{Tag: "lang"},
+			wantIDs: []string{"id-2", "id-3"},
+		},
+		{
+			name:    "Query Matches Description Case-Insensitively",
+			filter:  domain.BookmarkFilter{Query: "language"},
+			wantIDs: []string{"id-2"},
+		},
+		{
+			name:    "Filter By Link Health",
+			filter:  domain.BookmarkFilter{Health: domain.LinkBroken},
+			wantIDs: []string{"id-2"},
+		},
+		{
+			name:    "Unchecked Links",
+			filter:  domain.BookmarkFilter{Health: domain.LinkUnchecked},
+			wantIDs: []string{"id-1", "id-3"},
+		},
+		{
+			name:    "No Match",
+			filter:  domain.BookmarkFilter{Tag: "lang", Query: "example"},
+			wantIDs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := newTestRepo()
+			ctx := context.Background()
+			for _, b := range bookmarks {
+				repo.Create(ctx, b)
+			}
+
+			var gotIDs []string
+			err := repo.Walk(ctx, tt.filter, func(b *domain.Bookmark) error {
+				gotIDs = append(gotIDs, b.ID)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Walk() unexpected error = %v", err)
+			}
+			if !slices.Equal(gotIDs, tt.wantIDs) {
+				t.Errorf("Walk() got IDs %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestInMemoryBookmarkRepository_Update(t *testing.T) {
+	t.Parallel()
+
+	original := &domain.Bookmark{
+		ID:        "id-1",
+		URL:       "https://example.com/1",
+		Title:     "https://example.com/1",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name      string
+		update    *domain.Bookmark
+		wantErr   error
+		wantTitle string
+	}{
+		{
+			name: "Replace Existing Bookmark",
+			update: func() *domain.Bookmark {
+				b := original.Clone()
+				b.Title = "Example One"
+				return b
+			}(),
+			wantTitle: "Example One",
+		},
+		{
+			name:      "Update Non-Existent Bookmark",
+			update:    &domain.Bookmark{ID: "missing", Title: "Missing"},
+			wantErr:   domain.ErrBookmarkNotFound,
+			wantTitle: "https://example.com/1",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := newTestRepo()
+			ctx := context.Background()
+			repo.Create(ctx, original.Clone())
+
+			err := repo.Update(ctx, tt.update)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			got, _ := repo.GetByID(ctx, "id-1")
+			if got.Title != tt.wantTitle {
+				t.Errorf("Update() stored title = %q, want %q", got.Title, tt.wantTitle)
+			}
+			if original.Title != "https://example.com/1" {
+				t.Errorf("Update() modified the previously stored value")
+			}
+		})
+	}
+}
+
+func TestInMemoryBookmarkRepository_Version(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := newTestRepo()
+	b := &domain.Bookmark{ID: "id-1", URL: "https://example.com/1", Title: "Example"}
+	if err := repo.Create(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	if b.Version != 1 {
+		t.Errorf("Create() version = %d, want 1", b.Version)
+	}
+
+	for want := int64(2); want <= 3; want++ {
+		stored, _ := repo.GetByID(ctx, "id-1")
+		update := stored.Clone()
+		// The repository counts, whatever the caller passes.
+		update.Version = 42
+		if err := repo.Update(ctx, update); err != nil {
+			t.Fatal(err)
+		}
+		got, _ := repo.GetByID(ctx, "id-1")
+		if got.Version != want {
+			t.Errorf("Update() version = %d, want %d", got.Version, want)
+		}
+	}
+}
+
+func TestInMemoryBookmarkRepository_Trash(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := newTestRepo()
+	for _, id := range []string{"id-1", "id-2", "id-3"} {
+		repo.Create(ctx, &domain.Bookmark{ID: id, URL: "https://example.com/" + id, Title: id, CreatedAt: time.Now()})
+	}
+	kept, _ := repo.GetByID(ctx, "id-1")
+
+	if err := repo.Delete(ctx, "id-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Delete(ctx, "id-2"); err != nil {
+		t.Fatal(err)
+	}
+	if kept.DeletedAt != nil {
+		t.Errorf("Delete() modified the previously stored value")
+	}
+	if err := repo.Delete(ctx, "id-1"); !errors.Is(err, domain.ErrBookmarkNotFound) {
+		t.Errorf("Delete() of trashed bookmark error = %v, want %v", err, domain.ErrBookmarkNotFound)
+	}
+	if err := repo.Update(ctx, kept.Clone()); !errors.Is(err, domain.ErrBookmarkNotFound) {
+		t.Errorf("Update() of trashed bookmark error = %v, want %v", err, domain.ErrBookmarkNotFound)
+	}
+	var walked []string
+	repo.Walk(ctx, domain.BookmarkFilter{}, func(b *domain.Bookmark) error {
+		walked = append(walked, b.ID)
+		return nil
+	})
+	if !slices.Equal(walked, []string{"id-3"}) {
+		t.Errorf("Walk() = %v, want only the bookmark outside the trash", walked)
+	}
+
+	trash, _ := repo.Trash(ctx)
+	if len(trash) != 2 || trash[0].ID != "id-2" || trash[0].DeletedAt == nil {
+		t.Fatalf("Trash() = %v, want id-2 then id-1", trash)
+	}
+
+	if err := repo.Restore(ctx, "id-1"); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	restored, err := repo.GetByID(ctx, "id-1")
+	if err != nil || restored.DeletedAt != nil || restored.Version != 2 {
+		t.Errorf("GetByID() after Restore() = %+v, %v, want version 2 outside the trash", restored, err)
+	}
+	if err := repo.Restore(ctx, "id-1"); !errors.Is(err, domain.ErrBookmarkNotFound) {
+		t.Errorf("Restore() of live bookmark error = %v, want %v", err, domain.ErrBookmarkNotFound)
+	}
+
+	if err := repo.Purge(ctx, "id-3"); !errors.Is(err, domain.ErrBookmarkNotFound) {
+		t.Errorf("Purge() of live bookmark error = %v, want %v", err, domain.ErrBookmarkNotFound)
+	}
+	if err := repo.Purge(ctx, "id-2"); err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+	if trash, _ := repo.Trash(ctx); len(trash) != 0 {
+		t.Errorf("Trash() after Purge() = %v, want empty", trash)
+	}
+}
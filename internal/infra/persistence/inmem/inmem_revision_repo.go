@@ -0,0 +1,105 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// InMemoryRevisionRepository keeps the revisions of bookmarks for the
+// lifetime of the process.
+type InMemoryRevisionRepository struct {
+	mu sync.RWMutex
+	// revisions holds each bookmark's revisions, oldest first.
+	revisions map[string][]*domain.Revision
+	// last is the number of each bookmark's latest revision, which pruning
+	// does not reset.
+	last   map[string]int64
+	events map[string]struct{}
+}
+
+func NewInMemoryRevisionRepository() *InMemoryRevisionRepository {
+	return &InMemoryRevisionRepository{
+		revisions: make(map[string][]*domain.Revision),
+		last:      make(map[string]int64),
+		events:    make(map[string]struct{}),
+	}
+}
+
+func (r *InMemoryRevisionRepository) Add(_ context.Context, rev *domain.Revision) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if rev.EventID != "" {
+		if _, dup := r.events[rev.EventID]; dup {
+			return nil
+		}
+		r.events[rev.EventID] = struct{}{}
+	}
+	r.last[rev.BookmarkID]++
+	rev.Number = r.last[rev.BookmarkID]
+	r.revisions[rev.BookmarkID] = append(r.revisions[rev.BookmarkID], rev.Clone())
+	return nil
+}
+
+func (r *InMemoryRevisionRepository) List(_ context.Context, bookmarkID string) ([]*domain.Revision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := r.revisions[bookmarkID]
+	list := make([]*domain.Revision, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		list = append(list, stored[i].Clone())
+	}
+	return list, nil
+}
+
+func (r *InMemoryRevisionRepository) Get(_ context.Context, bookmarkID string, number int64) (*domain.Revision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, rev := range r.revisions[bookmarkID] {
+		if rev.Number == number {
+			return rev.Clone(), nil
+		}
+	}
+	return nil, domain.ErrRevisionNotFound
+}
+
+func (r *InMemoryRevisionRepository) Prune(_ context.Context, keep int, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keep = max(keep, 1)
+	pruned := 0
+	for id, stored := range r.revisions {
+		kept := stored[:0]
+		for i, rev := range stored {
+			// Revisions are stored oldest first, so the keep newest are
+			// the last ones.
+			if len(stored)-i > keep && rev.At.Before(before) {
+				delete(r.events, rev.EventID)
+				pruned++
+				continue
+			}
+			kept = append(kept, rev)
+		}
+		clear(stored[len(kept):])
+		r.revisions[id] = kept
+	}
+	return pruned, nil
+}
+
+func (r *InMemoryRevisionRepository) DeleteByBookmark(_ context.Context, bookmarkID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, rev := range r.revisions[bookmarkID] {
+		delete(r.events, rev.EventID)
+	}
+	delete(r.revisions, bookmarkID)
+	delete(r.last, bookmarkID)
+	return nil
+}
@@ -0,0 +1,92 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+func revisionNumbers(revisions []*domain.Revision) []int64 {
+	numbers := make([]int64, len(revisions))
+	for i, r := range revisions {
+		numbers[i] = r.Number
+	}
+	return numbers
+}
+
+func TestInMemoryRevisionRepository_Add(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := NewInMemoryRevisionRepository()
+	for _, eventID := range []string{"e1", "e2", "e2", ""} {
+		rev := &domain.Revision{BookmarkID: "b1", EventID: eventID, Bookmark: &domain.Bookmark{ID: "b1"}}
+		if err := repo.Add(ctx, rev); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list, err := repo.List(ctx, "b1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := revisionNumbers(list), []int64{3, 2, 1}; !slices.Equal(got, want) {
+		t.Errorf("List() numbers = %v, want %v", got, want)
+	}
+	if _, err := repo.Get(ctx, "b1", 4); !errors.Is(err, domain.ErrRevisionNotFound) {
+		t.Errorf("Get() error = %v, want %v", err, domain.ErrRevisionNotFound)
+	}
+}
+
+func TestInMemoryRevisionRepository_Prune(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tests := []struct {
+		name   string
+		keep   int
+		before time.Time
+		want   []int64
+	}{
+		{name: "Keep Newest", keep: 2, before: now, want: []int64{4, 3}},
+		{name: "Keep Young", keep: 1, before: now.Add(-210 * time.Minute), want: []int64{4, 3, 2}},
+		{name: "Keep Newest Or Young", keep: 3, before: now.Add(-30 * time.Minute), want: []int64{4, 3, 2}},
+		{name: "Newest Is Always Kept", keep: 0, before: now, want: []int64{4}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := NewInMemoryRevisionRepository()
+			// One revision an hour, the newest an hour ago.
+			for i := 4; i > 0; i-- {
+				rev := &domain.Revision{BookmarkID: "b1", At: now.Add(-time.Duration(i) * time.Hour), Bookmark: &domain.Bookmark{ID: "b1"}}
+				if err := repo.Add(ctx, rev); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			pruned, err := repo.Prune(ctx, tt.keep, tt.before)
+			if err != nil {
+				t.Fatal(err)
+			}
+			list, _ := repo.List(ctx, "b1")
+			if got := revisionNumbers(list); !slices.Equal(got, tt.want) || pruned != 4-len(tt.want) {
+				t.Errorf("Prune() = %d, left %v, want %d, %v", pruned, got, 4-len(tt.want), tt.want)
+			}
+
+			// Numbers carry on after pruning.
+			rev := &domain.Revision{BookmarkID: "b1", At: now, Bookmark: &domain.Bookmark{ID: "b1"}}
+			if err := repo.Add(ctx, rev); err != nil || rev.Number != 5 {
+				t.Errorf("Add() after Prune() = %d, %v, want 5", rev.Number, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package persistence
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// InMemoryWebhookRepository keeps webhook subscriptions for the lifetime of
+// the process.
+type InMemoryWebhookRepository struct {
+	mu       sync.RWMutex
+	webhooks map[string]*domain.Webhook
+}
+
+func NewInMemoryWebhookRepository() *InMemoryWebhookRepository {
+	return &InMemoryWebhookRepository{
+		webhooks: make(map[string]*domain.Webhook),
+	}
+}
+
+func (r *InMemoryWebhookRepository) Create(_ context.Context, w *domain.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.webhooks[w.ID]; exists {
+		return fmt.Errorf("persistence.InMemoryWebhookRepository.Create: webhook with ID %s already exists", w.ID)
+	}
+	r.webhooks[w.ID] = w.Clone()
+	return nil
+}
+
+func (r *InMemoryWebhookRepository) GetByID(_ context.Context, id string) (*domain.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	w, ok := r.webhooks[id]
+	if !ok {
+		return nil, domain.ErrWebhookNotFound
+	}
+	return w.Clone(), nil
+}
+
+func (r *InMemoryWebhookRepository) List(_ context.Context) ([]*domain.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]*domain.Webhook, 0, len(r.webhooks))
+	for _, w := range r.webhooks {
+		list = append(list, w.Clone())
+	}
+	slices.SortFunc(list, func(a, b *domain.Webhook) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return list, nil
+}
+
+func (r *InMemoryWebhookRepository) Update(_ context.Context, w *domain.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[w.ID]; !ok {
+		return domain.ErrWebhookNotFound
+	}
+	r.webhooks[w.ID] = w.Clone()
+	return nil
+}
+
+func (r *InMemoryWebhookRepository) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[id]; !ok {
+		return domain.ErrWebhookNotFound
+	}
+	delete(r.webhooks, id)
+	return nil
+}
+
+// InMemoryWebhookDeliveryRepository keeps the delivery history of webhooks
+// for the lifetime of the process.
+type InMemoryWebhookDeliveryRepository struct {
+	mu         sync.RWMutex
+	deliveries map[string]*domain.WebhookDelivery
+}
+
+func NewInMemoryWebhookDeliveryRepository() *InMemoryWebhookDeliveryRepository {
+	return &InMemoryWebhookDeliveryRepository{
+		deliveries: make(map[string]*domain.WebhookDelivery),
+	}
+}
+
+func (r *InMemoryWebhookDeliveryRepository) Create(_ context.Context, d *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.deliveries[d.ID]; exists {
+		return fmt.Errorf("persistence.InMemoryWebhookDeliveryRepository.Create: delivery with ID %s already exists", d.ID)
+	}
+	r.deliveries[d.ID] = d.Clone()
+	return nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) GetByID(_ context.Context, webhookID, id string) (*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	d, ok := r.deliveries[id]
+	if !ok || d.WebhookID != webhookID {
+		return nil, domain.ErrWebhookDeliveryNotFound
+	}
+	return d.Clone(), nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) ListByWebhook(_ context.Context, webhookID string) ([]*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.byWebhook(webhookID), nil
+}
+
+// byWebhook returns copies of a webhook's deliveries, newest first. The
+// caller holds mu.
+func (r *InMemoryWebhookDeliveryRepository) byWebhook(webhookID string) []*domain.WebhookDelivery {
+	list := []*domain.WebhookDelivery{}
+	for _, d := range r.deliveries {
+		if d.WebhookID == webhookID {
+			list = append(list, d.Clone())
+		}
+	}
+	slices.SortFunc(list, func(a, b *domain.WebhookDelivery) int {
+		return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), cmp.Compare(b.ID, a.ID))
+	})
+	return list
+}
+
+func (r *InMemoryWebhookDeliveryRepository) Update(_ context.Context, d *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.deliveries[d.ID]; !ok {
+		return domain.ErrWebhookDeliveryNotFound
+	}
+	r.deliveries[d.ID] = d.Clone()
+	return nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) Due(_ context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var due []*domain.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status == domain.DeliveryPending && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	slices.SortFunc(due, func(a, b *domain.WebhookDelivery) int {
+		return cmp.Or(a.NextAttemptAt.Compare(*b.NextAttemptAt), a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+	due = due[:min(limit, len(due))]
+	for i, d := range due {
+		due[i] = d.Clone()
+	}
+	return due, nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) Trim(_ context.Context, webhookID string, keep int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, d := range r.byWebhook(webhookID) {
+		if i >= keep && d.Status != domain.DeliveryPending {
+			delete(r.deliveries, d.ID)
+		}
+	}
+	return nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) DeleteByWebhook(_ context.Context, webhookID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, d := range r.deliveries {
+		if d.WebhookID == webhookID {
+			delete(r.deliveries, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package persistence
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+
+// startSpan starts a span for a repository operation, named like
+// "InMemoryBookmarkRepository.GetByID". The time spent waiting for locks is
+// part of it.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) trace.Span {
+	_, span := otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+	return span
+}
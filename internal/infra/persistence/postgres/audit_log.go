@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// AuditLog keeps audit entries in the audit_log table of a Postgres
+// database. Several processes may append to it at once.
+type AuditLog struct {
+	db *sql.DB
+}
+
+func NewAuditLog(db *sql.DB) *AuditLog {
+	return &AuditLog{db: db}
+}
+
+const auditColumns = "seq, at, actor, asserted_actor, action, target, request_id, source_ip, user_agent, outcome, status, prev_hash, hash"
+
+func (l *AuditLog) Append(ctx context.Context, e *domain.AuditEntry) error {
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("persistence.AuditLog.Append: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Appends wait for each other, so that each is sealed to the one before.
+	// Readers are not blocked.
+	if _, err := tx.ExecContext(ctx, "LOCK TABLE audit_log IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+		return fmt.Errorf("persistence.AuditLog.Append: %w", err)
+	}
+	var seq int64
+	var prev string
+	err = tx.QueryRowContext(ctx, "SELECT seq, hash FROM audit_log ORDER BY seq DESC LIMIT 1").Scan(&seq, &prev)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("persistence.AuditLog.Append: %w", err)
+	}
+
+	e.Seq = seq + 1
+	e.Seal(prev)
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO audit_log ("+auditColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
+		e.Seq, e.At, e.Actor, e.AssertedActor, e.Action, e.Target, e.RequestID, e.SourceIP, e.UserAgent, string(e.Outcome), e.Status, e.PrevHash, e.Hash)
+	if err != nil {
+		return fmt.Errorf("persistence.AuditLog.Append: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("persistence.AuditLog.Append: %w", err)
+	}
+	return nil
+}
+
+func (l *AuditLog) Query(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	var where []string
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		where = append(where, strings.ReplaceAll(cond, "?", "$"+strconv.Itoa(len(args))))
+	}
+	if filter.Actor != "" {
+		add("actor = ?", filter.Actor)
+	}
+	if filter.AssertedActor != "" {
+		add("asserted_actor = ?", filter.AssertedActor)
+	}
+	if filter.Action != "" {
+		add("action = ?", filter.Action)
+	}
+	if filter.Target != "" {
+		add("target = ?", filter.Target)
+	}
+	if filter.Outcome != "" {
+		add("outcome = ?", string(filter.Outcome))
+	}
+	if !filter.Since.IsZero() {
+		add("at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		add("at < ?", filter.Until)
+	}
+	if filter.Before != 0 {
+		add("seq < ?", filter.Before)
+	}
+
+	query := "SELECT " + auditColumns + " FROM audit_log"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	args = append(args, filter.Limit)
+	query += " ORDER BY seq DESC LIMIT $" + strconv.Itoa(len(args))
+
+	entries, err := l.query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("persistence.AuditLog.Query: %w", err)
+	}
+	return entries, nil
+}
+
+func (l *AuditLog) Range(ctx context.Context, after int64, limit int) ([]*domain.AuditEntry, error) {
+	entries, err := l.query(ctx, "SELECT "+auditColumns+" FROM audit_log WHERE seq > $1 ORDER BY seq LIMIT $2", after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("persistence.AuditLog.Range: %w", err)
+	}
+	return entries, nil
+}
+
+var _ domain.HealthChecker = (*AuditLog)(nil)
+
+func (l *AuditLog) CheckHealth(ctx context.Context) error {
+	if err := l.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("persistence.AuditLog.CheckHealth: %w", err)
+	}
+	return nil
+}
+
+func (l *AuditLog) query(ctx context.Context, query string, args ...any) ([]*domain.AuditEntry, error) {
+	rows, err := l.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*domain.AuditEntry{}
+	for rows.Next() {
+		var e domain.AuditEntry
+		var outcome string
+		err := rows.Scan(&e.Seq, &e.At, &e.Actor, &e.AssertedActor, &e.Action, &e.Target, &e.RequestID, &e.SourceIP, &e.UserAgent, &outcome, &e.Status, &e.PrevHash, &e.Hash)
+		if err != nil {
+			return nil, err
+		}
+		e.Outcome = domain.AuditOutcome(outcome)
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,254 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+)
+
+// testDB connects to the database named by TEST_DATABASE_URL, in a schema
+// of its own holding a freshly migrated audit_log, which is dropped when
+// the test ends. Tests using it are skipped when the variable is unset.
+func testDB(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		admin.Close()
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.Exec("DROP SCHEMA " + schema + " CASCADE"); err != nil {
+			t.Errorf("dropping schema %s: %v", schema, err)
+		}
+		admin.Close()
+	})
+
+	db, err := sql.Open("postgres", withSearchPath(dsn, schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	up, err := os.ReadFile("migrations/000002_audit_log.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(string(up)); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// withSearchPath makes every connection opened with dsn use schema.
+func withSearchPath(dsn, schema string) string {
+	if u, err := url.Parse(dsn); err == nil && (u.Scheme == "postgres" || u.Scheme == "postgresql") {
+		q := u.Query()
+		q.Set("search_path", schema)
+		u.RawQuery = q.Encode()
+		return u.String()
+	}
+	return dsn + " search_path=" + schema
+}
+
+func auditSeqs(entries []*domain.AuditEntry) []int64 {
+	seqs := make([]int64, len(entries))
+	for i, e := range entries {
+		seqs[i] = e.Seq
+	}
+	return seqs
+}
+
+// appendEntries appends one entry per asserted actor, a minute apart from
+// start: the first two to /bookmarks/2, the others to /bookmarks/1. Those
+// of mallory are denied deletes.
+func appendEntries(t *testing.T, l *AuditLog, start time.Time, actors ...string) {
+	t.Helper()
+	for i, actor := range actors {
+		target := "/bookmarks/1"
+		if i < 2 {
+			target = "/bookmarks/2"
+		}
+		e := &domain.AuditEntry{
+			At:            start.Add(time.Duration(i) * time.Minute),
+			Actor:         "anonymous",
+			AssertedActor: actor,
+			Action:        "PUT /bookmarks/{id}",
+			Target:        target,
+			SourceIP:      "192.0.2.1",
+			Outcome:       domain.AuditSuccess,
+			Status:        200,
+		}
+		if actor == "mallory" {
+			e.Action, e.Outcome, e.Status = "DELETE /bookmarks/{id}", domain.AuditDenied, 401
+		}
+		if err := l.Append(context.Background(), e); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAuditLog_Append(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	l := NewAuditLog(testDB(t))
+	start := time.Now().UTC().Truncate(time.Microsecond)
+	appendEntries(t, l, start, "alice", "bob")
+
+	// Appends from several connections at once still make one chain.
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			e := &domain.AuditEntry{At: start, Actor: "anonymous", Action: "POST /bookmarks", Target: "/bookmarks", Outcome: domain.AuditSuccess, Status: 201}
+			if err := l.Append(ctx, e); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+
+	all, err := l.Range(ctx, 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := auditSeqs(all), []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}; !slices.Equal(got, want) {
+		t.Fatalf("Range() seqs = %v, want %v", got, want)
+	}
+	if all[0].PrevHash != "" {
+		t.Errorf("first entry PrevHash = %q, want empty", all[0].PrevHash)
+	}
+	for i, e := range all {
+		if i > 0 && e.PrevHash != all[i-1].Hash {
+			t.Errorf("entry %d PrevHash = %q, want %q", e.Seq, e.PrevHash, all[i-1].Hash)
+		}
+		// Read back, the entry still hashes as it did when sealed.
+		if e.Hash != e.Digest() {
+			t.Errorf("entry %d Hash does not match its digest", e.Seq)
+		}
+	}
+	if got := all[0]; got.AssertedActor != "alice" || !got.At.Equal(start) {
+		t.Errorf("first entry = %+v, want asserted actor alice at %v", got, start)
+	}
+
+	page, err := l.Range(ctx, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := auditSeqs(page), []int64{3, 4, 5}; !slices.Equal(got, want) {
+		t.Errorf("Range(2, 3) seqs = %v, want %v", got, want)
+	}
+}
+
+func TestAuditLog_Query(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	l := NewAuditLog(testDB(t))
+	start := time.Now().UTC().Truncate(time.Microsecond)
+	appendEntries(t, l, start, "alice", "bob", "alice", "mallory", "alice")
+
+	tests := []struct {
+		name   string
+		filter domain.AuditFilter
+		want   []int64
+	}{
+		{name: "All", filter: domain.AuditFilter{Limit: 10}, want: []int64{5, 4, 3, 2, 1}},
+		{name: "Actor", filter: domain.AuditFilter{Actor: "anonymous", Limit: 10}, want: []int64{5, 4, 3, 2, 1}},
+		{name: "Asserted Actor", filter: domain.AuditFilter{AssertedActor: "alice", Limit: 10}, want: []int64{5, 3, 1}},
+		{name: "Action", filter: domain.AuditFilter{Action: "DELETE /bookmarks/{id}", Limit: 10}, want: []int64{4}},
+		{name: "Target", filter: domain.AuditFilter{Target: "/bookmarks/1", Limit: 10}, want: []int64{5, 4, 3}},
+		{name: "Outcome", filter: domain.AuditFilter{Outcome: domain.AuditDenied, Limit: 10}, want: []int64{4}},
+		{name: "Since", filter: domain.AuditFilter{Since: start.Add(3 * time.Minute), Limit: 10}, want: []int64{5, 4}},
+		{name: "Until", filter: domain.AuditFilter{Until: start.Add(time.Minute), Limit: 10}, want: []int64{1}},
+		{name: "Limit", filter: domain.AuditFilter{AssertedActor: "alice", Limit: 2}, want: []int64{5, 3}},
+		{name: "Before", filter: domain.AuditFilter{AssertedActor: "alice", Before: 5, Limit: 10}, want: []int64{3, 1}},
+		{
+			name:   "Combined",
+			filter: domain.AuditFilter{AssertedActor: "alice", Target: "/bookmarks/1", Since: start.Add(time.Minute), Before: 5, Limit: 10},
+			want:   []int64{3},
+		},
+		{name: "No Match", filter: domain.AuditFilter{AssertedActor: "carol", Limit: 10}, want: []int64{}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			entries, err := l.Query(ctx, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if entries == nil {
+				t.Error("Query() = nil, want an empty page")
+			}
+			if got := auditSeqs(entries); !slices.Equal(got, tt.want) {
+				t.Errorf("Query() seqs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditLog_Verify(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := testDB(t)
+	l := NewAuditLog(db)
+	svc := service.NewAuditService(l, service.AuditOptions{})
+	for _, actor := range []string{"alice", "bob", "carol", "dave"} {
+		e := &domain.AuditEntry{Actor: "anonymous", AssertedActor: actor, Action: "POST /bookmarks", Target: "/bookmarks", Outcome: domain.AuditSuccess, Status: 201}
+		if err := svc.Record(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := svc.Verify(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (domain.AuditVerification{Valid: true, Checked: 4}); *got != want {
+		t.Errorf("Verify() = %+v, want %+v", *got, want)
+	}
+
+	if _, err := db.Exec("UPDATE audit_log SET asserted_actor = 'mallory' WHERE seq = 2"); err == nil {
+		t.Error("updating an entry succeeded, want it refused")
+	}
+	if _, err := db.Exec("DELETE FROM audit_log WHERE seq = 2"); err == nil {
+		t.Error("deleting an entry succeeded, want it refused")
+	}
+
+	// Changed around the trigger, the entry no longer fits the chain.
+	tamper := "ALTER TABLE audit_log DISABLE TRIGGER audit_log_append_only;" +
+		"UPDATE audit_log SET asserted_actor = 'mallory' WHERE seq = 2;" +
+		"ALTER TABLE audit_log ENABLE TRIGGER audit_log_append_only"
+	if _, err := db.Exec(tamper); err != nil {
+		t.Fatal(err)
+	}
+	got, err = svc.Verify(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (domain.AuditVerification{Checked: 1, BrokenAt: 2}); *got != want {
+		t.Errorf("Verify() after tampering = %+v, want %+v", *got, want)
+	}
+}
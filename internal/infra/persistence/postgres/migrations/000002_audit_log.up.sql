@@ -0,0 +1,32 @@
+-- Entries are only ever appended. The trigger refuses changes made through
+-- SQL; the hash chain reveals those made around it.
+CREATE TABLE IF NOT EXISTS audit_log (
+    seq BIGINT PRIMARY KEY,
+    at TIMESTAMP WITH TIME ZONE NOT NULL,
+    actor TEXT NOT NULL,
+    asserted_actor TEXT NOT NULL DEFAULT '',
+    action TEXT NOT NULL,
+    target TEXT NOT NULL,
+    request_id TEXT NOT NULL DEFAULT '',
+    source_ip TEXT NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    outcome TEXT NOT NULL,
+    status INTEGER NOT NULL,
+    prev_hash TEXT NOT NULL,
+    hash TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log (actor, seq);
+CREATE INDEX IF NOT EXISTS idx_audit_log_asserted_actor ON audit_log (asserted_actor, seq);
+CREATE INDEX IF NOT EXISTS idx_audit_log_target ON audit_log (target, seq);
+CREATE INDEX IF NOT EXISTS idx_audit_log_at ON audit_log (at);
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
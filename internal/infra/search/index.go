@@ -0,0 +1,337 @@
+// Package search keeps a full-text index of bookmarks in memory and ranks
+// matches with BM25.
+package search
+
+import (
+	"cmp"
+	"context"
+	"html"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// fieldBoosts weighs a match by the field it is in. Their order is the order
+// of a hit's snippets.
+var fieldBoosts = []struct {
+	name  string
+	boost float64
+}{
+	{domain.FieldTitle, 3},
+	{domain.FieldTags, 2},
+	{domain.FieldDescription, 1.5},
+	{domain.FieldURL, 1},
+	{domain.FieldContent, 1},
+}
+
+// BM25 parameters, at their customary values.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+const (
+	// snippetWords is how many words of a long field a snippet shows, and
+	// snippetLead how many of them come before the first match.
+	snippetWords = 30
+	snippetLead  = 8
+)
+
+// Index implements domain.SearchIndex in memory.
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[string]map[string]*field   // by bookmark ID, then field name
+	postings map[string]map[string]struct{} // IDs of the bookmarks containing each term
+	stats    map[string]*fieldStats         // by field name
+}
+
+// fieldStats gives the average length of a field across bookmarks.
+type fieldStats struct {
+	docs, words int
+}
+
+func (s *fieldStats) avgWords() float64 {
+	if s == nil || s.docs == 0 {
+		return 1
+	}
+	return float64(s.words) / float64(s.docs)
+}
+
+type field struct {
+	text   string
+	tokens []token
+	freq   map[string]int
+}
+
+var _ domain.SearchIndex = (*Index)(nil)
+
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[string]map[string]*field),
+		postings: make(map[string]map[string]struct{}),
+		stats:    make(map[string]*fieldStats),
+	}
+}
+
+func (x *Index) IndexMetadata(_ context.Context, bm *domain.Bookmark) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.update(bm.ID, map[string]string{
+		domain.FieldTitle:       bm.Title,
+		domain.FieldURL:         bm.URL,
+		domain.FieldDescription: bm.Description,
+		domain.FieldTags:        strings.Join(bm.Tags, ", "),
+	})
+	return nil
+}
+
+func (x *Index) IndexContent(_ context.Context, id, text string) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.update(id, map[string]string{domain.FieldContent: text})
+	return nil
+}
+
+func (x *Index) Remove(_ context.Context, id string) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	doc := x.docs[id]
+	for name := range doc {
+		x.setField(id, doc, name, "")
+	}
+	delete(x.docs, id)
+	return nil
+}
+
+// update replaces the given fields of bookmark id, leaving its other fields
+// as they are.
+func (x *Index) update(id string, fields map[string]string) {
+	doc := x.docs[id]
+	if doc == nil {
+		doc = make(map[string]*field)
+		x.docs[id] = doc
+	}
+	for name, text := range fields {
+		x.setField(id, doc, name, text)
+	}
+	if len(doc) == 0 {
+		delete(x.docs, id)
+	}
+}
+
+func (x *Index) setField(id string, doc map[string]*field, name, text string) {
+	old := doc[name]
+	if (old == nil && text == "") || (old != nil && old.text == text) {
+		return
+	}
+	stats := x.stats[name]
+	if stats == nil {
+		stats = &fieldStats{}
+		x.stats[name] = stats
+	}
+	if old != nil {
+		stats.docs--
+		stats.words -= len(old.tokens)
+		delete(doc, name)
+	}
+
+	var f *field
+	if text != "" {
+		f = &field{text: text, tokens: tokenize(text), freq: make(map[string]int)}
+		for _, tok := range f.tokens {
+			f.freq[tok.term]++
+		}
+		doc[name] = f
+		stats.docs++
+		stats.words += len(f.tokens)
+	}
+
+	// Terms the field lost stay posted if another field still has them.
+	if old != nil {
+		for term := range old.freq {
+			if !hasTerm(doc, term) {
+				delete(x.postings[term], id)
+				if len(x.postings[term]) == 0 {
+					delete(x.postings, term)
+				}
+			}
+		}
+	}
+	if f != nil {
+		for term := range f.freq {
+			ids := x.postings[term]
+			if ids == nil {
+				ids = make(map[string]struct{})
+				x.postings[term] = ids
+			}
+			ids[id] = struct{}{}
+		}
+	}
+}
+
+func hasTerm(doc map[string]*field, term string) bool {
+	for _, f := range doc {
+		if f.freq[term] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Search returns the bookmarks that contain every word of query, in any of
+// their fields. A limit of zero or less returns every match.
+func (x *Index) Search(_ context.Context, query string, limit int) ([]domain.SearchHit, error) {
+	words := terms(query)
+	if len(words) == 0 {
+		return []domain.SearchHit{}, nil
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	// Intersect postings starting from the rarest term.
+	slices.SortFunc(words, func(a, b string) int {
+		return cmp.Compare(len(x.postings[a]), len(x.postings[b]))
+	})
+	var ids []string
+	for id := range x.postings[words[0]] {
+		if x.hasAll(id, words[1:]) {
+			ids = append(ids, id)
+		}
+	}
+
+	hits := make([]domain.SearchHit, 0, len(ids))
+	for _, id := range ids {
+		hits = append(hits, domain.SearchHit{ID: id, Score: x.score(id, words)})
+	}
+	slices.SortFunc(hits, func(a, b domain.SearchHit) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	// Snippets are only built for the hits returned.
+	want := make(map[string]bool, len(words))
+	for _, w := range words {
+		want[w] = true
+	}
+	for i := range hits {
+		hits[i].Snippets = x.snippets(hits[i].ID, want)
+	}
+	return hits, nil
+}
+
+func (x *Index) hasAll(id string, words []string) bool {
+	for _, w := range words {
+		if _, ok := x.postings[w][id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// score sums the BM25 score of every query word over the fields of bookmark
+// id, weighed by fieldBoosts.
+func (x *Index) score(id string, words []string) float64 {
+	doc := x.docs[id]
+	n := float64(len(x.docs))
+	var score float64
+	for _, w := range words {
+		df := float64(len(x.postings[w]))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for _, fb := range fieldBoosts {
+			f := doc[fb.name]
+			if f == nil || f.freq[w] == 0 {
+				continue
+			}
+			tf := float64(f.freq[w])
+			norm := 1 - bm25B + bm25B*float64(len(f.tokens))/x.stats[fb.name].avgWords()
+			score += fb.boost * idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+	}
+	return score
+}
+
+func (x *Index) snippets(id string, words map[string]bool) []domain.Snippet {
+	var out []domain.Snippet
+	for _, fb := range fieldBoosts {
+		if f := x.docs[id][fb.name]; f != nil {
+			if text, ok := snippet(f, words); ok {
+				out = append(out, domain.Snippet{Field: fb.name, Text: text})
+			}
+		}
+	}
+	return out
+}
+
+// snippet excerpts the snippetWords words of f that cover the most distinct
+// query words, escapes them as HTML and marks the matches. It reports false
+// when f matches none of words.
+func snippet(f *field, words map[string]bool) (string, bool) {
+	var matches []int
+	for i, tok := range f.tokens {
+		if words[tok.term] {
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	from, to := 0, len(f.tokens)
+	if len(f.tokens) > snippetWords {
+		best := -1
+		for _, m := range matches {
+			start := max(0, min(m-snippetLead, len(f.tokens)-snippetWords))
+			seen := make(map[string]bool)
+			for _, i := range matches {
+				if i >= start && i < start+snippetWords {
+					seen[f.tokens[i].term] = true
+				}
+			}
+			if len(seen) > best {
+				best, from = len(seen), start
+			}
+		}
+		to = from + snippetWords
+	}
+
+	var sb strings.Builder
+	start := f.tokens[from].start
+	if from == 0 {
+		start = 0
+	} else {
+		sb.WriteString("…")
+	}
+	end := f.tokens[to-1].end
+	if to == len(f.tokens) {
+		end = len(f.text)
+	}
+
+	pos := start
+	for _, tok := range f.tokens[from:to] {
+		if !words[tok.term] {
+			continue
+		}
+		sb.WriteString(html.EscapeString(f.text[pos:tok.start]))
+		sb.WriteString("<mark>")
+		sb.WriteString(html.EscapeString(f.text[tok.start:tok.end]))
+		sb.WriteString("</mark>")
+		pos = tok.end
+	}
+	sb.WriteString(html.EscapeString(f.text[pos:end]))
+	if to < len(f.tokens) {
+		sb.WriteString("…")
+	}
+	return strings.Join(strings.Fields(sb.String()), " "), true
+}
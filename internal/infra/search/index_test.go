@@ -0,0 +1,157 @@
+package search
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ids(hits []domain.SearchHit) []string {
+	out := make([]string, len(hits))
+	for i, h := range hits {
+		out[i] = h.ID
+	}
+	return out
+}
+
+func newTestIndex(t *testing.T) *Index {
+	t.Helper()
+
+	ctx := context.Background()
+	x := NewIndex()
+	require.NoError(t, x.IndexMetadata(ctx, &domain.Bookmark{
+		ID: "spec", URL: "https://go.dev/ref/spec", Title: "The Go Programming Language Specification",
+		Tags: []string{"go", "reference"},
+	}))
+	require.NoError(t, x.IndexMetadata(ctx, &domain.Bookmark{
+		ID: "channels", URL: "https://example.com/posts/channels", Title: "Understanding channels",
+		Description: "A tour of Go's concurrency primitives.",
+	}))
+	require.NoError(t, x.IndexContent(ctx, "channels",
+		"Channels are the pipes that connect concurrent goroutines. "+strings.Repeat("Filler text about nothing in particular. ", 20)+
+			"A buffered channel accepts values without a receiver, up to its capacity."))
+	require.NoError(t, x.IndexMetadata(ctx, &domain.Bookmark{
+		ID: "cafe", URL: "https://example.com/cafe", Title: "Café <Review>",
+	}))
+	return x
+}
+
+func TestIndex_Search(t *testing.T) {
+	t.Parallel()
+
+	x := newTestIndex(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"Title", "specification", []string{"spec"}},
+		{"Case Insensitive", "UNDERSTANDING", []string{"channels"}},
+		{"Content", "goroutines", []string{"channels"}},
+		{"Every Word Must Match", "buffered goroutines", []string{"channels"}},
+		{"Words Across Fields", "channels concurrency", []string{"channels"}},
+		{"No Match For One Word", "buffered specification", []string{}},
+		{"Accents Ignored", "cafe", []string{"cafe"}},
+		{"Tag", "reference", []string{"spec"}},
+		{"Title Ranks Above Content", "go", []string{"spec", "channels"}},
+		{"Empty Query", "  ", []string{}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			hits, err := x.Search(ctx, tt.query, 0)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ids(hits))
+		})
+	}
+
+	t.Run("Limit", func(t *testing.T) {
+		t.Parallel()
+
+		hits, err := x.Search(ctx, "go", 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"spec"}, ids(hits))
+	})
+}
+
+func TestIndex_Snippets(t *testing.T) {
+	t.Parallel()
+
+	x := newTestIndex(t)
+	ctx := context.Background()
+
+	hits, err := x.Search(ctx, "buffered channel", 0)
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	require.Len(t, hits[0].Snippets, 1, "the title has channels, not channel")
+
+	content := hits[0].Snippets[0]
+	assert.Equal(t, domain.FieldContent, content.Field)
+	assert.True(t, strings.HasPrefix(content.Text, "…"), content.Text)
+	assert.True(t, strings.HasSuffix(content.Text, "capacity."), content.Text)
+	assert.Contains(t, content.Text, "A <mark>buffered</mark> <mark>channel</mark> accepts")
+
+	hits, err = x.Search(ctx, "understanding goroutines", 0)
+	require.NoError(t, err)
+	require.Len(t, hits[0].Snippets, 2)
+	assert.Equal(t, domain.Snippet{Field: domain.FieldTitle, Text: "<mark>Understanding</mark> channels"}, hits[0].Snippets[0])
+	assert.True(t, strings.HasPrefix(hits[0].Snippets[1].Text, "Channels are the pipes that connect concurrent <mark>goroutines</mark>."))
+
+	hits, err = x.Search(ctx, "cafe", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "<mark>Café</mark> &lt;Review&gt;", hits[0].Snippets[0].Text)
+}
+
+func TestIndex_IndependentUpdates(t *testing.T) {
+	t.Parallel()
+
+	x := newTestIndex(t)
+	ctx := context.Background()
+	search := func(q string) []string {
+		hits, err := x.Search(ctx, q, 0)
+		require.NoError(t, err)
+		return ids(hits)
+	}
+
+	// New metadata keeps the content.
+	require.NoError(t, x.IndexMetadata(ctx, &domain.Bookmark{ID: "channels", URL: "https://example.com/c", Title: "Go channels", Description: "Notes"}))
+	assert.Equal(t, []string{"channels"}, search("goroutines"))
+	assert.Empty(t, search("understanding"))
+
+	// New content keeps the metadata.
+	require.NoError(t, x.IndexContent(ctx, "channels", "Select statements wait on several channels."))
+	assert.Empty(t, search("goroutines"))
+	assert.Equal(t, []string{"channels"}, search("select"))
+	assert.Equal(t, []string{"channels"}, search("notes"))
+
+	// A word in both fields stays indexed while either has it.
+	assert.Equal(t, []string{"channels"}, search("channels"))
+	require.NoError(t, x.IndexContent(ctx, "channels", ""))
+	assert.Equal(t, []string{"channels"}, search("channels"))
+
+	require.NoError(t, x.Remove(ctx, "channels"))
+	assert.Empty(t, search("channels"))
+	assert.Empty(t, x.docs["channels"])
+	assert.NotContains(t, x.postings, "channels")
+}
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"don", "t", "panic", "42"}, terms("Don't PANIC: 42!"))
+	assert.Equal(t, []string{"creme", "brulee"}, terms("Crème brûlée"))
+	assert.Equal(t, []string{"go", "言", "語"}, terms("Go言語"))
+
+	tokens := tokenize("¡Hola, señor!")
+	require.Len(t, tokens, 2)
+	assert.Equal(t, "señor", "¡Hola, señor!"[tokens[1].start:tokens[1].end])
+}
@@ -0,0 +1,84 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// token is one word of a field: its normalized term and where it appears in
+// the original text, as byte offsets.
+type token struct {
+	term       string
+	start, end int
+}
+
+// tokenize splits text into words of letters and digits. Han, Hiragana and
+// Katakana characters are words of their own, since those scripts do not
+// separate words with spaces.
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			tokens = append(tokens, token{term: normalize(text[start:end]), start: start, end: end})
+			start = -1
+		}
+	}
+
+	for i, r := range text {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+			flush(i)
+			size := utf8.RuneLen(r)
+			tokens = append(tokens, token{term: string(r), start: i, end: i + size})
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r):
+			if start < 0 {
+				start = i
+			}
+		default:
+			flush(i)
+		}
+	}
+	flush(len(text))
+	return tokens
+}
+
+// terms returns the distinct normalized words of text, in order.
+func terms(text string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, tok := range tokenize(text) {
+		if !seen[tok.term] {
+			seen[tok.term] = true
+			out = append(out, tok.term)
+		}
+	}
+	return out
+}
+
+// normalize lowercases a word and strips its accents, so that "Café" matches
+// "cafe".
+func normalize(word string) string {
+	if isASCII(word) {
+		return strings.ToLower(word)
+	}
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), word)
+	if err != nil {
+		folded = word
+	}
+	return strings.ToLower(folded)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+// Package thumbnail makes small previews of JPEG, PNG and GIF images.
+package thumbnail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	_ "image/gif" // register the GIF decoder
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+type Options struct {
+	// MaxSize bounds the preview's width and height. Defaults to 256.
+	MaxSize int
+	// MaxPixels refuses images larger than this before decoding them, since
+	// a small file can declare enormous dimensions. Defaults to 50 million.
+	MaxPixels int
+	// MaxBytes bounds the image file read. Defaults to 50MB.
+	MaxBytes int64
+}
+
+// Thumbnailer implements domain.Thumbnailer. Previews of opaque images are
+// JPEGs and the rest PNGs, so that transparency survives.
+type Thumbnailer struct {
+	opts Options
+}
+
+var _ domain.Thumbnailer = (*Thumbnailer)(nil)
+
+func New(opts Options) *Thumbnailer {
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = 256
+	}
+	if opts.MaxPixels <= 0 {
+		opts.MaxPixels = 50_000_000
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = 50 << 20
+	}
+	return &Thumbnailer{opts: opts}
+}
+
+func (t *Thumbnailer) Thumbnail(ctx context.Context, w io.Writer, r io.Reader) (string, image.Point, error) {
+	data, err := io.ReadAll(io.LimitReader(r, t.opts.MaxBytes+1))
+	if err != nil {
+		return "", image.Point{}, fmt.Errorf("thumbnail.Thumbnail: %w", err)
+	}
+	if int64(len(data)) > t.opts.MaxBytes {
+		return "", image.Point{}, fmt.Errorf("thumbnail.Thumbnail: %w: more than %d bytes", domain.ErrNotAnImage, t.opts.MaxBytes)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", image.Point{}, fmt.Errorf("thumbnail.Thumbnail: %w: %v", domain.ErrNotAnImage, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > t.opts.MaxPixels {
+		return "", image.Point{}, fmt.Errorf("thumbnail.Thumbnail: %w: %dx%d pixels", domain.ErrNotAnImage, cfg.Width, cfg.Height)
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", image.Point{}, fmt.Errorf("thumbnail.Thumbnail: %w: %v", domain.ErrNotAnImage, err)
+	}
+	if err := ctx.Err(); err != nil {
+		return "", image.Point{}, fmt.Errorf("thumbnail.Thumbnail: %w", err)
+	}
+
+	thumb := Resize(src, fit(src.Bounds().Size(), t.opts.MaxSize))
+	contentType := "image/png"
+	if thumb.Opaque() {
+		contentType = "image/jpeg"
+		err = jpeg.Encode(w, thumb, &jpeg.Options{Quality: 85})
+	} else {
+		err = png.Encode(w, thumb)
+	}
+	if err != nil {
+		return "", image.Point{}, fmt.Errorf("thumbnail.Thumbnail: %w", err)
+	}
+	return contentType, thumb.Bounds().Size(), nil
+}
+
+// fit scales size down to fit within a max by max square, keeping its aspect
+// ratio. Smaller images keep their size.
+func fit(size image.Point, max int) image.Point {
+	if size.X <= max && size.Y <= max {
+		return size
+	}
+	if size.X >= size.Y {
+		return image.Pt(max, (size.Y*max+size.X/2)/size.X)
+	}
+	return image.Pt((size.X*max+size.Y/2)/size.Y, max)
+}
+
+// Resize scales src to size by averaging the source pixels that each target
+// pixel covers, which keeps detail when shrinking by large factors.
+func Resize(src image.Image, size image.Point) *image.NRGBA {
+	size.X, size.Y = max(size.X, 1), max(size.Y, 1)
+
+	// Converting up front lets the loop below read pixels directly; draw has
+	// fast paths for the decoders' own image types.
+	b := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, size.X, size.Y))
+	sw, sh := b.Dx(), b.Dy()
+	for y := range size.Y {
+		y0, y1 := y*sh/size.Y, max((y+1)*sh/size.Y, y*sh/size.Y+1)
+		for x := range size.X {
+			x0, x1 := x*sw/size.X, max((x+1)*sw/size.X, x*sw/size.X+1)
+
+			// Sums are of premultiplied values, so transparent pixels do not
+			// darken the edges of what they border.
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride+x0*4 : sy*rgba.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					r += uint64(row[i])
+					g += uint64(row[i+1])
+					bl += uint64(row[i+2])
+					a += uint64(row[i+3])
+				}
+				n += uint64(x1 - x0)
+			}
+
+			i := dst.PixOffset(x, y)
+			if a == 0 {
+				continue
+			}
+			dst.Pix[i] = uint8(min(r*255/a, 255))
+			dst.Pix[i+1] = uint8(min(g*255/a, 255))
+			dst.Pix[i+2] = uint8(min(bl*255/a, 255))
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
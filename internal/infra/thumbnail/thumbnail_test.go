@@ -0,0 +1,90 @@
+package thumbnail
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encode(t *testing.T, img image.Image, format string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		require.NoError(t, jpeg.Encode(&buf, img, nil))
+	} else {
+		require.NoError(t, png.Encode(&buf, img))
+	}
+	return buf.Bytes()
+}
+
+func TestThumbnail(t *testing.T) {
+	t.Parallel()
+
+	photo := image.NewRGBA(image.Rect(0, 0, 1000, 500))
+	for y := range 500 {
+		for x := range 1000 {
+			photo.Set(x, y, color.RGBA{uint8(x / 4), uint8(y / 2), 128, 255})
+		}
+	}
+	logo := image.NewNRGBA(image.Rect(0, 0, 300, 600))
+	logo.Set(10, 10, color.NRGBA{255, 0, 0, 255})
+
+	tests := []struct {
+		name     string
+		data     []byte
+		opts     Options
+		wantType string
+		wantSize image.Point
+		wantErr  error
+	}{
+		{"Opaque Photo", encode(t, photo, "jpeg"), Options{}, "image/jpeg", image.Pt(256, 128), nil},
+		{"Transparent Logo", encode(t, logo, "png"), Options{MaxSize: 100}, "image/png", image.Pt(50, 100), nil},
+		{"Small Image Kept", encode(t, logo, "png"), Options{MaxSize: 1000}, "image/png", image.Pt(300, 600), nil},
+		{"Not An Image", []byte("%PDF-1.7"), Options{}, "", image.Point{}, domain.ErrNotAnImage},
+		{"Too Many Pixels", encode(t, logo, "png"), Options{MaxPixels: 1000}, "", image.Point{}, domain.ErrNotAnImage},
+		{"Too Many Bytes", encode(t, photo, "png"), Options{MaxBytes: 100}, "", image.Point{}, domain.ErrNotAnImage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			contentType, size, err := New(tt.opts).Thumbnail(context.Background(), &out, bytes.NewReader(tt.data))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantType, contentType)
+			assert.Equal(t, tt.wantSize, size)
+
+			img, format, err := image.Decode(&out)
+			require.NoError(t, err)
+			assert.Equal(t, strings.TrimPrefix(tt.wantType, "image/"), format)
+			assert.Equal(t, tt.wantSize, img.Bounds().Size())
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	t.Parallel()
+
+	// One red pixel among three transparent ones averages to a faint red,
+	// not a faint dark red.
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	src.Set(0, 0, color.NRGBA{255, 0, 0, 255})
+
+	got := Resize(src, image.Pt(1, 1)).NRGBAAt(0, 0)
+	assert.Equal(t, color.NRGBA{255, 0, 0, 63}, got)
+}
@@ -0,0 +1,23 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// ActorHeader names who is making a request, for revisions and events.
+// The API has no accounts, so it is taken on trust.
+const ActorHeader = "X-Actor"
+
+// IdentifyActor attributes the changes a request makes to the caller named
+// in its ActorHeader, or to "anonymous".
+func IdentifyActor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := r.Header.Get(ActorHeader)
+		if actor == "" {
+			actor = "anonymous"
+		}
+		next.ServeHTTP(w, r.WithContext(domain.WithActor(r.Context(), actor)))
+	})
+}
@@ -0,0 +1,89 @@
+package rest
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/archive"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/service"
+)
+
+// archiveCSP keeps archived pages inert: scripts do not run, the page gets an
+// opaque origin so it cannot act on behalf of the API, and nothing loads from
+// outside the archive.
+const archiveCSP = "sandbox; default-src 'self' data: 'unsafe-inline'; script-src 'none'; form-action 'none'"
+
+// ArchiveHandler serves the /bookmarks/{id}/archive endpoints. A nil service
+// means archiving is disabled.
+type ArchiveHandler struct {
+	svc service.ArchiveService
+}
+
+func NewArchiveHandler(svc service.ArchiveService) *ArchiveHandler {
+	return &ArchiveHandler{svc: svc}
+}
+
+// GetBookmarkArchive handles GET /bookmarks/{id}/archive
+func (h *ArchiveHandler) GetBookmarkArchive(w http.ResponseWriter, r *http.Request, id string, params gen.GetBookmarkArchiveParams) {
+	if h.svc == nil {
+		http.Error(w, "Archiving is disabled", http.StatusNotFound)
+		return
+	}
+
+	var target string
+	if params.Url != nil {
+		target = *params.Url
+	}
+	res, err := h.svc.Resource(r.Context(), id, target)
+	if errors.Is(err, domain.ErrArchiveNotFound) {
+		http.Error(w, "Archive not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	body := res.Body
+	mediaType, _, _ := mime.ParseMediaType(res.ContentType)
+	switch mediaType {
+	case "text/html", "application/xhtml+xml":
+		body = archive.RewriteHTML(body, res.URL, archive.ArchiveURL)
+	case "text/css":
+		body = archive.RewriteCSS(body, res.URL, archive.ArchiveURL)
+	}
+
+	contentType := res.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Security-Policy", archiveCSP)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if _, err := w.Write(body); err != nil {
+		domain.LoggerFrom(r.Context()).Error("Error writing archived resource", "error", err)
+	}
+}
+
+// ArchiveBookmark handles POST /bookmarks/{id}/archive
+func (h *ArchiveHandler) ArchiveBookmark(w http.ResponseWriter, r *http.Request, id string) {
+	if h.svc == nil {
+		http.Error(w, "Archiving is disabled", http.StatusNotFound)
+		return
+	}
+
+	err := h.svc.Request(r.Context(), id)
+	switch {
+	case errors.Is(err, domain.ErrBookmarkNotFound):
+		http.Error(w, "Bookmark not found", http.StatusNotFound)
+	case errors.Is(err, service.ErrArchiveQueueFull):
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
@@ -0,0 +1,166 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestArchiveHandler_GetBookmarkArchive(t *testing.T) {
+	t.Parallel()
+
+	page := &domain.ArchivedResource{
+		URL:         "https://example.com/post",
+		StatusCode:  http.StatusOK,
+		ContentType: "text/html; charset=utf-8",
+		Body:        []byte(`<img src="/cover.png"><a href="/about">About</a>`),
+	}
+	image := &domain.ArchivedResource{
+		URL:         "https://example.com/cover.png",
+		StatusCode:  http.StatusOK,
+		ContentType: "image/png",
+		Body:        []byte("png"),
+	}
+
+	tests := []struct {
+		name             string
+		url              *string
+		mockBehavior     func(m *mocks.ArchiveService)
+		expectedCode     int
+		expectedType     string
+		expectedContains []string
+	}{
+		{
+			name: "Page With Rewritten Links",
+			mockBehavior: func(m *mocks.ArchiveService) {
+				m.On("Resource", mock.Anything, "b1", "").Return(page, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedType: "text/html; charset=utf-8",
+			expectedContains: []string{
+				`src="archive?url=https%3A%2F%2Fexample.com%2Fcover.png"`,
+				`href="https://example.com/about"`,
+			},
+		},
+		{
+			name: "Asset",
+			url:  &image.URL,
+			mockBehavior: func(m *mocks.ArchiveService) {
+				m.On("Resource", mock.Anything, "b1", image.URL).Return(image, nil).Once()
+			},
+			expectedCode:     http.StatusOK,
+			expectedType:     "image/png",
+			expectedContains: []string{"png"},
+		},
+		{
+			name: "Not Archived",
+			mockBehavior: func(m *mocks.ArchiveService) {
+				err := fmt.Errorf("service.Resource: %w", domain.ErrArchiveNotFound)
+				m.On("Resource", mock.Anything, "b1", "").Return(nil, err).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewArchiveService(t)
+			tt.mockBehavior(mockSvc)
+
+			handler := NewArchiveHandler(mockSvc)
+			req := httptest.NewRequest("GET", "/bookmarks/b1/archive", nil)
+			w := httptest.NewRecorder()
+
+			handler.GetBookmarkArchive(w, req, "b1", gen.GetBookmarkArchiveParams{Url: tt.url})
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("GetBookmarkArchive() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+			if tt.expectedCode != http.StatusOK {
+				return
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.expectedType {
+				t.Errorf("GetBookmarkArchive() Content-Type = %q, want %q", got, tt.expectedType)
+			}
+			if got := w.Header().Get("Content-Security-Policy"); !strings.Contains(got, "sandbox") {
+				t.Errorf("GetBookmarkArchive() Content-Security-Policy = %q, want sandbox", got)
+			}
+			for _, want := range tt.expectedContains {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Errorf("GetBookmarkArchive() body = %q, want it to contain %q", w.Body.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestArchiveHandler_ArchiveBookmark(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		svc          func(t *testing.T) service.ArchiveService
+		expectedCode int
+	}{
+		{
+			name: "Scheduled",
+			svc: func(t *testing.T) service.ArchiveService {
+				m := mocks.NewArchiveService(t)
+				m.On("Request", mock.Anything, "b1").Return(nil).Once()
+				return m
+			},
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name: "Bookmark Not Found",
+			svc: func(t *testing.T) service.ArchiveService {
+				m := mocks.NewArchiveService(t)
+				m.On("Request", mock.Anything, "b1").Return(fmt.Errorf("service.Request: %w", domain.ErrBookmarkNotFound)).Once()
+				return m
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Queue Full",
+			svc: func(t *testing.T) service.ArchiveService {
+				m := mocks.NewArchiveService(t)
+				m.On("Request", mock.Anything, "b1").Return(fmt.Errorf("service.Request: %w", service.ErrArchiveQueueFull)).Once()
+				return m
+			},
+			expectedCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:         "Archiving Disabled",
+			svc:          func(t *testing.T) service.ArchiveService { return nil },
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := NewArchiveHandler(tt.svc(t))
+			req := httptest.NewRequest("POST", "/bookmarks/b1/archive", nil)
+			w := httptest.NewRecorder()
+
+			handler.ArchiveBookmark(w, req, "b1")
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("ArchiveBookmark() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+		})
+	}
+}
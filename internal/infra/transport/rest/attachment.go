@@ -0,0 +1,207 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/service"
+)
+
+// AttachmentHandler serves the /bookmarks/{id}/attachments endpoints. A nil
+// service means attachments are disabled.
+type AttachmentHandler struct {
+	svc service.AttachmentService
+}
+
+func NewAttachmentHandler(svc service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{svc: svc}
+}
+
+// ListAttachments handles GET /bookmarks/{id}/attachments
+func (h *AttachmentHandler) ListAttachments(w http.ResponseWriter, r *http.Request, id string) {
+	if h.svc == nil {
+		http.Error(w, "Attachments are disabled", http.StatusNotFound)
+		return
+	}
+
+	list, err := h.svc.List(r.Context(), id)
+	if errors.Is(err, domain.ErrBookmarkNotFound) {
+		http.Error(w, "Bookmark not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		domain.LoggerFrom(r.Context()).Error("Error encoding attachments", "error", err)
+	}
+}
+
+// UploadAttachment handles POST /bookmarks/{id}/attachments. The file is
+// streamed to the blob store as it arrives rather than parsed into memory.
+func (h *AttachmentHandler) UploadAttachment(w http.ResponseWriter, r *http.Request, id string) {
+	if h.svc == nil {
+		http.Error(w, "Attachments are disabled", http.StatusNotFound)
+		return
+	}
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, "Expected a multipart/form-data body", http.StatusBadRequest)
+		return
+	}
+	var a *domain.Attachment
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			http.Error(w, "Missing file field", http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, "Invalid multipart body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+		a, err = h.svc.Upload(r.Context(), id, part.FileName(), part)
+		part.Close()
+		if err != nil {
+			writeUploadError(w, err)
+			return
+		}
+		break
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", r.URL.Path+"/"+a.ID)
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(a); err != nil {
+		domain.LoggerFrom(r.Context()).Error("Error encoding attachment", "error", err)
+	}
+}
+
+func writeUploadError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrBookmarkNotFound):
+		http.Error(w, "Bookmark not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrAttachmentTooLarge):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, domain.ErrContentTypeDenied):
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+	case errors.Is(err, domain.ErrQuotaExceeded):
+		http.Error(w, err.Error(), http.StatusInsufficientStorage)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// inlineContentTypes are shown in the browser; everything else is served as a
+// download, so that an uploaded page or script never runs as part of the API.
+var inlineContentTypes = map[string]bool{
+	"application/pdf": true,
+	"text/plain":      true,
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"image/bmp":       true,
+}
+
+// GetAttachment handles GET /bookmarks/{id}/attachments/{attachmentId}
+func (h *AttachmentHandler) GetAttachment(w http.ResponseWriter, r *http.Request, id string, attachmentId string) {
+	if h.svc == nil {
+		http.Error(w, "Attachments are disabled", http.StatusNotFound)
+		return
+	}
+
+	a, body, err := h.svc.Open(r.Context(), id, attachmentId)
+	if errors.Is(err, domain.ErrBookmarkNotFound) {
+		http.Error(w, "Bookmark not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, domain.ErrAttachmentNotFound) {
+		http.Error(w, "Attachment not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer body.Close()
+
+	mediaType, _, _ := mime.ParseMediaType(a.ContentType)
+	disposition := "attachment"
+	if inlineContentTypes[mediaType] || strings.HasPrefix(mediaType, "audio/") || strings.HasPrefix(mediaType, "video/") {
+		disposition = "inline"
+	}
+	w.Header().Set("Content-Type", a.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": a.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// Content never changes under an ID, so its key is a strong validator.
+	w.Header().Set("ETag", strconv.Quote(a.BlobKey))
+	http.ServeContent(w, r, "", a.CreatedAt, body)
+}
+
+// GetAttachmentThumbnail handles GET /bookmarks/{id}/attachments/{attachmentId}/thumbnail
+func (h *AttachmentHandler) GetAttachmentThumbnail(w http.ResponseWriter, r *http.Request, id string, attachmentId string) {
+	if h.svc == nil {
+		http.Error(w, "Attachments are disabled", http.StatusNotFound)
+		return
+	}
+
+	a, body, err := h.svc.OpenThumbnail(r.Context(), id, attachmentId)
+	if errors.Is(err, domain.ErrBookmarkNotFound) {
+		http.Error(w, "Bookmark not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, domain.ErrAttachmentNotFound) {
+		http.Error(w, "Thumbnail not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", a.Thumbnail.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	if _, err := io.Copy(w, body); err != nil {
+		domain.LoggerFrom(r.Context()).Error("Error writing thumbnail", "error", err)
+	}
+}
+
+// DeleteAttachment handles DELETE /bookmarks/{id}/attachments/{attachmentId}
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request, id string, attachmentId string) {
+	if h.svc == nil {
+		http.Error(w, "Attachments are disabled", http.StatusNotFound)
+		return
+	}
+
+	err := h.svc.Delete(r.Context(), id, attachmentId)
+	if errors.Is(err, domain.ErrBookmarkNotFound) {
+		http.Error(w, "Bookmark not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, domain.ErrAttachmentNotFound) {
+		http.Error(w, "Attachment not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,223 @@
+package rest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func multipartBody(t *testing.T, field, filename, content string) (*bytes.Buffer, string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	if err := mw.WriteField("note", "ignored"); err != nil {
+		t.Fatal(err)
+	}
+	fw, err := mw.CreateFormFile(field, filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(fw, content)
+	mw.Close()
+	return &buf, mw.FormDataContentType()
+}
+
+func TestAttachmentHandler_UploadAttachment(t *testing.T) {
+	t.Parallel()
+
+	attached := &domain.Attachment{ID: "a1", BookmarkID: "b1", Filename: "paper.pdf", ContentType: "application/pdf", Size: 8}
+
+	tests := []struct {
+		name         string
+		field        string
+		mockBehavior func(m *mocks.AttachmentService)
+		expectedCode int
+	}{
+		{
+			name:  "Attached",
+			field: "file",
+			mockBehavior: func(m *mocks.AttachmentService) {
+				m.On("Upload", mock.Anything, "b1", "paper.pdf", mock.MatchedBy(func(r io.Reader) bool {
+					b, _ := io.ReadAll(r)
+					return string(b) == "%PDF-1.7"
+				})).Return(attached, nil).Once()
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "Missing File Field",
+			field:        "upload",
+			mockBehavior: func(m *mocks.AttachmentService) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Bookmark Not Found",
+			field: "file",
+			mockBehavior: func(m *mocks.AttachmentService) {
+				m.On("Upload", mock.Anything, "b1", "paper.pdf", mock.Anything).
+					Return(nil, fmt.Errorf("service.Upload: %w", domain.ErrBookmarkNotFound)).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:  "Too Large",
+			field: "file",
+			mockBehavior: func(m *mocks.AttachmentService) {
+				m.On("Upload", mock.Anything, "b1", "paper.pdf", mock.Anything).
+					Return(nil, fmt.Errorf("service.Upload: %w", domain.ErrAttachmentTooLarge)).Once()
+			},
+			expectedCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:  "Type Denied",
+			field: "file",
+			mockBehavior: func(m *mocks.AttachmentService) {
+				m.On("Upload", mock.Anything, "b1", "paper.pdf", mock.Anything).
+					Return(nil, fmt.Errorf("service.Upload: %w", domain.ErrContentTypeDenied)).Once()
+			},
+			expectedCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:  "Over Quota",
+			field: "file",
+			mockBehavior: func(m *mocks.AttachmentService) {
+				m.On("Upload", mock.Anything, "b1", "paper.pdf", mock.Anything).
+					Return(nil, fmt.Errorf("service.Upload: %w", domain.ErrQuotaExceeded)).Once()
+			},
+			expectedCode: http.StatusInsufficientStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewAttachmentService(t)
+			tt.mockBehavior(mockSvc)
+
+			handler := NewAttachmentHandler(mockSvc)
+			body, contentType := multipartBody(t, tt.field, "paper.pdf", "%PDF-1.7")
+			req := httptest.NewRequest("POST", "/bookmarks/b1/attachments", body)
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+
+			handler.UploadAttachment(w, req, "b1")
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("UploadAttachment() status code = %v, want %v: %s", w.Code, tt.expectedCode, w.Body)
+			}
+			if tt.expectedCode == http.StatusCreated {
+				if got := w.Header().Get("Location"); got != "/bookmarks/b1/attachments/a1" {
+					t.Errorf("UploadAttachment() Location = %q", got)
+				}
+				if !strings.Contains(w.Body.String(), `"content_type":"application/pdf"`) {
+					t.Errorf("UploadAttachment() body = %s", w.Body)
+				}
+			}
+		})
+	}
+}
+
+type readSeekNopCloser struct {
+	io.ReadSeeker
+}
+
+func (readSeekNopCloser) Close() error { return nil }
+
+func TestAttachmentHandler_GetAttachment(t *testing.T) {
+	t.Parallel()
+
+	const content = "abcdefghijklmnopqrstuvwxyz"
+
+	tests := []struct {
+		name                string
+		contentType         string
+		rangeHeader         string
+		expectedCode        int
+		expectedBody        string
+		expectedDisposition string
+	}{
+		{"Whole PDF", "application/pdf", "", http.StatusOK, content, `inline; filename=abc.txt`},
+		{"Range", "application/pdf", "bytes=10-12", http.StatusPartialContent, "klm", `inline; filename=abc.txt`},
+		{"Range Outside", "application/pdf", "bytes=100-", http.StatusRequestedRangeNotSatisfiable, "", ""},
+		{"HTML Is Downloaded", "text/html; charset=utf-8", "", http.StatusOK, content, `attachment; filename=abc.txt`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := &domain.Attachment{ID: "a1", BookmarkID: "b1", Filename: "abc.txt", ContentType: tt.contentType,
+				Size: int64(len(content)), BlobKey: "k", CreatedAt: time.Now()}
+			mockSvc := mocks.NewAttachmentService(t)
+			mockSvc.On("Open", mock.Anything, "b1", "a1").
+				Return(a, readSeekNopCloser{strings.NewReader(content)}, nil).Once()
+
+			handler := NewAttachmentHandler(mockSvc)
+			req := httptest.NewRequest("GET", "/bookmarks/b1/attachments/a1", nil)
+			if tt.rangeHeader != "" {
+				req.Header.Set("Range", tt.rangeHeader)
+			}
+			w := httptest.NewRecorder()
+
+			handler.GetAttachment(w, req, "b1", "a1")
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("GetAttachment() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+			if tt.expectedBody != "" && w.Body.String() != tt.expectedBody {
+				t.Errorf("GetAttachment() body = %q, want %q", w.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedDisposition == "" {
+				return
+			}
+			if got := w.Header().Get("Content-Disposition"); got != tt.expectedDisposition {
+				t.Errorf("GetAttachment() Content-Disposition = %q, want %q", got, tt.expectedDisposition)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("GetAttachment() Content-Type = %q, want %q", got, tt.contentType)
+			}
+			if got := w.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+				t.Errorf("GetAttachment() X-Content-Type-Options = %q", got)
+			}
+		})
+	}
+}
+
+func TestAttachmentHandler_GetAttachmentOfTrashedBookmark(t *testing.T) {
+	t.Parallel()
+
+	mockSvc := mocks.NewAttachmentService(t)
+	mockSvc.On("Open", mock.Anything, "b1", "a1").Return(nil, nil, domain.ErrBookmarkNotFound).Once()
+
+	w := httptest.NewRecorder()
+	NewAttachmentHandler(mockSvc).GetAttachment(w, httptest.NewRequest("GET", "/bookmarks/b1/attachments/a1", nil), "b1", "a1")
+
+	if w.Code != http.StatusNotFound || w.Body.String() != "Bookmark not found\n" {
+		t.Errorf("GetAttachment() = %v %q, want %v %q", w.Code, w.Body.String(), http.StatusNotFound, "Bookmark not found\n")
+	}
+}
+
+func TestAttachmentHandler_Disabled(t *testing.T) {
+	t.Parallel()
+
+	handler := NewAttachmentHandler(nil)
+	w := httptest.NewRecorder()
+	handler.ListAttachments(w, httptest.NewRequest("GET", "/bookmarks/b1/attachments", nil), "b1")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("ListAttachments() status code = %v, want %v", w.Code, http.StatusNotFound)
+	}
+}
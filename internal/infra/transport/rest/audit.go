@@ -0,0 +1,233 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/service"
+)
+
+// AuditOptions configures the audit log endpoints and middleware.
+type AuditOptions struct {
+	// AdminTokens may read the audit log. With none, no one may.
+	AdminTokens []string
+	// TrustedProxies is how many reverse proxies stand in front of the
+	// server, each adding the address it was reached from to
+	// X-Forwarded-For. With none, the header is ignored.
+	TrustedProxies int
+}
+
+// AdminAuthAction is the action of the audit entry recorded whenever an
+// admin token is checked, whether or not it is valid.
+const AdminAuthAction = "authenticate admin"
+
+// AuditHandler serves GET /audit and records requests in the audit log
+// through Record. A nil service means the audit log is disabled.
+type AuditHandler struct {
+	svc  service.AuditService
+	opts AuditOptions
+}
+
+func NewAuditHandler(svc service.AuditService, opts AuditOptions) *AuditHandler {
+	return &AuditHandler{svc: svc, opts: opts}
+}
+
+// QueryAuditLog handles GET /audit
+func (h *AuditHandler) QueryAuditLog(w http.ResponseWriter, r *http.Request, params gen.QueryAuditLogParams) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	var filter domain.AuditFilter
+	if params.Actor != nil {
+		filter.Actor = *params.Actor
+	}
+	if params.AssertedActor != nil {
+		filter.AssertedActor = *params.AssertedActor
+	}
+	if params.Action != nil {
+		filter.Action = *params.Action
+	}
+	if params.Target != nil {
+		filter.Target = *params.Target
+	}
+	if params.Outcome != nil {
+		filter.Outcome = domain.AuditOutcome(*params.Outcome)
+		switch filter.Outcome {
+		case domain.AuditSuccess, domain.AuditFailure, domain.AuditDenied:
+		default:
+			http.Error(w, "Unknown outcome", http.StatusBadRequest)
+			return
+		}
+	}
+	if params.Since != nil {
+		filter.Since = *params.Since
+	}
+	if params.Until != nil {
+		filter.Until = *params.Until
+	}
+	if params.Before != nil {
+		filter.Before = *params.Before
+	}
+	if params.Limit != nil {
+		if *params.Limit <= 0 {
+			http.Error(w, "limit must be positive", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = *params.Limit
+	}
+
+	page, err := h.svc.Query(r.Context(), filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeAuditJSON(r.Context(), w, page)
+}
+
+// VerifyAuditLog handles GET /audit/verify
+func (h *AuditHandler) VerifyAuditLog(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(w, r) {
+		return
+	}
+
+	result, err := h.svc.Verify(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeAuditJSON(r.Context(), w, result)
+}
+
+// authorize writes an error response unless the request may read the audit
+// log. Either way, the check is recorded as an AdminAuthAction.
+func (h *AuditHandler) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if h.svc == nil {
+		http.Error(w, "Audit log is disabled", http.StatusNotFound)
+		return false
+	}
+	if !bearsAdminToken(r, h.opts.AdminTokens) {
+		h.recordAuth(r, "anonymous", domain.AuditDenied, http.StatusUnauthorized)
+		w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+		http.Error(w, "Admin token required", http.StatusUnauthorized)
+		return false
+	}
+	h.recordAuth(r, AdminPrincipal, domain.AuditSuccess, http.StatusOK)
+	return true
+}
+
+// recordAuth records an authentication of actor, in place of the entry
+// Record would add for the request.
+func (h *AuditHandler) recordAuth(r *http.Request, actor string, outcome domain.AuditOutcome, status int) {
+	if done, ok := r.Context().Value(auditedKey{}).(*bool); ok {
+		*done = true
+	}
+	e := h.entry(r, outcome, status)
+	e.Actor, e.Action = actor, AdminAuthAction
+	h.record(r.Context(), e)
+}
+
+// auditedKey marks, in Record, the requests whose handler has recorded them
+// itself.
+type auditedKey struct{}
+
+// Record is middleware that adds an entry to the audit log for every
+// request that may change something, and for every request refused with
+// 401 or 403. Mutations are recorded whether or not they succeed.
+func (h *AuditHandler) Record(next http.Handler) http.Handler {
+	if h.svc == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		audited := false
+		r = r.WithContext(context.WithValue(r.Context(), auditedKey{}, &audited))
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+		if audited {
+			return
+		}
+
+		denied := sw.status == http.StatusUnauthorized || sw.status == http.StatusForbidden
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			if !denied {
+				return
+			}
+		}
+		outcome := domain.AuditSuccess
+		switch {
+		case denied:
+			outcome = domain.AuditDenied
+		case sw.status >= 400:
+			outcome = domain.AuditFailure
+		}
+		h.record(r.Context(), h.entry(r, outcome, sw.status))
+	})
+}
+
+// entry describes r in an audit entry. Its actor is who r authenticated as;
+// ActorHeader is only who the caller claims to be.
+func (h *AuditHandler) entry(r *http.Request, outcome domain.AuditOutcome, status int) *domain.AuditEntry {
+	actor := domain.PrincipalFrom(r.Context())
+	if actor == "" {
+		actor = "anonymous"
+	}
+	return &domain.AuditEntry{
+		Actor:         actor,
+		AssertedActor: r.Header.Get(ActorHeader),
+		Action:        r.Pattern,
+		Target:        r.URL.Path,
+		RequestID:     requestIDFrom(r.Context()),
+		SourceIP:      clientIP(r, h.opts.TrustedProxies),
+		UserAgent:     r.UserAgent(),
+		Outcome:       outcome,
+		Status:        status,
+	}
+}
+
+func (h *AuditHandler) record(ctx context.Context, e *domain.AuditEntry) {
+	// What the request did is done even when the client has gone by now, so
+	// it is recorded all the same.
+	ctx = context.WithoutCancel(ctx)
+	if err := h.svc.Record(ctx, e); err != nil {
+		domain.LoggerFrom(ctx).Error("Error recording audit entry", "error", err)
+	}
+}
+
+// clientIP returns the address of the client that sent r. Behind proxies,
+// it is the one the outermost of them was reached from: the entry of
+// X-Forwarded-For that many from the right. Entries further left are
+// whatever the client sent, and cannot be trusted. A header with fewer
+// entries than there are proxies did not come through them all, so the
+// connecting address is taken instead.
+func clientIP(r *http.Request, proxies int) string {
+	if proxies > 0 {
+		var fwd []string
+		for _, v := range r.Header.Values("X-Forwarded-For") {
+			for addr := range strings.SplitSeq(v, ",") {
+				fwd = append(fwd, strings.TrimSpace(addr))
+			}
+		}
+		if len(fwd) >= proxies {
+			return fwd[len(fwd)-proxies]
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func writeAuditJSON(ctx context.Context, w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		domain.LoggerFrom(ctx).Error("Error encoding audit response", "error", err)
+	}
+}
@@ -0,0 +1,300 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+// expectAuth expects the admin token check to be recorded.
+func expectAuth(m *mocks.AuditService, actor string, outcome domain.AuditOutcome) {
+	m.On("Record", mock.Anything, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+		return e.Action == AdminAuthAction && e.Actor == actor && e.Outcome == outcome
+	})).Return(nil).Once()
+}
+
+func TestAuditHandler_QueryAuditLog(t *testing.T) {
+	t.Parallel()
+
+	actor, outcome, bogus, limit := "alice", gen.Denied, gen.AuditOutcome("bogus"), 0
+	tests := []struct {
+		name         string
+		auth         string
+		params       gen.QueryAuditLogParams
+		mockBehavior func(m *mocks.AuditService)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:   "Queried",
+			auth:   "Bearer admin",
+			params: gen.QueryAuditLogParams{AssertedActor: &actor, Outcome: &outcome},
+			mockBehavior: func(m *mocks.AuditService) {
+				expectAuth(m, AdminPrincipal, domain.AuditSuccess)
+				m.On("Query", mock.Anything, domain.AuditFilter{AssertedActor: "alice", Outcome: domain.AuditDenied}).
+					Return(&domain.AuditPage{Entries: []*domain.AuditEntry{}}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"entries":[]}` + "\n",
+		},
+		{
+			name: "No Token",
+			mockBehavior: func(m *mocks.AuditService) {
+				expectAuth(m, "anonymous", domain.AuditDenied)
+			},
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: "Admin token required\n",
+		},
+		{
+			name: "Wrong Token",
+			auth: "Bearer guess",
+			mockBehavior: func(m *mocks.AuditService) {
+				expectAuth(m, "anonymous", domain.AuditDenied)
+			},
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: "Admin token required\n",
+		},
+		{
+			name:   "Unknown Outcome",
+			auth:   "Bearer admin",
+			params: gen.QueryAuditLogParams{Outcome: &bogus},
+			mockBehavior: func(m *mocks.AuditService) {
+				expectAuth(m, AdminPrincipal, domain.AuditSuccess)
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "Unknown outcome\n",
+		},
+		{
+			name:   "Bad Limit",
+			auth:   "Bearer admin",
+			params: gen.QueryAuditLogParams{Limit: &limit},
+			mockBehavior: func(m *mocks.AuditService) {
+				expectAuth(m, AdminPrincipal, domain.AuditSuccess)
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "limit must be positive\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewAuditService(t)
+			tt.mockBehavior(mockSvc)
+
+			r := httptest.NewRequest("GET", "/audit", nil)
+			if tt.auth != "" {
+				r.Header.Set("Authorization", tt.auth)
+			}
+			w := httptest.NewRecorder()
+			NewAuditHandler(mockSvc, AuditOptions{AdminTokens: []string{"admin"}}).QueryAuditLog(w, r, tt.params)
+
+			if w.Code != tt.expectedCode || w.Body.String() != tt.expectedBody {
+				t.Errorf("QueryAuditLog() = %v %q, want %v %q", w.Code, w.Body.String(), tt.expectedCode, tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestAuditHandler_Disabled(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest("GET", "/audit/verify", nil)
+	r.Header.Set("Authorization", "Bearer admin")
+	w := httptest.NewRecorder()
+	NewAuditHandler(nil, AuditOptions{AdminTokens: []string{"admin"}}).VerifyAuditLog(w, r)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("VerifyAuditLog() status code = %v, want %v", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestAuditHandler_Record(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		method       string
+		status       int
+		principal    string
+		proxies      int
+		disconnect   bool // the client goes away while the request is handled
+		mockBehavior func(m *mocks.AuditService)
+	}{
+		{
+			name:   "Mutation",
+			method: "DELETE",
+			status: http.StatusNoContent,
+			mockBehavior: func(m *mocks.AuditService) {
+				m.On("Record", mock.Anything, &domain.AuditEntry{
+					Actor: "anonymous", AssertedActor: "alice", Action: "DELETE /bookmarks/{id}", Target: "/bookmarks/1", RequestID: "req-1",
+					SourceIP: "192.0.2.1", UserAgent: "curl/8", Outcome: domain.AuditSuccess, Status: http.StatusNoContent,
+				}).Return(nil).Once()
+			},
+		},
+		{
+			name:      "Admin Mutation",
+			method:    "DELETE",
+			status:    http.StatusNoContent,
+			principal: AdminPrincipal,
+			mockBehavior: func(m *mocks.AuditService) {
+				m.On("Record", mock.Anything, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+					return e.Actor == AdminPrincipal && e.AssertedActor == "alice"
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:    "Failed Mutation Behind Proxy",
+			method:  "DELETE",
+			status:  http.StatusNotFound,
+			proxies: 1,
+			mockBehavior: func(m *mocks.AuditService) {
+				m.On("Record", mock.Anything, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+					return e.Outcome == domain.AuditFailure && e.SourceIP == "203.0.113.7"
+				})).Return(errors.New("log unavailable")).Once()
+			},
+		},
+		{
+			name:       "Mutation After Client Left",
+			method:     "DELETE",
+			status:     http.StatusNoContent,
+			disconnect: true,
+			mockBehavior: func(m *mocks.AuditService) {
+				m.On("Record", mock.MatchedBy(func(ctx context.Context) bool {
+					return ctx.Err() == nil
+				}), mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:   "Denied Read",
+			method: "GET",
+			status: http.StatusUnauthorized,
+			mockBehavior: func(m *mocks.AuditService) {
+				m.On("Record", mock.Anything, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+					return e.Outcome == domain.AuditDenied && e.Status == http.StatusUnauthorized
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:         "Read",
+			method:       "GET",
+			status:       http.StatusOK,
+			mockBehavior: func(m *mocks.AuditService) {},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewAuditService(t)
+			tt.mockBehavior(mockSvc)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			h := NewAuditHandler(mockSvc, AuditOptions{TrustedProxies: tt.proxies})
+			handler := h.Record(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				if tt.disconnect {
+					cancel()
+				}
+			}))
+			r := httptest.NewRequestWithContext(ctx, tt.method, "/bookmarks/1", nil)
+			r.Pattern = tt.method + " /bookmarks/{id}"
+			r.RemoteAddr = "192.0.2.1:1234"
+			// The client made up the first address; the proxy added the last.
+			r.Header.Set("X-Forwarded-For", "198.51.100.66, 203.0.113.7")
+			r.Header.Set("User-Agent", "curl/8")
+			r.Header.Set(ActorHeader, "alice")
+			// Only a credential could make the request anyone's, so the
+			// actor the header gave the context is not trusted either.
+			ctx = context.WithValue(domain.WithActor(r.Context(), "alice"), requestIDKey{}, "req-1")
+			if tt.principal != "" {
+				ctx = domain.WithPrincipal(ctx, tt.principal)
+			}
+			r = r.WithContext(ctx)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Errorf("Record() status code = %v, want %v", w.Code, tt.status)
+			}
+		})
+	}
+}
+
+func TestAuditHandler_RecordAuth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		auth        string
+		wantActor   string
+		wantOutcome domain.AuditOutcome
+	}{
+		{name: "Admin", auth: "Bearer admin", wantActor: AdminPrincipal, wantOutcome: domain.AuditSuccess},
+		{name: "Wrong Token", auth: "Bearer guess", wantActor: "anonymous", wantOutcome: domain.AuditDenied},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The check is recorded once, as itself rather than as the
+			// request.
+			mockSvc := mocks.NewAuditService(t)
+			expectAuth(mockSvc, tt.wantActor, tt.wantOutcome)
+			mockSvc.On("Verify", mock.Anything).Return(&domain.AuditVerification{Valid: true}, nil).Maybe()
+
+			h := NewAuditHandler(mockSvc, AuditOptions{AdminTokens: []string{"admin"}})
+			r := httptest.NewRequest("GET", "/audit/verify", nil)
+			r.Header.Set("Authorization", tt.auth)
+			w := httptest.NewRecorder()
+			h.Record(http.HandlerFunc(h.VerifyAuditLog)).ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		fwd     []string
+		proxies int
+		want    string
+	}{
+		{name: "No Proxy", fwd: []string{"198.51.100.66"}, want: "192.0.2.1"},
+		{name: "One Proxy", fwd: []string{"198.51.100.66, 203.0.113.7"}, proxies: 1, want: "203.0.113.7"},
+		{name: "Two Proxies", fwd: []string{"198.51.100.66, 203.0.113.7", "10.0.0.1"}, proxies: 2, want: "203.0.113.7"},
+		{name: "Too Few Entries", fwd: []string{"203.0.113.7"}, proxies: 2, want: "192.0.2.1"},
+		{name: "No Header", proxies: 1, want: "192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = "192.0.2.1:1234"
+			for _, v := range tt.fwd {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			if got := clientIP(r, tt.proxies); got != tt.want {
+				t.Errorf("clientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
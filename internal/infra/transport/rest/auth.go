@@ -0,0 +1,41 @@
+package rest
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// AdminPrincipal is who a request bearing an admin token authenticates as.
+const AdminPrincipal = "admin"
+
+// Authenticate makes every request bearing one of adminTokens as a bearer
+// token the AdminPrincipal's. Feed and stream tokens open one endpoint each
+// and are checked there, so other requests are left as they are.
+func Authenticate(adminTokens []string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if bearsAdminToken(r, adminTokens) {
+				r = r.WithContext(domain.WithPrincipal(r.Context(), AdminPrincipal))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// bearsAdminToken reports whether r's bearer token is one of adminTokens.
+func bearsAdminToken(r *http.Request, adminTokens []string) bool {
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || got == "" {
+		return false
+	}
+	found := false
+	for _, t := range adminTokens {
+		if subtle.ConstantTimeCompare([]byte(got), []byte(t)) == 1 {
+			found = true
+		}
+	}
+	return found
+}
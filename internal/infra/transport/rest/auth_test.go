@@ -0,0 +1,45 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+func TestAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		auth          string
+		wantPrincipal string
+	}{
+		{name: "Admin Token", auth: "Bearer admin", wantPrincipal: AdminPrincipal},
+		{name: "Other Token", auth: "Bearer feed-token"},
+		{name: "Empty Token", auth: "Bearer "},
+		{name: "None"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			handler := Authenticate([]string{"admin"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = domain.PrincipalFrom(r.Context())
+			}))
+			r := httptest.NewRequest("POST", "/bookmarks", nil)
+			if tt.auth != "" {
+				r.Header.Set("Authorization", tt.auth)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			if got != tt.wantPrincipal {
+				t.Errorf("principal = %q, want %q", got, tt.wantPrincipal)
+			}
+		})
+	}
+}
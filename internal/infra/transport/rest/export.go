@@ -0,0 +1,115 @@
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/codec"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/service"
+)
+
+// ExportHandler serves GET /export and the per-bookmark citations, which share
+// its encoders.
+type ExportHandler struct {
+	svc service.BookmarkService
+}
+
+func NewExportHandler(svc service.BookmarkService) *ExportHandler {
+	return &ExportHandler{svc: svc}
+}
+
+// ExportBookmarks handles GET /export
+func (h *ExportHandler) ExportBookmarks(w http.ResponseWriter, r *http.Request, params gen.ExportBookmarksParams) {
+	name := "json"
+	if params.Format != nil {
+		name = string(*params.Format)
+	}
+	format, ok := codec.Lookup(name)
+	if !ok {
+		http.Error(w, fmt.Sprintf("Unknown format %q, want one of: %s", name, codec.Names()), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="bookmarks-%s.%s"`,
+		time.Now().UTC().Format("20060102-150405"), format.Extension))
+
+	out := &trackingWriter{w: w}
+	enc := format.NewEncoder(out)
+	filter := bookmarkFilter(params.Tag, params.Q)
+	if params.Id != nil {
+		filter.IDs = *params.Id
+	}
+	err := h.svc.Stream(r.Context(), filter, enc.Encode)
+	if err == nil {
+		err = enc.Close()
+	}
+	if err != nil {
+		if !out.written {
+			w.Header().Del("Content-Disposition")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// The status line is already sent; cutting the body short is the only
+		// signal left, and clients see a truncated download.
+		domain.LoggerFrom(r.Context()).Error("Error streaming export", "error", err)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// CiteBookmark handles GET /bookmarks/{id}/cite
+func (h *ExportHandler) CiteBookmark(w http.ResponseWriter, r *http.Request, id string, params gen.CiteBookmarkParams) {
+	name := "bibtex"
+	if params.Style != nil {
+		name = string(*params.Style)
+	}
+	format, ok := codec.Lookup(name)
+	if !ok || (name != "bibtex" && name != "csl-json") {
+		http.Error(w, fmt.Sprintf("Unknown style %q, want bibtex or csl-json", name), http.StatusBadRequest)
+		return
+	}
+
+	bm, err := h.svc.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrBookmarkNotFound) {
+			http.Error(w, "Bookmark not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	enc := format.NewEncoder(&buf)
+	if err := enc.Encode(bm); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := enc.Close(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType)
+	if _, err := buf.WriteTo(w); err != nil {
+		domain.LoggerFrom(r.Context()).Error("Error writing citation", "error", err)
+	}
+}
+
+// trackingWriter records whether the response has started, which decides if an
+// error can still be reported with a status code.
+type trackingWriter struct {
+	w       io.Writer
+	written bool
+}
+
+func (t *trackingWriter) Write(p []byte) (int, error) {
+	t.written = true
+	return t.w.Write(p)
+}
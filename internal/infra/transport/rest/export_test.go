@@ -0,0 +1,207 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportHandler_ExportBookmarks(t *testing.T) {
+	t.Parallel()
+
+	bookmarks := []*domain.Bookmark{
+		{ID: "1", Title: "Google", URL: "https://google.com", Tags: []string{"search"}},
+		{ID: "2", Title: "Example", URL: "https://example.com"},
+	}
+	streamAll := func(_ context.Context, _ domain.BookmarkFilter, fn func(*domain.Bookmark) error) error {
+		for _, b := range bookmarks {
+			if err := fn(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	format := func(f gen.ExportBookmarksParamsFormat) *gen.ExportBookmarksParamsFormat { return &f }
+	tag := "search"
+
+	tests := []struct {
+		name            string
+		params          gen.ExportBookmarksParams
+		mockBehavior    func(m *mocks.BookmarkService)
+		expectedCode    int
+		expectedType    string
+		expectedContain string
+	}{
+		{
+			name: "Default JSON",
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Stream", mock.Anything, domain.BookmarkFilter{}, mock.Anything).Return(streamAll).Once()
+			},
+			expectedCode:    http.StatusOK,
+			expectedType:    "application/json",
+			expectedContain: `"url":"https://example.com"`,
+		},
+		{
+			name:   "CSV With Tag Filter",
+			params: gen.ExportBookmarksParams{Format: format("csv"), Tag: &tag},
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Stream", mock.Anything, domain.BookmarkFilter{Tag: "search"}, mock.Anything).Return(streamAll).Once()
+			},
+			expectedCode:    http.StatusOK,
+			expectedType:    "text/csv",
+			expectedContain: "id,url,title,description,tags,created_at,updated_at,metadata,archive_mode\n",
+		},
+		{
+			name:   "BibTeX Selection",
+			params: gen.ExportBookmarksParams{Format: format("bibtex"), Id: &[]string{"2"}},
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Stream", mock.Anything, domain.BookmarkFilter{IDs: []string{"2"}}, mock.Anything).Return(streamAll).Once()
+			},
+			expectedCode:    http.StatusOK,
+			expectedType:    "application/x-bibtex",
+			expectedContain: "@online{example",
+		},
+		{
+			name:         "Unknown Format",
+			params:       gen.ExportBookmarksParams{Format: format("pdf")},
+			mockBehavior: func(_ *mocks.BookmarkService) {},
+			expectedCode: http.StatusBadRequest,
+			expectedType: "text/plain; charset=utf-8",
+		},
+		{
+			name: "Service Error Before First Byte",
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Stream", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("storage down")).Once()
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedType: "text/plain; charset=utf-8",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewBookmarkService(t)
+			tt.mockBehavior(mockSvc)
+
+			handler := NewExportHandler(mockSvc)
+			req := httptest.NewRequest("GET", "/export", nil)
+			w := httptest.NewRecorder()
+
+			handler.ExportBookmarks(w, req, tt.params)
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("ExportBookmarks() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.expectedType {
+				t.Errorf("ExportBookmarks() Content-Type = %q, want %q", got, tt.expectedType)
+			}
+			if tt.expectedCode == http.StatusOK && !strings.HasPrefix(w.Header().Get("Content-Disposition"), "attachment;") {
+				t.Errorf("ExportBookmarks() Content-Disposition = %q, want attachment", w.Header().Get("Content-Disposition"))
+			}
+			if !strings.Contains(w.Body.String(), tt.expectedContain) {
+				t.Errorf("ExportBookmarks() body = %q, want it to contain %q", w.Body.String(), tt.expectedContain)
+			}
+		})
+	}
+}
+
+func TestExportHandler_CiteBookmark(t *testing.T) {
+	t.Parallel()
+
+	bm := &domain.Bookmark{
+		ID:        "1",
+		Title:     "Go Concurrency Patterns",
+		URL:       "https://go.dev/talks/2012/concurrency.slide",
+		CreatedAt: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+		Metadata:  map[string]string{domain.MetaAuthor: "Rob Pike"},
+	}
+	style := func(s gen.CiteBookmarkParamsStyle) *gen.CiteBookmarkParamsStyle { return &s }
+
+	tests := []struct {
+		name            string
+		id              string
+		params          gen.CiteBookmarkParams
+		mockBehavior    func(m *mocks.BookmarkService)
+		expectedCode    int
+		expectedType    string
+		expectedContain string
+	}{
+		{
+			name: "Default BibTeX",
+			id:   "1",
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("GetByID", mock.Anything, "1").Return(bm, nil).Once()
+			},
+			expectedCode:    http.StatusOK,
+			expectedType:    "application/x-bibtex",
+			expectedContain: "@online{pike2024go,",
+		},
+		{
+			name:   "CSL-JSON",
+			id:     "1",
+			params: gen.CiteBookmarkParams{Style: style("csl-json")},
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("GetByID", mock.Anything, "1").Return(bm, nil).Once()
+			},
+			expectedCode:    http.StatusOK,
+			expectedType:    "application/vnd.citationstyles.csl+json",
+			expectedContain: `"accessed":{"date-parts":[[2024,5,2]]}`,
+		},
+		{
+			name:         "Unknown Style",
+			id:           "1",
+			params:       gen.CiteBookmarkParams{Style: style("csv")},
+			mockBehavior: func(_ *mocks.BookmarkService) {},
+			expectedCode: http.StatusBadRequest,
+			expectedType: "text/plain; charset=utf-8",
+		},
+		{
+			name: "Not Found",
+			id:   "missing",
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("GetByID", mock.Anything, "missing").Return(nil, domain.ErrBookmarkNotFound).Once()
+			},
+			expectedCode:    http.StatusNotFound,
+			expectedType:    "text/plain; charset=utf-8",
+			expectedContain: "Bookmark not found",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewBookmarkService(t)
+			tt.mockBehavior(mockSvc)
+
+			handler := NewExportHandler(mockSvc)
+			req := httptest.NewRequest("GET", "/bookmarks/"+tt.id+"/cite", nil)
+			w := httptest.NewRecorder()
+
+			handler.CiteBookmark(w, req, tt.id, tt.params)
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("CiteBookmark() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.expectedType {
+				t.Errorf("CiteBookmark() Content-Type = %q, want %q", got, tt.expectedType)
+			}
+			if !strings.Contains(w.Body.String(), tt.expectedContain) {
+				t.Errorf("CiteBookmark() body = %q, want it to contain %q", w.Body.String(), tt.expectedContain)
+			}
+		})
+	}
+}
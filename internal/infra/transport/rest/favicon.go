@@ -0,0 +1,70 @@
+package rest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/service"
+)
+
+const (
+	defaultFaviconSize = 32
+	// Fetched icons are cached for as long as they are kept; generated ones
+	// only briefly, so a site's real icon replaces them soon after it appears.
+	faviconMaxAge  = 7 * 24 * 60 * 60
+	fallbackMaxAge = 60 * 60
+)
+
+// FaviconHandler serves the /favicons endpoint. A nil service means favicons
+// are disabled.
+type FaviconHandler struct {
+	svc service.FaviconService
+}
+
+func NewFaviconHandler(svc service.FaviconService) *FaviconHandler {
+	return &FaviconHandler{svc: svc}
+}
+
+// GetFavicon handles GET /favicons/{host}
+func (h *FaviconHandler) GetFavicon(w http.ResponseWriter, r *http.Request, host string, params gen.GetFaviconParams) {
+	if h.svc == nil {
+		http.Error(w, "Favicons are disabled", http.StatusNotFound)
+		return
+	}
+
+	size := defaultFaviconSize
+	if params.Size != nil {
+		if *params.Size < 1 {
+			http.Error(w, "Invalid size", http.StatusBadRequest)
+			return
+		}
+		size = *params.Size
+	}
+
+	icon, err := h.svc.Icon(r.Context(), host, size)
+	if errors.Is(err, domain.ErrInvalidHost) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	maxAge := faviconMaxAge
+	if icon.Fallback {
+		maxAge = fallbackMaxAge
+	}
+	sum := sha256.Sum256(icon.PNG)
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(maxAge))
+	w.Header().Set("ETag", strconv.Quote(hex.EncodeToString(sum[:16])))
+	http.ServeContent(w, r, "", icon.ModTime, bytes.NewReader(icon.PNG))
+}
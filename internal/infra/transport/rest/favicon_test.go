@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFaviconHandler_GetFavicon(t *testing.T) {
+	t.Parallel()
+
+	fetched := &service.FaviconIcon{PNG: []byte("\x89PNG icon"), Size: 64, ModTime: time.Now()}
+	generated := &service.FaviconIcon{PNG: []byte("\x89PNG fallback"), Size: 32, Fallback: true}
+	size := func(n int) *int { return &n }
+
+	tests := []struct {
+		name                 string
+		size                 *int
+		mockBehavior         func(m *mocks.FaviconService)
+		expectedCode         int
+		expectedCacheControl string
+	}{
+		{
+			name: "Fetched Icon",
+			size: size(48),
+			mockBehavior: func(m *mocks.FaviconService) {
+				m.On("Icon", mock.Anything, "example.com", 48).Return(fetched, nil).Once()
+			},
+			expectedCode:         http.StatusOK,
+			expectedCacheControl: "public, max-age=604800",
+		},
+		{
+			name: "Fallback Cached Briefly",
+			mockBehavior: func(m *mocks.FaviconService) {
+				m.On("Icon", mock.Anything, "example.com", 32).Return(generated, nil).Once()
+			},
+			expectedCode:         http.StatusOK,
+			expectedCacheControl: "public, max-age=3600",
+		},
+		{
+			name: "Invalid Host",
+			mockBehavior: func(m *mocks.FaviconService) {
+				m.On("Icon", mock.Anything, "example.com", 32).
+					Return(nil, fmt.Errorf("service.Icon: %w", domain.ErrInvalidHost)).Once()
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Invalid Size",
+			size:         size(0),
+			mockBehavior: func(m *mocks.FaviconService) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewFaviconService(t)
+			tt.mockBehavior(mockSvc)
+
+			handler := NewFaviconHandler(mockSvc)
+			req := httptest.NewRequest("GET", "/favicons/example.com", nil)
+			w := httptest.NewRecorder()
+
+			handler.GetFavicon(w, req, "example.com", gen.GetFaviconParams{Size: tt.size})
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("GetFavicon() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+			if tt.expectedCode != http.StatusOK {
+				return
+			}
+			if got := w.Header().Get("Cache-Control"); got != tt.expectedCacheControl {
+				t.Errorf("GetFavicon() Cache-Control = %q, want %q", got, tt.expectedCacheControl)
+			}
+			if got := w.Header().Get("Content-Type"); got != "image/png" {
+				t.Errorf("GetFavicon() Content-Type = %q", got)
+			}
+		})
+	}
+}
+
+func TestFaviconHandler_NotModified(t *testing.T) {
+	t.Parallel()
+
+	icon := &service.FaviconIcon{PNG: []byte("\x89PNG icon"), Size: 32, ModTime: time.Now()}
+	mockSvc := mocks.NewFaviconService(t)
+	mockSvc.On("Icon", mock.Anything, "example.com", 32).Return(icon, nil).Twice()
+	handler := NewFaviconHandler(mockSvc)
+
+	w := httptest.NewRecorder()
+	handler.GetFavicon(w, httptest.NewRequest("GET", "/favicons/example.com", nil), "example.com", gen.GetFaviconParams{})
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("GetFavicon() set no ETag")
+	}
+
+	req := httptest.NewRequest("GET", "/favicons/example.com", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	handler.GetFavicon(w, req, "example.com", gen.GetFaviconParams{})
+	if w.Code != http.StatusNotModified {
+		t.Errorf("GetFavicon() status code = %v, want %v", w.Code, http.StatusNotModified)
+	}
+}
+
+func TestFaviconHandler_Disabled(t *testing.T) {
+	t.Parallel()
+
+	handler := NewFaviconHandler(nil)
+	w := httptest.NewRecorder()
+	handler.GetFavicon(w, httptest.NewRequest("GET", "/favicons/example.com", nil), "example.com", gen.GetFaviconParams{})
+	if w.Code != http.StatusNotFound {
+		t.Errorf("GetFavicon() status code = %v, want %v", w.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,212 @@
+package rest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/feed"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/service"
+)
+
+// FeedOptions configures the /feeds endpoints.
+type FeedOptions struct {
+	// PrivateTags keeps bookmarks with any of these tags out of feeds, unless
+	// the request carries a token that grants the tag. The feed of a private
+	// tag needs such a token. Other bookmarks stay in public feeds.
+	PrivateTags []string
+	// Tokens maps each feed token to the private tags its holder may read,
+	// none meaning all of them. Requests carry it as ?token= or as a bearer
+	// token.
+	Tokens map[string][]string
+	// Limit is the number of bookmarks in a feed when the request does not
+	// ask for one; MaxLimit caps what it may ask for.
+	Limit    int
+	MaxLimit int
+	// BaseURL is the public address of the server, used for links and entry
+	// IDs. Empty derives it from each request's Host.
+	BaseURL string
+}
+
+// FeedHandler serves the /feeds endpoints.
+type FeedHandler struct {
+	svc  service.BookmarkService
+	opts FeedOptions
+}
+
+func NewFeedHandler(svc service.BookmarkService, opts FeedOptions) *FeedHandler {
+	if opts.Limit <= 0 {
+		opts.Limit = 50
+	}
+	if opts.MaxLimit < opts.Limit {
+		opts.MaxLimit = opts.Limit
+	}
+	return &FeedHandler{svc: svc, opts: opts}
+}
+
+// GetAllFeed handles GET /feeds/all
+func (h *FeedHandler) GetAllFeed(w http.ResponseWriter, r *http.Request, params gen.GetAllFeedParams) {
+	h.serve(w, r, feedRequest{
+		title:  "goprod: recent bookmarks",
+		link:   "/bookmarks",
+		format: (*string)(params.Format),
+		limit:  params.Limit,
+		token:  params.Token,
+	})
+}
+
+// GetTagFeed handles GET /feeds/tag/{tag}
+func (h *FeedHandler) GetTagFeed(w http.ResponseWriter, r *http.Request, tag string, params gen.GetTagFeedParams) {
+	h.serve(w, r, feedRequest{
+		title:  fmt.Sprintf("goprod: bookmarks tagged %q", tag),
+		link:   "/bookmarks?tag=" + url.QueryEscape(tag),
+		filter: domain.BookmarkFilter{Tag: tag},
+		format: (*string)(params.Format),
+		limit:  params.Limit,
+		token:  params.Token,
+	})
+}
+
+// SearchFeed handles GET /feeds/search
+func (h *FeedHandler) SearchFeed(w http.ResponseWriter, r *http.Request, params gen.SearchFeedParams) {
+	if strings.TrimSpace(params.Q) == "" {
+		http.Error(w, "Query parameter q is required", http.StatusBadRequest)
+		return
+	}
+	h.serve(w, r, feedRequest{
+		title:  fmt.Sprintf("goprod: bookmarks matching %q", params.Q),
+		link:   "/bookmarks?q=" + url.QueryEscape(params.Q),
+		filter: domain.BookmarkFilter{Query: params.Q},
+		format: (*string)(params.Format),
+		limit:  params.Limit,
+		token:  params.Token,
+	})
+}
+
+// feedRequest is what the three feed endpoints have in common once their
+// parameters are parsed.
+type feedRequest struct {
+	title  string
+	link   string
+	filter domain.BookmarkFilter
+	format *string
+	limit  *int
+	token  *string
+}
+
+func (h *FeedHandler) serve(w http.ResponseWriter, r *http.Request, req feedRequest) {
+	granted, ok := h.grant(r, req.token)
+	hidden := slices.DeleteFunc(slices.Clone(h.opts.PrivateTags), func(t string) bool { return slices.Contains(granted, t) })
+	if !ok || slices.Contains(hidden, req.filter.Tag) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="feeds"`)
+		http.Error(w, "Feed token required", http.StatusUnauthorized)
+		return
+	}
+	if len(hidden) > 0 {
+		req.filter.ExcludeTags = hidden
+	}
+
+	name := "atom"
+	if req.format != nil {
+		name = *req.format
+	}
+	format, ok := feed.Lookup(name)
+	if !ok {
+		http.Error(w, fmt.Sprintf("Unknown format %q, want atom, rss or json", name), http.StatusBadRequest)
+		return
+	}
+
+	limit := h.opts.Limit
+	if req.limit != nil {
+		if *req.limit <= 0 {
+			http.Error(w, "limit must be positive", http.StatusBadRequest)
+			return
+		}
+		limit = min(*req.limit, h.opts.MaxLimit)
+	}
+
+	items, err := h.svc.Recent(r.Context(), req.filter, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	base := h.baseURL(r)
+	f := &feed.Feed{
+		Title:   req.title,
+		Link:    base + req.link,
+		SelfURL: base + selfPath(r),
+		BaseURL: base,
+		Items:   items,
+	}
+	var buf bytes.Buffer
+	if err := format.Render(&buf, f); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Rendering is deterministic, so equal bodies mean an unchanged feed.
+	sum := sha256.Sum256(buf.Bytes())
+	w.Header().Set("Content-Type", format.ContentType)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	if len(granted) > 0 {
+		w.Header().Set("Cache-Control", "private")
+	}
+	// ServeContent answers If-None-Match and If-Modified-Since with 304.
+	http.ServeContent(w, r, "", f.Updated(), bytes.NewReader(buf.Bytes()))
+}
+
+// grant returns the private tags the request's token grants. It reports
+// false for a token it does not know, so that a mistyped token is not
+// silently served the public feed. The token is accepted from the query
+// string because most feed readers cannot send headers.
+func (h *FeedHandler) grant(r *http.Request, token *string) ([]string, bool) {
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok && token != nil {
+		got = *token
+	}
+	if got == "" {
+		return nil, true
+	}
+	var tags []string
+	found := false
+	for t, g := range h.opts.Tokens {
+		if subtle.ConstantTimeCompare([]byte(got), []byte(t)) == 1 {
+			tags, found = g, true
+		}
+	}
+	if found && len(tags) == 0 {
+		tags = h.opts.PrivateTags
+	}
+	return tags, found
+}
+
+func (h *FeedHandler) baseURL(r *http.Request) string {
+	if h.opts.BaseURL != "" {
+		return strings.TrimSuffix(h.opts.BaseURL, "/")
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// selfPath is the request path and query without the token, which must not
+// end up in the document.
+func selfPath(r *http.Request) string {
+	q := r.URL.Query()
+	q.Del("token")
+	if len(q) == 0 {
+		return r.URL.EscapedPath()
+	}
+	return r.URL.EscapedPath() + "?" + q.Encode()
+}
@@ -0,0 +1,205 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFeedHandler_GetTagFeed(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	bookmarks := []*domain.Bookmark{
+		{ID: "1", Title: "Go", URL: "https://go.dev", Tags: []string{"go"}, CreatedAt: created, UpdatedAt: created},
+	}
+	format := func(f gen.GetTagFeedParamsFormat) *gen.GetTagFeedParamsFormat { return &f }
+	limit := func(n int) *int { return &n }
+	token := func(s string) *string { return &s }
+
+	tests := []struct {
+		name            string
+		opts            FeedOptions
+		params          gen.GetTagFeedParams
+		header          http.Header
+		mockBehavior    func(m *mocks.BookmarkService)
+		expectedCode    int
+		expectedType    string
+		expectedContain string
+		expectedCache   string
+	}{
+		{
+			name: "Default Atom",
+			opts: FeedOptions{BaseURL: "https://bm.example.org"},
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Recent", mock.Anything, domain.BookmarkFilter{Tag: "go"}, 50).Return(bookmarks, nil).Once()
+			},
+			expectedCode:    http.StatusOK,
+			expectedType:    "application/atom+xml; charset=utf-8",
+			expectedContain: "<id>https://bm.example.org/bookmarks/1</id>",
+		},
+		{
+			name:   "JSON Feed With Capped Limit",
+			opts:   FeedOptions{Limit: 10, MaxLimit: 20},
+			params: gen.GetTagFeedParams{Format: format("json"), Limit: limit(1000)},
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Recent", mock.Anything, domain.BookmarkFilter{Tag: "go"}, 20).Return(bookmarks, nil).Once()
+			},
+			expectedCode:    http.StatusOK,
+			expectedType:    "application/feed+json; charset=utf-8",
+			expectedContain: `"feed_url": "http://example.com/feeds/tag/go?format=json&limit=1000"`,
+		},
+		{
+			name:   "Not Modified Since",
+			params: gen.GetTagFeedParams{Format: format("rss")},
+			header: http.Header{"If-Modified-Since": {created.Add(time.Minute).Format(http.TimeFormat)}},
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Recent", mock.Anything, mock.Anything, mock.Anything).Return(bookmarks, nil).Once()
+			},
+			expectedCode: http.StatusNotModified,
+		},
+		{
+			name:         "Unknown Format",
+			params:       gen.GetTagFeedParams{Format: format("pdf")},
+			mockBehavior: func(_ *mocks.BookmarkService) {},
+			expectedCode: http.StatusBadRequest,
+			expectedType: "text/plain; charset=utf-8",
+		},
+		{
+			name: "Public Tag Without Private Bookmarks",
+			opts: FeedOptions{PrivateTags: []string{"work"}},
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Recent", mock.Anything, domain.BookmarkFilter{Tag: "go", ExcludeTags: []string{"work"}}, 50).Return(bookmarks, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedType: "application/atom+xml; charset=utf-8",
+		},
+		{
+			name:         "Private Tag Without Token",
+			opts:         FeedOptions{PrivateTags: []string{"go"}, Tokens: map[string][]string{"s3cret": nil}},
+			mockBehavior: func(_ *mocks.BookmarkService) {},
+			expectedCode: http.StatusUnauthorized,
+			expectedType: "text/plain; charset=utf-8",
+		},
+		{
+			name:         "Unknown Token",
+			opts:         FeedOptions{Tokens: map[string][]string{"s3cret": nil}},
+			params:       gen.GetTagFeedParams{Token: token("guess")},
+			mockBehavior: func(_ *mocks.BookmarkService) {},
+			expectedCode: http.StatusUnauthorized,
+			expectedType: "text/plain; charset=utf-8",
+		},
+		{
+			name:         "Token For Another Tag",
+			opts:         FeedOptions{PrivateTags: []string{"go", "work"}, Tokens: map[string][]string{"s3cret": {"work"}}},
+			params:       gen.GetTagFeedParams{Token: token("s3cret")},
+			mockBehavior: func(_ *mocks.BookmarkService) {},
+			expectedCode: http.StatusUnauthorized,
+			expectedType: "text/plain; charset=utf-8",
+		},
+		{
+			name:   "Private Tag With Bearer Token",
+			opts:   FeedOptions{PrivateTags: []string{"go", "work"}, Tokens: map[string][]string{"s3cret": {"go"}}},
+			header: http.Header{"Authorization": {"Bearer s3cret"}},
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Recent", mock.Anything, domain.BookmarkFilter{Tag: "go", ExcludeTags: []string{"work"}}, 50).Return(bookmarks, nil).Once()
+			},
+			expectedCode:  http.StatusOK,
+			expectedType:  "application/atom+xml; charset=utf-8",
+			expectedCache: "private",
+		},
+		{
+			name:   "Token For Every Private Tag",
+			opts:   FeedOptions{PrivateTags: []string{"go", "work"}, Tokens: map[string][]string{"s3cret": nil}},
+			params: gen.GetTagFeedParams{Token: token("s3cret")},
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Recent", mock.Anything, domain.BookmarkFilter{Tag: "go"}, 50).Return(bookmarks, nil).Once()
+			},
+			expectedCode:  http.StatusOK,
+			expectedType:  "application/atom+xml; charset=utf-8",
+			expectedCache: "private",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewBookmarkService(t)
+			tt.mockBehavior(mockSvc)
+
+			handler := NewFeedHandler(mockSvc, tt.opts)
+			target := "/feeds/tag/go"
+			if tt.params.Format != nil {
+				target += "?format=" + string(*tt.params.Format)
+			}
+			if tt.params.Limit != nil {
+				target += "&limit=1000"
+			}
+			req := httptest.NewRequest("GET", target, nil)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			w := httptest.NewRecorder()
+
+			handler.GetTagFeed(w, req, "go", tt.params)
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("GetTagFeed() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+			if got := w.Header().Get("Content-Type"); tt.expectedType != "" && got != tt.expectedType {
+				t.Errorf("GetTagFeed() Content-Type = %q, want %q", got, tt.expectedType)
+			}
+			if got := w.Header().Get("Cache-Control"); got != tt.expectedCache {
+				t.Errorf("GetTagFeed() Cache-Control = %q, want %q", got, tt.expectedCache)
+			}
+			if !strings.Contains(w.Body.String(), tt.expectedContain) {
+				t.Errorf("GetTagFeed() body = %q, want it to contain %q", w.Body.String(), tt.expectedContain)
+			}
+		})
+	}
+}
+
+func TestFeedHandler_ETag(t *testing.T) {
+	t.Parallel()
+
+	bookmarks := []*domain.Bookmark{{ID: "1", Title: "Go", URL: "https://go.dev", CreatedAt: time.Now()}}
+	mockSvc := mocks.NewBookmarkService(t)
+	mockSvc.On("Recent", mock.Anything, mock.Anything, mock.Anything).Return(bookmarks, nil).Twice()
+	handler := NewFeedHandler(mockSvc, FeedOptions{})
+
+	w := httptest.NewRecorder()
+	handler.GetAllFeed(w, httptest.NewRequest("GET", "/feeds/all", nil), gen.GetAllFeedParams{})
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag == "" {
+		t.Fatalf("GetAllFeed() status = %v, ETag = %q, want 200 with an ETag", w.Code, etag)
+	}
+
+	req := httptest.NewRequest("GET", "/feeds/all", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	handler.GetAllFeed(w, req, gen.GetAllFeedParams{})
+	if w.Code != http.StatusNotModified {
+		t.Errorf("GetAllFeed() with If-None-Match status = %v, want %v", w.Code, http.StatusNotModified)
+	}
+}
+
+func TestFeedHandler_SearchFeedRequiresQuery(t *testing.T) {
+	t.Parallel()
+
+	handler := NewFeedHandler(mocks.NewBookmarkService(t), FeedOptions{})
+	w := httptest.NewRecorder()
+	handler.SearchFeed(w, httptest.NewRequest("GET", "/feeds/search?q=", nil), gen.SearchFeedParams{Q: " "})
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("SearchFeed() status code = %v, want %v", w.Code, http.StatusBadRequest)
+	}
+}
@@ -8,65 +8,1978 @@ package gen
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/oapi-codegen/runtime"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for ArchiveMode.
+const (
+	Always ArchiveMode = "always"
+	Never  ArchiveMode = "never"
+)
+
+// Defines values for AuditOutcome.
+const (
+	Denied  AuditOutcome = "denied"
+	Failure AuditOutcome = "failure"
+	Success AuditOutcome = "success"
+)
+
+// Defines values for FieldChangeField.
+const (
+	FieldChangeFieldArchiveMode FieldChangeField = "archive_mode"
+	FieldChangeFieldDescription FieldChangeField = "description"
+	FieldChangeFieldTags        FieldChangeField = "tags"
+	FieldChangeFieldTitle       FieldChangeField = "title"
+	FieldChangeFieldUrl         FieldChangeField = "url"
+)
+
+// Defines values for ImportJobStatus.
+const (
+	ImportJobStatusCanceled  ImportJobStatus = "canceled"
+	ImportJobStatusCompleted ImportJobStatus = "completed"
+	ImportJobStatusFailed    ImportJobStatus = "failed"
+	ImportJobStatusPending   ImportJobStatus = "pending"
+	ImportJobStatusRunning   ImportJobStatus = "running"
+)
+
+// Defines values for LinkStatus.
+const (
+	Broken     LinkStatus = "broken"
+	Failing    LinkStatus = "failing"
+	Ok         LinkStatus = "ok"
+	Redirected LinkStatus = "redirected"
+	Unchecked  LinkStatus = "unchecked"
+)
+
+// Defines values for RevisionChange.
+const (
+	Created  RevisionChange = "created"
+	Deleted  RevisionChange = "deleted"
+	Restored RevisionChange = "restored"
+	Updated  RevisionChange = "updated"
+)
+
+// Defines values for SnippetField.
+const (
+	SnippetFieldContent     SnippetField = "content"
+	SnippetFieldDescription SnippetField = "description"
+	SnippetFieldTags        SnippetField = "tags"
+	SnippetFieldTitle       SnippetField = "title"
+	SnippetFieldUrl         SnippetField = "url"
+)
+
+// Defines values for SyncResultStatus.
+const (
+	SyncResultStatusApplied  SyncResultStatus = "applied"
+	SyncResultStatusConflict SyncResultStatus = "conflict"
+	SyncResultStatusFailed   SyncResultStatus = "failed"
+	SyncResultStatusInvalid  SyncResultStatus = "invalid"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	Failed    WebhookDeliveryStatus = "failed"
+	Pending   WebhookDeliveryStatus = "pending"
+	Succeeded WebhookDeliveryStatus = "succeeded"
+)
+
+// Defines values for FeedFormat.
+const (
+	FeedFormatAtom FeedFormat = "atom"
+	FeedFormatJson FeedFormat = "json"
+	FeedFormatRss  FeedFormat = "rss"
+)
+
+// Defines values for CiteBookmarkParamsStyle.
+const (
+	Bibtex  CiteBookmarkParamsStyle = "bibtex"
+	CslJson CiteBookmarkParamsStyle = "csl-json"
+)
+
+// Defines values for ExportBookmarksParamsFormat.
+const (
+	ExportBookmarksParamsFormatBibtex        ExportBookmarksParamsFormat = "bibtex"
+	ExportBookmarksParamsFormatCslJson       ExportBookmarksParamsFormat = "csl-json"
+	ExportBookmarksParamsFormatCsv           ExportBookmarksParamsFormat = "csv"
+	ExportBookmarksParamsFormatJson          ExportBookmarksParamsFormat = "json"
+	ExportBookmarksParamsFormatMarkdown      ExportBookmarksParamsFormat = "markdown"
+	ExportBookmarksParamsFormatMarkdownByTag ExportBookmarksParamsFormat = "markdown-by-tag"
+	ExportBookmarksParamsFormatNdjson        ExportBookmarksParamsFormat = "ndjson"
+	ExportBookmarksParamsFormatXbel          ExportBookmarksParamsFormat = "xbel"
+)
+
+// Defines values for GetAllFeedParamsFormat.
+const (
+	GetAllFeedParamsFormatAtom GetAllFeedParamsFormat = "atom"
+	GetAllFeedParamsFormatJson GetAllFeedParamsFormat = "json"
+	GetAllFeedParamsFormatRss  GetAllFeedParamsFormat = "rss"
+)
+
+// Defines values for SearchFeedParamsFormat.
+const (
+	SearchFeedParamsFormatAtom SearchFeedParamsFormat = "atom"
+	SearchFeedParamsFormatJson SearchFeedParamsFormat = "json"
+	SearchFeedParamsFormatRss  SearchFeedParamsFormat = "rss"
+)
+
+// Defines values for GetTagFeedParamsFormat.
+const (
+	Atom GetTagFeedParamsFormat = "atom"
+	Json GetTagFeedParamsFormat = "json"
+	Rss  GetTagFeedParamsFormat = "rss"
+)
+
+// ArchiveInfo The latest capture of the page. Absent before the first.
+type ArchiveInfo struct {
+	CapturedAt time.Time `json:"captured_at"`
+
+	// Error Why the latest capture failed; an earlier capture is kept.
+	Error *string `json:"error,omitempty"`
+
+	// Resources The page and the assets captured with it.
+	Resources int `json:"resources"`
+
+	// Size Bytes on disk.
+	Size int64 `json:"size"`
+}
+
+// ArchiveMode Overrides the tag policy for archiving the bookmark: `always` archives
+// it on creation, `never` does not.
+type ArchiveMode string
+
+// Attachment defines model for Attachment.
+type Attachment struct {
+	BookmarkId openapi_types.UUID `json:"bookmark_id"`
+
+	// ContentType Sniffed from the content.
+	ContentType string             `json:"content_type"`
+	CreatedAt   time.Time          `json:"created_at"`
+	Filename    string             `json:"filename"`
+	Id          openapi_types.UUID `json:"id"`
+
+	// Owner Who the upload authenticated as, whose quota it counts against:
+	// `admin` with an admin token, `anonymous` otherwise.
+	Owner string `json:"owner"`
+	Size  int64  `json:"size"`
+
+	// Thumbnail A preview of an image. Absent for other files.
+	Thumbnail *Thumbnail `json:"thumbnail,omitempty"`
+}
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	Action string `json:"action"`
+
+	// Actor Who the request authenticated as: `admin` with an admin token,
+	// `anonymous` otherwise.
+	Actor string `json:"actor"`
+
+	// AssertedActor The request's `X-Actor` header: who the caller claimed to be,
+	// which nothing verifies.
+	AssertedActor *string   `json:"asserted_actor,omitempty"`
+	At            time.Time `json:"at"`
+
+	// Hash SHA-256 of the entry's fields and prev_hash.
+	Hash      string       `json:"hash"`
+	Outcome   AuditOutcome `json:"outcome"`
+	PrevHash  string       `json:"prev_hash"`
+	RequestId *string      `json:"request_id,omitempty"`
+	Seq       int64        `json:"seq"`
+	SourceIp  string       `json:"source_ip"`
+
+	// Status The HTTP status of the response.
+	Status    int     `json:"status"`
+	Target    string  `json:"target"`
+	UserAgent *string `json:"user_agent,omitempty"`
+}
+
+// AuditOutcome defines model for AuditOutcome.
+type AuditOutcome string
+
+// AuditPage defines model for AuditPage.
+type AuditPage struct {
+	Entries []AuditEntry `json:"entries"`
+
+	// Next Pass as before to get the next page. Absent on the last page.
+	Next *int64 `json:"next,omitempty"`
+}
+
+// AuditVerification defines model for AuditVerification.
+type AuditVerification struct {
+	// BrokenAt The first entry that does not fit the chain.
+	BrokenAt *int64 `json:"broken_at,omitempty"`
+
+	// Checked How many entries were found intact.
+	Checked int  `json:"checked"`
+	Valid   bool `json:"valid"`
+}
+
 // Bookmark defines model for Bookmark.
 type Bookmark struct {
+	// Archive The latest capture of the page. Absent before the first.
+	Archive *ArchiveInfo `json:"archive,omitempty"`
+
+	// ArchiveMode Overrides the tag policy for archiving the bookmark: `always` archives
+	// it on creation, `never` does not.
+	ArchiveMode *ArchiveMode `json:"archive_mode,omitempty"`
+
+	// Content The text last extracted from the page. Absent before the first extraction.
+	Content   *ContentInfo `json:"content,omitempty"`
+	CreatedAt *time.Time   `json:"created_at,omitempty"`
+
+	// DeletedAt When the bookmark was moved to the trash. Absent outside it.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// Description Free-form notes about the bookmark.
+	Description *string `json:"description,omitempty"`
+
+	// Health Result of the latest link check. Absent before the first.
+	Health *LinkHealth `json:"health,omitempty"`
+
 	// Id Unique identifier for the bookmark.
 	Id *openapi_types.UUID `json:"id,omitempty"`
 
-	// Title The title of the bookmark.
-	Title string `json:"title"`
+	// Metadata Page metadata such as author and publisher.
+	Metadata *map[string]string `json:"metadata,omitempty"`
+	Tags     *[]string          `json:"tags,omitempty"`
+
+	// Title The title of the bookmark.
+	Title     string     `json:"title"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+
+	// Url The URL of the bookmark.
+	Url string `json:"url"`
+
+	// Version Counts the writes to the bookmark.
+	Version *int64 `json:"version,omitempty"`
+}
+
+// BookmarkInput defines model for BookmarkInput.
+type BookmarkInput struct {
+	// ArchiveMode Overrides the tag policy for archiving the bookmark: `always` archives
+	// it on creation, `never` does not.
+	ArchiveMode *ArchiveMode `json:"archive_mode,omitempty"`
+	Tags        *[]string    `json:"tags,omitempty"`
+
+	// Title The title of the bookmark.
+	Title string `json:"title"`
+
+	// Url The URL of the bookmark.
+	Url string `json:"url"`
+}
+
+// ContentInfo The text last extracted from the page. Absent before the first extraction.
+type ContentInfo struct {
+	// Error Why the latest extraction failed; earlier text stays searchable.
+	Error       *string   `json:"error,omitempty"`
+	ExtractedAt time.Time `json:"extracted_at"`
+
+	// ReadingMinutes Estimated reading time, at 230 words a minute.
+	ReadingMinutes int `json:"reading_minutes"`
+	WordCount      int `json:"word_count"`
+}
+
+// FieldChange defines model for FieldChange.
+type FieldChange struct {
+	Field FieldChangeField `json:"field"`
+
+	// From The value in the older revision.
+	From interface{} `json:"from"`
+
+	// To The value in the newer revision.
+	To interface{} `json:"to"`
+}
+
+// FieldChangeField defines model for FieldChange.Field.
+type FieldChangeField string
+
+// ImportItem defines model for ImportItem.
+type ImportItem struct {
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	Description *string    `json:"description,omitempty"`
+
+	// Id Kept when present so that exports can be restored with their IDs.
+	Id        *string            `json:"id,omitempty"`
+	Metadata  *map[string]string `json:"metadata,omitempty"`
+	Tags      *[]string          `json:"tags,omitempty"`
+	Title     string             `json:"title"`
+	UpdatedAt *time.Time         `json:"updated_at,omitempty"`
+	Url       string             `json:"url"`
+}
+
+// ImportItemError defines model for ImportItemError.
+type ImportItemError struct {
+	Error string `json:"error"`
+
+	// Index Position of the rejected item in the submitted array.
+	Index int    `json:"index"`
+	Url   string `json:"url"`
+}
+
+// ImportJob defines model for ImportJob.
+type ImportJob struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Errors Details of the first 100 rejected items.
+	Errors     []ImportItemError   `json:"errors"`
+	Failed     int                 `json:"failed"`
+	FinishedAt *time.Time          `json:"finished_at,omitempty"`
+	Id         *openapi_types.UUID `json:"id,omitempty"`
+	Imported   int                 `json:"imported"`
+
+	// Processed Items handled so far; also the resume checkpoint.
+	Processed int             `json:"processed"`
+	Status    ImportJobStatus `json:"status"`
+	Total     int             `json:"total"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// ImportJobStatus defines model for ImportJob.Status.
+type ImportJobStatus string
+
+// LinkCheckRun defines model for LinkCheckRun.
+type LinkCheckRun struct {
+	Checked int `json:"checked"`
+	Failed  int `json:"failed"`
+
+	// FinishedAt Absent while the pass is still running.
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	// Moved Bookmarks whose URL was replaced after a permanent redirect.
+	Moved     int       `json:"moved"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// LinkHealth Result of the latest link check. Absent before the first.
+type LinkHealth struct {
+	CheckedAt           time.Time `json:"checked_at"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	Error               *string   `json:"error,omitempty"`
+
+	// FinalUrl Where redirects ended.
+	FinalUrl  *string `json:"final_url,omitempty"`
+	LatencyMs int64   `json:"latency_ms"`
+
+	// PreviousUrl The URL before a permanent redirect replaced it.
+	PreviousUrl *string    `json:"previous_url,omitempty"`
+	Status      LinkStatus `json:"status"`
+
+	// StatusCode HTTP status of the response; absent when none arrived.
+	StatusCode *int `json:"status_code,omitempty"`
+}
+
+// LinkHealthReport defines model for LinkHealthReport.
+type LinkHealthReport struct {
+	Broken   []Bookmark     `json:"broken"`
+	ByStatus map[string]int `json:"by_status"`
+
+	// ByStatusCode Bookmarks by HTTP status of their latest check; "error" counts checks without a response.
+	ByStatusCode map[string]int `json:"by_status_code"`
+	LastRun      *LinkCheckRun  `json:"last_run,omitempty"`
+	Total        int            `json:"total"`
+}
+
+// LinkStatus defines model for LinkStatus.
+type LinkStatus string
+
+// Revision defines model for Revision.
+type Revision struct {
+	// Actor Who made the change, when known.
+	Actor      *string        `json:"actor,omitempty"`
+	At         time.Time      `json:"at"`
+	Bookmark   Bookmark       `json:"bookmark"`
+	BookmarkId string         `json:"bookmark_id"`
+	Change     RevisionChange `json:"change"`
+
+	// Fields The fields that differ from the revision before.
+	Fields []string `json:"fields"`
+	Number int64    `json:"number"`
+}
+
+// RevisionChange defines model for Revision.Change.
+type RevisionChange string
+
+// RevisionDiff defines model for RevisionDiff.
+type RevisionDiff struct {
+	BookmarkId string        `json:"bookmark_id"`
+	Changes    []FieldChange `json:"changes"`
+	From       int64         `json:"from"`
+	To         int64         `json:"to"`
+}
+
+// SearchResult defines model for SearchResult.
+type SearchResult struct {
+	Bookmark Bookmark  `json:"bookmark"`
+	Score    float64   `json:"score"`
+	Snippets []Snippet `json:"snippets"`
+}
+
+// Snippet defines model for Snippet.
+type Snippet struct {
+	Field SnippetField `json:"field"`
+
+	// Text HTML-escaped excerpt with every matched word wrapped in `<mark>`.
+	Text string `json:"text"`
+}
+
+// SnippetField defines model for Snippet.Field.
+type SnippetField string
+
+// SyncChangeInput defines model for SyncChangeInput.
+type SyncChangeInput struct {
+	Deleted     *bool   `json:"deleted,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	// Id The bookmark's ID. A client creating a bookmark may choose it,
+	// so that pushing the change again does not create a second one.
+	Id    *openapi_types.UUID `json:"id,omitempty"`
+	Tags  *[]string           `json:"tags,omitempty"`
+	Title *string             `json:"title,omitempty"`
+
+	// UpdatedAt When the change was made.
+	UpdatedAt time.Time `json:"updated_at"`
+	Url       *string   `json:"url,omitempty"`
+
+	// Version The version the change was made to; 0 or absent for a new bookmark.
+	Version *int64 `json:"version,omitempty"`
+}
+
+// SyncPage defines model for SyncPage.
+type SyncPage struct {
+	// Full The upserts are every bookmark, replacing the local copy.
+	Full bool `json:"full"`
+
+	// More More changes are waiting.
+	More bool `json:"more"`
+
+	// Token Pass as `since` in the next pull.
+	Token      string      `json:"token"`
+	Tombstones []Tombstone `json:"tombstones"`
+	Upserts    []Bookmark  `json:"upserts"`
+}
+
+// SyncPushInput defines model for SyncPushInput.
+type SyncPushInput struct {
+	Changes []SyncChangeInput `json:"changes"`
+}
+
+// SyncResult defines model for SyncResult.
+type SyncResult struct {
+	Bookmark *Bookmark          `json:"bookmark,omitempty"`
+	Error    *string            `json:"error,omitempty"`
+	Id       openapi_types.UUID `json:"id"`
+	Status   SyncResultStatus   `json:"status"`
+}
+
+// SyncResultStatus defines model for SyncResult.Status.
+type SyncResultStatus string
+
+// Thumbnail A preview of an image. Absent for other files.
+type Thumbnail struct {
+	ContentType string `json:"content_type"`
+	Height      int    `json:"height"`
+	Width       int    `json:"width"`
+}
+
+// Tombstone defines model for Tombstone.
+type Tombstone struct {
+	DeletedAt time.Time          `json:"deleted_at"`
+	Id        openapi_types.UUID `json:"id"`
+}
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// DisabledAt When the webhook was disabled after failing repeatedly.
+	DisabledAt     *time.Time `json:"disabled_at,omitempty"`
+	DisabledReason *string    `json:"disabled_reason,omitempty"`
+	Events         []string   `json:"events"`
+
+	// Failures Failed attempts since the last success.
+	Failures  int                 `json:"failures"`
+	Id        *openapi_types.UUID `json:"id,omitempty"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	Url       string              `json:"url"`
+}
+
+// WebhookAttempt defines model for WebhookAttempt.
+type WebhookAttempt struct {
+	At         time.Time `json:"at"`
+	DurationMs int64     `json:"duration_ms"`
+	Error      *string   `json:"error,omitempty"`
+
+	// Response The start of the response body.
+	Response *string `json:"response,omitempty"`
+
+	// StatusCode Absent when no response was received.
+	StatusCode *int `json:"status_code,omitempty"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts  []WebhookAttempt `json:"attempts"`
+	CreatedAt time.Time        `json:"created_at"`
+
+	// EventId The same for every delivery of an event, so receivers can ignore repeats.
+	EventId       string                `json:"event_id"`
+	EventType     string                `json:"event_type"`
+	Id            openapi_types.UUID    `json:"id"`
+	NextAttemptAt *time.Time            `json:"next_attempt_at,omitempty"`
+	RedeliveryOf  *string               `json:"redelivery_of,omitempty"`
+	Status        WebhookDeliveryStatus `json:"status"`
+	WebhookId     openapi_types.UUID    `json:"webhook_id"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDelivery.Status.
+type WebhookDeliveryStatus string
+
+// WebhookInput defines model for WebhookInput.
+type WebhookInput struct {
+	// Active Defaults to true. New webhooks are always active.
+	Active *bool `json:"active,omitempty"`
+
+	// Events Event types to send, such as `bookmark.created`, or prefixes such
+	// as `bookmark.*`. Empty or absent sends every event.
+	Events *[]string `json:"events,omitempty"`
+
+	// Secret At least 16 characters. Generated when absent.
+	Secret *string `json:"secret,omitempty"`
+
+	// Url Where events are POSTed. Private and loopback addresses are refused unless allowlisted.
+	Url string `json:"url"`
+}
+
+// FeedFormat defines model for FeedFormat.
+type FeedFormat string
+
+// FeedLimit defines model for FeedLimit.
+type FeedLimit = int
+
+// FeedToken defines model for FeedToken.
+type FeedToken = string
+
+// QueryFilter defines model for QueryFilter.
+type QueryFilter = string
+
+// TagFilter defines model for TagFilter.
+type TagFilter = string
+
+// QueryAuditLogParams defines parameters for QueryAuditLog.
+type QueryAuditLogParams struct {
+	Actor *string `form:"actor,omitempty" json:"actor,omitempty"`
+
+	// AssertedActor The `X-Actor` header the requests were sent with.
+	AssertedActor *string `form:"asserted_actor,omitempty" json:"asserted_actor,omitempty"`
+
+	// Action The route, e.g. "DELETE /bookmarks/{id}".
+	Action *string `form:"action,omitempty" json:"action,omitempty"`
+
+	// Target The request path, e.g. "/bookmarks/4f0c…".
+	Target  *string       `form:"target,omitempty" json:"target,omitempty"`
+	Outcome *AuditOutcome `form:"outcome,omitempty" json:"outcome,omitempty"`
+
+	// Since Only entries at or after this time.
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only entries before this time.
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+
+	// Before Only entries older than this sequence number.
+	Before *int64 `form:"before,omitempty" json:"before,omitempty"`
+
+	// Limit Most entries to return. Defaults to 100, at most 1000.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetAllBookmarksParams defines parameters for GetAllBookmarks.
+type GetAllBookmarksParams struct {
+	// Tag Only bookmarks carrying this tag.
+	Tag *TagFilter `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Q Case-insensitive text matched against title, URL and description.
+	Q *QueryFilter `form:"q,omitempty" json:"q,omitempty"`
+
+	// Health Only bookmarks whose latest link check has this status.
+	Health *LinkStatus `form:"health,omitempty" json:"health,omitempty"`
+}
+
+// GetBookmarkArchiveParams defines parameters for GetBookmarkArchive.
+type GetBookmarkArchiveParams struct {
+	// Url Original URL of a captured asset. Omit for the page itself.
+	Url *string `form:"url,omitempty" json:"url,omitempty"`
+}
+
+// UploadAttachmentMultipartBody defines parameters for UploadAttachment.
+type UploadAttachmentMultipartBody struct {
+	File openapi_types.File `json:"file"`
+}
+
+// CiteBookmarkParams defines parameters for CiteBookmark.
+type CiteBookmarkParams struct {
+	// Style Citation format. Defaults to bibtex.
+	Style *CiteBookmarkParamsStyle `form:"style,omitempty" json:"style,omitempty"`
+}
+
+// CiteBookmarkParamsStyle defines parameters for CiteBookmark.
+type CiteBookmarkParamsStyle string
+
+// DiffRevisionsParams defines parameters for DiffRevisions.
+type DiffRevisionsParams struct {
+	// From The number of the older revision.
+	From int64 `form:"from" json:"from"`
+
+	// To The number of the newer revision. Defaults to the latest.
+	To *int64 `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// StreamEventsParams defines parameters for StreamEvents.
+type StreamEventsParams struct {
+	// Tag Only bookmarks with this tag.
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Host Only bookmarks whose URL has this host name.
+	Host *string `form:"host,omitempty" json:"host,omitempty"`
+
+	// Token Stream access token, when tokens are configured. May also be sent
+	// as an "Authorization: Bearer" header. A token may be limited to
+	// bookmarks with some tags.
+	Token *string `form:"token,omitempty" json:"token,omitempty"`
+
+	// LastEventID Resume after this event, replaying what was missed.
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// ExportBookmarksParams defines parameters for ExportBookmarks.
+type ExportBookmarksParams struct {
+	// Format Output format. Defaults to json. The markdown formats download a zip
+	// of an Obsidian-style vault; bibtex and csl-json are citation
+	// libraries. None of these can be imported back.
+	Format *ExportBookmarksParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Id Only the bookmarks with these IDs. Repeat to select several.
+	Id *[]string `form:"id,omitempty" json:"id,omitempty"`
+
+	// Tag Only bookmarks carrying this tag.
+	Tag *TagFilter `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Q Case-insensitive text matched against title, URL and description.
+	Q *QueryFilter `form:"q,omitempty" json:"q,omitempty"`
+}
+
+// ExportBookmarksParamsFormat defines parameters for ExportBookmarks.
+type ExportBookmarksParamsFormat string
+
+// GetFaviconParams defines parameters for GetFavicon.
+type GetFaviconParams struct {
+	// Size Width and height in pixels, rounded up to 16, 32, 64 or 128.
+	// Defaults to 32.
+	Size *int `form:"size,omitempty" json:"size,omitempty"`
+}
+
+// GetAllFeedParams defines parameters for GetAllFeed.
+type GetAllFeedParams struct {
+	// Format Feed format. Defaults to atom.
+	Format *GetAllFeedParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Limit Number of most recent bookmarks in the feed.
+	Limit *FeedLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Token Access token that adds the private tags it grants to the feed. May
+	// also be sent as an "Authorization: Bearer" header.
+	Token *FeedToken `form:"token,omitempty" json:"token,omitempty"`
+}
+
+// GetAllFeedParamsFormat defines parameters for GetAllFeed.
+type GetAllFeedParamsFormat string
+
+// SearchFeedParams defines parameters for SearchFeed.
+type SearchFeedParams struct {
+	// Q Case-insensitive text matched against title, URL and description.
+	Q string `form:"q" json:"q"`
+
+	// Format Feed format. Defaults to atom.
+	Format *SearchFeedParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Limit Number of most recent bookmarks in the feed.
+	Limit *FeedLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Token Access token that adds the private tags it grants to the feed. May
+	// also be sent as an "Authorization: Bearer" header.
+	Token *FeedToken `form:"token,omitempty" json:"token,omitempty"`
+}
+
+// SearchFeedParamsFormat defines parameters for SearchFeed.
+type SearchFeedParamsFormat string
+
+// GetTagFeedParams defines parameters for GetTagFeed.
+type GetTagFeedParams struct {
+	// Format Feed format. Defaults to atom.
+	Format *GetTagFeedParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Limit Number of most recent bookmarks in the feed.
+	Limit *FeedLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Token Access token that adds the private tags it grants to the feed. May
+	// also be sent as an "Authorization: Bearer" header.
+	Token *FeedToken `form:"token,omitempty" json:"token,omitempty"`
+}
+
+// GetTagFeedParamsFormat defines parameters for GetTagFeed.
+type GetTagFeedParamsFormat string
+
+// CreateImportJSONBody defines parameters for CreateImport.
+type CreateImportJSONBody = []ImportItem
+
+// SearchBookmarksParams defines parameters for SearchBookmarks.
+type SearchBookmarksParams struct {
+	Q string `form:"q" json:"q"`
+
+	// Limit Maximum number of results. Defaults to 20.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PullChangesParams defines parameters for PullChanges.
+type PullChangesParams struct {
+	// Since The token from the previous pull.
+	Since *string `form:"since,omitempty" json:"since,omitempty"`
+
+	// Limit Most changes to return. Capped by the server.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// CreateBookmarkJSONRequestBody defines body for CreateBookmark for application/json ContentType.
+type CreateBookmarkJSONRequestBody = BookmarkInput
+
+// UploadAttachmentMultipartRequestBody defines body for UploadAttachment for multipart/form-data ContentType.
+type UploadAttachmentMultipartRequestBody UploadAttachmentMultipartBody
+
+// CreateImportJSONRequestBody defines body for CreateImport for application/json ContentType.
+type CreateImportJSONRequestBody = CreateImportJSONBody
+
+// PushChangesJSONRequestBody defines body for PushChanges for application/json ContentType.
+type PushChangesJSONRequestBody = SyncPushInput
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = WebhookInput
+
+// UpdateWebhookJSONRequestBody defines body for UpdateWebhook for application/json ContentType.
+type UpdateWebhookJSONRequestBody = WebhookInput
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Query the audit log
+	// (GET /audit)
+	QueryAuditLog(w http.ResponseWriter, r *http.Request, params QueryAuditLogParams)
+	// Check the audit log for tampering
+	// (GET /audit/verify)
+	VerifyAuditLog(w http.ResponseWriter, r *http.Request)
+	// Get all bookmarks
+	// (GET /bookmarks)
+	GetAllBookmarks(w http.ResponseWriter, r *http.Request, params GetAllBookmarksParams)
+	// Create a new bookmark
+	// (POST /bookmarks)
+	CreateBookmark(w http.ResponseWriter, r *http.Request)
+	// Move a bookmark to the trash
+	// (DELETE /bookmarks/{id})
+	DeleteBookmark(w http.ResponseWriter, r *http.Request, id string)
+	// Get a bookmark by ID
+	// (GET /bookmarks/{id})
+	GetBookmarkByID(w http.ResponseWriter, r *http.Request, id string)
+	// View the archived page
+	// (GET /bookmarks/{id}/archive)
+	GetBookmarkArchive(w http.ResponseWriter, r *http.Request, id string, params GetBookmarkArchiveParams)
+	// Archive the page now
+	// (POST /bookmarks/{id}/archive)
+	ArchiveBookmark(w http.ResponseWriter, r *http.Request, id string)
+	// List a bookmark's attachments
+	// (GET /bookmarks/{id}/attachments)
+	ListAttachments(w http.ResponseWriter, r *http.Request, id string)
+	// Attach a file
+	// (POST /bookmarks/{id}/attachments)
+	UploadAttachment(w http.ResponseWriter, r *http.Request, id string)
+	// Delete an attachment
+	// (DELETE /bookmarks/{id}/attachments/{attachmentId})
+	DeleteAttachment(w http.ResponseWriter, r *http.Request, id string, attachmentId string)
+	// Download an attachment
+	// (GET /bookmarks/{id}/attachments/{attachmentId})
+	GetAttachment(w http.ResponseWriter, r *http.Request, id string, attachmentId string)
+	// Get an image attachment's thumbnail
+	// (GET /bookmarks/{id}/attachments/{attachmentId}/thumbnail)
+	GetAttachmentThumbnail(w http.ResponseWriter, r *http.Request, id string, attachmentId string)
+	// Cite a bookmark
+	// (GET /bookmarks/{id}/cite)
+	CiteBookmark(w http.ResponseWriter, r *http.Request, id string, params CiteBookmarkParams)
+	// List a bookmark's revisions
+	// (GET /bookmarks/{id}/revisions)
+	ListRevisions(w http.ResponseWriter, r *http.Request, id string)
+	// Compare two revisions of a bookmark
+	// (GET /bookmarks/{id}/revisions/diff)
+	DiffRevisions(w http.ResponseWriter, r *http.Request, id string, params DiffRevisionsParams)
+	// Revert a bookmark to a revision
+	// (POST /bookmarks/{id}/revisions/{rev}/revert)
+	RevertRevision(w http.ResponseWriter, r *http.Request, id string, rev int64)
+	// Stream bookmark changes as Server-Sent Events
+	// (GET /events/stream)
+	StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams)
+	// Export bookmarks
+	// (GET /export)
+	ExportBookmarks(w http.ResponseWriter, r *http.Request, params ExportBookmarksParams)
+	// Get a site's icon
+	// (GET /favicons/{host})
+	GetFavicon(w http.ResponseWriter, r *http.Request, host string, params GetFaviconParams)
+	// Feed of recent bookmarks
+	// (GET /feeds/all)
+	GetAllFeed(w http.ResponseWriter, r *http.Request, params GetAllFeedParams)
+	// Feed of recent bookmarks matching a search
+	// (GET /feeds/search)
+	SearchFeed(w http.ResponseWriter, r *http.Request, params SearchFeedParams)
+	// Feed of recent bookmarks carrying a tag
+	// (GET /feeds/tag/{tag})
+	GetTagFeed(w http.ResponseWriter, r *http.Request, tag string, params GetTagFeedParams)
+	// Start a background import
+	// (POST /imports)
+	CreateImport(w http.ResponseWriter, r *http.Request)
+	// Get the progress of an import job
+	// (GET /imports/{id})
+	GetImport(w http.ResponseWriter, r *http.Request, id string)
+	// Cancel an import job
+	// (POST /imports/{id}/cancel)
+	CancelImport(w http.ResponseWriter, r *http.Request, id string)
+	// Summarize link health
+	// (GET /reports/link-health)
+	GetLinkHealthReport(w http.ResponseWriter, r *http.Request)
+	// Search bookmarks and their pages
+	// (GET /search)
+	SearchBookmarks(w http.ResponseWriter, r *http.Request, params SearchBookmarksParams)
+	// Pull the changes since a sync token
+	// (GET /sync)
+	PullChanges(w http.ResponseWriter, r *http.Request, params PullChangesParams)
+	// Push changes made to a local copy
+	// (POST /sync)
+	PushChanges(w http.ResponseWriter, r *http.Request)
+	// List the bookmarks in the trash
+	// (GET /trash)
+	ListTrash(w http.ResponseWriter, r *http.Request)
+	// Purge a bookmark from the trash
+	// (DELETE /trash/{id})
+	PurgeBookmark(w http.ResponseWriter, r *http.Request, id string)
+	// Restore a bookmark from the trash
+	// (POST /trash/{id}/restore)
+	RestoreBookmark(w http.ResponseWriter, r *http.Request, id string)
+	// List webhooks
+	// (GET /webhooks)
+	ListWebhooks(w http.ResponseWriter, r *http.Request)
+	// Subscribe a URL to events
+	// (POST /webhooks)
+	CreateWebhook(w http.ResponseWriter, r *http.Request)
+	// Delete a webhook and its delivery history
+	// (DELETE /webhooks/{id})
+	DeleteWebhook(w http.ResponseWriter, r *http.Request, id string)
+	// Get a webhook
+	// (GET /webhooks/{id})
+	GetWebhook(w http.ResponseWriter, r *http.Request, id string)
+	// Replace a webhook's settings
+	// (PUT /webhooks/{id})
+	UpdateWebhook(w http.ResponseWriter, r *http.Request, id string)
+	// List a webhook's recent deliveries
+	// (GET /webhooks/{id}/deliveries)
+	ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, id string)
+	// Get a delivery and its attempts
+	// (GET /webhooks/{id}/deliveries/{deliveryId})
+	GetWebhookDelivery(w http.ResponseWriter, r *http.Request, id string, deliveryId string)
+	// Send a delivery again
+	// (POST /webhooks/{id}/deliveries/{deliveryId}/redeliver)
+	RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request, id string, deliveryId string)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// QueryAuditLog operation middleware
+func (siw *ServerInterfaceWrapper) QueryAuditLog(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params QueryAuditLogParams
+
+	// ------------- Optional query parameter "actor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor", r.URL.Query(), &params.Actor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "actor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "asserted_actor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "asserted_actor", r.URL.Query(), &params.AssertedActor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "asserted_actor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "action" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "action", r.URL.Query(), &params.Action)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "action", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "target" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target", r.URL.Query(), &params.Target)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "target", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "outcome" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "outcome", r.URL.Query(), &params.Outcome)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "outcome", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", r.URL.Query(), &params.Until)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "until", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "before", r.URL.Query(), &params.Before)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.QueryAuditLog(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// VerifyAuditLog operation middleware
+func (siw *ServerInterfaceWrapper) VerifyAuditLog(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.VerifyAuditLog(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAllBookmarks operation middleware
+func (siw *ServerInterfaceWrapper) GetAllBookmarks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAllBookmarksParams
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "health" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "health", r.URL.Query(), &params.Health)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "health", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAllBookmarks(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateBookmark operation middleware
+func (siw *ServerInterfaceWrapper) CreateBookmark(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateBookmark(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteBookmark operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBookmark(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBookmark(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBookmarkByID operation middleware
+func (siw *ServerInterfaceWrapper) GetBookmarkByID(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBookmarkByID(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBookmarkArchive operation middleware
+func (siw *ServerInterfaceWrapper) GetBookmarkArchive(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBookmarkArchiveParams
+
+	// ------------- Optional query parameter "url" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "url", r.URL.Query(), &params.Url)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "url", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBookmarkArchive(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ArchiveBookmark operation middleware
+func (siw *ServerInterfaceWrapper) ArchiveBookmark(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ArchiveBookmark(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListAttachments operation middleware
+func (siw *ServerInterfaceWrapper) ListAttachments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAttachments(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UploadAttachment operation middleware
+func (siw *ServerInterfaceWrapper) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadAttachment(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAttachment operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "attachmentId" -------------
+	var attachmentId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "attachmentId", r.PathValue("attachmentId"), &attachmentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "attachmentId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAttachment(w, r, id, attachmentId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAttachment operation middleware
+func (siw *ServerInterfaceWrapper) GetAttachment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "attachmentId" -------------
+	var attachmentId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "attachmentId", r.PathValue("attachmentId"), &attachmentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "attachmentId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAttachment(w, r, id, attachmentId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAttachmentThumbnail operation middleware
+func (siw *ServerInterfaceWrapper) GetAttachmentThumbnail(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "attachmentId" -------------
+	var attachmentId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "attachmentId", r.PathValue("attachmentId"), &attachmentId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "attachmentId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAttachmentThumbnail(w, r, id, attachmentId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CiteBookmark operation middleware
+func (siw *ServerInterfaceWrapper) CiteBookmark(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CiteBookmarkParams
+
+	// ------------- Optional query parameter "style" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "style", r.URL.Query(), &params.Style)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "style", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CiteBookmark(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListRevisions operation middleware
+func (siw *ServerInterfaceWrapper) ListRevisions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListRevisions(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DiffRevisions operation middleware
+func (siw *ServerInterfaceWrapper) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DiffRevisionsParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DiffRevisions(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevertRevision operation middleware
+func (siw *ServerInterfaceWrapper) RevertRevision(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "rev" -------------
+	var rev int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "rev", r.PathValue("rev"), &rev, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "rev", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevertRevision(w, r, id, rev)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StreamEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamEventsParams
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "host" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "host", r.URL.Query(), &params.Host)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "host", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "token" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "token", r.URL.Query(), &params.Token)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportBookmarks operation middleware
+func (siw *ServerInterfaceWrapper) ExportBookmarks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportBookmarksParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "id", r.URL.Query(), &params.Id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportBookmarks(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetFavicon operation middleware
+func (siw *ServerInterfaceWrapper) GetFavicon(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "host" -------------
+	var host string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "host", r.PathValue("host"), &host, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "host", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFaviconParams
+
+	// ------------- Optional query parameter "size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "size", r.URL.Query(), &params.Size)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "size", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFavicon(w, r, host, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAllFeed operation middleware
+func (siw *ServerInterfaceWrapper) GetAllFeed(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAllFeedParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "token" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "token", r.URL.Query(), &params.Token)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAllFeed(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchFeed operation middleware
+func (siw *ServerInterfaceWrapper) SearchFeed(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchFeedParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "token" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "token", r.URL.Query(), &params.Token)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchFeed(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTagFeed operation middleware
+func (siw *ServerInterfaceWrapper) GetTagFeed(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tag" -------------
+	var tag string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tag", r.PathValue("tag"), &tag, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTagFeedParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "token" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "token", r.URL.Query(), &params.Token)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTagFeed(w, r, tag, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateImport operation middleware
+func (siw *ServerInterfaceWrapper) CreateImport(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateImport(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetImport operation middleware
+func (siw *ServerInterfaceWrapper) GetImport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetImport(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelImport operation middleware
+func (siw *ServerInterfaceWrapper) CancelImport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelImport(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetLinkHealthReport operation middleware
+func (siw *ServerInterfaceWrapper) GetLinkHealthReport(w http.ResponseWriter, r *http.Request) {
 
-	// Url The URL of the bookmark.
-	Url string `json:"url"`
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLinkHealthReport(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// BookmarkInput defines model for BookmarkInput.
-type BookmarkInput struct {
-	// Title The title of the bookmark.
-	Title string `json:"title"`
+// SearchBookmarks operation middleware
+func (siw *ServerInterfaceWrapper) SearchBookmarks(w http.ResponseWriter, r *http.Request) {
 
-	// Url The URL of the bookmark.
-	Url string `json:"url"`
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchBookmarksParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchBookmarks(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// CreateBookmarkJSONRequestBody defines body for CreateBookmark for application/json ContentType.
-type CreateBookmarkJSONRequestBody = BookmarkInput
+// PullChanges operation middleware
+func (siw *ServerInterfaceWrapper) PullChanges(w http.ResponseWriter, r *http.Request) {
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Get all bookmarks
-	// (GET /bookmarks)
-	GetAllBookmarks(w http.ResponseWriter, r *http.Request)
-	// Create a new bookmark
-	// (POST /bookmarks)
-	CreateBookmark(w http.ResponseWriter, r *http.Request)
-	// Delete a bookmark by ID
-	// (DELETE /bookmarks/{id})
-	DeleteBookmark(w http.ResponseWriter, r *http.Request, id string)
-	// Get a bookmark by ID
-	// (GET /bookmarks/{id})
-	GetBookmarkByID(w http.ResponseWriter, r *http.Request, id string)
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PullChangesParams
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PullChanges(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// PushChanges operation middleware
+func (siw *ServerInterfaceWrapper) PushChanges(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PushChanges(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// ListTrash operation middleware
+func (siw *ServerInterfaceWrapper) ListTrash(w http.ResponseWriter, r *http.Request) {
 
-// GetAllBookmarks operation middleware
-func (siw *ServerInterfaceWrapper) GetAllBookmarks(w http.ResponseWriter, r *http.Request) {
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTrash(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PurgeBookmark operation middleware
+func (siw *ServerInterfaceWrapper) PurgeBookmark(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAllBookmarks(w, r)
+		siw.Handler.PurgeBookmark(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -76,11 +1989,22 @@ func (siw *ServerInterfaceWrapper) GetAllBookmarks(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// CreateBookmark operation middleware
-func (siw *ServerInterfaceWrapper) CreateBookmark(w http.ResponseWriter, r *http.Request) {
+// RestoreBookmark operation middleware
+func (siw *ServerInterfaceWrapper) RestoreBookmark(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateBookmark(w, r)
+		siw.Handler.RestoreBookmark(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -90,8 +2014,36 @@ func (siw *ServerInterfaceWrapper) CreateBookmark(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteBookmark operation middleware
-func (siw *ServerInterfaceWrapper) DeleteBookmark(w http.ResponseWriter, r *http.Request) {
+// ListWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhooks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateWebhook(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -105,7 +2057,7 @@ func (siw *ServerInterfaceWrapper) DeleteBookmark(w http.ResponseWriter, r *http
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteBookmark(w, r, id)
+		siw.Handler.DeleteWebhook(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -115,8 +2067,8 @@ func (siw *ServerInterfaceWrapper) DeleteBookmark(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// GetBookmarkByID operation middleware
-func (siw *ServerInterfaceWrapper) GetBookmarkByID(w http.ResponseWriter, r *http.Request) {
+// GetWebhook operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhook(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -130,7 +2082,125 @@ func (siw *ServerInterfaceWrapper) GetBookmarkByID(w http.ResponseWriter, r *htt
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetBookmarkByID(w, r, id)
+		siw.Handler.GetWebhook(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateWebhook(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhookDeliveries(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetWebhookDelivery operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "deliveryId" -------------
+	var deliveryId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deliveryId", r.PathValue("deliveryId"), &deliveryId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deliveryId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebhookDelivery(w, r, id, deliveryId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RedeliverWebhookDelivery operation middleware
+func (siw *ServerInterfaceWrapper) RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "deliveryId" -------------
+	var deliveryId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deliveryId", r.PathValue("deliveryId"), &deliveryId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deliveryId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RedeliverWebhookDelivery(w, r, id, deliveryId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -260,10 +2330,47 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	m.HandleFunc("GET "+options.BaseURL+"/audit", wrapper.QueryAuditLog)
+	m.HandleFunc("GET "+options.BaseURL+"/audit/verify", wrapper.VerifyAuditLog)
 	m.HandleFunc("GET "+options.BaseURL+"/bookmarks", wrapper.GetAllBookmarks)
 	m.HandleFunc("POST "+options.BaseURL+"/bookmarks", wrapper.CreateBookmark)
 	m.HandleFunc("DELETE "+options.BaseURL+"/bookmarks/{id}", wrapper.DeleteBookmark)
 	m.HandleFunc("GET "+options.BaseURL+"/bookmarks/{id}", wrapper.GetBookmarkByID)
+	m.HandleFunc("GET "+options.BaseURL+"/bookmarks/{id}/archive", wrapper.GetBookmarkArchive)
+	m.HandleFunc("POST "+options.BaseURL+"/bookmarks/{id}/archive", wrapper.ArchiveBookmark)
+	m.HandleFunc("GET "+options.BaseURL+"/bookmarks/{id}/attachments", wrapper.ListAttachments)
+	m.HandleFunc("POST "+options.BaseURL+"/bookmarks/{id}/attachments", wrapper.UploadAttachment)
+	m.HandleFunc("DELETE "+options.BaseURL+"/bookmarks/{id}/attachments/{attachmentId}", wrapper.DeleteAttachment)
+	m.HandleFunc("GET "+options.BaseURL+"/bookmarks/{id}/attachments/{attachmentId}", wrapper.GetAttachment)
+	m.HandleFunc("GET "+options.BaseURL+"/bookmarks/{id}/attachments/{attachmentId}/thumbnail", wrapper.GetAttachmentThumbnail)
+	m.HandleFunc("GET "+options.BaseURL+"/bookmarks/{id}/cite", wrapper.CiteBookmark)
+	m.HandleFunc("GET "+options.BaseURL+"/bookmarks/{id}/revisions", wrapper.ListRevisions)
+	m.HandleFunc("GET "+options.BaseURL+"/bookmarks/{id}/revisions/diff", wrapper.DiffRevisions)
+	m.HandleFunc("POST "+options.BaseURL+"/bookmarks/{id}/revisions/{rev}/revert", wrapper.RevertRevision)
+	m.HandleFunc("GET "+options.BaseURL+"/events/stream", wrapper.StreamEvents)
+	m.HandleFunc("GET "+options.BaseURL+"/export", wrapper.ExportBookmarks)
+	m.HandleFunc("GET "+options.BaseURL+"/favicons/{host}", wrapper.GetFavicon)
+	m.HandleFunc("GET "+options.BaseURL+"/feeds/all", wrapper.GetAllFeed)
+	m.HandleFunc("GET "+options.BaseURL+"/feeds/search", wrapper.SearchFeed)
+	m.HandleFunc("GET "+options.BaseURL+"/feeds/tag/{tag}", wrapper.GetTagFeed)
+	m.HandleFunc("POST "+options.BaseURL+"/imports", wrapper.CreateImport)
+	m.HandleFunc("GET "+options.BaseURL+"/imports/{id}", wrapper.GetImport)
+	m.HandleFunc("POST "+options.BaseURL+"/imports/{id}/cancel", wrapper.CancelImport)
+	m.HandleFunc("GET "+options.BaseURL+"/reports/link-health", wrapper.GetLinkHealthReport)
+	m.HandleFunc("GET "+options.BaseURL+"/search", wrapper.SearchBookmarks)
+	m.HandleFunc("GET "+options.BaseURL+"/sync", wrapper.PullChanges)
+	m.HandleFunc("POST "+options.BaseURL+"/sync", wrapper.PushChanges)
+	m.HandleFunc("GET "+options.BaseURL+"/trash", wrapper.ListTrash)
+	m.HandleFunc("DELETE "+options.BaseURL+"/trash/{id}", wrapper.PurgeBookmark)
+	m.HandleFunc("POST "+options.BaseURL+"/trash/{id}/restore", wrapper.RestoreBookmark)
+	m.HandleFunc("GET "+options.BaseURL+"/webhooks", wrapper.ListWebhooks)
+	m.HandleFunc("POST "+options.BaseURL+"/webhooks", wrapper.CreateWebhook)
+	m.HandleFunc("DELETE "+options.BaseURL+"/webhooks/{id}", wrapper.DeleteWebhook)
+	m.HandleFunc("GET "+options.BaseURL+"/webhooks/{id}", wrapper.GetWebhook)
+	m.HandleFunc("PUT "+options.BaseURL+"/webhooks/{id}", wrapper.UpdateWebhook)
+	m.HandleFunc("GET "+options.BaseURL+"/webhooks/{id}/deliveries", wrapper.ListWebhookDeliveries)
+	m.HandleFunc("GET "+options.BaseURL+"/webhooks/{id}/deliveries/{deliveryId}", wrapper.GetWebhookDelivery)
+	m.HandleFunc("POST "+options.BaseURL+"/webhooks/{id}/deliveries/{deliveryId}/redeliver", wrapper.RedeliverWebhookDelivery)
 
 	return m
 }
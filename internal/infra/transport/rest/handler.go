@@ -2,7 +2,7 @@ package rest
 
 import (
 	"encoding/json"
-	"log"
+	"errors"
 	"net/http"
 
 	"github.com/etsrc/goprod/internal/domain"
@@ -10,7 +10,7 @@ import (
 	"github.com/etsrc/goprod/internal/service"
 )
 
-// BookmarkHandler implements gen.ServerInterface
+// BookmarkHandler serves the /bookmarks endpoints.
 type BookmarkHandler struct {
 	svc service.BookmarkService
 }
@@ -20,9 +20,17 @@ func NewBookmarkHandler(svc service.BookmarkService) *BookmarkHandler {
 }
 
 // GetAllBookmarks handles GET /bookmarks
-func (h *BookmarkHandler) GetAllBookmarks(w http.ResponseWriter, r *http.Request) {
-	// Assuming your service List method takes context and an optional search string
-	bookmarks, err := h.svc.List(r.Context())
+func (h *BookmarkHandler) GetAllBookmarks(w http.ResponseWriter, r *http.Request, params gen.GetAllBookmarksParams) {
+	filter := bookmarkFilter(params.Tag, params.Q)
+	if params.Health != nil {
+		if !domain.ValidLinkStatus(string(*params.Health)) {
+			http.Error(w, "Unknown health status", http.StatusBadRequest)
+			return
+		}
+		filter.Health = domain.LinkStatus(*params.Health)
+	}
+
+	bookmarks, err := h.svc.List(r.Context(), filter)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -30,7 +38,7 @@ func (h *BookmarkHandler) GetAllBookmarks(w http.ResponseWriter, r *http.Request
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(bookmarks); err != nil {
-		log.Printf("Error encoding bookmarks: %v", err)
+		domain.LoggerFrom(r.Context()).Error("Error encoding bookmarks", "error", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
@@ -50,6 +58,17 @@ func (h *BookmarkHandler) CreateBookmark(w http.ResponseWriter, r *http.Request)
 		Title: input.Title,
 		URL:   input.Url,
 	}
+	if input.Tags != nil {
+		bm.Tags = *input.Tags
+	}
+	if input.ArchiveMode != nil {
+		mode := domain.ArchiveMode(*input.ArchiveMode)
+		if mode != domain.ArchiveAlways && mode != domain.ArchiveNever {
+			http.Error(w, "Unknown archive mode", http.StatusBadRequest)
+			return
+		}
+		bm.ArchiveMode = mode
+	}
 
 	if err := h.svc.Create(r.Context(), bm); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -59,7 +78,7 @@ func (h *BookmarkHandler) CreateBookmark(w http.ResponseWriter, r *http.Request)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(bm); err != nil {
-		log.Printf("Error encoding new bookmark: %v", err)
+		domain.LoggerFrom(r.Context()).Error("Error encoding new bookmark", "error", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
@@ -76,18 +95,36 @@ func (h *BookmarkHandler) GetBookmarkByID(w http.ResponseWriter, r *http.Request
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(bm); err != nil {
-		log.Printf("Error encoding new bookmark: %v", err)
+		domain.LoggerFrom(r.Context()).Error("Error encoding bookmark", "error", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 }
 
-// DeleteBookmark handles DELETE /bookmarks/{id}
+// DeleteBookmark handles DELETE /bookmarks/{id}. The bookmark goes to the
+// trash.
 func (h *BookmarkHandler) DeleteBookmark(w http.ResponseWriter, r *http.Request, id string) {
-	if err := h.svc.Delete(r.Context(), id); err != nil {
+	err := h.svc.Delete(r.Context(), id)
+	if errors.Is(err, domain.ErrBookmarkNotFound) {
+		http.Error(w, "Bookmark not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
 		http.Error(w, "Delete failed", http.StatusInternalServerError)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// bookmarkFilter maps the shared tag and q query parameters to a domain filter.
+func bookmarkFilter(tag *gen.TagFilter, q *gen.QueryFilter) domain.BookmarkFilter {
+	var f domain.BookmarkFilter
+	if tag != nil {
+		f.Tag = *tag
+	}
+	if q != nil {
+		f.Query = *q
+	}
+	return f
+}
@@ -3,11 +3,13 @@ package rest
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
 	"github.com/etsrc/goprod/internal/mocks"
 	"github.com/stretchr/testify/mock"
 )
@@ -29,7 +31,7 @@ func TestBookmarkHandler_GetAllBookmarks(t *testing.T) {
 		{
 			name: "Success",
 			mockBehavior: func(m *mocks.BookmarkService) {
-				m.On("List", mock.Anything).Return(bookmarks, nil).Once()
+				m.On("List", mock.Anything, mock.Anything).Return(bookmarks, nil).Once()
 			},
 			expectedCode: http.StatusOK,
 			expectedBody: `[{"id":"1","url":"https://google.com","title":"Google","description":"","tags":null,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"},{"id":"2","url":"https://example.com","title":"Example","description":"","tags":null,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}]` + "\n",
@@ -37,7 +39,7 @@ func TestBookmarkHandler_GetAllBookmarks(t *testing.T) {
 		{
 			name: "Service Error",
 			mockBehavior: func(m *mocks.BookmarkService) {
-				m.On("List", mock.Anything).Return(nil, domain.ErrBookmarkNotFound).Once()
+				m.On("List", mock.Anything, mock.Anything).Return(nil, domain.ErrBookmarkNotFound).Once()
 			},
 			expectedCode: http.StatusInternalServerError,
 			expectedBody: "bookmark not found\n",
@@ -56,7 +58,7 @@ func TestBookmarkHandler_GetAllBookmarks(t *testing.T) {
 			req := httptest.NewRequest("GET", "/bookmarks", nil)
 			w := httptest.NewRecorder()
 
-			handler.GetAllBookmarks(w, req)
+			handler.GetAllBookmarks(w, req, gen.GetAllBookmarksParams{})
 
 			if w.Code != tt.expectedCode {
 				t.Errorf("GetAllBookmarks() status code = %v, want %v", w.Code, tt.expectedCode)
@@ -69,6 +71,54 @@ func TestBookmarkHandler_GetAllBookmarks(t *testing.T) {
 	}
 }
 
+func TestBookmarkHandler_GetAllBookmarksByHealth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		health       string
+		mockBehavior func(m *mocks.BookmarkService)
+		expectedCode int
+	}{
+		{
+			name:   "Broken",
+			health: "broken",
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("List", mock.Anything, domain.BookmarkFilter{Health: domain.LinkBroken}).
+					Return([]*domain.Bookmark{}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Unknown Status",
+			health:       "dead",
+			mockBehavior: func(m *mocks.BookmarkService) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewBookmarkService(t)
+			tt.mockBehavior(mockSvc)
+
+			handler := NewBookmarkHandler(mockSvc)
+			req := httptest.NewRequest("GET", "/bookmarks?health="+tt.health, nil)
+			w := httptest.NewRecorder()
+
+			health := gen.LinkStatus(tt.health)
+			handler.GetAllBookmarks(w, req, gen.GetAllBookmarksParams{Health: &health})
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("GetAllBookmarks() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+		})
+	}
+}
+
 func TestBookmarkHandler_CreateBookmark(t *testing.T) {
 	t.Parallel()
 
@@ -93,6 +143,27 @@ func TestBookmarkHandler_CreateBookmark(t *testing.T) {
 			expectedCode: http.StatusCreated,
 			expectedBody: `{"id":"3","url":"https://newsite.com","title":"New Site","description":"","tags":null,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}` + "\n",
 		},
+		{
+			name:        "Tags And Archive Mode",
+			requestBody: `{"title": "Spec", "url": "https://go.dev/ref/spec", "tags": ["go"], "archive_mode": "always"}`,
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Create", mock.Anything, mock.MatchedBy(func(b *domain.Bookmark) bool {
+					return len(b.Tags) == 1 && b.Tags[0] == "go" && b.ArchiveMode == domain.ArchiveAlways
+				})).Return(nil).Run(func(args mock.Arguments) {
+					arg := args.Get(1).(*domain.Bookmark)
+					arg.ID = "4"
+				}).Once()
+			},
+			expectedCode: http.StatusCreated,
+			expectedBody: `{"id":"4","url":"https://go.dev/ref/spec","title":"Spec","description":"","tags":["go"],"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","archive_mode":"always"}` + "\n",
+		},
+		{
+			name:         "Unknown Archive Mode",
+			requestBody:  `{"title": "Spec", "url": "https://go.dev/ref/spec", "archive_mode": "sometimes"}`,
+			mockBehavior: func(_ *mocks.BookmarkService) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "Unknown archive mode\n",
+		},
 		{
 			name:        "Invalid Request Body",
 			requestBody: `{"title": "New Site",`,
@@ -229,6 +300,14 @@ func TestBookmarkHandler_DeleteBookmark(t *testing.T) {
 			},
 			expectedCode: http.StatusInternalServerError,
 		},
+		{
+			name:       "Not Found Or Already In Trash",
+			bookmarkID: "1",
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Delete", mock.Anything, "1").Return(fmt.Errorf("service.Delete: %w", domain.ErrBookmarkNotFound)).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,60 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/service"
+)
+
+// HealthHandler serves the probes of the orchestrator and load balancer.
+// They are not part of the API, so they are registered on the mux directly
+// and are neither audited nor counted in the HTTP metrics; they are logged
+// like every request.
+type HealthHandler struct {
+	svc service.HealthService
+}
+
+func NewHealthHandler(svc service.HealthService) *HealthHandler {
+	return &HealthHandler{svc: svc}
+}
+
+// Live handles GET /livez. The process answering is all it reports, so that
+// a failing dependency does not get the application restarted.
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte("ok\n"))
+}
+
+// Ready handles GET /readyz. It fails while shutting down, without checking
+// the components, so that traffic drains away.
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	if h.svc.Draining() {
+		http.Error(w, "Shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	if report := h.svc.Check(r.Context()); !report.Ready {
+		http.Error(w, "Not ready", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte("ok\n"))
+}
+
+// Health handles GET /healthz with the status and latency of every
+// component.
+func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
+	report := h.svc.Check(r.Context())
+	status := http.StatusOK
+	if report.Status != domain.HealthUp {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		domain.LoggerFrom(r.Context()).Error("Error encoding health report", "error", err)
+	}
+}
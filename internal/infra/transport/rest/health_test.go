@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHealthHandler(t *testing.T) {
+	t.Parallel()
+
+	up := &domain.HealthReport{Status: domain.HealthUp, Ready: true, Components: []domain.ComponentHealth{
+		{Name: "bookmark repository", Status: domain.HealthUp, LatencyMS: 0.25},
+	}}
+	down := &domain.HealthReport{Status: domain.HealthDown, Components: []domain.ComponentHealth{
+		{Name: "bookmark repository", Status: domain.HealthDown, LatencyMS: 2000, Error: "timed out"},
+	}}
+
+	tests := []struct {
+		name         string
+		probe        func(h *HealthHandler) http.HandlerFunc
+		mockBehavior func(m *mocks.HealthService)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "Live",
+			probe:        func(h *HealthHandler) http.HandlerFunc { return h.Live },
+			mockBehavior: func(m *mocks.HealthService) {},
+			expectedCode: http.StatusOK,
+			expectedBody: "ok\n",
+		},
+		{
+			name:  "Ready",
+			probe: func(h *HealthHandler) http.HandlerFunc { return h.Ready },
+			mockBehavior: func(m *mocks.HealthService) {
+				m.On("Draining").Return(false).Once()
+				m.On("Check", mock.Anything).Return(up).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "ok\n",
+		},
+		{
+			name:  "Not Ready",
+			probe: func(h *HealthHandler) http.HandlerFunc { return h.Ready },
+			mockBehavior: func(m *mocks.HealthService) {
+				m.On("Draining").Return(false).Once()
+				m.On("Check", mock.Anything).Return(down).Once()
+			},
+			expectedCode: http.StatusServiceUnavailable,
+			expectedBody: "Not ready\n",
+		},
+		{
+			name:  "Draining",
+			probe: func(h *HealthHandler) http.HandlerFunc { return h.Ready },
+			mockBehavior: func(m *mocks.HealthService) {
+				m.On("Draining").Return(true).Once()
+			},
+			expectedCode: http.StatusServiceUnavailable,
+			expectedBody: "Shutting down\n",
+		},
+		{
+			name:  "Healthy",
+			probe: func(h *HealthHandler) http.HandlerFunc { return h.Health },
+			mockBehavior: func(m *mocks.HealthService) {
+				m.On("Check", mock.Anything).Return(up).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":"up","ready":true,"draining":false,"components":[{"name":"bookmark repository","status":"up","latency_ms":0.25}]}` + "\n",
+		},
+		{
+			name:  "Unhealthy",
+			probe: func(h *HealthHandler) http.HandlerFunc { return h.Health },
+			mockBehavior: func(m *mocks.HealthService) {
+				m.On("Check", mock.Anything).Return(down).Once()
+			},
+			expectedCode: http.StatusServiceUnavailable,
+			expectedBody: `{"status":"down","ready":false,"draining":false,"components":[{"name":"bookmark repository","status":"down","latency_ms":2000,"error":"timed out"}]}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewHealthService(t)
+			tt.mockBehavior(mockSvc)
+
+			r := httptest.NewRequest("GET", "/", nil)
+			w := httptest.NewRecorder()
+			tt.probe(NewHealthHandler(mockSvc))(w, r)
+
+			if w.Code != tt.expectedCode || w.Body.String() != tt.expectedBody {
+				t.Errorf("probe = %v %q, want %v %q", w.Code, w.Body.String(), tt.expectedCode, tt.expectedBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/codec"
+	"github.com/etsrc/goprod/internal/service"
+)
+
+// ImportHandler serves the /imports endpoints.
+type ImportHandler struct {
+	svc     service.ImportService
+	maxBody int64
+}
+
+func NewImportHandler(svc service.ImportService, maxBody int64) *ImportHandler {
+	return &ImportHandler{svc: svc, maxBody: maxBody}
+}
+
+// CreateImport handles POST /imports
+// The body may be in any format GET /export produces, chosen by Content-Type;
+// a missing Content-Type is read as JSON.
+func (h *ImportHandler) CreateImport(w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	format, ok := codec.ForContentType(contentType)
+	if !ok {
+		http.Error(w, "Unsupported import format", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxBody)
+	items, err := codec.DecodeAll(format.NewDecoder(r.Body))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.svc.Submit(r.Context(), items)
+	if err != nil {
+		if errors.Is(err, domain.ErrImportEmpty) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Location", "/imports/"+job.ID)
+	writeImportJob(r.Context(), w, http.StatusAccepted, job)
+}
+
+// GetImport handles GET /imports/{id}
+func (h *ImportHandler) GetImport(w http.ResponseWriter, r *http.Request, id string) {
+	job, err := h.svc.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrImportJobNotFound) {
+			http.Error(w, "Import job not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeImportJob(r.Context(), w, http.StatusOK, job)
+}
+
+// CancelImport handles POST /imports/{id}/cancel
+func (h *ImportHandler) CancelImport(w http.ResponseWriter, r *http.Request, id string) {
+	job, err := h.svc.Cancel(r.Context(), id)
+	switch {
+	case errors.Is(err, domain.ErrImportJobNotFound):
+		http.Error(w, "Import job not found", http.StatusNotFound)
+		return
+	case errors.Is(err, domain.ErrImportJobFinished):
+		http.Error(w, "Import job has already finished", http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeImportJob(r.Context(), w, http.StatusAccepted, job)
+}
+
+func writeImportJob(ctx context.Context, w http.ResponseWriter, status int, job *domain.ImportJob) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		domain.LoggerFrom(ctx).Error("Error encoding import job", "error", err)
+	}
+}
@@ -0,0 +1,142 @@
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestImportHandler_CreateImport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		requestBody  string
+		mockBehavior func(m *mocks.ImportService)
+		expectedCode int
+		expectedLoc  string
+	}{
+		{
+			name:        "Accepted",
+			requestBody: `[{"id":"keep-me","title":"Go","url":"https://go.dev","tags":["lang"]},{"title":"Example","url":"https://example.com"}]`,
+			mockBehavior: func(m *mocks.ImportService) {
+				m.On("Submit", mock.Anything, mock.MatchedBy(func(items []*domain.Bookmark) bool {
+					return len(items) == 2 && items[0].ID == "keep-me" && len(items[0].Tags) == 1 && items[1].ID == ""
+				})).Return(domain.NewImportJob("job-1", 2), nil).Once()
+			},
+			expectedCode: http.StatusAccepted,
+			expectedLoc:  "/imports/job-1",
+		},
+		{
+			name:         "Invalid Request Body",
+			requestBody:  `{"title":`,
+			mockBehavior: func(_ *mocks.ImportService) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Body Too Large",
+			requestBody:  `[{"title":"` + strings.Repeat("x", 256) + `","url":"https://example.com"}]`,
+			mockBehavior: func(_ *mocks.ImportService) {},
+			expectedCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:        "Empty Import",
+			requestBody: `[]`,
+			mockBehavior: func(m *mocks.ImportService) {
+				m.On("Submit", mock.Anything, mock.Anything).Return(nil, domain.ErrImportEmpty).Once()
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewImportService(t)
+			tt.mockBehavior(mockSvc)
+
+			handler := NewImportHandler(mockSvc, 128)
+			req := httptest.NewRequest("POST", "/imports", bytes.NewBufferString(tt.requestBody))
+			w := httptest.NewRecorder()
+
+			handler.CreateImport(w, req)
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("CreateImport() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+			if loc := w.Header().Get("Location"); loc != tt.expectedLoc {
+				t.Errorf("CreateImport() Location = %q, want %q", loc, tt.expectedLoc)
+			}
+		})
+	}
+}
+
+func TestImportHandler_CancelImport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		mockBehavior func(m *mocks.ImportService)
+		expectedCode int
+	}{
+		{
+			name: "Accepted",
+			mockBehavior: func(m *mocks.ImportService) {
+				job := domain.NewImportJob("job-1", 1)
+				job.Status = domain.ImportJobCanceled
+				m.On("Cancel", mock.Anything, "job-1").Return(job, nil).Once()
+			},
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name: "Not Found",
+			mockBehavior: func(m *mocks.ImportService) {
+				m.On("Cancel", mock.Anything, "job-1").Return(nil, domain.ErrImportJobNotFound).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Already Finished",
+			mockBehavior: func(m *mocks.ImportService) {
+				m.On("Cancel", mock.Anything, "job-1").Return(nil, domain.ErrImportJobFinished).Once()
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name: "Service Error",
+			mockBehavior: func(m *mocks.ImportService) {
+				m.On("Cancel", mock.Anything, "job-1").Return(nil, errors.New("boom")).Once()
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewImportService(t)
+			tt.mockBehavior(mockSvc)
+
+			handler := NewImportHandler(mockSvc, 1<<20)
+			req := httptest.NewRequest("POST", "/imports/job-1/cancel", nil)
+			w := httptest.NewRecorder()
+
+			handler.CancelImport(w, req, "job-1")
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("CancelImport() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+		})
+	}
+}
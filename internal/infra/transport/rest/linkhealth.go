@@ -0,0 +1,34 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/service"
+)
+
+// LinkHealthHandler serves the link health report.
+type LinkHealthHandler struct {
+	svc service.LinkCheckService
+}
+
+func NewLinkHealthHandler(svc service.LinkCheckService) *LinkHealthHandler {
+	return &LinkHealthHandler{svc: svc}
+}
+
+// GetLinkHealthReport handles GET /reports/link-health
+func (h *LinkHealthHandler) GetLinkHealthReport(w http.ResponseWriter, r *http.Request) {
+	report, err := h.svc.Report(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		domain.LoggerFrom(r.Context()).Error("Error encoding link health report", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+}
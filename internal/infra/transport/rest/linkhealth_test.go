@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLinkHealthHandler_GetLinkHealthReport(t *testing.T) {
+	t.Parallel()
+
+	report := &domain.LinkHealthReport{
+		Total:        2,
+		ByStatus:     map[domain.LinkStatus]int{domain.LinkOK: 1, domain.LinkBroken: 1},
+		ByStatusCode: map[string]int{"200": 1, "404": 1},
+		Broken: []*domain.Bookmark{{
+			ID:     "1",
+			URL:    "https://example.com/gone",
+			Health: &domain.LinkHealth{Status: domain.LinkBroken, StatusCode: 404, ConsecutiveFailures: 3},
+		}},
+	}
+
+	tests := []struct {
+		name         string
+		mockBehavior func(m *mocks.LinkCheckService)
+		expectedCode int
+		wantInBody   []string
+	}{
+		{
+			name: "Success",
+			mockBehavior: func(m *mocks.LinkCheckService) {
+				m.On("Report", mock.Anything).Return(report, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			wantInBody: []string{
+				`"total":2`,
+				`"by_status":{"broken":1,"ok":1}`,
+				`"status_code":404`,
+				`"consecutive_failures":3`,
+			},
+		},
+		{
+			name: "Service Error",
+			mockBehavior: func(m *mocks.LinkCheckService) {
+				m.On("Report", mock.Anything).Return(nil, errors.New("boom")).Once()
+			},
+			expectedCode: http.StatusInternalServerError,
+			wantInBody:   []string{"boom"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewLinkCheckService(t)
+			tt.mockBehavior(mockSvc)
+
+			handler := NewLinkHealthHandler(mockSvc)
+			req := httptest.NewRequest("GET", "/reports/link-health", nil)
+			w := httptest.NewRecorder()
+
+			handler.GetLinkHealthReport(w, req)
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("GetLinkHealthReport() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+			for _, want := range tt.wantInBody {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Errorf("GetLinkHealthReport() body = %q, want it to contain %q", w.Body.String(), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package rest
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the ID that ties together every log line, audit
+// entry and response of a request. One sent by the caller is kept, so that
+// a request can be followed through the proxies in front of the server.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLen bounds request IDs taken from callers.
+const maxRequestIDLen = 128
+
+// sensitiveHeaders are logged as "[REDACTED]".
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+	"X-Auth-Token":        true,
+}
+
+type requestIDKey struct{}
+
+// requestIDFrom returns the ID of the request ctx belongs to, or "".
+func requestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// LogRequests gives every request an ID and a logger that tags its lines
+// with it, and logs one line when the request is done. Request headers are
+// logged at debug level, with credentials redacted.
+func LogRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+		w.Header().Set(RequestIDHeader, id)
+
+		logger := domain.LoggerFrom(r.Context()).With("request_id", id)
+		if actor := domain.ActorFrom(r.Context()); actor != "" {
+			logger = logger.With("actor", actor)
+		}
+		ctx := context.WithValue(r.Context(), requestIDKey{}, id)
+		ctx = domain.WithLogger(ctx, logger)
+
+		// The mux sets the route's pattern on the request it is given.
+		r = r.WithContext(ctx)
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+
+		level := slog.LevelInfo
+		if sw.status >= 500 {
+			level = slog.LevelError
+		}
+		attrs := []any{
+			"method", r.Method,
+			"route", r.Pattern,
+			// The query is left out: it may carry a feed or stream token.
+			"path", r.URL.Path,
+			"status", sw.status,
+			"bytes", sw.bytes,
+			"duration_ms", float64(time.Since(start).Microseconds()) / 1000,
+			"remote_addr", r.RemoteAddr,
+			"user_agent", r.UserAgent(),
+		}
+		if logger.Enabled(ctx, slog.LevelDebug) {
+			attrs = append(attrs, slog.Group("headers", redactHeaders(r.Header)...))
+		}
+		logger.Log(ctx, level, "request", attrs...)
+	})
+}
+
+// validRequestID reports whether a caller's request ID is short and
+// printable enough to be logged and echoed as it is.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for _, c := range id {
+		if c <= ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// redactHeaders returns h as log attributes, with the values of
+// sensitiveHeaders replaced.
+func redactHeaders(h http.Header) []any {
+	attrs := make([]any, 0, len(h))
+	for name, values := range h {
+		value := strings.Join(values, ", ")
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			value = "[REDACTED]"
+		}
+		attrs = append(attrs, slog.String(name, value))
+	}
+	return attrs
+}
+
+// statusWriter remembers the status code and size of a response.
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status, w.wroteHeader = status, true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, for
+// streaming responses.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
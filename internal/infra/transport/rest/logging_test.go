@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+func TestLogRequests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		requestID string
+		wantID    string
+	}{
+		{name: "Propagated", requestID: "req-1", wantID: "req-1"},
+		{name: "Assigned", requestID: ""},
+		{name: "Unprintable Replaced", requestID: "req\x001"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			var handlerID string
+			handler := LogRequests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// As the mux does, the route is set on the request passed down.
+				r.Pattern = "POST /bookmarks"
+				handlerID = requestIDFrom(r.Context())
+				domain.LoggerFrom(r.Context()).Info("handling")
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte("created"))
+			}))
+			r := httptest.NewRequest("POST", "/bookmarks?token=secret", nil)
+			r.Header.Set("Authorization", "Bearer secret")
+			r.Header.Set("Accept", "application/json")
+			if tt.requestID != "" {
+				r.Header.Set(RequestIDHeader, tt.requestID)
+			}
+			r = r.WithContext(domain.WithLogger(domain.WithActor(r.Context(), "alice"), logger))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			id := w.Header().Get(RequestIDHeader)
+			if id == "" || id != handlerID || (tt.wantID != "" && id != tt.wantID) {
+				t.Fatalf("request ID = %q in response, %q in handler, want %q", id, handlerID, tt.wantID)
+			}
+			if strings.Contains(buf.String(), "secret") {
+				t.Errorf("log contains a credential: %s", buf.String())
+			}
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != 2 {
+				t.Fatalf("logged %d lines, want 2: %s", len(lines), buf.String())
+			}
+			for _, l := range lines {
+				var line map[string]any
+				if err := json.Unmarshal([]byte(l), &line); err != nil {
+					t.Fatal(err)
+				}
+				if line["request_id"] != id || line["actor"] != "alice" {
+					t.Errorf("line %s lacks request_id %q or actor", l, id)
+				}
+			}
+
+			var access struct {
+				Route   string            `json:"route"`
+				Path    string            `json:"path"`
+				Status  int               `json:"status"`
+				Bytes   int               `json:"bytes"`
+				Headers map[string]string `json:"headers"`
+			}
+			if err := json.Unmarshal([]byte(lines[1]), &access); err != nil {
+				t.Fatal(err)
+			}
+			if access.Route != "POST /bookmarks" || access.Path != "/bookmarks" || access.Status != http.StatusCreated || access.Bytes != 7 {
+				t.Errorf("access log = %+v, want route, path, status 201 and 7 bytes", access)
+			}
+			if access.Headers["Authorization"] != "[REDACTED]" || access.Headers["Accept"] != "application/json" {
+				t.Errorf("access log headers = %v, want Authorization redacted and Accept kept", access.Headers)
+			}
+		})
+	}
+}
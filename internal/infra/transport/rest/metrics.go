@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"net/http"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/etsrc/goprod/internal/infra/metrics"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// HTTPMetrics counts and times requests by the gen.ServerInterface
+// operation that serves them.
+type HTTPMetrics struct {
+	// operations maps route patterns to operation names. It is filled while
+	// routes are registered, before any request is served.
+	operations map[string]string
+
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+}
+
+// NewHTTPMetrics registers the HTTP metrics with reg.
+func NewHTTPMetrics(reg prometheus.Registerer) *HTTPMetrics {
+	m := &HTTPMetrics{
+		operations: make(map[string]string),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Requests served, by operation and status code.",
+		}, []string{"operation", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Time taken to serve requests, by operation.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "http",
+			Name:      "requests_in_flight",
+			Help:      "Requests being served, by operation.",
+		}, []string{"operation"}),
+	}
+	reg.MustRegister(m.requests, m.duration, m.inFlight)
+	return m
+}
+
+// Router returns mux wrapped so that m learns the operation of every route
+// gen.HandlerWithOptions registers on it.
+func (m *HTTPMetrics) Router(mux gen.ServeMux) gen.ServeMux {
+	return &operationRouter{ServeMux: mux, operations: m.operations}
+}
+
+// Middleware counts and times every request.
+func (m *HTTPMetrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		operation, ok := m.operations[r.Pattern]
+		if !ok {
+			operation = "unknown"
+		}
+		inFlight := m.inFlight.WithLabelValues(operation)
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+
+		m.duration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+		m.requests.WithLabelValues(operation, strconv.Itoa(sw.status)).Inc()
+	})
+}
+
+type operationRouter struct {
+	gen.ServeMux
+	operations map[string]string
+}
+
+func (o *operationRouter) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	o.operations[pattern] = operationName(handler)
+	o.ServeMux.HandleFunc(pattern, handler)
+}
+
+// operationName returns the name of the method of gen.ServerInterfaceWrapper
+// that handler is bound to, such as "CreateBookmark".
+func operationName(handler func(http.ResponseWriter, *http.Request)) string {
+	// A method value is named like
+	// ".../gen.(*ServerInterfaceWrapper).CreateBookmark-fm".
+	name := runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name()
+	name = strings.TrimSuffix(name, "-fm")
+	return name[strings.LastIndex(name, ".")+1:]
+}
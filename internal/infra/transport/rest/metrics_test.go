@@ -0,0 +1,54 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHTTPMetrics(t *testing.T) {
+	t.Parallel()
+
+	server := mocks.NewServerInterface(t)
+	server.On("GetBookmarkByID", mock.Anything, mock.Anything, "1").Return().Once()
+	server.On("DeleteBookmark", mock.Anything, mock.Anything, "1").Run(func(args mock.Arguments) {
+		args.Get(0).(http.ResponseWriter).WriteHeader(http.StatusNotFound)
+	}).Return().Once()
+
+	m := NewHTTPMetrics(prometheus.NewRegistry())
+	mux := http.NewServeMux()
+	gen.HandlerWithOptions(server, gen.StdHTTPServerOptions{
+		BaseRouter:  m.Router(mux),
+		Middlewares: []gen.MiddlewareFunc{m.Middleware},
+	})
+	for _, r := range []*http.Request{
+		httptest.NewRequest("GET", "/bookmarks/1", nil),
+		httptest.NewRequest("DELETE", "/bookmarks/1", nil),
+	} {
+		mux.ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	tests := []struct {
+		operation string
+		code      string
+		want      float64
+	}{
+		{operation: "GetBookmarkByID", code: "200", want: 1},
+		{operation: "DeleteBookmark", code: "404", want: 1},
+		{operation: "DeleteBookmark", code: "204", want: 0},
+	}
+	for _, tt := range tests {
+		if got := testutil.ToFloat64(m.requests.WithLabelValues(tt.operation, tt.code)); got != tt.want {
+			t.Errorf("requests{operation=%q, code=%q} = %v, want %v", tt.operation, tt.code, got, tt.want)
+		}
+	}
+	if got := testutil.ToFloat64(m.inFlight.WithLabelValues("GetBookmarkByID")); got != 0 {
+		t.Errorf("requests in flight = %v, want 0", got)
+	}
+}
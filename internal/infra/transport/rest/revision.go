@@ -0,0 +1,92 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/service"
+)
+
+// RevisionHandler serves the /bookmarks/{id}/revisions endpoints. A nil
+// service means revisions are disabled.
+type RevisionHandler struct {
+	svc service.RevisionService
+}
+
+func NewRevisionHandler(svc service.RevisionService) *RevisionHandler {
+	return &RevisionHandler{svc: svc}
+}
+
+// ListRevisions handles GET /bookmarks/{id}/revisions
+func (h *RevisionHandler) ListRevisions(w http.ResponseWriter, r *http.Request, id string) {
+	if h.svc == nil {
+		http.Error(w, "Revisions are disabled", http.StatusNotFound)
+		return
+	}
+
+	revisions, err := h.svc.List(r.Context(), id)
+	if err != nil {
+		writeRevisionError(w, err)
+		return
+	}
+	writeRevisionJSON(r.Context(), w, revisions)
+}
+
+// DiffRevisions handles GET /bookmarks/{id}/revisions/diff
+func (h *RevisionHandler) DiffRevisions(w http.ResponseWriter, r *http.Request, id string, params gen.DiffRevisionsParams) {
+	if h.svc == nil {
+		http.Error(w, "Revisions are disabled", http.StatusNotFound)
+		return
+	}
+
+	var to int64
+	if params.To != nil {
+		to = *params.To
+	}
+	diff, err := h.svc.Diff(r.Context(), id, params.From, to)
+	if err != nil {
+		writeRevisionError(w, err)
+		return
+	}
+	writeRevisionJSON(r.Context(), w, diff)
+}
+
+// RevertRevision handles POST /bookmarks/{id}/revisions/{rev}/revert
+func (h *RevisionHandler) RevertRevision(w http.ResponseWriter, r *http.Request, id string, rev int64) {
+	if h.svc == nil {
+		http.Error(w, "Revisions are disabled", http.StatusNotFound)
+		return
+	}
+
+	b, err := h.svc.Revert(r.Context(), id, rev)
+	if err != nil {
+		writeRevisionError(w, err)
+		return
+	}
+	writeRevisionJSON(r.Context(), w, b)
+}
+
+func writeRevisionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrBookmarkNotFound):
+		http.Error(w, "Bookmark not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrRevisionNotFound):
+		http.Error(w, "Revision not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrInvalidURL), errors.Is(err, domain.ErrTitleTooShort):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeRevisionJSON(ctx context.Context, w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		domain.LoggerFrom(ctx).Error("Error encoding revision response", "error", err)
+	}
+}
@@ -0,0 +1,233 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRevisionHandler_ListRevisions(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		mockBehavior func(m *mocks.RevisionService)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "Listed",
+			mockBehavior: func(m *mocks.RevisionService) {
+				m.On("List", mock.Anything, "1").Return([]*domain.Revision{{
+					BookmarkID: "1", Number: 2, Change: domain.RevisionUpdated, Actor: "alice", At: at,
+					Fields: []string{"title"}, Bookmark: &domain.Bookmark{ID: "1", URL: "https://go.dev", Title: "Go"},
+				}}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"bookmark_id":"1","number":2,"change":"updated","actor":"alice","at":"2024-05-01T12:00:00Z","fields":["title"],"bookmark":{"id":"1","url":"https://go.dev","title":"Go","description":"","tags":null,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}}]` + "\n",
+		},
+		{
+			name: "Bookmark Not Found",
+			mockBehavior: func(m *mocks.RevisionService) {
+				m.On("List", mock.Anything, "1").Return(nil, fmt.Errorf("service.List: %w", domain.ErrBookmarkNotFound)).Once()
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "Bookmark not found\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewRevisionService(t)
+			tt.mockBehavior(mockSvc)
+
+			w := httptest.NewRecorder()
+			NewRevisionHandler(mockSvc).ListRevisions(w, httptest.NewRequest("GET", "/bookmarks/1/revisions", nil), "1")
+
+			if w.Code != tt.expectedCode || w.Body.String() != tt.expectedBody {
+				t.Errorf("ListRevisions() = %v %q, want %v %q", w.Code, w.Body.String(), tt.expectedCode, tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestRevisionHandler_DiffRevisions(t *testing.T) {
+	t.Parallel()
+
+	to := int64(3)
+	tests := []struct {
+		name         string
+		params       gen.DiffRevisionsParams
+		mockBehavior func(m *mocks.RevisionService)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:   "Diffed",
+			params: gen.DiffRevisionsParams{From: 1, To: &to},
+			mockBehavior: func(m *mocks.RevisionService) {
+				m.On("Diff", mock.Anything, "1", int64(1), int64(3)).Return(&domain.RevisionDiff{
+					BookmarkID: "1", From: 1, To: 3,
+					Changes: []domain.FieldChange{{Field: "description", From: "Carefully written", To: ""}},
+				}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"bookmark_id":"1","from":1,"to":3,"changes":[{"field":"description","from":"Carefully written","to":""}]}` + "\n",
+		},
+		{
+			name:   "To Latest",
+			params: gen.DiffRevisionsParams{From: 1},
+			mockBehavior: func(m *mocks.RevisionService) {
+				m.On("Diff", mock.Anything, "1", int64(1), int64(0)).Return(&domain.RevisionDiff{BookmarkID: "1", From: 1, To: 1, Changes: []domain.FieldChange{}}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"bookmark_id":"1","from":1,"to":1,"changes":[]}` + "\n",
+		},
+		{
+			name:   "Revision Not Found",
+			params: gen.DiffRevisionsParams{From: 9},
+			mockBehavior: func(m *mocks.RevisionService) {
+				m.On("Diff", mock.Anything, "1", int64(9), int64(0)).Return(nil, fmt.Errorf("service.Diff: %w", domain.ErrRevisionNotFound)).Once()
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "Revision not found\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewRevisionService(t)
+			tt.mockBehavior(mockSvc)
+
+			w := httptest.NewRecorder()
+			NewRevisionHandler(mockSvc).DiffRevisions(w, httptest.NewRequest("GET", "/bookmarks/1/revisions/diff", nil), "1", tt.params)
+
+			if w.Code != tt.expectedCode || w.Body.String() != tt.expectedBody {
+				t.Errorf("DiffRevisions() = %v %q, want %v %q", w.Code, w.Body.String(), tt.expectedCode, tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestRevisionHandler_RevertRevision(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		mockBehavior func(m *mocks.RevisionService)
+		expectedCode int
+	}{
+		{
+			name: "Reverted",
+			mockBehavior: func(m *mocks.RevisionService) {
+				m.On("Revert", mock.Anything, "1", int64(2)).Return(&domain.Bookmark{ID: "1"}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Revision Not Found",
+			mockBehavior: func(m *mocks.RevisionService) {
+				m.On("Revert", mock.Anything, "1", int64(2)).Return(nil, fmt.Errorf("service.Revert: %w", domain.ErrRevisionNotFound)).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Bookmark In Trash",
+			mockBehavior: func(m *mocks.RevisionService) {
+				m.On("Revert", mock.Anything, "1", int64(2)).Return(nil, fmt.Errorf("service.Revert: %w", domain.ErrBookmarkNotFound)).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "No Longer Valid",
+			mockBehavior: func(m *mocks.RevisionService) {
+				m.On("Revert", mock.Anything, "1", int64(2)).Return(nil, fmt.Errorf("service.Revert: %w", domain.ErrTitleTooShort)).Once()
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Service Error",
+			mockBehavior: func(m *mocks.RevisionService) {
+				m.On("Revert", mock.Anything, "1", int64(2)).Return(nil, errors.New("boom")).Once()
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewRevisionService(t)
+			tt.mockBehavior(mockSvc)
+
+			w := httptest.NewRecorder()
+			NewRevisionHandler(mockSvc).RevertRevision(w, httptest.NewRequest("POST", "/bookmarks/1/revisions/2/revert", nil), "1", 2)
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("RevertRevision() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+		})
+	}
+}
+
+func TestRevisionHandler_Disabled(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	NewRevisionHandler(nil).ListRevisions(w, httptest.NewRequest("GET", "/bookmarks/1/revisions", nil), "1")
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("ListRevisions() status code = %v, want %v", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestIdentifyActor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "Named", header: "alice", want: "alice"},
+		{name: "Anonymous", header: "", want: "anonymous"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			handler := IdentifyActor(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = domain.ActorFrom(r.Context())
+			}))
+			r := httptest.NewRequest("POST", "/bookmarks", nil)
+			if tt.header != "" {
+				r.Header.Set(ActorHeader, tt.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			if got != tt.want {
+				t.Errorf("IdentifyActor() actor = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
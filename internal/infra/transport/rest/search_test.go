@@ -0,0 +1,104 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchHandler_SearchBookmarks(t *testing.T) {
+	t.Parallel()
+
+	result := domain.SearchResult{
+		Bookmark: &domain.Bookmark{ID: "0b5e8a8e-7f38-4d5b-9d7e-3c1c1b1f6a01", URL: "https://go.dev/blog/pipelines", Title: "Go Concurrency Patterns: Pipelines"},
+		Score:    4.2,
+		Snippets: []domain.Snippet{{Field: domain.FieldContent, Text: "…a series of <mark>stages</mark> connected by channels…"}},
+	}
+	intPtr := func(n int) *int { return &n }
+
+	tests := []struct {
+		name          string
+		params        gen.SearchBookmarksParams
+		mockBehavior  func(m *mocks.SearchService)
+		expectedCode  int
+		expectedCount int
+	}{
+		{
+			name:   "Default Limit",
+			params: gen.SearchBookmarksParams{Q: "stages"},
+			mockBehavior: func(m *mocks.SearchService) {
+				m.On("Search", mock.Anything, "stages", 20).Return([]domain.SearchResult{result}, nil).Once()
+			},
+			expectedCode:  http.StatusOK,
+			expectedCount: 1,
+		},
+		{
+			name:   "Limit Capped",
+			params: gen.SearchBookmarksParams{Q: "stages", Limit: intPtr(1000)},
+			mockBehavior: func(m *mocks.SearchService) {
+				m.On("Search", mock.Anything, "stages", 100).Return([]domain.SearchResult{}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Blank Query",
+			params:       gen.SearchBookmarksParams{Q: "  "},
+			mockBehavior: func(m *mocks.SearchService) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Invalid Limit",
+			params:       gen.SearchBookmarksParams{Q: "stages", Limit: intPtr(0)},
+			mockBehavior: func(m *mocks.SearchService) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Service Error",
+			params: gen.SearchBookmarksParams{Q: "stages"},
+			mockBehavior: func(m *mocks.SearchService) {
+				m.On("Search", mock.Anything, "stages", 20).Return(nil, errors.New("boom")).Once()
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewSearchService(t)
+			tt.mockBehavior(mockSvc)
+
+			handler := NewSearchHandler(mockSvc)
+			req := httptest.NewRequest("GET", "/search", nil)
+			w := httptest.NewRecorder()
+
+			handler.SearchBookmarks(w, req, tt.params)
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("SearchBookmarks() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+			if tt.expectedCode != http.StatusOK {
+				return
+			}
+			var got []gen.SearchResult
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(got) != tt.expectedCount {
+				t.Errorf("SearchBookmarks() returned %d results, want %d", len(got), tt.expectedCount)
+			}
+			if len(got) > 0 && (got[0].Bookmark.Title != result.Bookmark.Title || got[0].Snippets[0].Field != gen.SnippetField("content")) {
+				t.Errorf("SearchBookmarks() result = %+v", got[0])
+			}
+		})
+	}
+}
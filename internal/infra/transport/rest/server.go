@@ -0,0 +1,24 @@
+package rest
+
+import "github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+
+// Server implements gen.ServerInterface by embedding one handler per resource.
+type Server struct {
+	*BookmarkHandler
+	*ImportHandler
+	*ExportHandler
+	*FeedHandler
+	*LinkHealthHandler
+	*ArchiveHandler
+	*SearchHandler
+	*AttachmentHandler
+	*FaviconHandler
+	*WebhookHandler
+	*StreamHandler
+	*SyncHandler
+	*TrashHandler
+	*RevisionHandler
+	*AuditHandler
+}
+
+var _ gen.ServerInterface = (*Server)(nil)
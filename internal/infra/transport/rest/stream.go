@@ -0,0 +1,189 @@
+package rest
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/service"
+)
+
+// StreamOptions configures GET /events/stream.
+type StreamOptions struct {
+	// Tokens maps each access token to what its holder may see. Empty lets
+	// anyone see every change.
+	Tokens map[string]service.StreamGrant
+	// Heartbeat is the time between comments sent to keep idle connections
+	// open through proxies.
+	Heartbeat time.Duration
+	// WriteTimeout disconnects a client that takes longer to accept one
+	// write.
+	WriteTimeout time.Duration
+}
+
+// StreamHandler serves GET /events/stream. A nil service means streaming is
+// disabled.
+type StreamHandler struct {
+	svc  service.StreamService
+	opts StreamOptions
+
+	shutdown     chan struct{}
+	shutdownOnce sync.Once
+}
+
+func NewStreamHandler(svc service.StreamService, opts StreamOptions) *StreamHandler {
+	if opts.Heartbeat <= 0 {
+		opts.Heartbeat = 15 * time.Second
+	}
+	if opts.WriteTimeout <= 0 {
+		opts.WriteTimeout = 10 * time.Second
+	}
+	return &StreamHandler{svc: svc, opts: opts, shutdown: make(chan struct{})}
+}
+
+// Shutdown ends every open stream. http.Server.Shutdown waits for handlers
+// to return, and streams otherwise never do; register it with
+// RegisterOnShutdown.
+func (h *StreamHandler) Shutdown() {
+	h.shutdownOnce.Do(func() { close(h.shutdown) })
+}
+
+// StreamEvents handles GET /events/stream
+func (h *StreamHandler) StreamEvents(w http.ResponseWriter, r *http.Request, params gen.StreamEventsParams) {
+	if h.svc == nil {
+		http.Error(w, "Streaming is disabled", http.StatusNotFound)
+		return
+	}
+	grant, ok := h.grant(r, params.Token)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="events"`)
+		http.Error(w, "Stream token required", http.StatusUnauthorized)
+		return
+	}
+
+	var filter domain.BookmarkFilter
+	if params.Tag != nil {
+		filter.Tag = *params.Tag
+	}
+	if params.Host != nil {
+		filter.Host = *params.Host
+	}
+	var lastEventID string
+	if params.LastEventID != nil {
+		lastEventID = *params.LastEventID
+	}
+
+	sub := h.svc.Subscribe(r.Context(), grant, filter, lastEventID)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	// Keeps nginx from buffering the stream.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	sw := &sseWriter{w: w, rc: http.NewResponseController(w), timeout: h.opts.WriteTimeout}
+	sw.comment("connected")
+	if sub.Reset {
+		sw.event("", "reset", []byte("{}"))
+	}
+	for _, e := range sub.Replay {
+		sw.streamEvent(r.Context(), e)
+	}
+	sw.flush()
+
+	heartbeat := time.NewTicker(h.opts.Heartbeat)
+	defer heartbeat.Stop()
+	for sw.err == nil {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-h.shutdown:
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				// The client fell behind. It reconnects with Last-Event-ID
+				// and catches up from the replay buffer.
+				return
+			}
+			sw.streamEvent(r.Context(), e)
+		case <-heartbeat.C:
+			sw.comment("heartbeat")
+		}
+		sw.flush()
+	}
+}
+
+// grant returns what the request's token allows. The token is accepted from
+// the query string because browsers' EventSource cannot send headers.
+func (h *StreamHandler) grant(r *http.Request, token *string) (service.StreamGrant, bool) {
+	if len(h.opts.Tokens) == 0 {
+		return service.StreamGrant{}, true
+	}
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok && token != nil {
+		got = *token
+	}
+	var grant service.StreamGrant
+	found := false
+	for t, g := range h.opts.Tokens {
+		if subtle.ConstantTimeCompare([]byte(got), []byte(t)) == 1 {
+			grant, found = g, true
+		}
+	}
+	return grant, found
+}
+
+// sseWriter writes Server-Sent Events, keeping the first error so that the
+// caller can check once per batch.
+type sseWriter struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	timeout time.Duration
+	err     error
+}
+
+func (s *sseWriter) streamEvent(ctx context.Context, e service.StreamEvent) {
+	data, err := json.Marshal(e.Bookmark)
+	if err != nil {
+		domain.LoggerFrom(ctx).Error("Error encoding stream event", "error", err)
+		return
+	}
+	s.event(e.ID, string(e.Type), data)
+}
+
+// event writes one event. data must not contain newlines, which JSON
+// encoding guarantees.
+func (s *sseWriter) event(id, name string, data []byte) {
+	if id != "" {
+		s.printf("id: %s\n", id)
+	}
+	s.printf("event: %s\ndata: %s\n\n", name, data)
+}
+
+func (s *sseWriter) comment(text string) {
+	s.printf(": %s\n\n", text)
+}
+
+func (s *sseWriter) printf(format string, args ...any) {
+	if s.err != nil {
+		return
+	}
+	// Not every ResponseWriter supports deadlines; without one, a stuck
+	// client is only noticed when its connection closes.
+	_ = s.rc.SetWriteDeadline(time.Now().Add(s.timeout))
+	_, s.err = fmt.Fprintf(s.w, format, args...)
+}
+
+func (s *sseWriter) flush() {
+	if s.err == nil {
+		s.err = s.rc.Flush()
+	}
+}
@@ -0,0 +1,155 @@
+package rest
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/service"
+)
+
+func streamCreated(id string, tags ...string) domain.Event {
+	return domain.BookmarkCreated{Bookmark: &domain.Bookmark{ID: id, URL: "https://example.com/" + id, Tags: tags}}
+}
+
+func TestStreamHandler_Auth(t *testing.T) {
+	t.Parallel()
+
+	tokens := map[string]service.StreamGrant{"secret": {}}
+	token := "secret"
+	wrong := "guess"
+
+	tests := []struct {
+		name         string
+		header       string
+		token        *string
+		expectedCode int
+	}{
+		{name: "Bearer Header", header: "Bearer secret", expectedCode: http.StatusOK},
+		{name: "Query Token", token: &token, expectedCode: http.StatusOK},
+		{name: "Wrong Token", token: &wrong, expectedCode: http.StatusUnauthorized},
+		{name: "No Token", expectedCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := NewStreamHandler(service.NewStreamService(service.StreamOptions{}), StreamOptions{Tokens: tokens})
+			// Ends the stream as soon as it has started.
+			handler.Shutdown()
+			req := httptest.NewRequest("GET", "/events/stream", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			handler.StreamEvents(w, req, gen.StreamEventsParams{Token: tt.token})
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("StreamEvents() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+			if tt.expectedCode == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Errorf("StreamEvents() missing WWW-Authenticate header")
+			}
+		})
+	}
+}
+
+func TestStreamHandler_Replay(t *testing.T) {
+	t.Parallel()
+
+	svc := service.NewStreamService(service.StreamOptions{})
+	first := svc.Subscribe(context.Background(), service.StreamGrant{}, domain.BookmarkFilter{}, "")
+	defer first.Close()
+	for _, e := range []domain.Event{streamCreated("a", "go"), streamCreated("b", "rust"), streamCreated("c", "go")} {
+		if err := svc.Handle(context.Background(), e); err != nil {
+			t.Fatalf("Handle() error = %v", err)
+		}
+	}
+	lastSeen := (<-first.Events()).ID
+
+	handler := NewStreamHandler(svc, StreamOptions{})
+	handler.Shutdown()
+	tag := "go"
+	w := httptest.NewRecorder()
+	handler.StreamEvents(w, httptest.NewRequest("GET", "/events/stream", nil), gen.StreamEventsParams{Tag: &tag, LastEventID: &lastSeen})
+
+	if got := w.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("StreamEvents() Content-Type = %q, want text/event-stream", got)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "event: bookmark.created\ndata: {") || !strings.Contains(body, `"id":"c"`) {
+		t.Errorf("StreamEvents() body = %q, want replayed event for c", body)
+	}
+	if strings.Contains(body, `"id":"b"`) || strings.Contains(body, "event: reset") {
+		t.Errorf("StreamEvents() body = %q, want only matching events and no reset", body)
+	}
+}
+
+func TestStreamHandler_ResetOnUnknownEventID(t *testing.T) {
+	t.Parallel()
+
+	handler := NewStreamHandler(service.NewStreamService(service.StreamOptions{}), StreamOptions{})
+	handler.Shutdown()
+	lastSeen := "earlier-process-42"
+	w := httptest.NewRecorder()
+	handler.StreamEvents(w, httptest.NewRequest("GET", "/events/stream", nil), gen.StreamEventsParams{LastEventID: &lastSeen})
+
+	if !strings.Contains(w.Body.String(), "event: reset\n") {
+		t.Errorf("StreamEvents() body = %q, want a reset event", w.Body.String())
+	}
+}
+
+func TestStreamHandler_Live(t *testing.T) {
+	t.Parallel()
+
+	svc := service.NewStreamService(service.StreamOptions{})
+	handler := NewStreamHandler(svc, StreamOptions{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.StreamEvents(w, r, gen.StreamEventsParams{})
+	}))
+	defer server.Close()
+	defer handler.Shutdown()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	defer resp.Body.Close()
+	lines := bufio.NewScanner(resp.Body)
+	// The connected comment tells the subscription is registered.
+	if !lines.Scan() || lines.Text() != ": connected" {
+		t.Fatalf("first line = %q, want connected comment", lines.Text())
+	}
+
+	if err := svc.Handle(context.Background(), streamCreated("live")); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	var got []string
+	for len(got) < 3 && lines.Scan() {
+		if lines.Text() != "" {
+			got = append(got, lines.Text())
+		}
+	}
+	if len(got) != 3 || !strings.HasPrefix(got[0], "id: ") || got[1] != "event: bookmark.created" || !strings.Contains(got[2], `"id":"live"`) {
+		t.Errorf("stream = %q, want the live event", got)
+	}
+}
+
+func TestStreamHandler_Disabled(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	NewStreamHandler(nil, StreamOptions{}).StreamEvents(w, httptest.NewRequest("GET", "/events/stream", nil), gen.StreamEventsParams{})
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("StreamEvents() status code = %v, want %v", w.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,109 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/service"
+)
+
+// SyncHandler serves GET and POST /sync. A nil service means sync is
+// disabled.
+type SyncHandler struct {
+	svc service.SyncService
+}
+
+func NewSyncHandler(svc service.SyncService) *SyncHandler {
+	return &SyncHandler{svc: svc}
+}
+
+// PullChanges handles GET /sync
+func (h *SyncHandler) PullChanges(w http.ResponseWriter, r *http.Request, params gen.PullChangesParams) {
+	if h.svc == nil {
+		http.Error(w, "Sync is disabled", http.StatusNotFound)
+		return
+	}
+
+	var since string
+	if params.Since != nil {
+		since = *params.Since
+	}
+	var limit int
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	page, err := h.svc.Pull(r.Context(), since, limit)
+	if errors.Is(err, domain.ErrChangesExpired) {
+		http.Error(w, "Sync token expired; pull again without since", http.StatusGone)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeSyncJSON(r.Context(), w, page)
+}
+
+// PushChanges handles POST /sync
+func (h *SyncHandler) PushChanges(w http.ResponseWriter, r *http.Request) {
+	if h.svc == nil {
+		http.Error(w, "Sync is disabled", http.StatusNotFound)
+		return
+	}
+
+	var input gen.SyncPushInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	changes := make([]domain.SyncChange, len(input.Changes))
+	for i, in := range input.Changes {
+		c := domain.SyncChange{UpdatedAt: in.UpdatedAt, Tags: []string{}}
+		if in.Id != nil {
+			c.ID = in.Id.String()
+		}
+		if in.Version != nil {
+			c.BaseVersion = *in.Version
+		}
+		if in.Deleted != nil {
+			c.Deleted = *in.Deleted
+		}
+		if in.Url != nil {
+			c.URL = *in.Url
+		}
+		if in.Title != nil {
+			c.Title = *in.Title
+		}
+		if in.Description != nil {
+			c.Description = *in.Description
+		}
+		if in.Tags != nil {
+			c.Tags = *in.Tags
+		}
+		changes[i] = c
+	}
+
+	results, err := h.svc.Push(r.Context(), changes)
+	if errors.Is(err, domain.ErrInvalidSync) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeSyncJSON(r.Context(), w, results)
+}
+
+func writeSyncJSON(ctx context.Context, w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		domain.LoggerFrom(ctx).Error("Error encoding sync response", "error", err)
+	}
+}
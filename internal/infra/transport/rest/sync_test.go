@@ -0,0 +1,159 @@
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSyncHandler_PullChanges(t *testing.T) {
+	t.Parallel()
+
+	since := "epoch-7"
+	limit := 50
+
+	tests := []struct {
+		name         string
+		params       gen.PullChangesParams
+		mockBehavior func(m *mocks.SyncService)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:   "Changes Since Token",
+			params: gen.PullChangesParams{Since: &since, Limit: &limit},
+			mockBehavior: func(m *mocks.SyncService) {
+				m.On("Pull", mock.Anything, "epoch-7", 50).Return(&domain.SyncPage{
+					Token:      "epoch-9",
+					Upserts:    []*domain.Bookmark{},
+					Tombstones: []domain.Tombstone{{ID: "gone"}},
+				}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"token":"epoch-9"`,
+		},
+		{
+			name: "Full Sync",
+			mockBehavior: func(m *mocks.SyncService) {
+				m.On("Pull", mock.Anything, "", 0).Return(&domain.SyncPage{Token: "epoch-0", Full: true}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"full":true`,
+		},
+		{
+			name:   "Expired Token",
+			params: gen.PullChangesParams{Since: &since},
+			mockBehavior: func(m *mocks.SyncService) {
+				m.On("Pull", mock.Anything, "epoch-7", 0).Return(nil, fmt.Errorf("service.Pull: %w", domain.ErrChangesExpired)).Once()
+			},
+			expectedCode: http.StatusGone,
+		},
+		{
+			name: "Service Error",
+			mockBehavior: func(m *mocks.SyncService) {
+				m.On("Pull", mock.Anything, "", 0).Return(nil, errors.New("boom")).Once()
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewSyncService(t)
+			tt.mockBehavior(mockSvc)
+
+			handler := NewSyncHandler(mockSvc)
+			w := httptest.NewRecorder()
+			handler.PullChanges(w, httptest.NewRequest("GET", "/sync", nil), tt.params)
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("PullChanges() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+			if tt.expectedBody != "" && !strings.Contains(w.Body.String(), tt.expectedBody) {
+				t.Errorf("PullChanges() body = %q, want it to contain %q", w.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestSyncHandler_PushChanges(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		requestBody  string
+		mockBehavior func(m *mocks.SyncService)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:        "Per Item Results",
+			requestBody: `{"changes":[{"id":"6f1c2a7e-8a57-4bd6-9b0a-2f4f3c2b1a10","version":3,"updated_at":"2024-05-01T12:00:00Z","url":"https://go.dev","title":"Go"}]}`,
+			mockBehavior: func(m *mocks.SyncService) {
+				m.On("Push", mock.Anything, mock.MatchedBy(func(cs []domain.SyncChange) bool {
+					return len(cs) == 1 && cs[0].ID == "6f1c2a7e-8a57-4bd6-9b0a-2f4f3c2b1a10" && cs[0].BaseVersion == 3 && cs[0].Title == "Go"
+				})).Return([]domain.SyncResult{{ID: "6f1c2a7e-8a57-4bd6-9b0a-2f4f3c2b1a10", Status: domain.SyncConflict}}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"status":"conflict"`,
+		},
+		{
+			name:         "Invalid Request Body",
+			requestBody:  `{"changes":`,
+			mockBehavior: func(_ *mocks.SyncService) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "Too Many Changes",
+			requestBody: `{"changes":[]}`,
+			mockBehavior: func(m *mocks.SyncService) {
+				m.On("Push", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("service.Push: %w: too many", domain.ErrInvalidSync)).Once()
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewSyncService(t)
+			tt.mockBehavior(mockSvc)
+
+			handler := NewSyncHandler(mockSvc)
+			w := httptest.NewRecorder()
+			handler.PushChanges(w, httptest.NewRequest("POST", "/sync", bytes.NewBufferString(tt.requestBody)))
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("PushChanges() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+			if tt.expectedBody != "" && !strings.Contains(w.Body.String(), tt.expectedBody) {
+				t.Errorf("PushChanges() body = %q, want it to contain %q", w.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestSyncHandler_Disabled(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	NewSyncHandler(nil).PullChanges(w, httptest.NewRequest("GET", "/sync", nil), gen.PullChangesParams{})
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("PullChanges() status code = %v, want %v", w.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,101 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestTraceRequests installs a global tracer provider, so it does not run in
+// parallel.
+func TestTraceRequests(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	tests := []struct {
+		name        string
+		traceparent string
+		status      int
+		wantTraceID string
+		wantParent  string
+		wantError   bool
+	}{
+		{
+			name:        "Continued",
+			traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			status:      http.StatusOK,
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantParent:  "00f067aa0ba902b7",
+		},
+		{
+			name:      "Started",
+			status:    http.StatusInternalServerError,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /bookmarks/{id}", func(w http.ResponseWriter, r *http.Request) {
+				domain.LoggerFrom(r.Context()).Info("handling")
+				w.WriteHeader(tt.status)
+			})
+			r := httptest.NewRequest("GET", "/bookmarks/1", nil)
+			if tt.traceparent != "" {
+				r.Header.Set("traceparent", tt.traceparent)
+			}
+			r = r.WithContext(domain.WithLogger(r.Context(), logger))
+			TraceRequests(mux).ServeHTTP(httptest.NewRecorder(), r)
+
+			spans := recorder.Ended()
+			span := spans[len(spans)-1]
+			sc := span.SpanContext()
+			if span.Name() != "GET /bookmarks/{id}" {
+				t.Errorf("span name = %q, want %q", span.Name(), "GET /bookmarks/{id}")
+			}
+			if tt.wantTraceID != "" && sc.TraceID().String() != tt.wantTraceID {
+				t.Errorf("trace ID = %v, want %v", sc.TraceID(), tt.wantTraceID)
+			}
+			if got := span.Parent().SpanID(); tt.wantParent != "" && got.String() != tt.wantParent {
+				t.Errorf("parent span ID = %v, want %v", got, tt.wantParent)
+			}
+			if (span.Status().Code == codes.Error) != tt.wantError {
+				t.Errorf("span status = %v, want error %v", span.Status().Code, tt.wantError)
+			}
+			attrs := attribute.NewSet(span.Attributes()...)
+			if route, _ := attrs.Value("http.route"); route.AsString() != "/bookmarks/{id}" {
+				t.Errorf("http.route = %q, want %q", route.AsString(), "/bookmarks/{id}")
+			}
+			if code, _ := attrs.Value("http.response.status_code"); code.AsInt64() != int64(tt.status) {
+				t.Errorf("http.response.status_code = %v, want %v", code.AsInt64(), tt.status)
+			}
+
+			var line struct {
+				TraceID string `json:"trace_id"`
+			}
+			if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+				t.Fatal(err)
+			}
+			if line.TraceID != sc.TraceID().String() {
+				t.Errorf("logged trace_id = %q, want %q", line.TraceID, sc.TraceID())
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/service"
+)
+
+// TrashHandler serves the /trash endpoints.
+type TrashHandler struct {
+	svc service.BookmarkService
+}
+
+func NewTrashHandler(svc service.BookmarkService) *TrashHandler {
+	return &TrashHandler{svc: svc}
+}
+
+// ListTrash handles GET /trash
+func (h *TrashHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	trash, err := h.svc.Trash(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(trash); err != nil {
+		domain.LoggerFrom(r.Context()).Error("Error encoding trash", "error", err)
+	}
+}
+
+// RestoreBookmark handles POST /trash/{id}/restore
+func (h *TrashHandler) RestoreBookmark(w http.ResponseWriter, r *http.Request, id string) {
+	b, err := h.svc.Restore(r.Context(), id)
+	if errors.Is(err, domain.ErrBookmarkNotFound) {
+		http.Error(w, "Bookmark not in trash", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(b); err != nil {
+		domain.LoggerFrom(r.Context()).Error("Error encoding restored bookmark", "error", err)
+	}
+}
+
+// PurgeBookmark handles DELETE /trash/{id}
+func (h *TrashHandler) PurgeBookmark(w http.ResponseWriter, r *http.Request, id string) {
+	err := h.svc.Purge(r.Context(), id)
+	if errors.Is(err, domain.ErrBookmarkNotFound) {
+		http.Error(w, "Bookmark not in trash", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,121 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTrashHandler_ListTrash(t *testing.T) {
+	t.Parallel()
+
+	deletedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockSvc := mocks.NewBookmarkService(t)
+	mockSvc.On("Trash", mock.Anything).Return([]*domain.Bookmark{{ID: "1", URL: "https://go.dev", Title: "Go", DeletedAt: &deletedAt}}, nil).Once()
+
+	w := httptest.NewRecorder()
+	NewTrashHandler(mockSvc).ListTrash(w, httptest.NewRequest("GET", "/trash", nil))
+
+	want := `[{"id":"1","url":"https://go.dev","title":"Go","description":"","tags":null,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","deleted_at":"2024-05-01T12:00:00Z"}]` + "\n"
+	if w.Code != http.StatusOK || w.Body.String() != want {
+		t.Errorf("ListTrash() = %v %q, want 200 %q", w.Code, w.Body.String(), want)
+	}
+}
+
+func TestTrashHandler_RestoreBookmark(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		mockBehavior func(m *mocks.BookmarkService)
+		expectedCode int
+	}{
+		{
+			name: "Restored",
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Restore", mock.Anything, "1").Return(&domain.Bookmark{ID: "1"}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Not In Trash",
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Restore", mock.Anything, "1").Return(nil, fmt.Errorf("service.Restore: %w", domain.ErrBookmarkNotFound)).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Service Error",
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Restore", mock.Anything, "1").Return(nil, errors.New("boom")).Once()
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewBookmarkService(t)
+			tt.mockBehavior(mockSvc)
+
+			w := httptest.NewRecorder()
+			NewTrashHandler(mockSvc).RestoreBookmark(w, httptest.NewRequest("POST", "/trash/1/restore", nil), "1")
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("RestoreBookmark() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+		})
+	}
+}
+
+func TestTrashHandler_PurgeBookmark(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		mockBehavior func(m *mocks.BookmarkService)
+		expectedCode int
+	}{
+		{
+			name: "Purged",
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Purge", mock.Anything, "1").Return(nil).Once()
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "Not In Trash",
+			mockBehavior: func(m *mocks.BookmarkService) {
+				m.On("Purge", mock.Anything, "1").Return(fmt.Errorf("service.Purge: %w", domain.ErrBookmarkNotFound)).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewBookmarkService(t)
+			tt.mockBehavior(mockSvc)
+
+			w := httptest.NewRecorder()
+			NewTrashHandler(mockSvc).PurgeBookmark(w, httptest.NewRequest("DELETE", "/trash/1", nil), "1")
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("PurgeBookmark() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+	"github.com/etsrc/goprod/internal/service"
+)
+
+// WebhookHandler serves the /webhooks endpoints. A nil service means
+// webhooks are disabled.
+type WebhookHandler struct {
+	svc service.WebhookService
+}
+
+func NewWebhookHandler(svc service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{svc: svc}
+}
+
+// ListWebhooks handles GET /webhooks
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	if h.svc == nil {
+		http.Error(w, "Webhooks are disabled", http.StatusNotFound)
+		return
+	}
+
+	list, err := h.svc.List(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeWebhookJSON(r.Context(), w, http.StatusOK, list)
+}
+
+// CreateWebhook handles POST /webhooks. The response is the only one that
+// includes the secret.
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.svc == nil {
+		http.Error(w, "Webhooks are disabled", http.StatusNotFound)
+		return
+	}
+
+	hook, ok := decodeWebhookInput(w, r)
+	if !ok {
+		return
+	}
+	if err := h.svc.Create(r.Context(), hook); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/webhooks/"+hook.ID)
+	writeWebhookJSON(r.Context(), w, http.StatusCreated, struct {
+		*domain.Webhook
+		Secret string `json:"secret"`
+	}{hook, hook.Secret})
+}
+
+// GetWebhook handles GET /webhooks/{id}
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request, id string) {
+	if h.svc == nil {
+		http.Error(w, "Webhooks are disabled", http.StatusNotFound)
+		return
+	}
+
+	hook, err := h.svc.GetByID(r.Context(), id)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	writeWebhookJSON(r.Context(), w, http.StatusOK, hook)
+}
+
+// UpdateWebhook handles PUT /webhooks/{id}
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request, id string) {
+	if h.svc == nil {
+		http.Error(w, "Webhooks are disabled", http.StatusNotFound)
+		return
+	}
+
+	hook, ok := decodeWebhookInput(w, r)
+	if !ok {
+		return
+	}
+	hook.ID = id
+	updated, err := h.svc.Update(r.Context(), hook)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	writeWebhookJSON(r.Context(), w, http.StatusOK, updated)
+}
+
+// DeleteWebhook handles DELETE /webhooks/{id}
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request, id string) {
+	if h.svc == nil {
+		http.Error(w, "Webhooks are disabled", http.StatusNotFound)
+		return
+	}
+
+	if err := h.svc.Delete(r.Context(), id); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries handles GET /webhooks/{id}/deliveries
+func (h *WebhookHandler) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, id string) {
+	if h.svc == nil {
+		http.Error(w, "Webhooks are disabled", http.StatusNotFound)
+		return
+	}
+
+	list, err := h.svc.Deliveries(r.Context(), id)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	writeWebhookJSON(r.Context(), w, http.StatusOK, list)
+}
+
+// GetWebhookDelivery handles GET /webhooks/{id}/deliveries/{deliveryId}
+func (h *WebhookHandler) GetWebhookDelivery(w http.ResponseWriter, r *http.Request, id string, deliveryID string) {
+	if h.svc == nil {
+		http.Error(w, "Webhooks are disabled", http.StatusNotFound)
+		return
+	}
+
+	d, err := h.svc.Delivery(r.Context(), id, deliveryID)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	writeWebhookJSON(r.Context(), w, http.StatusOK, d)
+}
+
+// RedeliverWebhookDelivery handles POST /webhooks/{id}/deliveries/{deliveryId}/redeliver
+func (h *WebhookHandler) RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request, id string, deliveryID string) {
+	if h.svc == nil {
+		http.Error(w, "Webhooks are disabled", http.StatusNotFound)
+		return
+	}
+
+	d, err := h.svc.Redeliver(r.Context(), id, deliveryID)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	writeWebhookJSON(r.Context(), w, http.StatusAccepted, d)
+}
+
+// decodeWebhookInput maps the request body to a webhook, writing an error
+// response when it cannot.
+func decodeWebhookInput(w http.ResponseWriter, r *http.Request) (*domain.Webhook, bool) {
+	var input gen.WebhookInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return nil, false
+	}
+
+	hook := &domain.Webhook{URL: input.Url, Events: []string{}, Active: true}
+	if input.Events != nil {
+		hook.Events = *input.Events
+	}
+	if input.Secret != nil {
+		hook.Secret = *input.Secret
+	}
+	if input.Active != nil {
+		hook.Active = *input.Active
+	}
+	return hook, true
+}
+
+func writeWebhookError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		http.Error(w, "Webhook not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrWebhookDeliveryNotFound):
+		http.Error(w, "Delivery not found", http.StatusNotFound)
+	case errors.Is(err, domain.ErrInvalidWebhook):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrWebhookDisabled):
+		http.Error(w, "Webhook is disabled", http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeWebhookJSON(ctx context.Context, w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		domain.LoggerFrom(ctx).Error("Error encoding webhook response", "error", err)
+	}
+}
@@ -0,0 +1,181 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookHandler_CreateWebhook(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		requestBody  string
+		mockBehavior func(m *mocks.WebhookService)
+		expectedCode int
+		wantSecret   bool
+	}{
+		{
+			name:        "Created",
+			requestBody: `{"url":"https://hooks.example.com/in","events":["bookmark.*"]}`,
+			mockBehavior: func(m *mocks.WebhookService) {
+				m.On("Create", mock.Anything, mock.MatchedBy(func(w *domain.Webhook) bool {
+					return w.URL == "https://hooks.example.com/in" && len(w.Events) == 1 && w.Secret == ""
+				})).Run(func(args mock.Arguments) {
+					w := args.Get(1).(*domain.Webhook)
+					w.ID = "hook-1"
+					w.Secret = "generated-secret-value"
+				}).Return(nil).Once()
+			},
+			expectedCode: http.StatusCreated,
+			wantSecret:   true,
+		},
+		{
+			name:         "Invalid Request Body",
+			requestBody:  `{"url":`,
+			mockBehavior: func(_ *mocks.WebhookService) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "Invalid Webhook",
+			requestBody: `{"url":"ftp://hooks.example.com"}`,
+			mockBehavior: func(m *mocks.WebhookService) {
+				m.On("Create", mock.Anything, mock.Anything).Return(fmt.Errorf("%w: bad url", domain.ErrInvalidWebhook)).Once()
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "Service Error",
+			requestBody: `{"url":"https://hooks.example.com/in"}`,
+			mockBehavior: func(m *mocks.WebhookService) {
+				m.On("Create", mock.Anything, mock.Anything).Return(errors.New("boom")).Once()
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewWebhookService(t)
+			tt.mockBehavior(mockSvc)
+
+			handler := NewWebhookHandler(mockSvc)
+			req := httptest.NewRequest("POST", "/webhooks", bytes.NewBufferString(tt.requestBody))
+			w := httptest.NewRecorder()
+
+			handler.CreateWebhook(w, req)
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("CreateWebhook() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+			if !tt.wantSecret {
+				return
+			}
+			var body struct {
+				ID     string `json:"id"`
+				Secret string `json:"secret"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			if body.ID != "hook-1" || body.Secret != "generated-secret-value" {
+				t.Errorf("CreateWebhook() body = %+v, want the ID and secret", body)
+			}
+			if loc := w.Header().Get("Location"); loc != "/webhooks/hook-1" {
+				t.Errorf("CreateWebhook() Location = %q, want %q", loc, "/webhooks/hook-1")
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_GetWebhookOmitsSecret(t *testing.T) {
+	t.Parallel()
+
+	mockSvc := mocks.NewWebhookService(t)
+	mockSvc.On("GetByID", mock.Anything, "hook-1").Return(&domain.Webhook{ID: "hook-1", Secret: "do-not-show-this"}, nil).Once()
+
+	w := httptest.NewRecorder()
+	NewWebhookHandler(mockSvc).GetWebhook(w, httptest.NewRequest("GET", "/webhooks/hook-1", nil), "hook-1")
+
+	if w.Code != http.StatusOK {
+		t.Errorf("GetWebhook() status code = %v, want %v", w.Code, http.StatusOK)
+	}
+	if bytes.Contains(w.Body.Bytes(), []byte("do-not-show-this")) {
+		t.Errorf("GetWebhook() body contains the secret: %s", w.Body)
+	}
+}
+
+func TestWebhookHandler_RedeliverWebhookDelivery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		mockBehavior func(m *mocks.WebhookService)
+		expectedCode int
+	}{
+		{
+			name: "Accepted",
+			mockBehavior: func(m *mocks.WebhookService) {
+				m.On("Redeliver", mock.Anything, "hook-1", "d-1").Return(&domain.WebhookDelivery{ID: "d-2", RedeliveryOf: "d-1"}, nil).Once()
+			},
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name: "Delivery Not Found",
+			mockBehavior: func(m *mocks.WebhookService) {
+				m.On("Redeliver", mock.Anything, "hook-1", "d-1").Return(nil, domain.ErrWebhookDeliveryNotFound).Once()
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Webhook Disabled",
+			mockBehavior: func(m *mocks.WebhookService) {
+				m.On("Redeliver", mock.Anything, "hook-1", "d-1").Return(nil, domain.ErrWebhookDisabled).Once()
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSvc := mocks.NewWebhookService(t)
+			tt.mockBehavior(mockSvc)
+
+			handler := NewWebhookHandler(mockSvc)
+			req := httptest.NewRequest("POST", "/webhooks/hook-1/deliveries/d-1/redeliver", nil)
+			w := httptest.NewRecorder()
+
+			handler.RedeliverWebhookDelivery(w, req, "hook-1", "d-1")
+
+			if w.Code != tt.expectedCode {
+				t.Errorf("RedeliverWebhookDelivery() status code = %v, want %v", w.Code, tt.expectedCode)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_Disabled(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	NewWebhookHandler(nil).ListWebhooks(w, httptest.NewRequest("GET", "/webhooks", nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("ListWebhooks() status code = %v, want %v", w.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,100 @@
+// Package webhook sends webhook deliveries over HTTP, signed so that
+// receivers can check that they come from goprod and are recent.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/outbound"
+)
+
+const (
+	DefaultUserAgent = "goprod-webhook/1.0 (+https://github.com/etsrc/goprod)"
+
+	HeaderSignature = "X-Goprod-Signature"
+	HeaderEvent     = "X-Goprod-Event"
+	HeaderDelivery  = "X-Goprod-Delivery"
+
+	// responseBytes is how much of a response body is kept with an attempt.
+	responseBytes = 1 << 10
+)
+
+type Options struct {
+	// Client sends every request. Redirects are not followed whatever its
+	// policy, so a delivery goes only to the URL the webhook names. Defaults
+	// to an outbound client with default limits, which refuses private
+	// addresses.
+	Client *http.Client
+	// UserAgent is sent with every request.
+	UserAgent string
+	// Timeout bounds one attempt.
+	Timeout time.Duration
+}
+
+// Sender implements domain.WebhookSender.
+type Sender struct {
+	client *http.Client
+	opts   Options
+}
+
+var _ domain.WebhookSender = (*Sender)(nil)
+
+func NewSender(opts Options) *Sender {
+	if opts.Client == nil {
+		// Without a proxy NewClient cannot fail.
+		opts.Client, _ = outbound.NewClient(outbound.Options{})
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = DefaultUserAgent
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	client := *opts.Client
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &Sender{client: &client, opts: opts}
+}
+
+// Send POSTs the payload, signed at the time of sending. Any 2xx response
+// counts as accepted; redirects do not.
+func (s *Sender) Send(ctx context.Context, req domain.WebhookRequest) (attempt domain.WebhookAttempt) {
+	ctx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	attempt.At = start
+	defer func() { attempt.DurationMS = time.Since(start).Milliseconds() }()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", s.opts.UserAgent)
+	httpReq.Header.Set(HeaderEvent, string(req.EventType))
+	httpReq.Header.Set(HeaderDelivery, req.DeliveryID)
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, start, req.Payload))
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer resp.Body.Close()
+
+	attempt.StatusCode = resp.StatusCode
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, responseBytes))
+	if utf8.Valid(body) {
+		attempt.Response = string(body)
+	}
+	return attempt
+}
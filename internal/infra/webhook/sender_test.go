@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/outbound"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSender_Send(t *testing.T) {
+	t.Parallel()
+
+	payload := []byte(`{"id":"e1","type":"bookmark.created"}`)
+	var got *http.Request
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/hook":
+			got = r
+			gotBody, _ = io.ReadAll(r.Body)
+			w.Write([]byte("thanks"))
+		case "/moved":
+			http.Redirect(w, r, "/hook", http.StatusFound)
+		default:
+			http.Error(w, "nope", http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	sender := NewSender(Options{Client: srv.Client()})
+	req := domain.WebhookRequest{
+		URL:        srv.URL + "/hook",
+		Secret:     "s3cret-s3cret-s3cret",
+		DeliveryID: "d1",
+		EventType:  domain.EventBookmarkCreated,
+		Payload:    payload,
+	}
+
+	attempt := sender.Send(context.Background(), req)
+	assert.True(t, attempt.OK(), "attempt = %+v", attempt)
+	assert.Equal(t, "thanks", attempt.Response)
+	require.NotNil(t, got)
+	assert.Equal(t, payload, gotBody)
+	assert.Equal(t, "application/json", got.Header.Get("Content-Type"))
+	assert.Equal(t, "bookmark.created", got.Header.Get(HeaderEvent))
+	assert.Equal(t, "d1", got.Header.Get(HeaderDelivery))
+	assert.Equal(t, DefaultUserAgent, got.Header.Get("User-Agent"))
+	assert.NoError(t, Verify(req.Secret, got.Header.Get(HeaderSignature), gotBody, time.Now(), time.Minute))
+
+	req.URL = srv.URL + "/fail"
+	attempt = sender.Send(context.Background(), req)
+	assert.False(t, attempt.OK())
+	assert.Equal(t, http.StatusInternalServerError, attempt.StatusCode)
+
+	// Redirects are not followed, and do not count as accepted.
+	req.URL = srv.URL + "/moved"
+	attempt = sender.Send(context.Background(), req)
+	assert.False(t, attempt.OK())
+	assert.Equal(t, http.StatusFound, attempt.StatusCode)
+}
+
+func TestSender_SendRefusesLoopback(t *testing.T) {
+	t.Parallel()
+
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	}))
+	defer srv.Close()
+	req := domain.WebhookRequest{URL: srv.URL, Secret: "s3cret-s3cret-s3cret"}
+
+	// The default client is an outbound client, which refuses loopback
+	// addresses unless allowlisted.
+	attempt := NewSender(Options{}).Send(context.Background(), req)
+	assert.False(t, attempt.OK())
+	assert.Contains(t, attempt.Error, "address not allowed")
+	assert.Zero(t, attempt.StatusCode)
+	assert.Zero(t, hits)
+
+	client, err := outbound.NewClient(outbound.Options{Allow: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	attempt = NewSender(Options{Client: client}).Send(context.Background(), req)
+	assert.True(t, attempt.OK(), "attempt = %+v", attempt)
+	assert.Equal(t, 1, hits)
+}
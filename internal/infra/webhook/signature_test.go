@@ -0,0 +1,41 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"type":"bookmark.created"}`)
+	sent := time.Unix(1700000000, 0)
+	header := Sign("s3cret-s3cret-s3cret", sent, body)
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		body    []byte
+		now     time.Time
+		wantErr error
+	}{
+		{"Valid", "s3cret-s3cret-s3cret", header, body, sent.Add(time.Minute), nil},
+		{"Other Secret", "another-secret-value", header, body, sent, ErrInvalidSignature},
+		{"Changed Body", "s3cret-s3cret-s3cret", header, []byte(`{"type":"bookmark.deleted"}`), sent, ErrInvalidSignature},
+		{"Changed Timestamp", "s3cret-s3cret-s3cret", "t=1700000001" + header[len("t=1700000000"):], body, sent, ErrInvalidSignature},
+		{"Too Old", "s3cret-s3cret-s3cret", header, body, sent.Add(10 * time.Minute), ErrSignatureExpired},
+		{"Rotated Secret", "s3cret-s3cret-s3cret", Sign("old-secret-old-secret", sent, body) + "," + header[len("t=1700000000,"):], body, sent, nil},
+		{"Malformed", "s3cret-s3cret-s3cret", "v1=zz", body, sent, ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := Verify(tt.secret, tt.header, tt.body, tt.now, 5*time.Minute); err != tt.wantErr {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
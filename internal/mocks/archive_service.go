@@ -0,0 +1,271 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ArchiveService is an autogenerated mock type for the ArchiveService type
+type ArchiveService struct {
+	mock.Mock
+}
+
+type ArchiveService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ArchiveService) EXPECT() *ArchiveService_Expecter {
+	return &ArchiveService_Expecter{mock: &_m.Mock}
+}
+
+// Enqueue provides a mock function with given fields: ctx, id
+func (_m *ArchiveService) Enqueue(ctx context.Context, id string) {
+	_m.Called(ctx, id)
+}
+
+// ArchiveService_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type ArchiveService_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ArchiveService_Expecter) Enqueue(ctx interface{}, id interface{}) *ArchiveService_Enqueue_Call {
+	return &ArchiveService_Enqueue_Call{Call: _e.mock.On("Enqueue", ctx, id)}
+}
+
+func (_c *ArchiveService_Enqueue_Call) Run(run func(ctx context.Context, id string)) *ArchiveService_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ArchiveService_Enqueue_Call) Return() *ArchiveService_Enqueue_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ArchiveService_Enqueue_Call) RunAndReturn(run func(context.Context, string)) *ArchiveService_Enqueue_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Remove provides a mock function with given fields: ctx, id
+func (_m *ArchiveService) Remove(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ArchiveService_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type ArchiveService_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ArchiveService_Expecter) Remove(ctx interface{}, id interface{}) *ArchiveService_Remove_Call {
+	return &ArchiveService_Remove_Call{Call: _e.mock.On("Remove", ctx, id)}
+}
+
+func (_c *ArchiveService_Remove_Call) Run(run func(ctx context.Context, id string)) *ArchiveService_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ArchiveService_Remove_Call) Return(_a0 error) *ArchiveService_Remove_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ArchiveService_Remove_Call) RunAndReturn(run func(context.Context, string) error) *ArchiveService_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Request provides a mock function with given fields: ctx, id
+func (_m *ArchiveService) Request(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Request")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ArchiveService_Request_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Request'
+type ArchiveService_Request_Call struct {
+	*mock.Call
+}
+
+// Request is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ArchiveService_Expecter) Request(ctx interface{}, id interface{}) *ArchiveService_Request_Call {
+	return &ArchiveService_Request_Call{Call: _e.mock.On("Request", ctx, id)}
+}
+
+func (_c *ArchiveService_Request_Call) Run(run func(ctx context.Context, id string)) *ArchiveService_Request_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ArchiveService_Request_Call) Return(_a0 error) *ArchiveService_Request_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ArchiveService_Request_Call) RunAndReturn(run func(context.Context, string) error) *ArchiveService_Request_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resource provides a mock function with given fields: ctx, id, url
+func (_m *ArchiveService) Resource(ctx context.Context, id string, url string) (*domain.ArchivedResource, error) {
+	ret := _m.Called(ctx, id, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resource")
+	}
+
+	var r0 *domain.ArchivedResource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.ArchivedResource, error)); ok {
+		return rf(ctx, id, url)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.ArchivedResource); ok {
+		r0 = rf(ctx, id, url)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ArchivedResource)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ArchiveService_Resource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resource'
+type ArchiveService_Resource_Call struct {
+	*mock.Call
+}
+
+// Resource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - url string
+func (_e *ArchiveService_Expecter) Resource(ctx interface{}, id interface{}, url interface{}) *ArchiveService_Resource_Call {
+	return &ArchiveService_Resource_Call{Call: _e.mock.On("Resource", ctx, id, url)}
+}
+
+func (_c *ArchiveService_Resource_Call) Run(run func(ctx context.Context, id string, url string)) *ArchiveService_Resource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ArchiveService_Resource_Call) Return(_a0 *domain.ArchivedResource, _a1 error) *ArchiveService_Resource_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ArchiveService_Resource_Call) RunAndReturn(run func(context.Context, string, string) (*domain.ArchivedResource, error)) *ArchiveService_Resource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *ArchiveService) Run(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Run")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ArchiveService_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type ArchiveService_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ArchiveService_Expecter) Run(ctx interface{}) *ArchiveService_Run_Call {
+	return &ArchiveService_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *ArchiveService_Run_Call) Run(run func(ctx context.Context)) *ArchiveService_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ArchiveService_Run_Call) Return(_a0 error) *ArchiveService_Run_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ArchiveService_Run_Call) RunAndReturn(run func(context.Context) error) *ArchiveService_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewArchiveService creates a new instance of ArchiveService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewArchiveService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ArchiveService {
+	mock := &ArchiveService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
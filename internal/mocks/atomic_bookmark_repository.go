@@ -0,0 +1,805 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AtomicBookmarkRepository is an autogenerated mock type for the AtomicBookmarkRepository type
+type AtomicBookmarkRepository struct {
+	mock.Mock
+}
+
+type AtomicBookmarkRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AtomicBookmarkRepository) EXPECT() *AtomicBookmarkRepository_Expecter {
+	return &AtomicBookmarkRepository_Expecter{mock: &_m.Mock}
+}
+
+// Ack provides a mock function with given fields: ctx, eventIDs
+func (_m *AtomicBookmarkRepository) Ack(ctx context.Context, eventIDs ...string) error {
+	_va := make([]interface{}, len(eventIDs))
+	for _i := range eventIDs {
+		_va[_i] = eventIDs[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ack")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = rf(ctx, eventIDs...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AtomicBookmarkRepository_Ack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ack'
+type AtomicBookmarkRepository_Ack_Call struct {
+	*mock.Call
+}
+
+// Ack is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventIDs ...string
+func (_e *AtomicBookmarkRepository_Expecter) Ack(ctx interface{}, eventIDs ...interface{}) *AtomicBookmarkRepository_Ack_Call {
+	return &AtomicBookmarkRepository_Ack_Call{Call: _e.mock.On("Ack",
+		append([]interface{}{ctx}, eventIDs...)...)}
+}
+
+func (_c *AtomicBookmarkRepository_Ack_Call) Run(run func(ctx context.Context, eventIDs ...string)) *AtomicBookmarkRepository_Ack_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Ack_Call) Return(_a0 error) *AtomicBookmarkRepository_Ack_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Ack_Call) RunAndReturn(run func(context.Context, ...string) error) *AtomicBookmarkRepository_Ack_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Atomically provides a mock function with given fields: ctx, fn
+func (_m *AtomicBookmarkRepository) Atomically(ctx context.Context, fn func(domain.BookmarkTx) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Atomically")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(domain.BookmarkTx) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AtomicBookmarkRepository_Atomically_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Atomically'
+type AtomicBookmarkRepository_Atomically_Call struct {
+	*mock.Call
+}
+
+// Atomically is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(domain.BookmarkTx) error
+func (_e *AtomicBookmarkRepository_Expecter) Atomically(ctx interface{}, fn interface{}) *AtomicBookmarkRepository_Atomically_Call {
+	return &AtomicBookmarkRepository_Atomically_Call{Call: _e.mock.On("Atomically", ctx, fn)}
+}
+
+func (_c *AtomicBookmarkRepository_Atomically_Call) Run(run func(ctx context.Context, fn func(domain.BookmarkTx) error)) *AtomicBookmarkRepository_Atomically_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(domain.BookmarkTx) error))
+	})
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Atomically_Call) Return(_a0 error) *AtomicBookmarkRepository_Atomically_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Atomically_Call) RunAndReturn(run func(context.Context, func(domain.BookmarkTx) error) error) *AtomicBookmarkRepository_Atomically_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompareAndDelete provides a mock function with given fields: ctx, id, version
+func (_m *AtomicBookmarkRepository) CompareAndDelete(ctx context.Context, id string, version int64) error {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompareAndDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AtomicBookmarkRepository_CompareAndDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareAndDelete'
+type AtomicBookmarkRepository_CompareAndDelete_Call struct {
+	*mock.Call
+}
+
+// CompareAndDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - version int64
+func (_e *AtomicBookmarkRepository_Expecter) CompareAndDelete(ctx interface{}, id interface{}, version interface{}) *AtomicBookmarkRepository_CompareAndDelete_Call {
+	return &AtomicBookmarkRepository_CompareAndDelete_Call{Call: _e.mock.On("CompareAndDelete", ctx, id, version)}
+}
+
+func (_c *AtomicBookmarkRepository_CompareAndDelete_Call) Run(run func(ctx context.Context, id string, version int64)) *AtomicBookmarkRepository_CompareAndDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_CompareAndDelete_Call) Return(_a0 error) *AtomicBookmarkRepository_CompareAndDelete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_CompareAndDelete_Call) RunAndReturn(run func(context.Context, string, int64) error) *AtomicBookmarkRepository_CompareAndDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompareAndUpdate provides a mock function with given fields: ctx, b, version
+func (_m *AtomicBookmarkRepository) CompareAndUpdate(ctx context.Context, b *domain.Bookmark, version int64) error {
+	ret := _m.Called(ctx, b, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompareAndUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Bookmark, int64) error); ok {
+		r0 = rf(ctx, b, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AtomicBookmarkRepository_CompareAndUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareAndUpdate'
+type AtomicBookmarkRepository_CompareAndUpdate_Call struct {
+	*mock.Call
+}
+
+// CompareAndUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - b *domain.Bookmark
+//   - version int64
+func (_e *AtomicBookmarkRepository_Expecter) CompareAndUpdate(ctx interface{}, b interface{}, version interface{}) *AtomicBookmarkRepository_CompareAndUpdate_Call {
+	return &AtomicBookmarkRepository_CompareAndUpdate_Call{Call: _e.mock.On("CompareAndUpdate", ctx, b, version)}
+}
+
+func (_c *AtomicBookmarkRepository_CompareAndUpdate_Call) Run(run func(ctx context.Context, b *domain.Bookmark, version int64)) *AtomicBookmarkRepository_CompareAndUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Bookmark), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_CompareAndUpdate_Call) Return(_a0 error) *AtomicBookmarkRepository_CompareAndUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_CompareAndUpdate_Call) RunAndReturn(run func(context.Context, *domain.Bookmark, int64) error) *AtomicBookmarkRepository_CompareAndUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, b
+func (_m *AtomicBookmarkRepository) Create(ctx context.Context, b *domain.Bookmark) error {
+	ret := _m.Called(ctx, b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Bookmark) error); ok {
+		r0 = rf(ctx, b)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AtomicBookmarkRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type AtomicBookmarkRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - b *domain.Bookmark
+func (_e *AtomicBookmarkRepository_Expecter) Create(ctx interface{}, b interface{}) *AtomicBookmarkRepository_Create_Call {
+	return &AtomicBookmarkRepository_Create_Call{Call: _e.mock.On("Create", ctx, b)}
+}
+
+func (_c *AtomicBookmarkRepository_Create_Call) Run(run func(ctx context.Context, b *domain.Bookmark)) *AtomicBookmarkRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Bookmark))
+	})
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Create_Call) Return(_a0 error) *AtomicBookmarkRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Create_Call) RunAndReturn(run func(context.Context, *domain.Bookmark) error) *AtomicBookmarkRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBatch provides a mock function with given fields: ctx, bs
+func (_m *AtomicBookmarkRepository) CreateBatch(ctx context.Context, bs []*domain.Bookmark) error {
+	ret := _m.Called(ctx, bs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Bookmark) error); ok {
+		r0 = rf(ctx, bs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AtomicBookmarkRepository_CreateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatch'
+type AtomicBookmarkRepository_CreateBatch_Call struct {
+	*mock.Call
+}
+
+// CreateBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bs []*domain.Bookmark
+func (_e *AtomicBookmarkRepository_Expecter) CreateBatch(ctx interface{}, bs interface{}) *AtomicBookmarkRepository_CreateBatch_Call {
+	return &AtomicBookmarkRepository_CreateBatch_Call{Call: _e.mock.On("CreateBatch", ctx, bs)}
+}
+
+func (_c *AtomicBookmarkRepository_CreateBatch_Call) Run(run func(ctx context.Context, bs []*domain.Bookmark)) *AtomicBookmarkRepository_CreateBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*domain.Bookmark))
+	})
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_CreateBatch_Call) Return(_a0 error) *AtomicBookmarkRepository_CreateBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_CreateBatch_Call) RunAndReturn(run func(context.Context, []*domain.Bookmark) error) *AtomicBookmarkRepository_CreateBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *AtomicBookmarkRepository) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AtomicBookmarkRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type AtomicBookmarkRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *AtomicBookmarkRepository_Expecter) Delete(ctx interface{}, id interface{}) *AtomicBookmarkRepository_Delete_Call {
+	return &AtomicBookmarkRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *AtomicBookmarkRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *AtomicBookmarkRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Delete_Call) Return(_a0 error) *AtomicBookmarkRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Delete_Call) RunAndReturn(run func(context.Context, string) error) *AtomicBookmarkRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: ctx
+func (_m *AtomicBookmarkRepository) GetAll(ctx context.Context) ([]*domain.Bookmark, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*domain.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.Bookmark, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.Bookmark); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AtomicBookmarkRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type AtomicBookmarkRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *AtomicBookmarkRepository_Expecter) GetAll(ctx interface{}) *AtomicBookmarkRepository_GetAll_Call {
+	return &AtomicBookmarkRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx)}
+}
+
+func (_c *AtomicBookmarkRepository_GetAll_Call) Run(run func(ctx context.Context)) *AtomicBookmarkRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_GetAll_Call) Return(_a0 []*domain.Bookmark, _a1 error) *AtomicBookmarkRepository_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_GetAll_Call) RunAndReturn(run func(context.Context) ([]*domain.Bookmark, error)) *AtomicBookmarkRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *AtomicBookmarkRepository) GetByID(ctx context.Context, id string) (*domain.Bookmark, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Bookmark, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Bookmark); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AtomicBookmarkRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type AtomicBookmarkRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *AtomicBookmarkRepository_Expecter) GetByID(ctx interface{}, id interface{}) *AtomicBookmarkRepository_GetByID_Call {
+	return &AtomicBookmarkRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *AtomicBookmarkRepository_GetByID_Call) Run(run func(ctx context.Context, id string)) *AtomicBookmarkRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_GetByID_Call) Return(_a0 *domain.Bookmark, _a1 error) *AtomicBookmarkRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_GetByID_Call) RunAndReturn(run func(context.Context, string) (*domain.Bookmark, error)) *AtomicBookmarkRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Pending provides a mock function with given fields: ctx, limit
+func (_m *AtomicBookmarkRepository) Pending(ctx context.Context, limit int) ([]domain.OutboxRecord, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pending")
+	}
+
+	var r0 []domain.OutboxRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.OutboxRecord, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.OutboxRecord); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OutboxRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AtomicBookmarkRepository_Pending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pending'
+type AtomicBookmarkRepository_Pending_Call struct {
+	*mock.Call
+}
+
+// Pending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *AtomicBookmarkRepository_Expecter) Pending(ctx interface{}, limit interface{}) *AtomicBookmarkRepository_Pending_Call {
+	return &AtomicBookmarkRepository_Pending_Call{Call: _e.mock.On("Pending", ctx, limit)}
+}
+
+func (_c *AtomicBookmarkRepository_Pending_Call) Run(run func(ctx context.Context, limit int)) *AtomicBookmarkRepository_Pending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Pending_Call) Return(_a0 []domain.OutboxRecord, _a1 error) *AtomicBookmarkRepository_Pending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Pending_Call) RunAndReturn(run func(context.Context, int) ([]domain.OutboxRecord, error)) *AtomicBookmarkRepository_Pending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Purge provides a mock function with given fields: ctx, id
+func (_m *AtomicBookmarkRepository) Purge(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AtomicBookmarkRepository_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type AtomicBookmarkRepository_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *AtomicBookmarkRepository_Expecter) Purge(ctx interface{}, id interface{}) *AtomicBookmarkRepository_Purge_Call {
+	return &AtomicBookmarkRepository_Purge_Call{Call: _e.mock.On("Purge", ctx, id)}
+}
+
+func (_c *AtomicBookmarkRepository_Purge_Call) Run(run func(ctx context.Context, id string)) *AtomicBookmarkRepository_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Purge_Call) Return(_a0 error) *AtomicBookmarkRepository_Purge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Purge_Call) RunAndReturn(run func(context.Context, string) error) *AtomicBookmarkRepository_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *AtomicBookmarkRepository) Restore(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AtomicBookmarkRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type AtomicBookmarkRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *AtomicBookmarkRepository_Expecter) Restore(ctx interface{}, id interface{}) *AtomicBookmarkRepository_Restore_Call {
+	return &AtomicBookmarkRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, id)}
+}
+
+func (_c *AtomicBookmarkRepository_Restore_Call) Run(run func(ctx context.Context, id string)) *AtomicBookmarkRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Restore_Call) Return(_a0 error) *AtomicBookmarkRepository_Restore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Restore_Call) RunAndReturn(run func(context.Context, string) error) *AtomicBookmarkRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Trash provides a mock function with given fields: ctx
+func (_m *AtomicBookmarkRepository) Trash(ctx context.Context) ([]*domain.Bookmark, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Trash")
+	}
+
+	var r0 []*domain.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.Bookmark, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.Bookmark); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AtomicBookmarkRepository_Trash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Trash'
+type AtomicBookmarkRepository_Trash_Call struct {
+	*mock.Call
+}
+
+// Trash is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *AtomicBookmarkRepository_Expecter) Trash(ctx interface{}) *AtomicBookmarkRepository_Trash_Call {
+	return &AtomicBookmarkRepository_Trash_Call{Call: _e.mock.On("Trash", ctx)}
+}
+
+func (_c *AtomicBookmarkRepository_Trash_Call) Run(run func(ctx context.Context)) *AtomicBookmarkRepository_Trash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Trash_Call) Return(_a0 []*domain.Bookmark, _a1 error) *AtomicBookmarkRepository_Trash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Trash_Call) RunAndReturn(run func(context.Context) ([]*domain.Bookmark, error)) *AtomicBookmarkRepository_Trash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, b
+func (_m *AtomicBookmarkRepository) Update(ctx context.Context, b *domain.Bookmark) error {
+	ret := _m.Called(ctx, b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Bookmark) error); ok {
+		r0 = rf(ctx, b)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AtomicBookmarkRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type AtomicBookmarkRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - b *domain.Bookmark
+func (_e *AtomicBookmarkRepository_Expecter) Update(ctx interface{}, b interface{}) *AtomicBookmarkRepository_Update_Call {
+	return &AtomicBookmarkRepository_Update_Call{Call: _e.mock.On("Update", ctx, b)}
+}
+
+func (_c *AtomicBookmarkRepository_Update_Call) Run(run func(ctx context.Context, b *domain.Bookmark)) *AtomicBookmarkRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Bookmark))
+	})
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Update_Call) Return(_a0 error) *AtomicBookmarkRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Update_Call) RunAndReturn(run func(context.Context, *domain.Bookmark) error) *AtomicBookmarkRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Walk provides a mock function with given fields: ctx, filter, fn
+func (_m *AtomicBookmarkRepository) Walk(ctx context.Context, filter domain.BookmarkFilter, fn func(*domain.Bookmark) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Walk")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BookmarkFilter, func(*domain.Bookmark) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AtomicBookmarkRepository_Walk_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Walk'
+type AtomicBookmarkRepository_Walk_Call struct {
+	*mock.Call
+}
+
+// Walk is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.BookmarkFilter
+//   - fn func(*domain.Bookmark) error
+func (_e *AtomicBookmarkRepository_Expecter) Walk(ctx interface{}, filter interface{}, fn interface{}) *AtomicBookmarkRepository_Walk_Call {
+	return &AtomicBookmarkRepository_Walk_Call{Call: _e.mock.On("Walk", ctx, filter, fn)}
+}
+
+func (_c *AtomicBookmarkRepository_Walk_Call) Run(run func(ctx context.Context, filter domain.BookmarkFilter, fn func(*domain.Bookmark) error)) *AtomicBookmarkRepository_Walk_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.BookmarkFilter), args[2].(func(*domain.Bookmark) error))
+	})
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Walk_Call) Return(_a0 error) *AtomicBookmarkRepository_Walk_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AtomicBookmarkRepository_Walk_Call) RunAndReturn(run func(context.Context, domain.BookmarkFilter, func(*domain.Bookmark) error) error) *AtomicBookmarkRepository_Walk_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAtomicBookmarkRepository creates a new instance of AtomicBookmarkRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAtomicBookmarkRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AtomicBookmarkRepository {
+	mock := &AtomicBookmarkRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,458 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AttachmentRepository is an autogenerated mock type for the AttachmentRepository type
+type AttachmentRepository struct {
+	mock.Mock
+}
+
+type AttachmentRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AttachmentRepository) EXPECT() *AttachmentRepository_Expecter {
+	return &AttachmentRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, a
+func (_m *AttachmentRepository) Create(ctx context.Context, a *domain.Attachment) error {
+	ret := _m.Called(ctx, a)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Attachment) error); ok {
+		r0 = rf(ctx, a)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AttachmentRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type AttachmentRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - a *domain.Attachment
+func (_e *AttachmentRepository_Expecter) Create(ctx interface{}, a interface{}) *AttachmentRepository_Create_Call {
+	return &AttachmentRepository_Create_Call{Call: _e.mock.On("Create", ctx, a)}
+}
+
+func (_c *AttachmentRepository_Create_Call) Run(run func(ctx context.Context, a *domain.Attachment)) *AttachmentRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Attachment))
+	})
+	return _c
+}
+
+func (_c *AttachmentRepository_Create_Call) Return(_a0 error) *AttachmentRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AttachmentRepository_Create_Call) RunAndReturn(run func(context.Context, *domain.Attachment) error) *AttachmentRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, bookmarkID, id
+func (_m *AttachmentRepository) Delete(ctx context.Context, bookmarkID string, id string) error {
+	ret := _m.Called(ctx, bookmarkID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, bookmarkID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AttachmentRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type AttachmentRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+//   - id string
+func (_e *AttachmentRepository_Expecter) Delete(ctx interface{}, bookmarkID interface{}, id interface{}) *AttachmentRepository_Delete_Call {
+	return &AttachmentRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, bookmarkID, id)}
+}
+
+func (_c *AttachmentRepository_Delete_Call) Run(run func(ctx context.Context, bookmarkID string, id string)) *AttachmentRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AttachmentRepository_Delete_Call) Return(_a0 error) *AttachmentRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AttachmentRepository_Delete_Call) RunAndReturn(run func(context.Context, string, string) error) *AttachmentRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByBookmark provides a mock function with given fields: ctx, bookmarkID
+func (_m *AttachmentRepository) DeleteByBookmark(ctx context.Context, bookmarkID string) error {
+	ret := _m.Called(ctx, bookmarkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByBookmark")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, bookmarkID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AttachmentRepository_DeleteByBookmark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByBookmark'
+type AttachmentRepository_DeleteByBookmark_Call struct {
+	*mock.Call
+}
+
+// DeleteByBookmark is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+func (_e *AttachmentRepository_Expecter) DeleteByBookmark(ctx interface{}, bookmarkID interface{}) *AttachmentRepository_DeleteByBookmark_Call {
+	return &AttachmentRepository_DeleteByBookmark_Call{Call: _e.mock.On("DeleteByBookmark", ctx, bookmarkID)}
+}
+
+func (_c *AttachmentRepository_DeleteByBookmark_Call) Run(run func(ctx context.Context, bookmarkID string)) *AttachmentRepository_DeleteByBookmark_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AttachmentRepository_DeleteByBookmark_Call) Return(_a0 error) *AttachmentRepository_DeleteByBookmark_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AttachmentRepository_DeleteByBookmark_Call) RunAndReturn(run func(context.Context, string) error) *AttachmentRepository_DeleteByBookmark_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, bookmarkID, id
+func (_m *AttachmentRepository) GetByID(ctx context.Context, bookmarkID string, id string) (*domain.Attachment, error) {
+	ret := _m.Called(ctx, bookmarkID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Attachment, error)); ok {
+		return rf(ctx, bookmarkID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.Attachment); ok {
+		r0 = rf(ctx, bookmarkID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bookmarkID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttachmentRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type AttachmentRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+//   - id string
+func (_e *AttachmentRepository_Expecter) GetByID(ctx interface{}, bookmarkID interface{}, id interface{}) *AttachmentRepository_GetByID_Call {
+	return &AttachmentRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, bookmarkID, id)}
+}
+
+func (_c *AttachmentRepository_GetByID_Call) Run(run func(ctx context.Context, bookmarkID string, id string)) *AttachmentRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AttachmentRepository_GetByID_Call) Return(_a0 *domain.Attachment, _a1 error) *AttachmentRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttachmentRepository_GetByID_Call) RunAndReturn(run func(context.Context, string, string) (*domain.Attachment, error)) *AttachmentRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByBookmark provides a mock function with given fields: ctx, bookmarkID
+func (_m *AttachmentRepository) ListByBookmark(ctx context.Context, bookmarkID string) ([]*domain.Attachment, error) {
+	ret := _m.Called(ctx, bookmarkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByBookmark")
+	}
+
+	var r0 []*domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.Attachment, error)); ok {
+		return rf(ctx, bookmarkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.Attachment); ok {
+		r0 = rf(ctx, bookmarkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, bookmarkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttachmentRepository_ListByBookmark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByBookmark'
+type AttachmentRepository_ListByBookmark_Call struct {
+	*mock.Call
+}
+
+// ListByBookmark is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+func (_e *AttachmentRepository_Expecter) ListByBookmark(ctx interface{}, bookmarkID interface{}) *AttachmentRepository_ListByBookmark_Call {
+	return &AttachmentRepository_ListByBookmark_Call{Call: _e.mock.On("ListByBookmark", ctx, bookmarkID)}
+}
+
+func (_c *AttachmentRepository_ListByBookmark_Call) Run(run func(ctx context.Context, bookmarkID string)) *AttachmentRepository_ListByBookmark_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AttachmentRepository_ListByBookmark_Call) Return(_a0 []*domain.Attachment, _a1 error) *AttachmentRepository_ListByBookmark_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttachmentRepository_ListByBookmark_Call) RunAndReturn(run func(context.Context, string) ([]*domain.Attachment, error)) *AttachmentRepository_ListByBookmark_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReferencedBlobs provides a mock function with given fields: ctx, fn
+func (_m *AttachmentRepository) ReferencedBlobs(ctx context.Context, fn func(string) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReferencedBlobs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(string) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AttachmentRepository_ReferencedBlobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReferencedBlobs'
+type AttachmentRepository_ReferencedBlobs_Call struct {
+	*mock.Call
+}
+
+// ReferencedBlobs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(string) error
+func (_e *AttachmentRepository_Expecter) ReferencedBlobs(ctx interface{}, fn interface{}) *AttachmentRepository_ReferencedBlobs_Call {
+	return &AttachmentRepository_ReferencedBlobs_Call{Call: _e.mock.On("ReferencedBlobs", ctx, fn)}
+}
+
+func (_c *AttachmentRepository_ReferencedBlobs_Call) Run(run func(ctx context.Context, fn func(string) error)) *AttachmentRepository_ReferencedBlobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(string) error))
+	})
+	return _c
+}
+
+func (_c *AttachmentRepository_ReferencedBlobs_Call) Return(_a0 error) *AttachmentRepository_ReferencedBlobs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AttachmentRepository_ReferencedBlobs_Call) RunAndReturn(run func(context.Context, func(string) error) error) *AttachmentRepository_ReferencedBlobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SizeByOwner provides a mock function with given fields: ctx, owner
+func (_m *AttachmentRepository) SizeByOwner(ctx context.Context, owner string) (int64, error) {
+	ret := _m.Called(ctx, owner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SizeByOwner")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, owner)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, owner)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, owner)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttachmentRepository_SizeByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SizeByOwner'
+type AttachmentRepository_SizeByOwner_Call struct {
+	*mock.Call
+}
+
+// SizeByOwner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+func (_e *AttachmentRepository_Expecter) SizeByOwner(ctx interface{}, owner interface{}) *AttachmentRepository_SizeByOwner_Call {
+	return &AttachmentRepository_SizeByOwner_Call{Call: _e.mock.On("SizeByOwner", ctx, owner)}
+}
+
+func (_c *AttachmentRepository_SizeByOwner_Call) Run(run func(ctx context.Context, owner string)) *AttachmentRepository_SizeByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AttachmentRepository_SizeByOwner_Call) Return(_a0 int64, _a1 error) *AttachmentRepository_SizeByOwner_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttachmentRepository_SizeByOwner_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *AttachmentRepository_SizeByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TotalSize provides a mock function with given fields: ctx
+func (_m *AttachmentRepository) TotalSize(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TotalSize")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttachmentRepository_TotalSize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalSize'
+type AttachmentRepository_TotalSize_Call struct {
+	*mock.Call
+}
+
+// TotalSize is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *AttachmentRepository_Expecter) TotalSize(ctx interface{}) *AttachmentRepository_TotalSize_Call {
+	return &AttachmentRepository_TotalSize_Call{Call: _e.mock.On("TotalSize", ctx)}
+}
+
+func (_c *AttachmentRepository_TotalSize_Call) Run(run func(ctx context.Context)) *AttachmentRepository_TotalSize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *AttachmentRepository_TotalSize_Call) Return(_a0 int64, _a1 error) *AttachmentRepository_TotalSize_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttachmentRepository_TotalSize_Call) RunAndReturn(run func(context.Context) (int64, error)) *AttachmentRepository_TotalSize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAttachmentRepository creates a new instance of AttachmentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttachmentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttachmentRepository {
+	mock := &AttachmentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,392 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AttachmentService is an autogenerated mock type for the AttachmentService type
+type AttachmentService struct {
+	mock.Mock
+}
+
+type AttachmentService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AttachmentService) EXPECT() *AttachmentService_Expecter {
+	return &AttachmentService_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, bookmarkID, id
+func (_m *AttachmentService) Delete(ctx context.Context, bookmarkID string, id string) error {
+	ret := _m.Called(ctx, bookmarkID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, bookmarkID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AttachmentService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type AttachmentService_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+//   - id string
+func (_e *AttachmentService_Expecter) Delete(ctx interface{}, bookmarkID interface{}, id interface{}) *AttachmentService_Delete_Call {
+	return &AttachmentService_Delete_Call{Call: _e.mock.On("Delete", ctx, bookmarkID, id)}
+}
+
+func (_c *AttachmentService_Delete_Call) Run(run func(ctx context.Context, bookmarkID string, id string)) *AttachmentService_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AttachmentService_Delete_Call) Return(_a0 error) *AttachmentService_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AttachmentService_Delete_Call) RunAndReturn(run func(context.Context, string, string) error) *AttachmentService_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAll provides a mock function with given fields: ctx, bookmarkID
+func (_m *AttachmentService) DeleteAll(ctx context.Context, bookmarkID string) error {
+	ret := _m.Called(ctx, bookmarkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, bookmarkID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AttachmentService_DeleteAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAll'
+type AttachmentService_DeleteAll_Call struct {
+	*mock.Call
+}
+
+// DeleteAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+func (_e *AttachmentService_Expecter) DeleteAll(ctx interface{}, bookmarkID interface{}) *AttachmentService_DeleteAll_Call {
+	return &AttachmentService_DeleteAll_Call{Call: _e.mock.On("DeleteAll", ctx, bookmarkID)}
+}
+
+func (_c *AttachmentService_DeleteAll_Call) Run(run func(ctx context.Context, bookmarkID string)) *AttachmentService_DeleteAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AttachmentService_DeleteAll_Call) Return(_a0 error) *AttachmentService_DeleteAll_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AttachmentService_DeleteAll_Call) RunAndReturn(run func(context.Context, string) error) *AttachmentService_DeleteAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, bookmarkID
+func (_m *AttachmentService) List(ctx context.Context, bookmarkID string) ([]*domain.Attachment, error) {
+	ret := _m.Called(ctx, bookmarkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.Attachment, error)); ok {
+		return rf(ctx, bookmarkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.Attachment); ok {
+		r0 = rf(ctx, bookmarkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, bookmarkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttachmentService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type AttachmentService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+func (_e *AttachmentService_Expecter) List(ctx interface{}, bookmarkID interface{}) *AttachmentService_List_Call {
+	return &AttachmentService_List_Call{Call: _e.mock.On("List", ctx, bookmarkID)}
+}
+
+func (_c *AttachmentService_List_Call) Run(run func(ctx context.Context, bookmarkID string)) *AttachmentService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AttachmentService_List_Call) Return(_a0 []*domain.Attachment, _a1 error) *AttachmentService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttachmentService_List_Call) RunAndReturn(run func(context.Context, string) ([]*domain.Attachment, error)) *AttachmentService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Open provides a mock function with given fields: ctx, bookmarkID, id
+func (_m *AttachmentService) Open(ctx context.Context, bookmarkID string, id string) (*domain.Attachment, io.ReadSeekCloser, error) {
+	ret := _m.Called(ctx, bookmarkID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 *domain.Attachment
+	var r1 io.ReadSeekCloser
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Attachment, io.ReadSeekCloser, error)); ok {
+		return rf(ctx, bookmarkID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.Attachment); ok {
+		r0 = rf(ctx, bookmarkID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) io.ReadSeekCloser); ok {
+		r1 = rf(ctx, bookmarkID, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadSeekCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, bookmarkID, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AttachmentService_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type AttachmentService_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+//   - id string
+func (_e *AttachmentService_Expecter) Open(ctx interface{}, bookmarkID interface{}, id interface{}) *AttachmentService_Open_Call {
+	return &AttachmentService_Open_Call{Call: _e.mock.On("Open", ctx, bookmarkID, id)}
+}
+
+func (_c *AttachmentService_Open_Call) Run(run func(ctx context.Context, bookmarkID string, id string)) *AttachmentService_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AttachmentService_Open_Call) Return(_a0 *domain.Attachment, _a1 io.ReadSeekCloser, _a2 error) *AttachmentService_Open_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *AttachmentService_Open_Call) RunAndReturn(run func(context.Context, string, string) (*domain.Attachment, io.ReadSeekCloser, error)) *AttachmentService_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenThumbnail provides a mock function with given fields: ctx, bookmarkID, id
+func (_m *AttachmentService) OpenThumbnail(ctx context.Context, bookmarkID string, id string) (*domain.Attachment, io.ReadCloser, error) {
+	ret := _m.Called(ctx, bookmarkID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenThumbnail")
+	}
+
+	var r0 *domain.Attachment
+	var r1 io.ReadCloser
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Attachment, io.ReadCloser, error)); ok {
+		return rf(ctx, bookmarkID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.Attachment); ok {
+		r0 = rf(ctx, bookmarkID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) io.ReadCloser); ok {
+		r1 = rf(ctx, bookmarkID, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, bookmarkID, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AttachmentService_OpenThumbnail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenThumbnail'
+type AttachmentService_OpenThumbnail_Call struct {
+	*mock.Call
+}
+
+// OpenThumbnail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+//   - id string
+func (_e *AttachmentService_Expecter) OpenThumbnail(ctx interface{}, bookmarkID interface{}, id interface{}) *AttachmentService_OpenThumbnail_Call {
+	return &AttachmentService_OpenThumbnail_Call{Call: _e.mock.On("OpenThumbnail", ctx, bookmarkID, id)}
+}
+
+func (_c *AttachmentService_OpenThumbnail_Call) Run(run func(ctx context.Context, bookmarkID string, id string)) *AttachmentService_OpenThumbnail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *AttachmentService_OpenThumbnail_Call) Return(_a0 *domain.Attachment, _a1 io.ReadCloser, _a2 error) *AttachmentService_OpenThumbnail_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *AttachmentService_OpenThumbnail_Call) RunAndReturn(run func(context.Context, string, string) (*domain.Attachment, io.ReadCloser, error)) *AttachmentService_OpenThumbnail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upload provides a mock function with given fields: ctx, bookmarkID, filename, r
+func (_m *AttachmentService) Upload(ctx context.Context, bookmarkID string, filename string, r io.Reader) (*domain.Attachment, error) {
+	ret := _m.Called(ctx, bookmarkID, filename, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upload")
+	}
+
+	var r0 *domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader) (*domain.Attachment, error)); ok {
+		return rf(ctx, bookmarkID, filename, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader) *domain.Attachment); ok {
+		r0 = rf(ctx, bookmarkID, filename, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, io.Reader) error); ok {
+		r1 = rf(ctx, bookmarkID, filename, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttachmentService_Upload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upload'
+type AttachmentService_Upload_Call struct {
+	*mock.Call
+}
+
+// Upload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+//   - filename string
+//   - r io.Reader
+func (_e *AttachmentService_Expecter) Upload(ctx interface{}, bookmarkID interface{}, filename interface{}, r interface{}) *AttachmentService_Upload_Call {
+	return &AttachmentService_Upload_Call{Call: _e.mock.On("Upload", ctx, bookmarkID, filename, r)}
+}
+
+func (_c *AttachmentService_Upload_Call) Run(run func(ctx context.Context, bookmarkID string, filename string, r io.Reader)) *AttachmentService_Upload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(io.Reader))
+	})
+	return _c
+}
+
+func (_c *AttachmentService_Upload_Call) Return(_a0 *domain.Attachment, _a1 error) *AttachmentService_Upload_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttachmentService_Upload_Call) RunAndReturn(run func(context.Context, string, string, io.Reader) (*domain.Attachment, error)) *AttachmentService_Upload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAttachmentService creates a new instance of AttachmentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttachmentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttachmentService {
+	mock := &AttachmentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
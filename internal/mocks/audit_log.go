@@ -0,0 +1,203 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditLog is an autogenerated mock type for the AuditLog type
+type AuditLog struct {
+	mock.Mock
+}
+
+type AuditLog_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditLog) EXPECT() *AuditLog_Expecter {
+	return &AuditLog_Expecter{mock: &_m.Mock}
+}
+
+// Append provides a mock function with given fields: ctx, e
+func (_m *AuditLog) Append(ctx context.Context, e *domain.AuditEntry) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Append")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AuditEntry) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditLog_Append_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Append'
+type AuditLog_Append_Call struct {
+	*mock.Call
+}
+
+// Append is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e *domain.AuditEntry
+func (_e *AuditLog_Expecter) Append(ctx interface{}, e interface{}) *AuditLog_Append_Call {
+	return &AuditLog_Append_Call{Call: _e.mock.On("Append", ctx, e)}
+}
+
+func (_c *AuditLog_Append_Call) Run(run func(ctx context.Context, e *domain.AuditEntry)) *AuditLog_Append_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *AuditLog_Append_Call) Return(_a0 error) *AuditLog_Append_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuditLog_Append_Call) RunAndReturn(run func(context.Context, *domain.AuditEntry) error) *AuditLog_Append_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Query provides a mock function with given fields: ctx, filter
+func (_m *AuditLog) Query(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Query")
+	}
+
+	var r0 []*domain.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuditFilter) ([]*domain.AuditEntry, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuditFilter) []*domain.AuditEntry); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.AuditFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditLog_Query_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Query'
+type AuditLog_Query_Call struct {
+	*mock.Call
+}
+
+// Query is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.AuditFilter
+func (_e *AuditLog_Expecter) Query(ctx interface{}, filter interface{}) *AuditLog_Query_Call {
+	return &AuditLog_Query_Call{Call: _e.mock.On("Query", ctx, filter)}
+}
+
+func (_c *AuditLog_Query_Call) Run(run func(ctx context.Context, filter domain.AuditFilter)) *AuditLog_Query_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.AuditFilter))
+	})
+	return _c
+}
+
+func (_c *AuditLog_Query_Call) Return(_a0 []*domain.AuditEntry, _a1 error) *AuditLog_Query_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditLog_Query_Call) RunAndReturn(run func(context.Context, domain.AuditFilter) ([]*domain.AuditEntry, error)) *AuditLog_Query_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Range provides a mock function with given fields: ctx, after, limit
+func (_m *AuditLog) Range(ctx context.Context, after int64, limit int) ([]*domain.AuditEntry, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Range")
+	}
+
+	var r0 []*domain.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]*domain.AuditEntry, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []*domain.AuditEntry); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditLog_Range_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Range'
+type AuditLog_Range_Call struct {
+	*mock.Call
+}
+
+// Range is a helper method to define mock.On call
+//   - ctx context.Context
+//   - after int64
+//   - limit int
+func (_e *AuditLog_Expecter) Range(ctx interface{}, after interface{}, limit interface{}) *AuditLog_Range_Call {
+	return &AuditLog_Range_Call{Call: _e.mock.On("Range", ctx, after, limit)}
+}
+
+func (_c *AuditLog_Range_Call) Run(run func(ctx context.Context, after int64, limit int)) *AuditLog_Range_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *AuditLog_Range_Call) Return(_a0 []*domain.AuditEntry, _a1 error) *AuditLog_Range_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditLog_Range_Call) RunAndReturn(run func(context.Context, int64, int) ([]*domain.AuditEntry, error)) *AuditLog_Range_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuditLog creates a new instance of AuditLog. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditLog(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditLog {
+	mock := &AuditLog{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
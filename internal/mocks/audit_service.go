@@ -0,0 +1,201 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditService is an autogenerated mock type for the AuditService type
+type AuditService struct {
+	mock.Mock
+}
+
+type AuditService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditService) EXPECT() *AuditService_Expecter {
+	return &AuditService_Expecter{mock: &_m.Mock}
+}
+
+// Query provides a mock function with given fields: ctx, filter
+func (_m *AuditService) Query(ctx context.Context, filter domain.AuditFilter) (*domain.AuditPage, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Query")
+	}
+
+	var r0 *domain.AuditPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuditFilter) (*domain.AuditPage, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuditFilter) *domain.AuditPage); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AuditPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.AuditFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditService_Query_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Query'
+type AuditService_Query_Call struct {
+	*mock.Call
+}
+
+// Query is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.AuditFilter
+func (_e *AuditService_Expecter) Query(ctx interface{}, filter interface{}) *AuditService_Query_Call {
+	return &AuditService_Query_Call{Call: _e.mock.On("Query", ctx, filter)}
+}
+
+func (_c *AuditService_Query_Call) Run(run func(ctx context.Context, filter domain.AuditFilter)) *AuditService_Query_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.AuditFilter))
+	})
+	return _c
+}
+
+func (_c *AuditService_Query_Call) Return(_a0 *domain.AuditPage, _a1 error) *AuditService_Query_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditService_Query_Call) RunAndReturn(run func(context.Context, domain.AuditFilter) (*domain.AuditPage, error)) *AuditService_Query_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function with given fields: ctx, e
+func (_m *AuditService) Record(ctx context.Context, e *domain.AuditEntry) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AuditEntry) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditService_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type AuditService_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e *domain.AuditEntry
+func (_e *AuditService_Expecter) Record(ctx interface{}, e interface{}) *AuditService_Record_Call {
+	return &AuditService_Record_Call{Call: _e.mock.On("Record", ctx, e)}
+}
+
+func (_c *AuditService_Record_Call) Run(run func(ctx context.Context, e *domain.AuditEntry)) *AuditService_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.AuditEntry))
+	})
+	return _c
+}
+
+func (_c *AuditService_Record_Call) Return(_a0 error) *AuditService_Record_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuditService_Record_Call) RunAndReturn(run func(context.Context, *domain.AuditEntry) error) *AuditService_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function with given fields: ctx
+func (_m *AuditService) Verify(ctx context.Context) (*domain.AuditVerification, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 *domain.AuditVerification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.AuditVerification, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.AuditVerification); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AuditVerification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuditService_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type AuditService_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *AuditService_Expecter) Verify(ctx interface{}) *AuditService_Verify_Call {
+	return &AuditService_Verify_Call{Call: _e.mock.On("Verify", ctx)}
+}
+
+func (_c *AuditService_Verify_Call) Run(run func(ctx context.Context)) *AuditService_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *AuditService_Verify_Call) Return(_a0 *domain.AuditVerification, _a1 error) *AuditService_Verify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuditService_Verify_Call) RunAndReturn(run func(context.Context) (*domain.AuditVerification, error)) *AuditService_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuditService creates a new instance of AuditService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditService {
+	mock := &AuditService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
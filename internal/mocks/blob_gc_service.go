@@ -0,0 +1,141 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BlobGCService is an autogenerated mock type for the BlobGCService type
+type BlobGCService struct {
+	mock.Mock
+}
+
+type BlobGCService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BlobGCService) EXPECT() *BlobGCService_Expecter {
+	return &BlobGCService_Expecter{mock: &_m.Mock}
+}
+
+// Collect provides a mock function with given fields: ctx
+func (_m *BlobGCService) Collect(ctx context.Context) (*domain.BlobGCRun, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Collect")
+	}
+
+	var r0 *domain.BlobGCRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.BlobGCRun, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.BlobGCRun); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.BlobGCRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlobGCService_Collect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Collect'
+type BlobGCService_Collect_Call struct {
+	*mock.Call
+}
+
+// Collect is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *BlobGCService_Expecter) Collect(ctx interface{}) *BlobGCService_Collect_Call {
+	return &BlobGCService_Collect_Call{Call: _e.mock.On("Collect", ctx)}
+}
+
+func (_c *BlobGCService_Collect_Call) Run(run func(ctx context.Context)) *BlobGCService_Collect_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *BlobGCService_Collect_Call) Return(_a0 *domain.BlobGCRun, _a1 error) *BlobGCService_Collect_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlobGCService_Collect_Call) RunAndReturn(run func(context.Context) (*domain.BlobGCRun, error)) *BlobGCService_Collect_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *BlobGCService) Run(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Run")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlobGCService_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type BlobGCService_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *BlobGCService_Expecter) Run(ctx interface{}) *BlobGCService_Run_Call {
+	return &BlobGCService_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *BlobGCService_Run_Call) Run(run func(ctx context.Context)) *BlobGCService_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *BlobGCService_Run_Call) Return(_a0 error) *BlobGCService_Run_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlobGCService_Run_Call) RunAndReturn(run func(context.Context) error) *BlobGCService_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBlobGCService creates a new instance of BlobGCService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBlobGCService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BlobGCService {
+	mock := &BlobGCService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
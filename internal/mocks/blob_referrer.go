@@ -0,0 +1,83 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BlobReferrer is an autogenerated mock type for the BlobReferrer type
+type BlobReferrer struct {
+	mock.Mock
+}
+
+type BlobReferrer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BlobReferrer) EXPECT() *BlobReferrer_Expecter {
+	return &BlobReferrer_Expecter{mock: &_m.Mock}
+}
+
+// ReferencedBlobs provides a mock function with given fields: ctx, fn
+func (_m *BlobReferrer) ReferencedBlobs(ctx context.Context, fn func(string) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReferencedBlobs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(string) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlobReferrer_ReferencedBlobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReferencedBlobs'
+type BlobReferrer_ReferencedBlobs_Call struct {
+	*mock.Call
+}
+
+// ReferencedBlobs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(string) error
+func (_e *BlobReferrer_Expecter) ReferencedBlobs(ctx interface{}, fn interface{}) *BlobReferrer_ReferencedBlobs_Call {
+	return &BlobReferrer_ReferencedBlobs_Call{Call: _e.mock.On("ReferencedBlobs", ctx, fn)}
+}
+
+func (_c *BlobReferrer_ReferencedBlobs_Call) Run(run func(ctx context.Context, fn func(string) error)) *BlobReferrer_ReferencedBlobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(string) error))
+	})
+	return _c
+}
+
+func (_c *BlobReferrer_ReferencedBlobs_Call) Return(_a0 error) *BlobReferrer_ReferencedBlobs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlobReferrer_ReferencedBlobs_Call) RunAndReturn(run func(context.Context, func(string) error) error) *BlobReferrer_ReferencedBlobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBlobReferrer creates a new instance of BlobReferrer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBlobReferrer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BlobReferrer {
+	mock := &BlobReferrer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
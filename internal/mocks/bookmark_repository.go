@@ -22,6 +22,102 @@ func (_m *BookmarkRepository) EXPECT() *BookmarkRepository_Expecter {
 	return &BookmarkRepository_Expecter{mock: &_m.Mock}
 }
 
+// CompareAndDelete provides a mock function with given fields: ctx, id, version
+func (_m *BookmarkRepository) CompareAndDelete(ctx context.Context, id string, version int64) error {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompareAndDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkRepository_CompareAndDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareAndDelete'
+type BookmarkRepository_CompareAndDelete_Call struct {
+	*mock.Call
+}
+
+// CompareAndDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - version int64
+func (_e *BookmarkRepository_Expecter) CompareAndDelete(ctx interface{}, id interface{}, version interface{}) *BookmarkRepository_CompareAndDelete_Call {
+	return &BookmarkRepository_CompareAndDelete_Call{Call: _e.mock.On("CompareAndDelete", ctx, id, version)}
+}
+
+func (_c *BookmarkRepository_CompareAndDelete_Call) Run(run func(ctx context.Context, id string, version int64)) *BookmarkRepository_CompareAndDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *BookmarkRepository_CompareAndDelete_Call) Return(_a0 error) *BookmarkRepository_CompareAndDelete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkRepository_CompareAndDelete_Call) RunAndReturn(run func(context.Context, string, int64) error) *BookmarkRepository_CompareAndDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompareAndUpdate provides a mock function with given fields: ctx, b, version
+func (_m *BookmarkRepository) CompareAndUpdate(ctx context.Context, b *domain.Bookmark, version int64) error {
+	ret := _m.Called(ctx, b, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompareAndUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Bookmark, int64) error); ok {
+		r0 = rf(ctx, b, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkRepository_CompareAndUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareAndUpdate'
+type BookmarkRepository_CompareAndUpdate_Call struct {
+	*mock.Call
+}
+
+// CompareAndUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - b *domain.Bookmark
+//   - version int64
+func (_e *BookmarkRepository_Expecter) CompareAndUpdate(ctx interface{}, b interface{}, version interface{}) *BookmarkRepository_CompareAndUpdate_Call {
+	return &BookmarkRepository_CompareAndUpdate_Call{Call: _e.mock.On("CompareAndUpdate", ctx, b, version)}
+}
+
+func (_c *BookmarkRepository_CompareAndUpdate_Call) Run(run func(ctx context.Context, b *domain.Bookmark, version int64)) *BookmarkRepository_CompareAndUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Bookmark), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *BookmarkRepository_CompareAndUpdate_Call) Return(_a0 error) *BookmarkRepository_CompareAndUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkRepository_CompareAndUpdate_Call) RunAndReturn(run func(context.Context, *domain.Bookmark, int64) error) *BookmarkRepository_CompareAndUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, b
 func (_m *BookmarkRepository) Create(ctx context.Context, b *domain.Bookmark) error {
 	ret := _m.Called(ctx, b)
@@ -69,6 +165,53 @@ func (_c *BookmarkRepository_Create_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// CreateBatch provides a mock function with given fields: ctx, bs
+func (_m *BookmarkRepository) CreateBatch(ctx context.Context, bs []*domain.Bookmark) error {
+	ret := _m.Called(ctx, bs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Bookmark) error); ok {
+		r0 = rf(ctx, bs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkRepository_CreateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatch'
+type BookmarkRepository_CreateBatch_Call struct {
+	*mock.Call
+}
+
+// CreateBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bs []*domain.Bookmark
+func (_e *BookmarkRepository_Expecter) CreateBatch(ctx interface{}, bs interface{}) *BookmarkRepository_CreateBatch_Call {
+	return &BookmarkRepository_CreateBatch_Call{Call: _e.mock.On("CreateBatch", ctx, bs)}
+}
+
+func (_c *BookmarkRepository_CreateBatch_Call) Run(run func(ctx context.Context, bs []*domain.Bookmark)) *BookmarkRepository_CreateBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*domain.Bookmark))
+	})
+	return _c
+}
+
+func (_c *BookmarkRepository_CreateBatch_Call) Return(_a0 error) *BookmarkRepository_CreateBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkRepository_CreateBatch_Call) RunAndReturn(run func(context.Context, []*domain.Bookmark) error) *BookmarkRepository_CreateBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (_m *BookmarkRepository) Delete(ctx context.Context, id string) error {
 	ret := _m.Called(ctx, id)
@@ -233,6 +376,253 @@ func (_c *BookmarkRepository_GetByID_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// Purge provides a mock function with given fields: ctx, id
+func (_m *BookmarkRepository) Purge(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkRepository_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type BookmarkRepository_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *BookmarkRepository_Expecter) Purge(ctx interface{}, id interface{}) *BookmarkRepository_Purge_Call {
+	return &BookmarkRepository_Purge_Call{Call: _e.mock.On("Purge", ctx, id)}
+}
+
+func (_c *BookmarkRepository_Purge_Call) Run(run func(ctx context.Context, id string)) *BookmarkRepository_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BookmarkRepository_Purge_Call) Return(_a0 error) *BookmarkRepository_Purge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkRepository_Purge_Call) RunAndReturn(run func(context.Context, string) error) *BookmarkRepository_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *BookmarkRepository) Restore(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type BookmarkRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *BookmarkRepository_Expecter) Restore(ctx interface{}, id interface{}) *BookmarkRepository_Restore_Call {
+	return &BookmarkRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, id)}
+}
+
+func (_c *BookmarkRepository_Restore_Call) Run(run func(ctx context.Context, id string)) *BookmarkRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BookmarkRepository_Restore_Call) Return(_a0 error) *BookmarkRepository_Restore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkRepository_Restore_Call) RunAndReturn(run func(context.Context, string) error) *BookmarkRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Trash provides a mock function with given fields: ctx
+func (_m *BookmarkRepository) Trash(ctx context.Context) ([]*domain.Bookmark, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Trash")
+	}
+
+	var r0 []*domain.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.Bookmark, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.Bookmark); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BookmarkRepository_Trash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Trash'
+type BookmarkRepository_Trash_Call struct {
+	*mock.Call
+}
+
+// Trash is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *BookmarkRepository_Expecter) Trash(ctx interface{}) *BookmarkRepository_Trash_Call {
+	return &BookmarkRepository_Trash_Call{Call: _e.mock.On("Trash", ctx)}
+}
+
+func (_c *BookmarkRepository_Trash_Call) Run(run func(ctx context.Context)) *BookmarkRepository_Trash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *BookmarkRepository_Trash_Call) Return(_a0 []*domain.Bookmark, _a1 error) *BookmarkRepository_Trash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BookmarkRepository_Trash_Call) RunAndReturn(run func(context.Context) ([]*domain.Bookmark, error)) *BookmarkRepository_Trash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, b
+func (_m *BookmarkRepository) Update(ctx context.Context, b *domain.Bookmark) error {
+	ret := _m.Called(ctx, b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Bookmark) error); ok {
+		r0 = rf(ctx, b)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type BookmarkRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - b *domain.Bookmark
+func (_e *BookmarkRepository_Expecter) Update(ctx interface{}, b interface{}) *BookmarkRepository_Update_Call {
+	return &BookmarkRepository_Update_Call{Call: _e.mock.On("Update", ctx, b)}
+}
+
+func (_c *BookmarkRepository_Update_Call) Run(run func(ctx context.Context, b *domain.Bookmark)) *BookmarkRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Bookmark))
+	})
+	return _c
+}
+
+func (_c *BookmarkRepository_Update_Call) Return(_a0 error) *BookmarkRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkRepository_Update_Call) RunAndReturn(run func(context.Context, *domain.Bookmark) error) *BookmarkRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Walk provides a mock function with given fields: ctx, filter, fn
+func (_m *BookmarkRepository) Walk(ctx context.Context, filter domain.BookmarkFilter, fn func(*domain.Bookmark) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Walk")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BookmarkFilter, func(*domain.Bookmark) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkRepository_Walk_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Walk'
+type BookmarkRepository_Walk_Call struct {
+	*mock.Call
+}
+
+// Walk is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.BookmarkFilter
+//   - fn func(*domain.Bookmark) error
+func (_e *BookmarkRepository_Expecter) Walk(ctx interface{}, filter interface{}, fn interface{}) *BookmarkRepository_Walk_Call {
+	return &BookmarkRepository_Walk_Call{Call: _e.mock.On("Walk", ctx, filter, fn)}
+}
+
+func (_c *BookmarkRepository_Walk_Call) Run(run func(ctx context.Context, filter domain.BookmarkFilter, fn func(*domain.Bookmark) error)) *BookmarkRepository_Walk_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.BookmarkFilter), args[2].(func(*domain.Bookmark) error))
+	})
+	return _c
+}
+
+func (_c *BookmarkRepository_Walk_Call) Return(_a0 error) *BookmarkRepository_Walk_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkRepository_Walk_Call) RunAndReturn(run func(context.Context, domain.BookmarkFilter, func(*domain.Bookmark) error) error) *BookmarkRepository_Walk_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewBookmarkRepository creates a new instance of BookmarkRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewBookmarkRepository(t interface {
@@ -175,14 +175,287 @@ func (_c *BookmarkService_GetByID_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
-// List provides a mock function with given fields: ctx
-func (_m *BookmarkService) List(ctx context.Context) ([]*domain.Bookmark, error) {
-	ret := _m.Called(ctx)
+// List provides a mock function with given fields: ctx, filter
+func (_m *BookmarkService) List(ctx context.Context, filter domain.BookmarkFilter) ([]*domain.Bookmark, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
 	}
 
+	var r0 []*domain.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BookmarkFilter) ([]*domain.Bookmark, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BookmarkFilter) []*domain.Bookmark); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BookmarkFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BookmarkService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type BookmarkService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.BookmarkFilter
+func (_e *BookmarkService_Expecter) List(ctx interface{}, filter interface{}) *BookmarkService_List_Call {
+	return &BookmarkService_List_Call{Call: _e.mock.On("List", ctx, filter)}
+}
+
+func (_c *BookmarkService_List_Call) Run(run func(ctx context.Context, filter domain.BookmarkFilter)) *BookmarkService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.BookmarkFilter))
+	})
+	return _c
+}
+
+func (_c *BookmarkService_List_Call) Return(_a0 []*domain.Bookmark, _a1 error) *BookmarkService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BookmarkService_List_Call) RunAndReturn(run func(context.Context, domain.BookmarkFilter) ([]*domain.Bookmark, error)) *BookmarkService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Purge provides a mock function with given fields: ctx, id
+func (_m *BookmarkService) Purge(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkService_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type BookmarkService_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *BookmarkService_Expecter) Purge(ctx interface{}, id interface{}) *BookmarkService_Purge_Call {
+	return &BookmarkService_Purge_Call{Call: _e.mock.On("Purge", ctx, id)}
+}
+
+func (_c *BookmarkService_Purge_Call) Run(run func(ctx context.Context, id string)) *BookmarkService_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BookmarkService_Purge_Call) Return(_a0 error) *BookmarkService_Purge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkService_Purge_Call) RunAndReturn(run func(context.Context, string) error) *BookmarkService_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Recent provides a mock function with given fields: ctx, filter, limit
+func (_m *BookmarkService) Recent(ctx context.Context, filter domain.BookmarkFilter, limit int) ([]*domain.Bookmark, error) {
+	ret := _m.Called(ctx, filter, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Recent")
+	}
+
+	var r0 []*domain.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BookmarkFilter, int) ([]*domain.Bookmark, error)); ok {
+		return rf(ctx, filter, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BookmarkFilter, int) []*domain.Bookmark); ok {
+		r0 = rf(ctx, filter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BookmarkFilter, int) error); ok {
+		r1 = rf(ctx, filter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BookmarkService_Recent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Recent'
+type BookmarkService_Recent_Call struct {
+	*mock.Call
+}
+
+// Recent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.BookmarkFilter
+//   - limit int
+func (_e *BookmarkService_Expecter) Recent(ctx interface{}, filter interface{}, limit interface{}) *BookmarkService_Recent_Call {
+	return &BookmarkService_Recent_Call{Call: _e.mock.On("Recent", ctx, filter, limit)}
+}
+
+func (_c *BookmarkService_Recent_Call) Run(run func(ctx context.Context, filter domain.BookmarkFilter, limit int)) *BookmarkService_Recent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.BookmarkFilter), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *BookmarkService_Recent_Call) Return(_a0 []*domain.Bookmark, _a1 error) *BookmarkService_Recent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BookmarkService_Recent_Call) RunAndReturn(run func(context.Context, domain.BookmarkFilter, int) ([]*domain.Bookmark, error)) *BookmarkService_Recent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *BookmarkService) Restore(ctx context.Context, id string) (*domain.Bookmark, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *domain.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Bookmark, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Bookmark); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BookmarkService_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type BookmarkService_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *BookmarkService_Expecter) Restore(ctx interface{}, id interface{}) *BookmarkService_Restore_Call {
+	return &BookmarkService_Restore_Call{Call: _e.mock.On("Restore", ctx, id)}
+}
+
+func (_c *BookmarkService_Restore_Call) Run(run func(ctx context.Context, id string)) *BookmarkService_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BookmarkService_Restore_Call) Return(_a0 *domain.Bookmark, _a1 error) *BookmarkService_Restore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BookmarkService_Restore_Call) RunAndReturn(run func(context.Context, string) (*domain.Bookmark, error)) *BookmarkService_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stream provides a mock function with given fields: ctx, filter, fn
+func (_m *BookmarkService) Stream(ctx context.Context, filter domain.BookmarkFilter, fn func(*domain.Bookmark) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stream")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BookmarkFilter, func(*domain.Bookmark) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkService_Stream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stream'
+type BookmarkService_Stream_Call struct {
+	*mock.Call
+}
+
+// Stream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.BookmarkFilter
+//   - fn func(*domain.Bookmark) error
+func (_e *BookmarkService_Expecter) Stream(ctx interface{}, filter interface{}, fn interface{}) *BookmarkService_Stream_Call {
+	return &BookmarkService_Stream_Call{Call: _e.mock.On("Stream", ctx, filter, fn)}
+}
+
+func (_c *BookmarkService_Stream_Call) Run(run func(ctx context.Context, filter domain.BookmarkFilter, fn func(*domain.Bookmark) error)) *BookmarkService_Stream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.BookmarkFilter), args[2].(func(*domain.Bookmark) error))
+	})
+	return _c
+}
+
+func (_c *BookmarkService_Stream_Call) Return(_a0 error) *BookmarkService_Stream_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkService_Stream_Call) RunAndReturn(run func(context.Context, domain.BookmarkFilter, func(*domain.Bookmark) error) error) *BookmarkService_Stream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Trash provides a mock function with given fields: ctx
+func (_m *BookmarkService) Trash(ctx context.Context) ([]*domain.Bookmark, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Trash")
+	}
+
 	var r0 []*domain.Bookmark
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.Bookmark, error)); ok {
@@ -205,30 +478,30 @@ func (_m *BookmarkService) List(ctx context.Context) ([]*domain.Bookmark, error)
 	return r0, r1
 }
 
-// BookmarkService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
-type BookmarkService_List_Call struct {
+// BookmarkService_Trash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Trash'
+type BookmarkService_Trash_Call struct {
 	*mock.Call
 }
 
-// List is a helper method to define mock.On call
+// Trash is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *BookmarkService_Expecter) List(ctx interface{}) *BookmarkService_List_Call {
-	return &BookmarkService_List_Call{Call: _e.mock.On("List", ctx)}
+func (_e *BookmarkService_Expecter) Trash(ctx interface{}) *BookmarkService_Trash_Call {
+	return &BookmarkService_Trash_Call{Call: _e.mock.On("Trash", ctx)}
 }
 
-func (_c *BookmarkService_List_Call) Run(run func(ctx context.Context)) *BookmarkService_List_Call {
+func (_c *BookmarkService_Trash_Call) Run(run func(ctx context.Context)) *BookmarkService_Trash_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *BookmarkService_List_Call) Return(_a0 []*domain.Bookmark, _a1 error) *BookmarkService_List_Call {
+func (_c *BookmarkService_Trash_Call) Return(_a0 []*domain.Bookmark, _a1 error) *BookmarkService_Trash_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *BookmarkService_List_Call) RunAndReturn(run func(context.Context) ([]*domain.Bookmark, error)) *BookmarkService_List_Call {
+func (_c *BookmarkService_Trash_Call) RunAndReturn(run func(context.Context) ([]*domain.Bookmark, error)) *BookmarkService_Trash_Call {
 	_c.Call.Return(run)
 	return _c
 }
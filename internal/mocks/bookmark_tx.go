@@ -0,0 +1,684 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BookmarkTx is an autogenerated mock type for the BookmarkTx type
+type BookmarkTx struct {
+	mock.Mock
+}
+
+type BookmarkTx_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BookmarkTx) EXPECT() *BookmarkTx_Expecter {
+	return &BookmarkTx_Expecter{mock: &_m.Mock}
+}
+
+// CompareAndDelete provides a mock function with given fields: ctx, id, version
+func (_m *BookmarkTx) CompareAndDelete(ctx context.Context, id string, version int64) error {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompareAndDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkTx_CompareAndDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareAndDelete'
+type BookmarkTx_CompareAndDelete_Call struct {
+	*mock.Call
+}
+
+// CompareAndDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - version int64
+func (_e *BookmarkTx_Expecter) CompareAndDelete(ctx interface{}, id interface{}, version interface{}) *BookmarkTx_CompareAndDelete_Call {
+	return &BookmarkTx_CompareAndDelete_Call{Call: _e.mock.On("CompareAndDelete", ctx, id, version)}
+}
+
+func (_c *BookmarkTx_CompareAndDelete_Call) Run(run func(ctx context.Context, id string, version int64)) *BookmarkTx_CompareAndDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *BookmarkTx_CompareAndDelete_Call) Return(_a0 error) *BookmarkTx_CompareAndDelete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkTx_CompareAndDelete_Call) RunAndReturn(run func(context.Context, string, int64) error) *BookmarkTx_CompareAndDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompareAndUpdate provides a mock function with given fields: ctx, b, version
+func (_m *BookmarkTx) CompareAndUpdate(ctx context.Context, b *domain.Bookmark, version int64) error {
+	ret := _m.Called(ctx, b, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompareAndUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Bookmark, int64) error); ok {
+		r0 = rf(ctx, b, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkTx_CompareAndUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompareAndUpdate'
+type BookmarkTx_CompareAndUpdate_Call struct {
+	*mock.Call
+}
+
+// CompareAndUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - b *domain.Bookmark
+//   - version int64
+func (_e *BookmarkTx_Expecter) CompareAndUpdate(ctx interface{}, b interface{}, version interface{}) *BookmarkTx_CompareAndUpdate_Call {
+	return &BookmarkTx_CompareAndUpdate_Call{Call: _e.mock.On("CompareAndUpdate", ctx, b, version)}
+}
+
+func (_c *BookmarkTx_CompareAndUpdate_Call) Run(run func(ctx context.Context, b *domain.Bookmark, version int64)) *BookmarkTx_CompareAndUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Bookmark), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *BookmarkTx_CompareAndUpdate_Call) Return(_a0 error) *BookmarkTx_CompareAndUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkTx_CompareAndUpdate_Call) RunAndReturn(run func(context.Context, *domain.Bookmark, int64) error) *BookmarkTx_CompareAndUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, b
+func (_m *BookmarkTx) Create(ctx context.Context, b *domain.Bookmark) error {
+	ret := _m.Called(ctx, b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Bookmark) error); ok {
+		r0 = rf(ctx, b)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkTx_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type BookmarkTx_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - b *domain.Bookmark
+func (_e *BookmarkTx_Expecter) Create(ctx interface{}, b interface{}) *BookmarkTx_Create_Call {
+	return &BookmarkTx_Create_Call{Call: _e.mock.On("Create", ctx, b)}
+}
+
+func (_c *BookmarkTx_Create_Call) Run(run func(ctx context.Context, b *domain.Bookmark)) *BookmarkTx_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Bookmark))
+	})
+	return _c
+}
+
+func (_c *BookmarkTx_Create_Call) Return(_a0 error) *BookmarkTx_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkTx_Create_Call) RunAndReturn(run func(context.Context, *domain.Bookmark) error) *BookmarkTx_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBatch provides a mock function with given fields: ctx, bs
+func (_m *BookmarkTx) CreateBatch(ctx context.Context, bs []*domain.Bookmark) error {
+	ret := _m.Called(ctx, bs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Bookmark) error); ok {
+		r0 = rf(ctx, bs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkTx_CreateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatch'
+type BookmarkTx_CreateBatch_Call struct {
+	*mock.Call
+}
+
+// CreateBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bs []*domain.Bookmark
+func (_e *BookmarkTx_Expecter) CreateBatch(ctx interface{}, bs interface{}) *BookmarkTx_CreateBatch_Call {
+	return &BookmarkTx_CreateBatch_Call{Call: _e.mock.On("CreateBatch", ctx, bs)}
+}
+
+func (_c *BookmarkTx_CreateBatch_Call) Run(run func(ctx context.Context, bs []*domain.Bookmark)) *BookmarkTx_CreateBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*domain.Bookmark))
+	})
+	return _c
+}
+
+func (_c *BookmarkTx_CreateBatch_Call) Return(_a0 error) *BookmarkTx_CreateBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkTx_CreateBatch_Call) RunAndReturn(run func(context.Context, []*domain.Bookmark) error) *BookmarkTx_CreateBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *BookmarkTx) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkTx_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type BookmarkTx_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *BookmarkTx_Expecter) Delete(ctx interface{}, id interface{}) *BookmarkTx_Delete_Call {
+	return &BookmarkTx_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *BookmarkTx_Delete_Call) Run(run func(ctx context.Context, id string)) *BookmarkTx_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BookmarkTx_Delete_Call) Return(_a0 error) *BookmarkTx_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkTx_Delete_Call) RunAndReturn(run func(context.Context, string) error) *BookmarkTx_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: ctx
+func (_m *BookmarkTx) GetAll(ctx context.Context) ([]*domain.Bookmark, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*domain.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.Bookmark, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.Bookmark); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BookmarkTx_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type BookmarkTx_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *BookmarkTx_Expecter) GetAll(ctx interface{}) *BookmarkTx_GetAll_Call {
+	return &BookmarkTx_GetAll_Call{Call: _e.mock.On("GetAll", ctx)}
+}
+
+func (_c *BookmarkTx_GetAll_Call) Run(run func(ctx context.Context)) *BookmarkTx_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *BookmarkTx_GetAll_Call) Return(_a0 []*domain.Bookmark, _a1 error) *BookmarkTx_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BookmarkTx_GetAll_Call) RunAndReturn(run func(context.Context) ([]*domain.Bookmark, error)) *BookmarkTx_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *BookmarkTx) GetByID(ctx context.Context, id string) (*domain.Bookmark, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Bookmark, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Bookmark); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BookmarkTx_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type BookmarkTx_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *BookmarkTx_Expecter) GetByID(ctx interface{}, id interface{}) *BookmarkTx_GetByID_Call {
+	return &BookmarkTx_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *BookmarkTx_GetByID_Call) Run(run func(ctx context.Context, id string)) *BookmarkTx_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BookmarkTx_GetByID_Call) Return(_a0 *domain.Bookmark, _a1 error) *BookmarkTx_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BookmarkTx_GetByID_Call) RunAndReturn(run func(context.Context, string) (*domain.Bookmark, error)) *BookmarkTx_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Purge provides a mock function with given fields: ctx, id
+func (_m *BookmarkTx) Purge(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkTx_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type BookmarkTx_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *BookmarkTx_Expecter) Purge(ctx interface{}, id interface{}) *BookmarkTx_Purge_Call {
+	return &BookmarkTx_Purge_Call{Call: _e.mock.On("Purge", ctx, id)}
+}
+
+func (_c *BookmarkTx_Purge_Call) Run(run func(ctx context.Context, id string)) *BookmarkTx_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BookmarkTx_Purge_Call) Return(_a0 error) *BookmarkTx_Purge_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkTx_Purge_Call) RunAndReturn(run func(context.Context, string) error) *BookmarkTx_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function with given fields: events
+func (_m *BookmarkTx) Record(events ...domain.Event) {
+	_va := make([]interface{}, len(events))
+	for _i := range events {
+		_va[_i] = events[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
+}
+
+// BookmarkTx_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type BookmarkTx_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - events ...domain.Event
+func (_e *BookmarkTx_Expecter) Record(events ...interface{}) *BookmarkTx_Record_Call {
+	return &BookmarkTx_Record_Call{Call: _e.mock.On("Record",
+		append([]interface{}{}, events...)...)}
+}
+
+func (_c *BookmarkTx_Record_Call) Run(run func(events ...domain.Event)) *BookmarkTx_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]domain.Event, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(domain.Event)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *BookmarkTx_Record_Call) Return() *BookmarkTx_Record_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *BookmarkTx_Record_Call) RunAndReturn(run func(...domain.Event)) *BookmarkTx_Record_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *BookmarkTx) Restore(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkTx_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type BookmarkTx_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *BookmarkTx_Expecter) Restore(ctx interface{}, id interface{}) *BookmarkTx_Restore_Call {
+	return &BookmarkTx_Restore_Call{Call: _e.mock.On("Restore", ctx, id)}
+}
+
+func (_c *BookmarkTx_Restore_Call) Run(run func(ctx context.Context, id string)) *BookmarkTx_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BookmarkTx_Restore_Call) Return(_a0 error) *BookmarkTx_Restore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkTx_Restore_Call) RunAndReturn(run func(context.Context, string) error) *BookmarkTx_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Trash provides a mock function with given fields: ctx
+func (_m *BookmarkTx) Trash(ctx context.Context) ([]*domain.Bookmark, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Trash")
+	}
+
+	var r0 []*domain.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.Bookmark, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.Bookmark); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BookmarkTx_Trash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Trash'
+type BookmarkTx_Trash_Call struct {
+	*mock.Call
+}
+
+// Trash is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *BookmarkTx_Expecter) Trash(ctx interface{}) *BookmarkTx_Trash_Call {
+	return &BookmarkTx_Trash_Call{Call: _e.mock.On("Trash", ctx)}
+}
+
+func (_c *BookmarkTx_Trash_Call) Run(run func(ctx context.Context)) *BookmarkTx_Trash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *BookmarkTx_Trash_Call) Return(_a0 []*domain.Bookmark, _a1 error) *BookmarkTx_Trash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BookmarkTx_Trash_Call) RunAndReturn(run func(context.Context) ([]*domain.Bookmark, error)) *BookmarkTx_Trash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, b
+func (_m *BookmarkTx) Update(ctx context.Context, b *domain.Bookmark) error {
+	ret := _m.Called(ctx, b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Bookmark) error); ok {
+		r0 = rf(ctx, b)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkTx_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type BookmarkTx_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - b *domain.Bookmark
+func (_e *BookmarkTx_Expecter) Update(ctx interface{}, b interface{}) *BookmarkTx_Update_Call {
+	return &BookmarkTx_Update_Call{Call: _e.mock.On("Update", ctx, b)}
+}
+
+func (_c *BookmarkTx_Update_Call) Run(run func(ctx context.Context, b *domain.Bookmark)) *BookmarkTx_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Bookmark))
+	})
+	return _c
+}
+
+func (_c *BookmarkTx_Update_Call) Return(_a0 error) *BookmarkTx_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkTx_Update_Call) RunAndReturn(run func(context.Context, *domain.Bookmark) error) *BookmarkTx_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Walk provides a mock function with given fields: ctx, filter, fn
+func (_m *BookmarkTx) Walk(ctx context.Context, filter domain.BookmarkFilter, fn func(*domain.Bookmark) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Walk")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BookmarkFilter, func(*domain.Bookmark) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BookmarkTx_Walk_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Walk'
+type BookmarkTx_Walk_Call struct {
+	*mock.Call
+}
+
+// Walk is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.BookmarkFilter
+//   - fn func(*domain.Bookmark) error
+func (_e *BookmarkTx_Expecter) Walk(ctx interface{}, filter interface{}, fn interface{}) *BookmarkTx_Walk_Call {
+	return &BookmarkTx_Walk_Call{Call: _e.mock.On("Walk", ctx, filter, fn)}
+}
+
+func (_c *BookmarkTx_Walk_Call) Run(run func(ctx context.Context, filter domain.BookmarkFilter, fn func(*domain.Bookmark) error)) *BookmarkTx_Walk_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.BookmarkFilter), args[2].(func(*domain.Bookmark) error))
+	})
+	return _c
+}
+
+func (_c *BookmarkTx_Walk_Call) Return(_a0 error) *BookmarkTx_Walk_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BookmarkTx_Walk_Call) RunAndReturn(run func(context.Context, domain.BookmarkFilter, func(*domain.Bookmark) error) error) *BookmarkTx_Walk_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBookmarkTx creates a new instance of BookmarkTx. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBookmarkTx(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BookmarkTx {
+	mock := &BookmarkTx{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
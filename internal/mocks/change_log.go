@@ -0,0 +1,329 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ChangeLog is an autogenerated mock type for the ChangeLog type
+type ChangeLog struct {
+	mock.Mock
+}
+
+type ChangeLog_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ChangeLog) EXPECT() *ChangeLog_Expecter {
+	return &ChangeLog_Expecter{mock: &_m.Mock}
+}
+
+// Append provides a mock function with given fields: ctx, changes
+func (_m *ChangeLog) Append(ctx context.Context, changes ...domain.Change) error {
+	_va := make([]interface{}, len(changes))
+	for _i := range changes {
+		_va[_i] = changes[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Append")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...domain.Change) error); ok {
+		r0 = rf(ctx, changes...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChangeLog_Append_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Append'
+type ChangeLog_Append_Call struct {
+	*mock.Call
+}
+
+// Append is a helper method to define mock.On call
+//   - ctx context.Context
+//   - changes ...domain.Change
+func (_e *ChangeLog_Expecter) Append(ctx interface{}, changes ...interface{}) *ChangeLog_Append_Call {
+	return &ChangeLog_Append_Call{Call: _e.mock.On("Append",
+		append([]interface{}{ctx}, changes...)...)}
+}
+
+func (_c *ChangeLog_Append_Call) Run(run func(ctx context.Context, changes ...domain.Change)) *ChangeLog_Append_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]domain.Change, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(domain.Change)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ChangeLog_Append_Call) Return(_a0 error) *ChangeLog_Append_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ChangeLog_Append_Call) RunAndReturn(run func(context.Context, ...domain.Change) error) *ChangeLog_Append_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Epoch provides a mock function with given fields: ctx
+func (_m *ChangeLog) Epoch(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Epoch")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeLog_Epoch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Epoch'
+type ChangeLog_Epoch_Call struct {
+	*mock.Call
+}
+
+// Epoch is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ChangeLog_Expecter) Epoch(ctx interface{}) *ChangeLog_Epoch_Call {
+	return &ChangeLog_Epoch_Call{Call: _e.mock.On("Epoch", ctx)}
+}
+
+func (_c *ChangeLog_Epoch_Call) Run(run func(ctx context.Context)) *ChangeLog_Epoch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ChangeLog_Epoch_Call) Return(_a0 string, _a1 error) *ChangeLog_Epoch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ChangeLog_Epoch_Call) RunAndReturn(run func(context.Context) (string, error)) *ChangeLog_Epoch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Latest provides a mock function with given fields: ctx
+func (_m *ChangeLog) Latest(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Latest")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeLog_Latest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Latest'
+type ChangeLog_Latest_Call struct {
+	*mock.Call
+}
+
+// Latest is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ChangeLog_Expecter) Latest(ctx interface{}) *ChangeLog_Latest_Call {
+	return &ChangeLog_Latest_Call{Call: _e.mock.On("Latest", ctx)}
+}
+
+func (_c *ChangeLog_Latest_Call) Run(run func(ctx context.Context)) *ChangeLog_Latest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ChangeLog_Latest_Call) Return(_a0 int64, _a1 error) *ChangeLog_Latest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ChangeLog_Latest_Call) RunAndReturn(run func(context.Context) (int64, error)) *ChangeLog_Latest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Prune provides a mock function with given fields: ctx, before
+func (_m *ChangeLog) Prune(ctx context.Context, before time.Time) (int, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Prune")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeLog_Prune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Prune'
+type ChangeLog_Prune_Call struct {
+	*mock.Call
+}
+
+// Prune is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *ChangeLog_Expecter) Prune(ctx interface{}, before interface{}) *ChangeLog_Prune_Call {
+	return &ChangeLog_Prune_Call{Call: _e.mock.On("Prune", ctx, before)}
+}
+
+func (_c *ChangeLog_Prune_Call) Run(run func(ctx context.Context, before time.Time)) *ChangeLog_Prune_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ChangeLog_Prune_Call) Return(_a0 int, _a1 error) *ChangeLog_Prune_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ChangeLog_Prune_Call) RunAndReturn(run func(context.Context, time.Time) (int, error)) *ChangeLog_Prune_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Since provides a mock function with given fields: ctx, seq, limit
+func (_m *ChangeLog) Since(ctx context.Context, seq int64, limit int) ([]domain.Change, error) {
+	ret := _m.Called(ctx, seq, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Since")
+	}
+
+	var r0 []domain.Change
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]domain.Change, error)); ok {
+		return rf(ctx, seq, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []domain.Change); ok {
+		r0 = rf(ctx, seq, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Change)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, seq, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeLog_Since_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Since'
+type ChangeLog_Since_Call struct {
+	*mock.Call
+}
+
+// Since is a helper method to define mock.On call
+//   - ctx context.Context
+//   - seq int64
+//   - limit int
+func (_e *ChangeLog_Expecter) Since(ctx interface{}, seq interface{}, limit interface{}) *ChangeLog_Since_Call {
+	return &ChangeLog_Since_Call{Call: _e.mock.On("Since", ctx, seq, limit)}
+}
+
+func (_c *ChangeLog_Since_Call) Run(run func(ctx context.Context, seq int64, limit int)) *ChangeLog_Since_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *ChangeLog_Since_Call) Return(_a0 []domain.Change, _a1 error) *ChangeLog_Since_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ChangeLog_Since_Call) RunAndReturn(run func(context.Context, int64, int) ([]domain.Change, error)) *ChangeLog_Since_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewChangeLog creates a new instance of ChangeLog. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewChangeLog(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ChangeLog {
+	mock := &ChangeLog{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
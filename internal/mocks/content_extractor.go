@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ContentExtractor is an autogenerated mock type for the ContentExtractor type
+type ContentExtractor struct {
+	mock.Mock
+}
+
+type ContentExtractor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ContentExtractor) EXPECT() *ContentExtractor_Expecter {
+	return &ContentExtractor_Expecter{mock: &_m.Mock}
+}
+
+// Extract provides a mock function with given fields: ctx, url
+func (_m *ContentExtractor) Extract(ctx context.Context, url string) (*domain.PageContent, error) {
+	ret := _m.Called(ctx, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Extract")
+	}
+
+	var r0 *domain.PageContent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.PageContent, error)); ok {
+		return rf(ctx, url)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.PageContent); ok {
+		r0 = rf(ctx, url)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PageContent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContentExtractor_Extract_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Extract'
+type ContentExtractor_Extract_Call struct {
+	*mock.Call
+}
+
+// Extract is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url string
+func (_e *ContentExtractor_Expecter) Extract(ctx interface{}, url interface{}) *ContentExtractor_Extract_Call {
+	return &ContentExtractor_Extract_Call{Call: _e.mock.On("Extract", ctx, url)}
+}
+
+func (_c *ContentExtractor_Extract_Call) Run(run func(ctx context.Context, url string)) *ContentExtractor_Extract_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ContentExtractor_Extract_Call) Return(_a0 *domain.PageContent, _a1 error) *ContentExtractor_Extract_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContentExtractor_Extract_Call) RunAndReturn(run func(context.Context, string) (*domain.PageContent, error)) *ContentExtractor_Extract_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewContentExtractor creates a new instance of ContentExtractor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewContentExtractor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ContentExtractor {
+	mock := &ContentExtractor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
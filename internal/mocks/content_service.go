@@ -0,0 +1,116 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ContentService is an autogenerated mock type for the ContentService type
+type ContentService struct {
+	mock.Mock
+}
+
+type ContentService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ContentService) EXPECT() *ContentService_Expecter {
+	return &ContentService_Expecter{mock: &_m.Mock}
+}
+
+// Enqueue provides a mock function with given fields: ctx, id
+func (_m *ContentService) Enqueue(ctx context.Context, id string) {
+	_m.Called(ctx, id)
+}
+
+// ContentService_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type ContentService_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ContentService_Expecter) Enqueue(ctx interface{}, id interface{}) *ContentService_Enqueue_Call {
+	return &ContentService_Enqueue_Call{Call: _e.mock.On("Enqueue", ctx, id)}
+}
+
+func (_c *ContentService_Enqueue_Call) Run(run func(ctx context.Context, id string)) *ContentService_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ContentService_Enqueue_Call) Return() *ContentService_Enqueue_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ContentService_Enqueue_Call) RunAndReturn(run func(context.Context, string)) *ContentService_Enqueue_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *ContentService) Run(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Run")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContentService_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type ContentService_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ContentService_Expecter) Run(ctx interface{}) *ContentService_Run_Call {
+	return &ContentService_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *ContentService_Run_Call) Run(run func(ctx context.Context)) *ContentService_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ContentService_Run_Call) Return(_a0 error) *ContentService_Run_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContentService_Run_Call) RunAndReturn(run func(context.Context) error) *ContentService_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewContentService creates a new instance of ContentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewContentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ContentService {
+	mock := &ContentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,116 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EnrichmentService is an autogenerated mock type for the EnrichmentService type
+type EnrichmentService struct {
+	mock.Mock
+}
+
+type EnrichmentService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *EnrichmentService) EXPECT() *EnrichmentService_Expecter {
+	return &EnrichmentService_Expecter{mock: &_m.Mock}
+}
+
+// Enqueue provides a mock function with given fields: ctx, id
+func (_m *EnrichmentService) Enqueue(ctx context.Context, id string) {
+	_m.Called(ctx, id)
+}
+
+// EnrichmentService_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type EnrichmentService_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *EnrichmentService_Expecter) Enqueue(ctx interface{}, id interface{}) *EnrichmentService_Enqueue_Call {
+	return &EnrichmentService_Enqueue_Call{Call: _e.mock.On("Enqueue", ctx, id)}
+}
+
+func (_c *EnrichmentService_Enqueue_Call) Run(run func(ctx context.Context, id string)) *EnrichmentService_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *EnrichmentService_Enqueue_Call) Return() *EnrichmentService_Enqueue_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *EnrichmentService_Enqueue_Call) RunAndReturn(run func(context.Context, string)) *EnrichmentService_Enqueue_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *EnrichmentService) Run(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Run")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrichmentService_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type EnrichmentService_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *EnrichmentService_Expecter) Run(ctx interface{}) *EnrichmentService_Run_Call {
+	return &EnrichmentService_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *EnrichmentService_Run_Call) Run(run func(ctx context.Context)) *EnrichmentService_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *EnrichmentService_Run_Call) Return(_a0 error) *EnrichmentService_Run_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EnrichmentService_Run_Call) RunAndReturn(run func(context.Context) error) *EnrichmentService_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewEnrichmentService creates a new instance of EnrichmentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEnrichmentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EnrichmentService {
+	mock := &EnrichmentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
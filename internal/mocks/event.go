@@ -0,0 +1,262 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Event is an autogenerated mock type for the Event type
+type Event struct {
+	mock.Mock
+}
+
+type Event_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Event) EXPECT() *Event_Expecter {
+	return &Event_Expecter{mock: &_m.Mock}
+}
+
+// AggregateID provides a mock function with no fields
+func (_m *Event) AggregateID() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregateID")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Event_AggregateID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AggregateID'
+type Event_AggregateID_Call struct {
+	*mock.Call
+}
+
+// AggregateID is a helper method to define mock.On call
+func (_e *Event_Expecter) AggregateID() *Event_AggregateID_Call {
+	return &Event_AggregateID_Call{Call: _e.mock.On("AggregateID")}
+}
+
+func (_c *Event_AggregateID_Call) Run(run func()) *Event_AggregateID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Event_AggregateID_Call) Return(_a0 string) *Event_AggregateID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Event_AggregateID_Call) RunAndReturn(run func() string) *Event_AggregateID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EventActor provides a mock function with no fields
+func (_m *Event) EventActor() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for EventActor")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Event_EventActor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EventActor'
+type Event_EventActor_Call struct {
+	*mock.Call
+}
+
+// EventActor is a helper method to define mock.On call
+func (_e *Event_Expecter) EventActor() *Event_EventActor_Call {
+	return &Event_EventActor_Call{Call: _e.mock.On("EventActor")}
+}
+
+func (_c *Event_EventActor_Call) Run(run func()) *Event_EventActor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Event_EventActor_Call) Return(_a0 string) *Event_EventActor_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Event_EventActor_Call) RunAndReturn(run func() string) *Event_EventActor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EventID provides a mock function with no fields
+func (_m *Event) EventID() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for EventID")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Event_EventID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EventID'
+type Event_EventID_Call struct {
+	*mock.Call
+}
+
+// EventID is a helper method to define mock.On call
+func (_e *Event_Expecter) EventID() *Event_EventID_Call {
+	return &Event_EventID_Call{Call: _e.mock.On("EventID")}
+}
+
+func (_c *Event_EventID_Call) Run(run func()) *Event_EventID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Event_EventID_Call) Return(_a0 string) *Event_EventID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Event_EventID_Call) RunAndReturn(run func() string) *Event_EventID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EventType provides a mock function with no fields
+func (_m *Event) EventType() domain.EventType {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for EventType")
+	}
+
+	var r0 domain.EventType
+	if rf, ok := ret.Get(0).(func() domain.EventType); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.EventType)
+	}
+
+	return r0
+}
+
+// Event_EventType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EventType'
+type Event_EventType_Call struct {
+	*mock.Call
+}
+
+// EventType is a helper method to define mock.On call
+func (_e *Event_Expecter) EventType() *Event_EventType_Call {
+	return &Event_EventType_Call{Call: _e.mock.On("EventType")}
+}
+
+func (_c *Event_EventType_Call) Run(run func()) *Event_EventType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Event_EventType_Call) Return(_a0 domain.EventType) *Event_EventType_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Event_EventType_Call) RunAndReturn(run func() domain.EventType) *Event_EventType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OccurredAt provides a mock function with no fields
+func (_m *Event) OccurredAt() time.Time {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for OccurredAt")
+	}
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// Event_OccurredAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OccurredAt'
+type Event_OccurredAt_Call struct {
+	*mock.Call
+}
+
+// OccurredAt is a helper method to define mock.On call
+func (_e *Event_Expecter) OccurredAt() *Event_OccurredAt_Call {
+	return &Event_OccurredAt_Call{Call: _e.mock.On("OccurredAt")}
+}
+
+func (_c *Event_OccurredAt_Call) Run(run func()) *Event_OccurredAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Event_OccurredAt_Call) Return(_a0 time.Time) *Event_OccurredAt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Event_OccurredAt_Call) RunAndReturn(run func() time.Time) *Event_OccurredAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewEvent creates a new instance of Event. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEvent(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Event {
+	mock := &Event{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventHandler is an autogenerated mock type for the EventHandler type
+type EventHandler struct {
+	mock.Mock
+}
+
+type EventHandler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *EventHandler) EXPECT() *EventHandler_Expecter {
+	return &EventHandler_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx, e
+func (_m *EventHandler) Execute(ctx context.Context, e domain.Event) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Event) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EventHandler_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type EventHandler_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e domain.Event
+func (_e *EventHandler_Expecter) Execute(ctx interface{}, e interface{}) *EventHandler_Execute_Call {
+	return &EventHandler_Execute_Call{Call: _e.mock.On("Execute", ctx, e)}
+}
+
+func (_c *EventHandler_Execute_Call) Run(run func(ctx context.Context, e domain.Event)) *EventHandler_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Event))
+	})
+	return _c
+}
+
+func (_c *EventHandler_Execute_Call) Return(_a0 error) *EventHandler_Execute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *EventHandler_Execute_Call) RunAndReturn(run func(context.Context, domain.Event) error) *EventHandler_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewEventHandler creates a new instance of EventHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventHandler {
+	mock := &EventHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
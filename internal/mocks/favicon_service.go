@@ -0,0 +1,97 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	service "github.com/etsrc/goprod/internal/service"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FaviconService is an autogenerated mock type for the FaviconService type
+type FaviconService struct {
+	mock.Mock
+}
+
+type FaviconService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FaviconService) EXPECT() *FaviconService_Expecter {
+	return &FaviconService_Expecter{mock: &_m.Mock}
+}
+
+// Icon provides a mock function with given fields: ctx, host, size
+func (_m *FaviconService) Icon(ctx context.Context, host string, size int) (*service.FaviconIcon, error) {
+	ret := _m.Called(ctx, host, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Icon")
+	}
+
+	var r0 *service.FaviconIcon
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*service.FaviconIcon, error)); ok {
+		return rf(ctx, host, size)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *service.FaviconIcon); ok {
+		r0 = rf(ctx, host, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.FaviconIcon)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, host, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FaviconService_Icon_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Icon'
+type FaviconService_Icon_Call struct {
+	*mock.Call
+}
+
+// Icon is a helper method to define mock.On call
+//   - ctx context.Context
+//   - host string
+//   - size int
+func (_e *FaviconService_Expecter) Icon(ctx interface{}, host interface{}, size interface{}) *FaviconService_Icon_Call {
+	return &FaviconService_Icon_Call{Call: _e.mock.On("Icon", ctx, host, size)}
+}
+
+func (_c *FaviconService_Icon_Call) Run(run func(ctx context.Context, host string, size int)) *FaviconService_Icon_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *FaviconService_Icon_Call) Return(_a0 *service.FaviconIcon, _a1 error) *FaviconService_Icon_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FaviconService_Icon_Call) RunAndReturn(run func(context.Context, string, int) (*service.FaviconIcon, error)) *FaviconService_Icon_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFaviconService creates a new instance of FaviconService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFaviconService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FaviconService {
+	mock := &FaviconService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,144 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FaviconSource is an autogenerated mock type for the FaviconSource type
+type FaviconSource struct {
+	mock.Mock
+}
+
+type FaviconSource_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FaviconSource) EXPECT() *FaviconSource_Expecter {
+	return &FaviconSource_Expecter{mock: &_m.Mock}
+}
+
+// Fallback provides a mock function with given fields: host, size
+func (_m *FaviconSource) Fallback(host string, size int) []byte {
+	ret := _m.Called(host, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Fallback")
+	}
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(string, int) []byte); ok {
+		r0 = rf(host, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	return r0
+}
+
+// FaviconSource_Fallback_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Fallback'
+type FaviconSource_Fallback_Call struct {
+	*mock.Call
+}
+
+// Fallback is a helper method to define mock.On call
+//   - host string
+//   - size int
+func (_e *FaviconSource_Expecter) Fallback(host interface{}, size interface{}) *FaviconSource_Fallback_Call {
+	return &FaviconSource_Fallback_Call{Call: _e.mock.On("Fallback", host, size)}
+}
+
+func (_c *FaviconSource_Fallback_Call) Run(run func(host string, size int)) *FaviconSource_Fallback_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *FaviconSource_Fallback_Call) Return(_a0 []byte) *FaviconSource_Fallback_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FaviconSource_Fallback_Call) RunAndReturn(run func(string, int) []byte) *FaviconSource_Fallback_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Fetch provides a mock function with given fields: ctx, host
+func (_m *FaviconSource) Fetch(ctx context.Context, host string) (map[int][]byte, error) {
+	ret := _m.Called(ctx, host)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Fetch")
+	}
+
+	var r0 map[int][]byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[int][]byte, error)); ok {
+		return rf(ctx, host)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[int][]byte); ok {
+		r0 = rf(ctx, host)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int][]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, host)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FaviconSource_Fetch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Fetch'
+type FaviconSource_Fetch_Call struct {
+	*mock.Call
+}
+
+// Fetch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - host string
+func (_e *FaviconSource_Expecter) Fetch(ctx interface{}, host interface{}) *FaviconSource_Fetch_Call {
+	return &FaviconSource_Fetch_Call{Call: _e.mock.On("Fetch", ctx, host)}
+}
+
+func (_c *FaviconSource_Fetch_Call) Run(run func(ctx context.Context, host string)) *FaviconSource_Fetch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FaviconSource_Fetch_Call) Return(_a0 map[int][]byte, _a1 error) *FaviconSource_Fetch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FaviconSource_Fetch_Call) RunAndReturn(run func(context.Context, string) (map[int][]byte, error)) *FaviconSource_Fetch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFaviconSource creates a new instance of FaviconSource. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFaviconSource(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FaviconSource {
+	mock := &FaviconSource{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,143 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FaviconStore is an autogenerated mock type for the FaviconStore type
+type FaviconStore struct {
+	mock.Mock
+}
+
+type FaviconStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FaviconStore) EXPECT() *FaviconStore_Expecter {
+	return &FaviconStore_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: ctx, host
+func (_m *FaviconStore) Get(ctx context.Context, host string) (*domain.Favicon, error) {
+	ret := _m.Called(ctx, host)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Favicon
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Favicon, error)); ok {
+		return rf(ctx, host)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Favicon); ok {
+		r0 = rf(ctx, host)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Favicon)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, host)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FaviconStore_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type FaviconStore_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - host string
+func (_e *FaviconStore_Expecter) Get(ctx interface{}, host interface{}) *FaviconStore_Get_Call {
+	return &FaviconStore_Get_Call{Call: _e.mock.On("Get", ctx, host)}
+}
+
+func (_c *FaviconStore_Get_Call) Run(run func(ctx context.Context, host string)) *FaviconStore_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FaviconStore_Get_Call) Return(_a0 *domain.Favicon, _a1 error) *FaviconStore_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FaviconStore_Get_Call) RunAndReturn(run func(context.Context, string) (*domain.Favicon, error)) *FaviconStore_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Put provides a mock function with given fields: ctx, f
+func (_m *FaviconStore) Put(ctx context.Context, f *domain.Favicon) error {
+	ret := _m.Called(ctx, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Favicon) error); ok {
+		r0 = rf(ctx, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FaviconStore_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type FaviconStore_Put_Call struct {
+	*mock.Call
+}
+
+// Put is a helper method to define mock.On call
+//   - ctx context.Context
+//   - f *domain.Favicon
+func (_e *FaviconStore_Expecter) Put(ctx interface{}, f interface{}) *FaviconStore_Put_Call {
+	return &FaviconStore_Put_Call{Call: _e.mock.On("Put", ctx, f)}
+}
+
+func (_c *FaviconStore_Put_Call) Run(run func(ctx context.Context, f *domain.Favicon)) *FaviconStore_Put_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Favicon))
+	})
+	return _c
+}
+
+func (_c *FaviconStore_Put_Call) Return(_a0 error) *FaviconStore_Put_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FaviconStore_Put_Call) RunAndReturn(run func(context.Context, *domain.Favicon) error) *FaviconStore_Put_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFaviconStore creates a new instance of FaviconStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFaviconStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FaviconStore {
+	mock := &FaviconStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HealthChecker is an autogenerated mock type for the HealthChecker type
+type HealthChecker struct {
+	mock.Mock
+}
+
+type HealthChecker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HealthChecker) EXPECT() *HealthChecker_Expecter {
+	return &HealthChecker_Expecter{mock: &_m.Mock}
+}
+
+// CheckHealth provides a mock function with given fields: ctx
+func (_m *HealthChecker) CheckHealth(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckHealth")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthChecker_CheckHealth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckHealth'
+type HealthChecker_CheckHealth_Call struct {
+	*mock.Call
+}
+
+// CheckHealth is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *HealthChecker_Expecter) CheckHealth(ctx interface{}) *HealthChecker_CheckHealth_Call {
+	return &HealthChecker_CheckHealth_Call{Call: _e.mock.On("CheckHealth", ctx)}
+}
+
+func (_c *HealthChecker_CheckHealth_Call) Run(run func(ctx context.Context)) *HealthChecker_CheckHealth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *HealthChecker_CheckHealth_Call) Return(_a0 error) *HealthChecker_CheckHealth_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthChecker_CheckHealth_Call) RunAndReturn(run func(context.Context) error) *HealthChecker_CheckHealth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHealthChecker creates a new instance of HealthChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHealthChecker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HealthChecker {
+	mock := &HealthChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
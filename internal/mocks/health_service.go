@@ -0,0 +1,196 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HealthService is an autogenerated mock type for the HealthService type
+type HealthService struct {
+	mock.Mock
+}
+
+type HealthService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HealthService) EXPECT() *HealthService_Expecter {
+	return &HealthService_Expecter{mock: &_m.Mock}
+}
+
+// Check provides a mock function with given fields: ctx
+func (_m *HealthService) Check(ctx context.Context) *domain.HealthReport {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 *domain.HealthReport
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.HealthReport); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.HealthReport)
+		}
+	}
+
+	return r0
+}
+
+// HealthService_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
+type HealthService_Check_Call struct {
+	*mock.Call
+}
+
+// Check is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *HealthService_Expecter) Check(ctx interface{}) *HealthService_Check_Call {
+	return &HealthService_Check_Call{Call: _e.mock.On("Check", ctx)}
+}
+
+func (_c *HealthService_Check_Call) Run(run func(ctx context.Context)) *HealthService_Check_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *HealthService_Check_Call) Return(_a0 *domain.HealthReport) *HealthService_Check_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_Check_Call) RunAndReturn(run func(context.Context) *domain.HealthReport) *HealthService_Check_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Drain provides a mock function with no fields
+func (_m *HealthService) Drain() {
+	_m.Called()
+}
+
+// HealthService_Drain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Drain'
+type HealthService_Drain_Call struct {
+	*mock.Call
+}
+
+// Drain is a helper method to define mock.On call
+func (_e *HealthService_Expecter) Drain() *HealthService_Drain_Call {
+	return &HealthService_Drain_Call{Call: _e.mock.On("Drain")}
+}
+
+func (_c *HealthService_Drain_Call) Run(run func()) *HealthService_Drain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *HealthService_Drain_Call) Return() *HealthService_Drain_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *HealthService_Drain_Call) RunAndReturn(run func()) *HealthService_Drain_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Draining provides a mock function with no fields
+func (_m *HealthService) Draining() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Draining")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// HealthService_Draining_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Draining'
+type HealthService_Draining_Call struct {
+	*mock.Call
+}
+
+// Draining is a helper method to define mock.On call
+func (_e *HealthService_Expecter) Draining() *HealthService_Draining_Call {
+	return &HealthService_Draining_Call{Call: _e.mock.On("Draining")}
+}
+
+func (_c *HealthService_Draining_Call) Run(run func()) *HealthService_Draining_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *HealthService_Draining_Call) Return(_a0 bool) *HealthService_Draining_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_Draining_Call) RunAndReturn(run func() bool) *HealthService_Draining_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Register provides a mock function with given fields: name, c
+func (_m *HealthService) Register(name string, c domain.HealthChecker) {
+	_m.Called(name, c)
+}
+
+// HealthService_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type HealthService_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - name string
+//   - c domain.HealthChecker
+func (_e *HealthService_Expecter) Register(name interface{}, c interface{}) *HealthService_Register_Call {
+	return &HealthService_Register_Call{Call: _e.mock.On("Register", name, c)}
+}
+
+func (_c *HealthService_Register_Call) Run(run func(name string, c domain.HealthChecker)) *HealthService_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(domain.HealthChecker))
+	})
+	return _c
+}
+
+func (_c *HealthService_Register_Call) Return() *HealthService_Register_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *HealthService_Register_Call) RunAndReturn(run func(string, domain.HealthChecker)) *HealthService_Register_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewHealthService creates a new instance of HealthService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHealthService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HealthService {
+	mock := &HealthService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
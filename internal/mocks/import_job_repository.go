@@ -0,0 +1,310 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ImportJobRepository is an autogenerated mock type for the ImportJobRepository type
+type ImportJobRepository struct {
+	mock.Mock
+}
+
+type ImportJobRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ImportJobRepository) EXPECT() *ImportJobRepository_Expecter {
+	return &ImportJobRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, job, items
+func (_m *ImportJobRepository) Create(ctx context.Context, job *domain.ImportJob, items []*domain.Bookmark) error {
+	ret := _m.Called(ctx, job, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ImportJob, []*domain.Bookmark) error); ok {
+		r0 = rf(ctx, job, items)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportJobRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type ImportJobRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job *domain.ImportJob
+//   - items []*domain.Bookmark
+func (_e *ImportJobRepository_Expecter) Create(ctx interface{}, job interface{}, items interface{}) *ImportJobRepository_Create_Call {
+	return &ImportJobRepository_Create_Call{Call: _e.mock.On("Create", ctx, job, items)}
+}
+
+func (_c *ImportJobRepository_Create_Call) Run(run func(ctx context.Context, job *domain.ImportJob, items []*domain.Bookmark)) *ImportJobRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ImportJob), args[2].([]*domain.Bookmark))
+	})
+	return _c
+}
+
+func (_c *ImportJobRepository_Create_Call) Return(_a0 error) *ImportJobRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ImportJobRepository_Create_Call) RunAndReturn(run func(context.Context, *domain.ImportJob, []*domain.Bookmark) error) *ImportJobRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *ImportJobRepository) GetByID(ctx context.Context, id string) (*domain.ImportJob, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.ImportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.ImportJob, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.ImportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ImportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportJobRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type ImportJobRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ImportJobRepository_Expecter) GetByID(ctx interface{}, id interface{}) *ImportJobRepository_GetByID_Call {
+	return &ImportJobRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *ImportJobRepository_GetByID_Call) Run(run func(ctx context.Context, id string)) *ImportJobRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ImportJobRepository_GetByID_Call) Return(_a0 *domain.ImportJob, _a1 error) *ImportJobRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ImportJobRepository_GetByID_Call) RunAndReturn(run func(context.Context, string) (*domain.ImportJob, error)) *ImportJobRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Items provides a mock function with given fields: ctx, id, offset, limit
+func (_m *ImportJobRepository) Items(ctx context.Context, id string, offset int, limit int) ([]*domain.Bookmark, error) {
+	ret := _m.Called(ctx, id, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Items")
+	}
+
+	var r0 []*domain.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*domain.Bookmark, error)); ok {
+		return rf(ctx, id, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*domain.Bookmark); ok {
+		r0 = rf(ctx, id, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, id, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportJobRepository_Items_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Items'
+type ImportJobRepository_Items_Call struct {
+	*mock.Call
+}
+
+// Items is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - offset int
+//   - limit int
+func (_e *ImportJobRepository_Expecter) Items(ctx interface{}, id interface{}, offset interface{}, limit interface{}) *ImportJobRepository_Items_Call {
+	return &ImportJobRepository_Items_Call{Call: _e.mock.On("Items", ctx, id, offset, limit)}
+}
+
+func (_c *ImportJobRepository_Items_Call) Run(run func(ctx context.Context, id string, offset int, limit int)) *ImportJobRepository_Items_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *ImportJobRepository_Items_Call) Return(_a0 []*domain.Bookmark, _a1 error) *ImportJobRepository_Items_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ImportJobRepository_Items_Call) RunAndReturn(run func(context.Context, string, int, int) ([]*domain.Bookmark, error)) *ImportJobRepository_Items_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUnfinished provides a mock function with given fields: ctx
+func (_m *ImportJobRepository) ListUnfinished(ctx context.Context) ([]*domain.ImportJob, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnfinished")
+	}
+
+	var r0 []*domain.ImportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.ImportJob, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.ImportJob); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ImportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportJobRepository_ListUnfinished_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnfinished'
+type ImportJobRepository_ListUnfinished_Call struct {
+	*mock.Call
+}
+
+// ListUnfinished is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ImportJobRepository_Expecter) ListUnfinished(ctx interface{}) *ImportJobRepository_ListUnfinished_Call {
+	return &ImportJobRepository_ListUnfinished_Call{Call: _e.mock.On("ListUnfinished", ctx)}
+}
+
+func (_c *ImportJobRepository_ListUnfinished_Call) Run(run func(ctx context.Context)) *ImportJobRepository_ListUnfinished_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ImportJobRepository_ListUnfinished_Call) Return(_a0 []*domain.ImportJob, _a1 error) *ImportJobRepository_ListUnfinished_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ImportJobRepository_ListUnfinished_Call) RunAndReturn(run func(context.Context) ([]*domain.ImportJob, error)) *ImportJobRepository_ListUnfinished_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, job
+func (_m *ImportJobRepository) Update(ctx context.Context, job *domain.ImportJob) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ImportJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportJobRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type ImportJobRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job *domain.ImportJob
+func (_e *ImportJobRepository_Expecter) Update(ctx interface{}, job interface{}) *ImportJobRepository_Update_Call {
+	return &ImportJobRepository_Update_Call{Call: _e.mock.On("Update", ctx, job)}
+}
+
+func (_c *ImportJobRepository_Update_Call) Run(run func(ctx context.Context, job *domain.ImportJob)) *ImportJobRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ImportJob))
+	})
+	return _c
+}
+
+func (_c *ImportJobRepository_Update_Call) Return(_a0 error) *ImportJobRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ImportJobRepository_Update_Call) RunAndReturn(run func(context.Context, *domain.ImportJob) error) *ImportJobRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewImportJobRepository creates a new instance of ImportJobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewImportJobRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ImportJobRepository {
+	mock := &ImportJobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,260 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ImportService is an autogenerated mock type for the ImportService type
+type ImportService struct {
+	mock.Mock
+}
+
+type ImportService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ImportService) EXPECT() *ImportService_Expecter {
+	return &ImportService_Expecter{mock: &_m.Mock}
+}
+
+// Cancel provides a mock function with given fields: ctx, id
+func (_m *ImportService) Cancel(ctx context.Context, id string) (*domain.ImportJob, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 *domain.ImportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.ImportJob, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.ImportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ImportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportService_Cancel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cancel'
+type ImportService_Cancel_Call struct {
+	*mock.Call
+}
+
+// Cancel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ImportService_Expecter) Cancel(ctx interface{}, id interface{}) *ImportService_Cancel_Call {
+	return &ImportService_Cancel_Call{Call: _e.mock.On("Cancel", ctx, id)}
+}
+
+func (_c *ImportService_Cancel_Call) Run(run func(ctx context.Context, id string)) *ImportService_Cancel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ImportService_Cancel_Call) Return(_a0 *domain.ImportJob, _a1 error) *ImportService_Cancel_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ImportService_Cancel_Call) RunAndReturn(run func(context.Context, string) (*domain.ImportJob, error)) *ImportService_Cancel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *ImportService) GetByID(ctx context.Context, id string) (*domain.ImportJob, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.ImportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.ImportJob, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.ImportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ImportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportService_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type ImportService_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ImportService_Expecter) GetByID(ctx interface{}, id interface{}) *ImportService_GetByID_Call {
+	return &ImportService_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *ImportService_GetByID_Call) Run(run func(ctx context.Context, id string)) *ImportService_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ImportService_GetByID_Call) Return(_a0 *domain.ImportJob, _a1 error) *ImportService_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ImportService_GetByID_Call) RunAndReturn(run func(context.Context, string) (*domain.ImportJob, error)) *ImportService_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *ImportService) Run(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Run")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportService_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type ImportService_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ImportService_Expecter) Run(ctx interface{}) *ImportService_Run_Call {
+	return &ImportService_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *ImportService_Run_Call) Run(run func(ctx context.Context)) *ImportService_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ImportService_Run_Call) Return(_a0 error) *ImportService_Run_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ImportService_Run_Call) RunAndReturn(run func(context.Context) error) *ImportService_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Submit provides a mock function with given fields: ctx, items
+func (_m *ImportService) Submit(ctx context.Context, items []*domain.Bookmark) (*domain.ImportJob, error) {
+	ret := _m.Called(ctx, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Submit")
+	}
+
+	var r0 *domain.ImportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Bookmark) (*domain.ImportJob, error)); ok {
+		return rf(ctx, items)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Bookmark) *domain.ImportJob); ok {
+		r0 = rf(ctx, items)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ImportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*domain.Bookmark) error); ok {
+		r1 = rf(ctx, items)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportService_Submit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Submit'
+type ImportService_Submit_Call struct {
+	*mock.Call
+}
+
+// Submit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - items []*domain.Bookmark
+func (_e *ImportService_Expecter) Submit(ctx interface{}, items interface{}) *ImportService_Submit_Call {
+	return &ImportService_Submit_Call{Call: _e.mock.On("Submit", ctx, items)}
+}
+
+func (_c *ImportService_Submit_Call) Run(run func(ctx context.Context, items []*domain.Bookmark)) *ImportService_Submit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*domain.Bookmark))
+	})
+	return _c
+}
+
+func (_c *ImportService_Submit_Call) Return(_a0 *domain.ImportJob, _a1 error) *ImportService_Submit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ImportService_Submit_Call) RunAndReturn(run func(context.Context, []*domain.Bookmark) (*domain.ImportJob, error)) *ImportService_Submit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewImportService creates a new instance of ImportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewImportService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ImportService {
+	mock := &ImportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,199 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LinkCheckService is an autogenerated mock type for the LinkCheckService type
+type LinkCheckService struct {
+	mock.Mock
+}
+
+type LinkCheckService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LinkCheckService) EXPECT() *LinkCheckService_Expecter {
+	return &LinkCheckService_Expecter{mock: &_m.Mock}
+}
+
+// CheckAll provides a mock function with given fields: ctx
+func (_m *LinkCheckService) CheckAll(ctx context.Context) (*domain.LinkCheckRun, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckAll")
+	}
+
+	var r0 *domain.LinkCheckRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.LinkCheckRun, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.LinkCheckRun); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.LinkCheckRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LinkCheckService_CheckAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckAll'
+type LinkCheckService_CheckAll_Call struct {
+	*mock.Call
+}
+
+// CheckAll is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *LinkCheckService_Expecter) CheckAll(ctx interface{}) *LinkCheckService_CheckAll_Call {
+	return &LinkCheckService_CheckAll_Call{Call: _e.mock.On("CheckAll", ctx)}
+}
+
+func (_c *LinkCheckService_CheckAll_Call) Run(run func(ctx context.Context)) *LinkCheckService_CheckAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *LinkCheckService_CheckAll_Call) Return(_a0 *domain.LinkCheckRun, _a1 error) *LinkCheckService_CheckAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LinkCheckService_CheckAll_Call) RunAndReturn(run func(context.Context) (*domain.LinkCheckRun, error)) *LinkCheckService_CheckAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Report provides a mock function with given fields: ctx
+func (_m *LinkCheckService) Report(ctx context.Context) (*domain.LinkHealthReport, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Report")
+	}
+
+	var r0 *domain.LinkHealthReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*domain.LinkHealthReport, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.LinkHealthReport); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.LinkHealthReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LinkCheckService_Report_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Report'
+type LinkCheckService_Report_Call struct {
+	*mock.Call
+}
+
+// Report is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *LinkCheckService_Expecter) Report(ctx interface{}) *LinkCheckService_Report_Call {
+	return &LinkCheckService_Report_Call{Call: _e.mock.On("Report", ctx)}
+}
+
+func (_c *LinkCheckService_Report_Call) Run(run func(ctx context.Context)) *LinkCheckService_Report_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *LinkCheckService_Report_Call) Return(_a0 *domain.LinkHealthReport, _a1 error) *LinkCheckService_Report_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LinkCheckService_Report_Call) RunAndReturn(run func(context.Context) (*domain.LinkHealthReport, error)) *LinkCheckService_Report_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *LinkCheckService) Run(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Run")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LinkCheckService_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type LinkCheckService_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *LinkCheckService_Expecter) Run(ctx interface{}) *LinkCheckService_Run_Call {
+	return &LinkCheckService_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *LinkCheckService_Run_Call) Run(run func(ctx context.Context)) *LinkCheckService_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *LinkCheckService_Run_Call) Return(_a0 error) *LinkCheckService_Run_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LinkCheckService_Run_Call) RunAndReturn(run func(context.Context) error) *LinkCheckService_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLinkCheckService creates a new instance of LinkCheckService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLinkCheckService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LinkCheckService {
+	mock := &LinkCheckService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LinkChecker is an autogenerated mock type for the LinkChecker type
+type LinkChecker struct {
+	mock.Mock
+}
+
+type LinkChecker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LinkChecker) EXPECT() *LinkChecker_Expecter {
+	return &LinkChecker_Expecter{mock: &_m.Mock}
+}
+
+// Check provides a mock function with given fields: ctx, url
+func (_m *LinkChecker) Check(ctx context.Context, url string) domain.LinkCheck {
+	ret := _m.Called(ctx, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 domain.LinkCheck
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.LinkCheck); ok {
+		r0 = rf(ctx, url)
+	} else {
+		r0 = ret.Get(0).(domain.LinkCheck)
+	}
+
+	return r0
+}
+
+// LinkChecker_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
+type LinkChecker_Check_Call struct {
+	*mock.Call
+}
+
+// Check is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url string
+func (_e *LinkChecker_Expecter) Check(ctx interface{}, url interface{}) *LinkChecker_Check_Call {
+	return &LinkChecker_Check_Call{Call: _e.mock.On("Check", ctx, url)}
+}
+
+func (_c *LinkChecker_Check_Call) Run(run func(ctx context.Context, url string)) *LinkChecker_Check_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *LinkChecker_Check_Call) Return(_a0 domain.LinkCheck) *LinkChecker_Check_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LinkChecker_Check_Call) RunAndReturn(run func(context.Context, string) domain.LinkCheck) *LinkChecker_Check_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLinkChecker creates a new instance of LinkChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLinkChecker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LinkChecker {
+	mock := &LinkChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,157 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Outbox is an autogenerated mock type for the Outbox type
+type Outbox struct {
+	mock.Mock
+}
+
+type Outbox_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Outbox) EXPECT() *Outbox_Expecter {
+	return &Outbox_Expecter{mock: &_m.Mock}
+}
+
+// Ack provides a mock function with given fields: ctx, eventIDs
+func (_m *Outbox) Ack(ctx context.Context, eventIDs ...string) error {
+	_va := make([]interface{}, len(eventIDs))
+	for _i := range eventIDs {
+		_va[_i] = eventIDs[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ack")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = rf(ctx, eventIDs...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Outbox_Ack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ack'
+type Outbox_Ack_Call struct {
+	*mock.Call
+}
+
+// Ack is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventIDs ...string
+func (_e *Outbox_Expecter) Ack(ctx interface{}, eventIDs ...interface{}) *Outbox_Ack_Call {
+	return &Outbox_Ack_Call{Call: _e.mock.On("Ack",
+		append([]interface{}{ctx}, eventIDs...)...)}
+}
+
+func (_c *Outbox_Ack_Call) Run(run func(ctx context.Context, eventIDs ...string)) *Outbox_Ack_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Outbox_Ack_Call) Return(_a0 error) *Outbox_Ack_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Outbox_Ack_Call) RunAndReturn(run func(context.Context, ...string) error) *Outbox_Ack_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Pending provides a mock function with given fields: ctx, limit
+func (_m *Outbox) Pending(ctx context.Context, limit int) ([]domain.OutboxRecord, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pending")
+	}
+
+	var r0 []domain.OutboxRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.OutboxRecord, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.OutboxRecord); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OutboxRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Outbox_Pending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pending'
+type Outbox_Pending_Call struct {
+	*mock.Call
+}
+
+// Pending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *Outbox_Expecter) Pending(ctx interface{}, limit interface{}) *Outbox_Pending_Call {
+	return &Outbox_Pending_Call{Call: _e.mock.On("Pending", ctx, limit)}
+}
+
+func (_c *Outbox_Pending_Call) Run(run func(ctx context.Context, limit int)) *Outbox_Pending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Outbox_Pending_Call) Return(_a0 []domain.OutboxRecord, _a1 error) *Outbox_Pending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Outbox_Pending_Call) RunAndReturn(run func(context.Context, int) ([]domain.OutboxRecord, error)) *Outbox_Pending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOutbox creates a new instance of Outbox. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutbox(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Outbox {
+	mock := &Outbox{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,114 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OutboxRelay is an autogenerated mock type for the OutboxRelay type
+type OutboxRelay struct {
+	mock.Mock
+}
+
+type OutboxRelay_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OutboxRelay) EXPECT() *OutboxRelay_Expecter {
+	return &OutboxRelay_Expecter{mock: &_m.Mock}
+}
+
+// Notify provides a mock function with no fields
+func (_m *OutboxRelay) Notify() {
+	_m.Called()
+}
+
+// OutboxRelay_Notify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Notify'
+type OutboxRelay_Notify_Call struct {
+	*mock.Call
+}
+
+// Notify is a helper method to define mock.On call
+func (_e *OutboxRelay_Expecter) Notify() *OutboxRelay_Notify_Call {
+	return &OutboxRelay_Notify_Call{Call: _e.mock.On("Notify")}
+}
+
+func (_c *OutboxRelay_Notify_Call) Run(run func()) *OutboxRelay_Notify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *OutboxRelay_Notify_Call) Return() *OutboxRelay_Notify_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *OutboxRelay_Notify_Call) RunAndReturn(run func()) *OutboxRelay_Notify_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *OutboxRelay) Run(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Run")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRelay_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type OutboxRelay_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *OutboxRelay_Expecter) Run(ctx interface{}) *OutboxRelay_Run_Call {
+	return &OutboxRelay_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *OutboxRelay_Run_Call) Run(run func(ctx context.Context)) *OutboxRelay_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *OutboxRelay_Run_Call) Return(_a0 error) *OutboxRelay_Run_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRelay_Run_Call) RunAndReturn(run func(context.Context) error) *OutboxRelay_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOutboxRelay creates a new instance of OutboxRelay. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxRelay(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxRelay {
+	mock := &OutboxRelay{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
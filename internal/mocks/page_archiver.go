@@ -0,0 +1,204 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PageArchiver is an autogenerated mock type for the PageArchiver type
+type PageArchiver struct {
+	mock.Mock
+}
+
+type PageArchiver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PageArchiver) EXPECT() *PageArchiver_Expecter {
+	return &PageArchiver_Expecter{mock: &_m.Mock}
+}
+
+// Capture provides a mock function with given fields: ctx, id, url
+func (_m *PageArchiver) Capture(ctx context.Context, id string, url string) (*domain.ArchiveInfo, error) {
+	ret := _m.Called(ctx, id, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Capture")
+	}
+
+	var r0 *domain.ArchiveInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.ArchiveInfo, error)); ok {
+		return rf(ctx, id, url)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.ArchiveInfo); ok {
+		r0 = rf(ctx, id, url)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ArchiveInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PageArchiver_Capture_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Capture'
+type PageArchiver_Capture_Call struct {
+	*mock.Call
+}
+
+// Capture is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - url string
+func (_e *PageArchiver_Expecter) Capture(ctx interface{}, id interface{}, url interface{}) *PageArchiver_Capture_Call {
+	return &PageArchiver_Capture_Call{Call: _e.mock.On("Capture", ctx, id, url)}
+}
+
+func (_c *PageArchiver_Capture_Call) Run(run func(ctx context.Context, id string, url string)) *PageArchiver_Capture_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PageArchiver_Capture_Call) Return(_a0 *domain.ArchiveInfo, _a1 error) *PageArchiver_Capture_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PageArchiver_Capture_Call) RunAndReturn(run func(context.Context, string, string) (*domain.ArchiveInfo, error)) *PageArchiver_Capture_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *PageArchiver) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PageArchiver_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type PageArchiver_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *PageArchiver_Expecter) Delete(ctx interface{}, id interface{}) *PageArchiver_Delete_Call {
+	return &PageArchiver_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *PageArchiver_Delete_Call) Run(run func(ctx context.Context, id string)) *PageArchiver_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PageArchiver_Delete_Call) Return(_a0 error) *PageArchiver_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PageArchiver_Delete_Call) RunAndReturn(run func(context.Context, string) error) *PageArchiver_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resource provides a mock function with given fields: ctx, id, url
+func (_m *PageArchiver) Resource(ctx context.Context, id string, url string) (*domain.ArchivedResource, error) {
+	ret := _m.Called(ctx, id, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resource")
+	}
+
+	var r0 *domain.ArchivedResource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.ArchivedResource, error)); ok {
+		return rf(ctx, id, url)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.ArchivedResource); ok {
+		r0 = rf(ctx, id, url)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ArchivedResource)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PageArchiver_Resource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resource'
+type PageArchiver_Resource_Call struct {
+	*mock.Call
+}
+
+// Resource is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - url string
+func (_e *PageArchiver_Expecter) Resource(ctx interface{}, id interface{}, url interface{}) *PageArchiver_Resource_Call {
+	return &PageArchiver_Resource_Call{Call: _e.mock.On("Resource", ctx, id, url)}
+}
+
+func (_c *PageArchiver_Resource_Call) Run(run func(ctx context.Context, id string, url string)) *PageArchiver_Resource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *PageArchiver_Resource_Call) Return(_a0 *domain.ArchivedResource, _a1 error) *PageArchiver_Resource_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PageArchiver_Resource_Call) RunAndReturn(run func(context.Context, string, string) (*domain.ArchivedResource, error)) *PageArchiver_Resource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPageArchiver creates a new instance of PageArchiver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPageArchiver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PageArchiver {
+	mock := &PageArchiver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PageInspector is an autogenerated mock type for the PageInspector type
+type PageInspector struct {
+	mock.Mock
+}
+
+type PageInspector_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PageInspector) EXPECT() *PageInspector_Expecter {
+	return &PageInspector_Expecter{mock: &_m.Mock}
+}
+
+// Inspect provides a mock function with given fields: ctx, url
+func (_m *PageInspector) Inspect(ctx context.Context, url string) (*domain.PageMetadata, error) {
+	ret := _m.Called(ctx, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Inspect")
+	}
+
+	var r0 *domain.PageMetadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.PageMetadata, error)); ok {
+		return rf(ctx, url)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.PageMetadata); ok {
+		r0 = rf(ctx, url)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PageMetadata)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PageInspector_Inspect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Inspect'
+type PageInspector_Inspect_Call struct {
+	*mock.Call
+}
+
+// Inspect is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url string
+func (_e *PageInspector_Expecter) Inspect(ctx interface{}, url interface{}) *PageInspector_Inspect_Call {
+	return &PageInspector_Inspect_Call{Call: _e.mock.On("Inspect", ctx, url)}
+}
+
+func (_c *PageInspector_Inspect_Call) Run(run func(ctx context.Context, url string)) *PageInspector_Inspect_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PageInspector_Inspect_Call) Return(_a0 *domain.PageMetadata, _a1 error) *PageInspector_Inspect_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PageInspector_Inspect_Call) RunAndReturn(run func(context.Context, string) (*domain.PageMetadata, error)) *PageInspector_Inspect_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPageInspector creates a new instance of PageInspector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPageInspector(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PageInspector {
+	mock := &PageInspector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,310 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RevisionRepository is an autogenerated mock type for the RevisionRepository type
+type RevisionRepository struct {
+	mock.Mock
+}
+
+type RevisionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RevisionRepository) EXPECT() *RevisionRepository_Expecter {
+	return &RevisionRepository_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function with given fields: ctx, r
+func (_m *RevisionRepository) Add(ctx context.Context, r *domain.Revision) error {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Revision) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevisionRepository_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type RevisionRepository_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r *domain.Revision
+func (_e *RevisionRepository_Expecter) Add(ctx interface{}, r interface{}) *RevisionRepository_Add_Call {
+	return &RevisionRepository_Add_Call{Call: _e.mock.On("Add", ctx, r)}
+}
+
+func (_c *RevisionRepository_Add_Call) Run(run func(ctx context.Context, r *domain.Revision)) *RevisionRepository_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Revision))
+	})
+	return _c
+}
+
+func (_c *RevisionRepository_Add_Call) Return(_a0 error) *RevisionRepository_Add_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RevisionRepository_Add_Call) RunAndReturn(run func(context.Context, *domain.Revision) error) *RevisionRepository_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByBookmark provides a mock function with given fields: ctx, bookmarkID
+func (_m *RevisionRepository) DeleteByBookmark(ctx context.Context, bookmarkID string) error {
+	ret := _m.Called(ctx, bookmarkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByBookmark")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, bookmarkID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevisionRepository_DeleteByBookmark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByBookmark'
+type RevisionRepository_DeleteByBookmark_Call struct {
+	*mock.Call
+}
+
+// DeleteByBookmark is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+func (_e *RevisionRepository_Expecter) DeleteByBookmark(ctx interface{}, bookmarkID interface{}) *RevisionRepository_DeleteByBookmark_Call {
+	return &RevisionRepository_DeleteByBookmark_Call{Call: _e.mock.On("DeleteByBookmark", ctx, bookmarkID)}
+}
+
+func (_c *RevisionRepository_DeleteByBookmark_Call) Run(run func(ctx context.Context, bookmarkID string)) *RevisionRepository_DeleteByBookmark_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RevisionRepository_DeleteByBookmark_Call) Return(_a0 error) *RevisionRepository_DeleteByBookmark_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RevisionRepository_DeleteByBookmark_Call) RunAndReturn(run func(context.Context, string) error) *RevisionRepository_DeleteByBookmark_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, bookmarkID, number
+func (_m *RevisionRepository) Get(ctx context.Context, bookmarkID string, number int64) (*domain.Revision, error) {
+	ret := _m.Called(ctx, bookmarkID, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (*domain.Revision, error)); ok {
+		return rf(ctx, bookmarkID, number)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) *domain.Revision); ok {
+		r0 = rf(ctx, bookmarkID, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, bookmarkID, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevisionRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type RevisionRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+//   - number int64
+func (_e *RevisionRepository_Expecter) Get(ctx interface{}, bookmarkID interface{}, number interface{}) *RevisionRepository_Get_Call {
+	return &RevisionRepository_Get_Call{Call: _e.mock.On("Get", ctx, bookmarkID, number)}
+}
+
+func (_c *RevisionRepository_Get_Call) Run(run func(ctx context.Context, bookmarkID string, number int64)) *RevisionRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *RevisionRepository_Get_Call) Return(_a0 *domain.Revision, _a1 error) *RevisionRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RevisionRepository_Get_Call) RunAndReturn(run func(context.Context, string, int64) (*domain.Revision, error)) *RevisionRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, bookmarkID
+func (_m *RevisionRepository) List(ctx context.Context, bookmarkID string) ([]*domain.Revision, error) {
+	ret := _m.Called(ctx, bookmarkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*domain.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.Revision, error)); ok {
+		return rf(ctx, bookmarkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.Revision); ok {
+		r0 = rf(ctx, bookmarkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, bookmarkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevisionRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type RevisionRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+func (_e *RevisionRepository_Expecter) List(ctx interface{}, bookmarkID interface{}) *RevisionRepository_List_Call {
+	return &RevisionRepository_List_Call{Call: _e.mock.On("List", ctx, bookmarkID)}
+}
+
+func (_c *RevisionRepository_List_Call) Run(run func(ctx context.Context, bookmarkID string)) *RevisionRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RevisionRepository_List_Call) Return(_a0 []*domain.Revision, _a1 error) *RevisionRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RevisionRepository_List_Call) RunAndReturn(run func(context.Context, string) ([]*domain.Revision, error)) *RevisionRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Prune provides a mock function with given fields: ctx, keep, before
+func (_m *RevisionRepository) Prune(ctx context.Context, keep int, before time.Time) (int, error) {
+	ret := _m.Called(ctx, keep, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Prune")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) (int, error)); ok {
+		return rf(ctx, keep, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) int); ok {
+		r0 = rf(ctx, keep, before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = rf(ctx, keep, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevisionRepository_Prune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Prune'
+type RevisionRepository_Prune_Call struct {
+	*mock.Call
+}
+
+// Prune is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keep int
+//   - before time.Time
+func (_e *RevisionRepository_Expecter) Prune(ctx interface{}, keep interface{}, before interface{}) *RevisionRepository_Prune_Call {
+	return &RevisionRepository_Prune_Call{Call: _e.mock.On("Prune", ctx, keep, before)}
+}
+
+func (_c *RevisionRepository_Prune_Call) Run(run func(ctx context.Context, keep int, before time.Time)) *RevisionRepository_Prune_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *RevisionRepository_Prune_Call) Return(_a0 int, _a1 error) *RevisionRepository_Prune_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RevisionRepository_Prune_Call) RunAndReturn(run func(context.Context, int, time.Time) (int, error)) *RevisionRepository_Prune_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRevisionRepository creates a new instance of RevisionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRevisionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RevisionRepository {
+	mock := &RevisionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
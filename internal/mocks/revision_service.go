@@ -0,0 +1,357 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RevisionService is an autogenerated mock type for the RevisionService type
+type RevisionService struct {
+	mock.Mock
+}
+
+type RevisionService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RevisionService) EXPECT() *RevisionService_Expecter {
+	return &RevisionService_Expecter{mock: &_m.Mock}
+}
+
+// DeleteAll provides a mock function with given fields: ctx, bookmarkID
+func (_m *RevisionService) DeleteAll(ctx context.Context, bookmarkID string) error {
+	ret := _m.Called(ctx, bookmarkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, bookmarkID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevisionService_DeleteAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAll'
+type RevisionService_DeleteAll_Call struct {
+	*mock.Call
+}
+
+// DeleteAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+func (_e *RevisionService_Expecter) DeleteAll(ctx interface{}, bookmarkID interface{}) *RevisionService_DeleteAll_Call {
+	return &RevisionService_DeleteAll_Call{Call: _e.mock.On("DeleteAll", ctx, bookmarkID)}
+}
+
+func (_c *RevisionService_DeleteAll_Call) Run(run func(ctx context.Context, bookmarkID string)) *RevisionService_DeleteAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RevisionService_DeleteAll_Call) Return(_a0 error) *RevisionService_DeleteAll_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RevisionService_DeleteAll_Call) RunAndReturn(run func(context.Context, string) error) *RevisionService_DeleteAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Diff provides a mock function with given fields: ctx, bookmarkID, from, to
+func (_m *RevisionService) Diff(ctx context.Context, bookmarkID string, from int64, to int64) (*domain.RevisionDiff, error) {
+	ret := _m.Called(ctx, bookmarkID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Diff")
+	}
+
+	var r0 *domain.RevisionDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) (*domain.RevisionDiff, error)); ok {
+		return rf(ctx, bookmarkID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) *domain.RevisionDiff); ok {
+		r0 = rf(ctx, bookmarkID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.RevisionDiff)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64) error); ok {
+		r1 = rf(ctx, bookmarkID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevisionService_Diff_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Diff'
+type RevisionService_Diff_Call struct {
+	*mock.Call
+}
+
+// Diff is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+//   - from int64
+//   - to int64
+func (_e *RevisionService_Expecter) Diff(ctx interface{}, bookmarkID interface{}, from interface{}, to interface{}) *RevisionService_Diff_Call {
+	return &RevisionService_Diff_Call{Call: _e.mock.On("Diff", ctx, bookmarkID, from, to)}
+}
+
+func (_c *RevisionService_Diff_Call) Run(run func(ctx context.Context, bookmarkID string, from int64, to int64)) *RevisionService_Diff_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *RevisionService_Diff_Call) Return(_a0 *domain.RevisionDiff, _a1 error) *RevisionService_Diff_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RevisionService_Diff_Call) RunAndReturn(run func(context.Context, string, int64, int64) (*domain.RevisionDiff, error)) *RevisionService_Diff_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Handle provides a mock function with given fields: ctx, e
+func (_m *RevisionService) Handle(ctx context.Context, e domain.Event) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Handle")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Event) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevisionService_Handle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Handle'
+type RevisionService_Handle_Call struct {
+	*mock.Call
+}
+
+// Handle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e domain.Event
+func (_e *RevisionService_Expecter) Handle(ctx interface{}, e interface{}) *RevisionService_Handle_Call {
+	return &RevisionService_Handle_Call{Call: _e.mock.On("Handle", ctx, e)}
+}
+
+func (_c *RevisionService_Handle_Call) Run(run func(ctx context.Context, e domain.Event)) *RevisionService_Handle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Event))
+	})
+	return _c
+}
+
+func (_c *RevisionService_Handle_Call) Return(_a0 error) *RevisionService_Handle_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RevisionService_Handle_Call) RunAndReturn(run func(context.Context, domain.Event) error) *RevisionService_Handle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, bookmarkID
+func (_m *RevisionService) List(ctx context.Context, bookmarkID string) ([]*domain.Revision, error) {
+	ret := _m.Called(ctx, bookmarkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*domain.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.Revision, error)); ok {
+		return rf(ctx, bookmarkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.Revision); ok {
+		r0 = rf(ctx, bookmarkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, bookmarkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevisionService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type RevisionService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+func (_e *RevisionService_Expecter) List(ctx interface{}, bookmarkID interface{}) *RevisionService_List_Call {
+	return &RevisionService_List_Call{Call: _e.mock.On("List", ctx, bookmarkID)}
+}
+
+func (_c *RevisionService_List_Call) Run(run func(ctx context.Context, bookmarkID string)) *RevisionService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RevisionService_List_Call) Return(_a0 []*domain.Revision, _a1 error) *RevisionService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RevisionService_List_Call) RunAndReturn(run func(context.Context, string) ([]*domain.Revision, error)) *RevisionService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revert provides a mock function with given fields: ctx, bookmarkID, number
+func (_m *RevisionService) Revert(ctx context.Context, bookmarkID string, number int64) (*domain.Bookmark, error) {
+	ret := _m.Called(ctx, bookmarkID, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revert")
+	}
+
+	var r0 *domain.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (*domain.Bookmark, error)); ok {
+		return rf(ctx, bookmarkID, number)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) *domain.Bookmark); ok {
+		r0 = rf(ctx, bookmarkID, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, bookmarkID, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevisionService_Revert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revert'
+type RevisionService_Revert_Call struct {
+	*mock.Call
+}
+
+// Revert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bookmarkID string
+//   - number int64
+func (_e *RevisionService_Expecter) Revert(ctx interface{}, bookmarkID interface{}, number interface{}) *RevisionService_Revert_Call {
+	return &RevisionService_Revert_Call{Call: _e.mock.On("Revert", ctx, bookmarkID, number)}
+}
+
+func (_c *RevisionService_Revert_Call) Run(run func(ctx context.Context, bookmarkID string, number int64)) *RevisionService_Revert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *RevisionService_Revert_Call) Return(_a0 *domain.Bookmark, _a1 error) *RevisionService_Revert_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RevisionService_Revert_Call) RunAndReturn(run func(context.Context, string, int64) (*domain.Bookmark, error)) *RevisionService_Revert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *RevisionService) Run(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Run")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevisionService_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type RevisionService_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *RevisionService_Expecter) Run(ctx interface{}) *RevisionService_Run_Call {
+	return &RevisionService_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *RevisionService_Run_Call) Run(run func(ctx context.Context)) *RevisionService_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *RevisionService_Run_Call) Return(_a0 error) *RevisionService_Run_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RevisionService_Run_Call) RunAndReturn(run func(context.Context) error) *RevisionService_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRevisionService creates a new instance of RevisionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRevisionService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RevisionService {
+	mock := &RevisionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,239 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SearchIndex is an autogenerated mock type for the SearchIndex type
+type SearchIndex struct {
+	mock.Mock
+}
+
+type SearchIndex_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SearchIndex) EXPECT() *SearchIndex_Expecter {
+	return &SearchIndex_Expecter{mock: &_m.Mock}
+}
+
+// IndexContent provides a mock function with given fields: ctx, id, text
+func (_m *SearchIndex) IndexContent(ctx context.Context, id string, text string) error {
+	ret := _m.Called(ctx, id, text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IndexContent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, text)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchIndex_IndexContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IndexContent'
+type SearchIndex_IndexContent_Call struct {
+	*mock.Call
+}
+
+// IndexContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - text string
+func (_e *SearchIndex_Expecter) IndexContent(ctx interface{}, id interface{}, text interface{}) *SearchIndex_IndexContent_Call {
+	return &SearchIndex_IndexContent_Call{Call: _e.mock.On("IndexContent", ctx, id, text)}
+}
+
+func (_c *SearchIndex_IndexContent_Call) Run(run func(ctx context.Context, id string, text string)) *SearchIndex_IndexContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SearchIndex_IndexContent_Call) Return(_a0 error) *SearchIndex_IndexContent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SearchIndex_IndexContent_Call) RunAndReturn(run func(context.Context, string, string) error) *SearchIndex_IndexContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IndexMetadata provides a mock function with given fields: ctx, b
+func (_m *SearchIndex) IndexMetadata(ctx context.Context, b *domain.Bookmark) error {
+	ret := _m.Called(ctx, b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IndexMetadata")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Bookmark) error); ok {
+		r0 = rf(ctx, b)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchIndex_IndexMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IndexMetadata'
+type SearchIndex_IndexMetadata_Call struct {
+	*mock.Call
+}
+
+// IndexMetadata is a helper method to define mock.On call
+//   - ctx context.Context
+//   - b *domain.Bookmark
+func (_e *SearchIndex_Expecter) IndexMetadata(ctx interface{}, b interface{}) *SearchIndex_IndexMetadata_Call {
+	return &SearchIndex_IndexMetadata_Call{Call: _e.mock.On("IndexMetadata", ctx, b)}
+}
+
+func (_c *SearchIndex_IndexMetadata_Call) Run(run func(ctx context.Context, b *domain.Bookmark)) *SearchIndex_IndexMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Bookmark))
+	})
+	return _c
+}
+
+func (_c *SearchIndex_IndexMetadata_Call) Return(_a0 error) *SearchIndex_IndexMetadata_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SearchIndex_IndexMetadata_Call) RunAndReturn(run func(context.Context, *domain.Bookmark) error) *SearchIndex_IndexMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remove provides a mock function with given fields: ctx, id
+func (_m *SearchIndex) Remove(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchIndex_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type SearchIndex_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *SearchIndex_Expecter) Remove(ctx interface{}, id interface{}) *SearchIndex_Remove_Call {
+	return &SearchIndex_Remove_Call{Call: _e.mock.On("Remove", ctx, id)}
+}
+
+func (_c *SearchIndex_Remove_Call) Run(run func(ctx context.Context, id string)) *SearchIndex_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SearchIndex_Remove_Call) Return(_a0 error) *SearchIndex_Remove_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SearchIndex_Remove_Call) RunAndReturn(run func(context.Context, string) error) *SearchIndex_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function with given fields: ctx, query, limit
+func (_m *SearchIndex) Search(ctx context.Context, query string, limit int) ([]domain.SearchHit, error) {
+	ret := _m.Called(ctx, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []domain.SearchHit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.SearchHit, error)); ok {
+		return rf(ctx, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []domain.SearchHit); ok {
+		r0 = rf(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SearchHit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchIndex_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type SearchIndex_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - limit int
+func (_e *SearchIndex_Expecter) Search(ctx interface{}, query interface{}, limit interface{}) *SearchIndex_Search_Call {
+	return &SearchIndex_Search_Call{Call: _e.mock.On("Search", ctx, query, limit)}
+}
+
+func (_c *SearchIndex_Search_Call) Run(run func(ctx context.Context, query string, limit int)) *SearchIndex_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *SearchIndex_Search_Call) Return(_a0 []domain.SearchHit, _a1 error) *SearchIndex_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SearchIndex_Search_Call) RunAndReturn(run func(context.Context, string, int) ([]domain.SearchHit, error)) *SearchIndex_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSearchIndex creates a new instance of SearchIndex. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSearchIndex(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SearchIndex {
+	mock := &SearchIndex{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SearchService is an autogenerated mock type for the SearchService type
+type SearchService struct {
+	mock.Mock
+}
+
+type SearchService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SearchService) EXPECT() *SearchService_Expecter {
+	return &SearchService_Expecter{mock: &_m.Mock}
+}
+
+// Search provides a mock function with given fields: ctx, query, limit
+func (_m *SearchService) Search(ctx context.Context, query string, limit int) ([]domain.SearchResult, error) {
+	ret := _m.Called(ctx, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []domain.SearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.SearchResult, error)); ok {
+		return rf(ctx, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []domain.SearchResult); ok {
+		r0 = rf(ctx, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchService_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type SearchService_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+//   - limit int
+func (_e *SearchService_Expecter) Search(ctx interface{}, query interface{}, limit interface{}) *SearchService_Search_Call {
+	return &SearchService_Search_Call{Call: _e.mock.On("Search", ctx, query, limit)}
+}
+
+func (_c *SearchService_Search_Call) Run(run func(ctx context.Context, query string, limit int)) *SearchService_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *SearchService_Search_Call) Return(_a0 []domain.SearchResult, _a1 error) *SearchService_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SearchService_Search_Call) RunAndReturn(run func(context.Context, string, int) ([]domain.SearchResult, error)) *SearchService_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSearchService creates a new instance of SearchService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSearchService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SearchService {
+	mock := &SearchService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,6 +5,8 @@ package mocks
 import (
 	http "net/http"
 
+	gen "github.com/etsrc/goprod/internal/infra/transport/rest/gen"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -21,6 +23,112 @@ func (_m *ServerInterface) EXPECT() *ServerInterface_Expecter {
 	return &ServerInterface_Expecter{mock: &_m.Mock}
 }
 
+// ArchiveBookmark provides a mock function with given fields: w, r, id
+func (_m *ServerInterface) ArchiveBookmark(w http.ResponseWriter, r *http.Request, id string) {
+	_m.Called(w, r, id)
+}
+
+// ServerInterface_ArchiveBookmark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveBookmark'
+type ServerInterface_ArchiveBookmark_Call struct {
+	*mock.Call
+}
+
+// ArchiveBookmark is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+func (_e *ServerInterface_Expecter) ArchiveBookmark(w interface{}, r interface{}, id interface{}) *ServerInterface_ArchiveBookmark_Call {
+	return &ServerInterface_ArchiveBookmark_Call{Call: _e.mock.On("ArchiveBookmark", w, r, id)}
+}
+
+func (_c *ServerInterface_ArchiveBookmark_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string)) *ServerInterface_ArchiveBookmark_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_ArchiveBookmark_Call) Return() *ServerInterface_ArchiveBookmark_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_ArchiveBookmark_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string)) *ServerInterface_ArchiveBookmark_Call {
+	_c.Run(run)
+	return _c
+}
+
+// CancelImport provides a mock function with given fields: w, r, id
+func (_m *ServerInterface) CancelImport(w http.ResponseWriter, r *http.Request, id string) {
+	_m.Called(w, r, id)
+}
+
+// ServerInterface_CancelImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelImport'
+type ServerInterface_CancelImport_Call struct {
+	*mock.Call
+}
+
+// CancelImport is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+func (_e *ServerInterface_Expecter) CancelImport(w interface{}, r interface{}, id interface{}) *ServerInterface_CancelImport_Call {
+	return &ServerInterface_CancelImport_Call{Call: _e.mock.On("CancelImport", w, r, id)}
+}
+
+func (_c *ServerInterface_CancelImport_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string)) *ServerInterface_CancelImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_CancelImport_Call) Return() *ServerInterface_CancelImport_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_CancelImport_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string)) *ServerInterface_CancelImport_Call {
+	_c.Run(run)
+	return _c
+}
+
+// CiteBookmark provides a mock function with given fields: w, r, id, params
+func (_m *ServerInterface) CiteBookmark(w http.ResponseWriter, r *http.Request, id string, params gen.CiteBookmarkParams) {
+	_m.Called(w, r, id, params)
+}
+
+// ServerInterface_CiteBookmark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CiteBookmark'
+type ServerInterface_CiteBookmark_Call struct {
+	*mock.Call
+}
+
+// CiteBookmark is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+//   - params gen.CiteBookmarkParams
+func (_e *ServerInterface_Expecter) CiteBookmark(w interface{}, r interface{}, id interface{}, params interface{}) *ServerInterface_CiteBookmark_Call {
+	return &ServerInterface_CiteBookmark_Call{Call: _e.mock.On("CiteBookmark", w, r, id, params)}
+}
+
+func (_c *ServerInterface_CiteBookmark_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string, params gen.CiteBookmarkParams)) *ServerInterface_CiteBookmark_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string), args[3].(gen.CiteBookmarkParams))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_CiteBookmark_Call) Return() *ServerInterface_CiteBookmark_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_CiteBookmark_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string, gen.CiteBookmarkParams)) *ServerInterface_CiteBookmark_Call {
+	_c.Run(run)
+	return _c
+}
+
 // CreateBookmark provides a mock function with given fields: w, r
 func (_m *ServerInterface) CreateBookmark(w http.ResponseWriter, r *http.Request) {
 	_m.Called(w, r)
@@ -55,6 +163,110 @@ func (_c *ServerInterface_CreateBookmark_Call) RunAndReturn(run func(http.Respon
 	return _c
 }
 
+// CreateImport provides a mock function with given fields: w, r
+func (_m *ServerInterface) CreateImport(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ServerInterface_CreateImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateImport'
+type ServerInterface_CreateImport_Call struct {
+	*mock.Call
+}
+
+// CreateImport is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ServerInterface_Expecter) CreateImport(w interface{}, r interface{}) *ServerInterface_CreateImport_Call {
+	return &ServerInterface_CreateImport_Call{Call: _e.mock.On("CreateImport", w, r)}
+}
+
+func (_c *ServerInterface_CreateImport_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ServerInterface_CreateImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_CreateImport_Call) Return() *ServerInterface_CreateImport_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_CreateImport_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ServerInterface_CreateImport_Call {
+	_c.Run(run)
+	return _c
+}
+
+// CreateWebhook provides a mock function with given fields: w, r
+func (_m *ServerInterface) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ServerInterface_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type ServerInterface_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ServerInterface_Expecter) CreateWebhook(w interface{}, r interface{}) *ServerInterface_CreateWebhook_Call {
+	return &ServerInterface_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", w, r)}
+}
+
+func (_c *ServerInterface_CreateWebhook_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ServerInterface_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_CreateWebhook_Call) Return() *ServerInterface_CreateWebhook_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_CreateWebhook_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ServerInterface_CreateWebhook_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteAttachment provides a mock function with given fields: w, r, id, attachmentId
+func (_m *ServerInterface) DeleteAttachment(w http.ResponseWriter, r *http.Request, id string, attachmentId string) {
+	_m.Called(w, r, id, attachmentId)
+}
+
+// ServerInterface_DeleteAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAttachment'
+type ServerInterface_DeleteAttachment_Call struct {
+	*mock.Call
+}
+
+// DeleteAttachment is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+//   - attachmentId string
+func (_e *ServerInterface_Expecter) DeleteAttachment(w interface{}, r interface{}, id interface{}, attachmentId interface{}) *ServerInterface_DeleteAttachment_Call {
+	return &ServerInterface_DeleteAttachment_Call{Call: _e.mock.On("DeleteAttachment", w, r, id, attachmentId)}
+}
+
+func (_c *ServerInterface_DeleteAttachment_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string, attachmentId string)) *ServerInterface_DeleteAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_DeleteAttachment_Call) Return() *ServerInterface_DeleteAttachment_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_DeleteAttachment_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string, string)) *ServerInterface_DeleteAttachment_Call {
+	_c.Run(run)
+	return _c
+}
+
 // DeleteBookmark provides a mock function with given fields: w, r, id
 func (_m *ServerInterface) DeleteBookmark(w http.ResponseWriter, r *http.Request, id string) {
 	_m.Called(w, r, id)
@@ -90,9 +302,115 @@ func (_c *ServerInterface_DeleteBookmark_Call) RunAndReturn(run func(http.Respon
 	return _c
 }
 
-// GetAllBookmarks provides a mock function with given fields: w, r
-func (_m *ServerInterface) GetAllBookmarks(w http.ResponseWriter, r *http.Request) {
-	_m.Called(w, r)
+// DeleteWebhook provides a mock function with given fields: w, r, id
+func (_m *ServerInterface) DeleteWebhook(w http.ResponseWriter, r *http.Request, id string) {
+	_m.Called(w, r, id)
+}
+
+// ServerInterface_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type ServerInterface_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+func (_e *ServerInterface_Expecter) DeleteWebhook(w interface{}, r interface{}, id interface{}) *ServerInterface_DeleteWebhook_Call {
+	return &ServerInterface_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", w, r, id)}
+}
+
+func (_c *ServerInterface_DeleteWebhook_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string)) *ServerInterface_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_DeleteWebhook_Call) Return() *ServerInterface_DeleteWebhook_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_DeleteWebhook_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string)) *ServerInterface_DeleteWebhook_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DiffRevisions provides a mock function with given fields: w, r, id, params
+func (_m *ServerInterface) DiffRevisions(w http.ResponseWriter, r *http.Request, id string, params gen.DiffRevisionsParams) {
+	_m.Called(w, r, id, params)
+}
+
+// ServerInterface_DiffRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiffRevisions'
+type ServerInterface_DiffRevisions_Call struct {
+	*mock.Call
+}
+
+// DiffRevisions is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+//   - params gen.DiffRevisionsParams
+func (_e *ServerInterface_Expecter) DiffRevisions(w interface{}, r interface{}, id interface{}, params interface{}) *ServerInterface_DiffRevisions_Call {
+	return &ServerInterface_DiffRevisions_Call{Call: _e.mock.On("DiffRevisions", w, r, id, params)}
+}
+
+func (_c *ServerInterface_DiffRevisions_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string, params gen.DiffRevisionsParams)) *ServerInterface_DiffRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string), args[3].(gen.DiffRevisionsParams))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_DiffRevisions_Call) Return() *ServerInterface_DiffRevisions_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_DiffRevisions_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string, gen.DiffRevisionsParams)) *ServerInterface_DiffRevisions_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ExportBookmarks provides a mock function with given fields: w, r, params
+func (_m *ServerInterface) ExportBookmarks(w http.ResponseWriter, r *http.Request, params gen.ExportBookmarksParams) {
+	_m.Called(w, r, params)
+}
+
+// ServerInterface_ExportBookmarks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportBookmarks'
+type ServerInterface_ExportBookmarks_Call struct {
+	*mock.Call
+}
+
+// ExportBookmarks is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - params gen.ExportBookmarksParams
+func (_e *ServerInterface_Expecter) ExportBookmarks(w interface{}, r interface{}, params interface{}) *ServerInterface_ExportBookmarks_Call {
+	return &ServerInterface_ExportBookmarks_Call{Call: _e.mock.On("ExportBookmarks", w, r, params)}
+}
+
+func (_c *ServerInterface_ExportBookmarks_Call) Run(run func(w http.ResponseWriter, r *http.Request, params gen.ExportBookmarksParams)) *ServerInterface_ExportBookmarks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(gen.ExportBookmarksParams))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_ExportBookmarks_Call) Return() *ServerInterface_ExportBookmarks_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_ExportBookmarks_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, gen.ExportBookmarksParams)) *ServerInterface_ExportBookmarks_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetAllBookmarks provides a mock function with given fields: w, r, params
+func (_m *ServerInterface) GetAllBookmarks(w http.ResponseWriter, r *http.Request, params gen.GetAllBookmarksParams) {
+	_m.Called(w, r, params)
 }
 
 // ServerInterface_GetAllBookmarks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllBookmarks'
@@ -103,13 +421,14 @@ type ServerInterface_GetAllBookmarks_Call struct {
 // GetAllBookmarks is a helper method to define mock.On call
 //   - w http.ResponseWriter
 //   - r *http.Request
-func (_e *ServerInterface_Expecter) GetAllBookmarks(w interface{}, r interface{}) *ServerInterface_GetAllBookmarks_Call {
-	return &ServerInterface_GetAllBookmarks_Call{Call: _e.mock.On("GetAllBookmarks", w, r)}
+//   - params gen.GetAllBookmarksParams
+func (_e *ServerInterface_Expecter) GetAllBookmarks(w interface{}, r interface{}, params interface{}) *ServerInterface_GetAllBookmarks_Call {
+	return &ServerInterface_GetAllBookmarks_Call{Call: _e.mock.On("GetAllBookmarks", w, r, params)}
 }
 
-func (_c *ServerInterface_GetAllBookmarks_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ServerInterface_GetAllBookmarks_Call {
+func (_c *ServerInterface_GetAllBookmarks_Call) Run(run func(w http.ResponseWriter, r *http.Request, params gen.GetAllBookmarksParams)) *ServerInterface_GetAllBookmarks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(gen.GetAllBookmarksParams))
 	})
 	return _c
 }
@@ -119,42 +438,1025 @@ func (_c *ServerInterface_GetAllBookmarks_Call) Return() *ServerInterface_GetAll
 	return _c
 }
 
-func (_c *ServerInterface_GetAllBookmarks_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ServerInterface_GetAllBookmarks_Call {
+func (_c *ServerInterface_GetAllBookmarks_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, gen.GetAllBookmarksParams)) *ServerInterface_GetAllBookmarks_Call {
 	_c.Run(run)
 	return _c
 }
 
-// GetBookmarkByID provides a mock function with given fields: w, r, id
-func (_m *ServerInterface) GetBookmarkByID(w http.ResponseWriter, r *http.Request, id string) {
-	_m.Called(w, r, id)
+// GetAllFeed provides a mock function with given fields: w, r, params
+func (_m *ServerInterface) GetAllFeed(w http.ResponseWriter, r *http.Request, params gen.GetAllFeedParams) {
+	_m.Called(w, r, params)
 }
 
-// ServerInterface_GetBookmarkByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookmarkByID'
-type ServerInterface_GetBookmarkByID_Call struct {
+// ServerInterface_GetAllFeed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllFeed'
+type ServerInterface_GetAllFeed_Call struct {
 	*mock.Call
 }
 
-// GetBookmarkByID is a helper method to define mock.On call
+// GetAllFeed is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - params gen.GetAllFeedParams
+func (_e *ServerInterface_Expecter) GetAllFeed(w interface{}, r interface{}, params interface{}) *ServerInterface_GetAllFeed_Call {
+	return &ServerInterface_GetAllFeed_Call{Call: _e.mock.On("GetAllFeed", w, r, params)}
+}
+
+func (_c *ServerInterface_GetAllFeed_Call) Run(run func(w http.ResponseWriter, r *http.Request, params gen.GetAllFeedParams)) *ServerInterface_GetAllFeed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(gen.GetAllFeedParams))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_GetAllFeed_Call) Return() *ServerInterface_GetAllFeed_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_GetAllFeed_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, gen.GetAllFeedParams)) *ServerInterface_GetAllFeed_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetAttachment provides a mock function with given fields: w, r, id, attachmentId
+func (_m *ServerInterface) GetAttachment(w http.ResponseWriter, r *http.Request, id string, attachmentId string) {
+	_m.Called(w, r, id, attachmentId)
+}
+
+// ServerInterface_GetAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAttachment'
+type ServerInterface_GetAttachment_Call struct {
+	*mock.Call
+}
+
+// GetAttachment is a helper method to define mock.On call
 //   - w http.ResponseWriter
 //   - r *http.Request
 //   - id string
-func (_e *ServerInterface_Expecter) GetBookmarkByID(w interface{}, r interface{}, id interface{}) *ServerInterface_GetBookmarkByID_Call {
-	return &ServerInterface_GetBookmarkByID_Call{Call: _e.mock.On("GetBookmarkByID", w, r, id)}
+//   - attachmentId string
+func (_e *ServerInterface_Expecter) GetAttachment(w interface{}, r interface{}, id interface{}, attachmentId interface{}) *ServerInterface_GetAttachment_Call {
+	return &ServerInterface_GetAttachment_Call{Call: _e.mock.On("GetAttachment", w, r, id, attachmentId)}
 }
 
-func (_c *ServerInterface_GetBookmarkByID_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string)) *ServerInterface_GetBookmarkByID_Call {
+func (_c *ServerInterface_GetAttachment_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string, attachmentId string)) *ServerInterface_GetAttachment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string))
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string), args[3].(string))
 	})
 	return _c
 }
 
-func (_c *ServerInterface_GetBookmarkByID_Call) Return() *ServerInterface_GetBookmarkByID_Call {
+func (_c *ServerInterface_GetAttachment_Call) Return() *ServerInterface_GetAttachment_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *ServerInterface_GetBookmarkByID_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string)) *ServerInterface_GetBookmarkByID_Call {
+func (_c *ServerInterface_GetAttachment_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string, string)) *ServerInterface_GetAttachment_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetAttachmentThumbnail provides a mock function with given fields: w, r, id, attachmentId
+func (_m *ServerInterface) GetAttachmentThumbnail(w http.ResponseWriter, r *http.Request, id string, attachmentId string) {
+	_m.Called(w, r, id, attachmentId)
+}
+
+// ServerInterface_GetAttachmentThumbnail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAttachmentThumbnail'
+type ServerInterface_GetAttachmentThumbnail_Call struct {
+	*mock.Call
+}
+
+// GetAttachmentThumbnail is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+//   - attachmentId string
+func (_e *ServerInterface_Expecter) GetAttachmentThumbnail(w interface{}, r interface{}, id interface{}, attachmentId interface{}) *ServerInterface_GetAttachmentThumbnail_Call {
+	return &ServerInterface_GetAttachmentThumbnail_Call{Call: _e.mock.On("GetAttachmentThumbnail", w, r, id, attachmentId)}
+}
+
+func (_c *ServerInterface_GetAttachmentThumbnail_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string, attachmentId string)) *ServerInterface_GetAttachmentThumbnail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_GetAttachmentThumbnail_Call) Return() *ServerInterface_GetAttachmentThumbnail_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_GetAttachmentThumbnail_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string, string)) *ServerInterface_GetAttachmentThumbnail_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetBookmarkArchive provides a mock function with given fields: w, r, id, params
+func (_m *ServerInterface) GetBookmarkArchive(w http.ResponseWriter, r *http.Request, id string, params gen.GetBookmarkArchiveParams) {
+	_m.Called(w, r, id, params)
+}
+
+// ServerInterface_GetBookmarkArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookmarkArchive'
+type ServerInterface_GetBookmarkArchive_Call struct {
+	*mock.Call
+}
+
+// GetBookmarkArchive is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+//   - params gen.GetBookmarkArchiveParams
+func (_e *ServerInterface_Expecter) GetBookmarkArchive(w interface{}, r interface{}, id interface{}, params interface{}) *ServerInterface_GetBookmarkArchive_Call {
+	return &ServerInterface_GetBookmarkArchive_Call{Call: _e.mock.On("GetBookmarkArchive", w, r, id, params)}
+}
+
+func (_c *ServerInterface_GetBookmarkArchive_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string, params gen.GetBookmarkArchiveParams)) *ServerInterface_GetBookmarkArchive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string), args[3].(gen.GetBookmarkArchiveParams))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_GetBookmarkArchive_Call) Return() *ServerInterface_GetBookmarkArchive_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_GetBookmarkArchive_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string, gen.GetBookmarkArchiveParams)) *ServerInterface_GetBookmarkArchive_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetBookmarkByID provides a mock function with given fields: w, r, id
+func (_m *ServerInterface) GetBookmarkByID(w http.ResponseWriter, r *http.Request, id string) {
+	_m.Called(w, r, id)
+}
+
+// ServerInterface_GetBookmarkByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookmarkByID'
+type ServerInterface_GetBookmarkByID_Call struct {
+	*mock.Call
+}
+
+// GetBookmarkByID is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+func (_e *ServerInterface_Expecter) GetBookmarkByID(w interface{}, r interface{}, id interface{}) *ServerInterface_GetBookmarkByID_Call {
+	return &ServerInterface_GetBookmarkByID_Call{Call: _e.mock.On("GetBookmarkByID", w, r, id)}
+}
+
+func (_c *ServerInterface_GetBookmarkByID_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string)) *ServerInterface_GetBookmarkByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_GetBookmarkByID_Call) Return() *ServerInterface_GetBookmarkByID_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_GetBookmarkByID_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string)) *ServerInterface_GetBookmarkByID_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetFavicon provides a mock function with given fields: w, r, host, params
+func (_m *ServerInterface) GetFavicon(w http.ResponseWriter, r *http.Request, host string, params gen.GetFaviconParams) {
+	_m.Called(w, r, host, params)
+}
+
+// ServerInterface_GetFavicon_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFavicon'
+type ServerInterface_GetFavicon_Call struct {
+	*mock.Call
+}
+
+// GetFavicon is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - host string
+//   - params gen.GetFaviconParams
+func (_e *ServerInterface_Expecter) GetFavicon(w interface{}, r interface{}, host interface{}, params interface{}) *ServerInterface_GetFavicon_Call {
+	return &ServerInterface_GetFavicon_Call{Call: _e.mock.On("GetFavicon", w, r, host, params)}
+}
+
+func (_c *ServerInterface_GetFavicon_Call) Run(run func(w http.ResponseWriter, r *http.Request, host string, params gen.GetFaviconParams)) *ServerInterface_GetFavicon_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string), args[3].(gen.GetFaviconParams))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_GetFavicon_Call) Return() *ServerInterface_GetFavicon_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_GetFavicon_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string, gen.GetFaviconParams)) *ServerInterface_GetFavicon_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetImport provides a mock function with given fields: w, r, id
+func (_m *ServerInterface) GetImport(w http.ResponseWriter, r *http.Request, id string) {
+	_m.Called(w, r, id)
+}
+
+// ServerInterface_GetImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImport'
+type ServerInterface_GetImport_Call struct {
+	*mock.Call
+}
+
+// GetImport is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+func (_e *ServerInterface_Expecter) GetImport(w interface{}, r interface{}, id interface{}) *ServerInterface_GetImport_Call {
+	return &ServerInterface_GetImport_Call{Call: _e.mock.On("GetImport", w, r, id)}
+}
+
+func (_c *ServerInterface_GetImport_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string)) *ServerInterface_GetImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_GetImport_Call) Return() *ServerInterface_GetImport_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_GetImport_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string)) *ServerInterface_GetImport_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetLinkHealthReport provides a mock function with given fields: w, r
+func (_m *ServerInterface) GetLinkHealthReport(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ServerInterface_GetLinkHealthReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLinkHealthReport'
+type ServerInterface_GetLinkHealthReport_Call struct {
+	*mock.Call
+}
+
+// GetLinkHealthReport is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ServerInterface_Expecter) GetLinkHealthReport(w interface{}, r interface{}) *ServerInterface_GetLinkHealthReport_Call {
+	return &ServerInterface_GetLinkHealthReport_Call{Call: _e.mock.On("GetLinkHealthReport", w, r)}
+}
+
+func (_c *ServerInterface_GetLinkHealthReport_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ServerInterface_GetLinkHealthReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_GetLinkHealthReport_Call) Return() *ServerInterface_GetLinkHealthReport_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_GetLinkHealthReport_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ServerInterface_GetLinkHealthReport_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetTagFeed provides a mock function with given fields: w, r, tag, params
+func (_m *ServerInterface) GetTagFeed(w http.ResponseWriter, r *http.Request, tag string, params gen.GetTagFeedParams) {
+	_m.Called(w, r, tag, params)
+}
+
+// ServerInterface_GetTagFeed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTagFeed'
+type ServerInterface_GetTagFeed_Call struct {
+	*mock.Call
+}
+
+// GetTagFeed is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - tag string
+//   - params gen.GetTagFeedParams
+func (_e *ServerInterface_Expecter) GetTagFeed(w interface{}, r interface{}, tag interface{}, params interface{}) *ServerInterface_GetTagFeed_Call {
+	return &ServerInterface_GetTagFeed_Call{Call: _e.mock.On("GetTagFeed", w, r, tag, params)}
+}
+
+func (_c *ServerInterface_GetTagFeed_Call) Run(run func(w http.ResponseWriter, r *http.Request, tag string, params gen.GetTagFeedParams)) *ServerInterface_GetTagFeed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string), args[3].(gen.GetTagFeedParams))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_GetTagFeed_Call) Return() *ServerInterface_GetTagFeed_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_GetTagFeed_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string, gen.GetTagFeedParams)) *ServerInterface_GetTagFeed_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetWebhook provides a mock function with given fields: w, r, id
+func (_m *ServerInterface) GetWebhook(w http.ResponseWriter, r *http.Request, id string) {
+	_m.Called(w, r, id)
+}
+
+// ServerInterface_GetWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhook'
+type ServerInterface_GetWebhook_Call struct {
+	*mock.Call
+}
+
+// GetWebhook is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+func (_e *ServerInterface_Expecter) GetWebhook(w interface{}, r interface{}, id interface{}) *ServerInterface_GetWebhook_Call {
+	return &ServerInterface_GetWebhook_Call{Call: _e.mock.On("GetWebhook", w, r, id)}
+}
+
+func (_c *ServerInterface_GetWebhook_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string)) *ServerInterface_GetWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_GetWebhook_Call) Return() *ServerInterface_GetWebhook_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_GetWebhook_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string)) *ServerInterface_GetWebhook_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetWebhookDelivery provides a mock function with given fields: w, r, id, deliveryId
+func (_m *ServerInterface) GetWebhookDelivery(w http.ResponseWriter, r *http.Request, id string, deliveryId string) {
+	_m.Called(w, r, id, deliveryId)
+}
+
+// ServerInterface_GetWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookDelivery'
+type ServerInterface_GetWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// GetWebhookDelivery is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+//   - deliveryId string
+func (_e *ServerInterface_Expecter) GetWebhookDelivery(w interface{}, r interface{}, id interface{}, deliveryId interface{}) *ServerInterface_GetWebhookDelivery_Call {
+	return &ServerInterface_GetWebhookDelivery_Call{Call: _e.mock.On("GetWebhookDelivery", w, r, id, deliveryId)}
+}
+
+func (_c *ServerInterface_GetWebhookDelivery_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string, deliveryId string)) *ServerInterface_GetWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_GetWebhookDelivery_Call) Return() *ServerInterface_GetWebhookDelivery_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_GetWebhookDelivery_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string, string)) *ServerInterface_GetWebhookDelivery_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ListAttachments provides a mock function with given fields: w, r, id
+func (_m *ServerInterface) ListAttachments(w http.ResponseWriter, r *http.Request, id string) {
+	_m.Called(w, r, id)
+}
+
+// ServerInterface_ListAttachments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAttachments'
+type ServerInterface_ListAttachments_Call struct {
+	*mock.Call
+}
+
+// ListAttachments is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+func (_e *ServerInterface_Expecter) ListAttachments(w interface{}, r interface{}, id interface{}) *ServerInterface_ListAttachments_Call {
+	return &ServerInterface_ListAttachments_Call{Call: _e.mock.On("ListAttachments", w, r, id)}
+}
+
+func (_c *ServerInterface_ListAttachments_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string)) *ServerInterface_ListAttachments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_ListAttachments_Call) Return() *ServerInterface_ListAttachments_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_ListAttachments_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string)) *ServerInterface_ListAttachments_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ListRevisions provides a mock function with given fields: w, r, id
+func (_m *ServerInterface) ListRevisions(w http.ResponseWriter, r *http.Request, id string) {
+	_m.Called(w, r, id)
+}
+
+// ServerInterface_ListRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRevisions'
+type ServerInterface_ListRevisions_Call struct {
+	*mock.Call
+}
+
+// ListRevisions is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+func (_e *ServerInterface_Expecter) ListRevisions(w interface{}, r interface{}, id interface{}) *ServerInterface_ListRevisions_Call {
+	return &ServerInterface_ListRevisions_Call{Call: _e.mock.On("ListRevisions", w, r, id)}
+}
+
+func (_c *ServerInterface_ListRevisions_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string)) *ServerInterface_ListRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_ListRevisions_Call) Return() *ServerInterface_ListRevisions_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_ListRevisions_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string)) *ServerInterface_ListRevisions_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ListTrash provides a mock function with given fields: w, r
+func (_m *ServerInterface) ListTrash(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ServerInterface_ListTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTrash'
+type ServerInterface_ListTrash_Call struct {
+	*mock.Call
+}
+
+// ListTrash is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ServerInterface_Expecter) ListTrash(w interface{}, r interface{}) *ServerInterface_ListTrash_Call {
+	return &ServerInterface_ListTrash_Call{Call: _e.mock.On("ListTrash", w, r)}
+}
+
+func (_c *ServerInterface_ListTrash_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ServerInterface_ListTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_ListTrash_Call) Return() *ServerInterface_ListTrash_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_ListTrash_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ServerInterface_ListTrash_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ListWebhookDeliveries provides a mock function with given fields: w, r, id
+func (_m *ServerInterface) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, id string) {
+	_m.Called(w, r, id)
+}
+
+// ServerInterface_ListWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeliveries'
+type ServerInterface_ListWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListWebhookDeliveries is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+func (_e *ServerInterface_Expecter) ListWebhookDeliveries(w interface{}, r interface{}, id interface{}) *ServerInterface_ListWebhookDeliveries_Call {
+	return &ServerInterface_ListWebhookDeliveries_Call{Call: _e.mock.On("ListWebhookDeliveries", w, r, id)}
+}
+
+func (_c *ServerInterface_ListWebhookDeliveries_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string)) *ServerInterface_ListWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_ListWebhookDeliveries_Call) Return() *ServerInterface_ListWebhookDeliveries_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_ListWebhookDeliveries_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string)) *ServerInterface_ListWebhookDeliveries_Call {
+	_c.Run(run)
+	return _c
+}
+
+// ListWebhooks provides a mock function with given fields: w, r
+func (_m *ServerInterface) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ServerInterface_ListWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhooks'
+type ServerInterface_ListWebhooks_Call struct {
+	*mock.Call
+}
+
+// ListWebhooks is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ServerInterface_Expecter) ListWebhooks(w interface{}, r interface{}) *ServerInterface_ListWebhooks_Call {
+	return &ServerInterface_ListWebhooks_Call{Call: _e.mock.On("ListWebhooks", w, r)}
+}
+
+func (_c *ServerInterface_ListWebhooks_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ServerInterface_ListWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_ListWebhooks_Call) Return() *ServerInterface_ListWebhooks_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_ListWebhooks_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ServerInterface_ListWebhooks_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PullChanges provides a mock function with given fields: w, r, params
+func (_m *ServerInterface) PullChanges(w http.ResponseWriter, r *http.Request, params gen.PullChangesParams) {
+	_m.Called(w, r, params)
+}
+
+// ServerInterface_PullChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PullChanges'
+type ServerInterface_PullChanges_Call struct {
+	*mock.Call
+}
+
+// PullChanges is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - params gen.PullChangesParams
+func (_e *ServerInterface_Expecter) PullChanges(w interface{}, r interface{}, params interface{}) *ServerInterface_PullChanges_Call {
+	return &ServerInterface_PullChanges_Call{Call: _e.mock.On("PullChanges", w, r, params)}
+}
+
+func (_c *ServerInterface_PullChanges_Call) Run(run func(w http.ResponseWriter, r *http.Request, params gen.PullChangesParams)) *ServerInterface_PullChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(gen.PullChangesParams))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_PullChanges_Call) Return() *ServerInterface_PullChanges_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_PullChanges_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, gen.PullChangesParams)) *ServerInterface_PullChanges_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PurgeBookmark provides a mock function with given fields: w, r, id
+func (_m *ServerInterface) PurgeBookmark(w http.ResponseWriter, r *http.Request, id string) {
+	_m.Called(w, r, id)
+}
+
+// ServerInterface_PurgeBookmark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeBookmark'
+type ServerInterface_PurgeBookmark_Call struct {
+	*mock.Call
+}
+
+// PurgeBookmark is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+func (_e *ServerInterface_Expecter) PurgeBookmark(w interface{}, r interface{}, id interface{}) *ServerInterface_PurgeBookmark_Call {
+	return &ServerInterface_PurgeBookmark_Call{Call: _e.mock.On("PurgeBookmark", w, r, id)}
+}
+
+func (_c *ServerInterface_PurgeBookmark_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string)) *ServerInterface_PurgeBookmark_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_PurgeBookmark_Call) Return() *ServerInterface_PurgeBookmark_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_PurgeBookmark_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string)) *ServerInterface_PurgeBookmark_Call {
+	_c.Run(run)
+	return _c
+}
+
+// PushChanges provides a mock function with given fields: w, r
+func (_m *ServerInterface) PushChanges(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ServerInterface_PushChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PushChanges'
+type ServerInterface_PushChanges_Call struct {
+	*mock.Call
+}
+
+// PushChanges is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ServerInterface_Expecter) PushChanges(w interface{}, r interface{}) *ServerInterface_PushChanges_Call {
+	return &ServerInterface_PushChanges_Call{Call: _e.mock.On("PushChanges", w, r)}
+}
+
+func (_c *ServerInterface_PushChanges_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ServerInterface_PushChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_PushChanges_Call) Return() *ServerInterface_PushChanges_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_PushChanges_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ServerInterface_PushChanges_Call {
+	_c.Run(run)
+	return _c
+}
+
+// QueryAuditLog provides a mock function with given fields: w, r, params
+func (_m *ServerInterface) QueryAuditLog(w http.ResponseWriter, r *http.Request, params gen.QueryAuditLogParams) {
+	_m.Called(w, r, params)
+}
+
+// ServerInterface_QueryAuditLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryAuditLog'
+type ServerInterface_QueryAuditLog_Call struct {
+	*mock.Call
+}
+
+// QueryAuditLog is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - params gen.QueryAuditLogParams
+func (_e *ServerInterface_Expecter) QueryAuditLog(w interface{}, r interface{}, params interface{}) *ServerInterface_QueryAuditLog_Call {
+	return &ServerInterface_QueryAuditLog_Call{Call: _e.mock.On("QueryAuditLog", w, r, params)}
+}
+
+func (_c *ServerInterface_QueryAuditLog_Call) Run(run func(w http.ResponseWriter, r *http.Request, params gen.QueryAuditLogParams)) *ServerInterface_QueryAuditLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(gen.QueryAuditLogParams))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_QueryAuditLog_Call) Return() *ServerInterface_QueryAuditLog_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_QueryAuditLog_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, gen.QueryAuditLogParams)) *ServerInterface_QueryAuditLog_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RedeliverWebhookDelivery provides a mock function with given fields: w, r, id, deliveryId
+func (_m *ServerInterface) RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request, id string, deliveryId string) {
+	_m.Called(w, r, id, deliveryId)
+}
+
+// ServerInterface_RedeliverWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RedeliverWebhookDelivery'
+type ServerInterface_RedeliverWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// RedeliverWebhookDelivery is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+//   - deliveryId string
+func (_e *ServerInterface_Expecter) RedeliverWebhookDelivery(w interface{}, r interface{}, id interface{}, deliveryId interface{}) *ServerInterface_RedeliverWebhookDelivery_Call {
+	return &ServerInterface_RedeliverWebhookDelivery_Call{Call: _e.mock.On("RedeliverWebhookDelivery", w, r, id, deliveryId)}
+}
+
+func (_c *ServerInterface_RedeliverWebhookDelivery_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string, deliveryId string)) *ServerInterface_RedeliverWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_RedeliverWebhookDelivery_Call) Return() *ServerInterface_RedeliverWebhookDelivery_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_RedeliverWebhookDelivery_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string, string)) *ServerInterface_RedeliverWebhookDelivery_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RestoreBookmark provides a mock function with given fields: w, r, id
+func (_m *ServerInterface) RestoreBookmark(w http.ResponseWriter, r *http.Request, id string) {
+	_m.Called(w, r, id)
+}
+
+// ServerInterface_RestoreBookmark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreBookmark'
+type ServerInterface_RestoreBookmark_Call struct {
+	*mock.Call
+}
+
+// RestoreBookmark is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+func (_e *ServerInterface_Expecter) RestoreBookmark(w interface{}, r interface{}, id interface{}) *ServerInterface_RestoreBookmark_Call {
+	return &ServerInterface_RestoreBookmark_Call{Call: _e.mock.On("RestoreBookmark", w, r, id)}
+}
+
+func (_c *ServerInterface_RestoreBookmark_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string)) *ServerInterface_RestoreBookmark_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_RestoreBookmark_Call) Return() *ServerInterface_RestoreBookmark_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_RestoreBookmark_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string)) *ServerInterface_RestoreBookmark_Call {
+	_c.Run(run)
+	return _c
+}
+
+// RevertRevision provides a mock function with given fields: w, r, id, rev
+func (_m *ServerInterface) RevertRevision(w http.ResponseWriter, r *http.Request, id string, rev int64) {
+	_m.Called(w, r, id, rev)
+}
+
+// ServerInterface_RevertRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevertRevision'
+type ServerInterface_RevertRevision_Call struct {
+	*mock.Call
+}
+
+// RevertRevision is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+//   - rev int64
+func (_e *ServerInterface_Expecter) RevertRevision(w interface{}, r interface{}, id interface{}, rev interface{}) *ServerInterface_RevertRevision_Call {
+	return &ServerInterface_RevertRevision_Call{Call: _e.mock.On("RevertRevision", w, r, id, rev)}
+}
+
+func (_c *ServerInterface_RevertRevision_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string, rev int64)) *ServerInterface_RevertRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_RevertRevision_Call) Return() *ServerInterface_RevertRevision_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_RevertRevision_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string, int64)) *ServerInterface_RevertRevision_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SearchBookmarks provides a mock function with given fields: w, r, params
+func (_m *ServerInterface) SearchBookmarks(w http.ResponseWriter, r *http.Request, params gen.SearchBookmarksParams) {
+	_m.Called(w, r, params)
+}
+
+// ServerInterface_SearchBookmarks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchBookmarks'
+type ServerInterface_SearchBookmarks_Call struct {
+	*mock.Call
+}
+
+// SearchBookmarks is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - params gen.SearchBookmarksParams
+func (_e *ServerInterface_Expecter) SearchBookmarks(w interface{}, r interface{}, params interface{}) *ServerInterface_SearchBookmarks_Call {
+	return &ServerInterface_SearchBookmarks_Call{Call: _e.mock.On("SearchBookmarks", w, r, params)}
+}
+
+func (_c *ServerInterface_SearchBookmarks_Call) Run(run func(w http.ResponseWriter, r *http.Request, params gen.SearchBookmarksParams)) *ServerInterface_SearchBookmarks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(gen.SearchBookmarksParams))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_SearchBookmarks_Call) Return() *ServerInterface_SearchBookmarks_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_SearchBookmarks_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, gen.SearchBookmarksParams)) *ServerInterface_SearchBookmarks_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SearchFeed provides a mock function with given fields: w, r, params
+func (_m *ServerInterface) SearchFeed(w http.ResponseWriter, r *http.Request, params gen.SearchFeedParams) {
+	_m.Called(w, r, params)
+}
+
+// ServerInterface_SearchFeed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchFeed'
+type ServerInterface_SearchFeed_Call struct {
+	*mock.Call
+}
+
+// SearchFeed is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - params gen.SearchFeedParams
+func (_e *ServerInterface_Expecter) SearchFeed(w interface{}, r interface{}, params interface{}) *ServerInterface_SearchFeed_Call {
+	return &ServerInterface_SearchFeed_Call{Call: _e.mock.On("SearchFeed", w, r, params)}
+}
+
+func (_c *ServerInterface_SearchFeed_Call) Run(run func(w http.ResponseWriter, r *http.Request, params gen.SearchFeedParams)) *ServerInterface_SearchFeed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(gen.SearchFeedParams))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_SearchFeed_Call) Return() *ServerInterface_SearchFeed_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_SearchFeed_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, gen.SearchFeedParams)) *ServerInterface_SearchFeed_Call {
+	_c.Run(run)
+	return _c
+}
+
+// StreamEvents provides a mock function with given fields: w, r, params
+func (_m *ServerInterface) StreamEvents(w http.ResponseWriter, r *http.Request, params gen.StreamEventsParams) {
+	_m.Called(w, r, params)
+}
+
+// ServerInterface_StreamEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamEvents'
+type ServerInterface_StreamEvents_Call struct {
+	*mock.Call
+}
+
+// StreamEvents is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - params gen.StreamEventsParams
+func (_e *ServerInterface_Expecter) StreamEvents(w interface{}, r interface{}, params interface{}) *ServerInterface_StreamEvents_Call {
+	return &ServerInterface_StreamEvents_Call{Call: _e.mock.On("StreamEvents", w, r, params)}
+}
+
+func (_c *ServerInterface_StreamEvents_Call) Run(run func(w http.ResponseWriter, r *http.Request, params gen.StreamEventsParams)) *ServerInterface_StreamEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(gen.StreamEventsParams))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_StreamEvents_Call) Return() *ServerInterface_StreamEvents_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_StreamEvents_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, gen.StreamEventsParams)) *ServerInterface_StreamEvents_Call {
+	_c.Run(run)
+	return _c
+}
+
+// UpdateWebhook provides a mock function with given fields: w, r, id
+func (_m *ServerInterface) UpdateWebhook(w http.ResponseWriter, r *http.Request, id string) {
+	_m.Called(w, r, id)
+}
+
+// ServerInterface_UpdateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebhook'
+type ServerInterface_UpdateWebhook_Call struct {
+	*mock.Call
+}
+
+// UpdateWebhook is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+func (_e *ServerInterface_Expecter) UpdateWebhook(w interface{}, r interface{}, id interface{}) *ServerInterface_UpdateWebhook_Call {
+	return &ServerInterface_UpdateWebhook_Call{Call: _e.mock.On("UpdateWebhook", w, r, id)}
+}
+
+func (_c *ServerInterface_UpdateWebhook_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string)) *ServerInterface_UpdateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_UpdateWebhook_Call) Return() *ServerInterface_UpdateWebhook_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_UpdateWebhook_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string)) *ServerInterface_UpdateWebhook_Call {
+	_c.Run(run)
+	return _c
+}
+
+// UploadAttachment provides a mock function with given fields: w, r, id
+func (_m *ServerInterface) UploadAttachment(w http.ResponseWriter, r *http.Request, id string) {
+	_m.Called(w, r, id)
+}
+
+// ServerInterface_UploadAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadAttachment'
+type ServerInterface_UploadAttachment_Call struct {
+	*mock.Call
+}
+
+// UploadAttachment is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - id string
+func (_e *ServerInterface_Expecter) UploadAttachment(w interface{}, r interface{}, id interface{}) *ServerInterface_UploadAttachment_Call {
+	return &ServerInterface_UploadAttachment_Call{Call: _e.mock.On("UploadAttachment", w, r, id)}
+}
+
+func (_c *ServerInterface_UploadAttachment_Call) Run(run func(w http.ResponseWriter, r *http.Request, id string)) *ServerInterface_UploadAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_UploadAttachment_Call) Return() *ServerInterface_UploadAttachment_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_UploadAttachment_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, string)) *ServerInterface_UploadAttachment_Call {
+	_c.Run(run)
+	return _c
+}
+
+// VerifyAuditLog provides a mock function with given fields: w, r
+func (_m *ServerInterface) VerifyAuditLog(w http.ResponseWriter, r *http.Request) {
+	_m.Called(w, r)
+}
+
+// ServerInterface_VerifyAuditLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyAuditLog'
+type ServerInterface_VerifyAuditLog_Call struct {
+	*mock.Call
+}
+
+// VerifyAuditLog is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+func (_e *ServerInterface_Expecter) VerifyAuditLog(w interface{}, r interface{}) *ServerInterface_VerifyAuditLog_Call {
+	return &ServerInterface_VerifyAuditLog_Call{Call: _e.mock.On("VerifyAuditLog", w, r)}
+}
+
+func (_c *ServerInterface_VerifyAuditLog_Call) Run(run func(w http.ResponseWriter, r *http.Request)) *ServerInterface_VerifyAuditLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ServerInterface_VerifyAuditLog_Call) Return() *ServerInterface_VerifyAuditLog_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ServerInterface_VerifyAuditLog_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request)) *ServerInterface_VerifyAuditLog_Call {
 	_c.Run(run)
 	return _c
 }
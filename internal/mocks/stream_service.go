@@ -0,0 +1,137 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/etsrc/goprod/internal/service"
+)
+
+// StreamService is an autogenerated mock type for the StreamService type
+type StreamService struct {
+	mock.Mock
+}
+
+type StreamService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *StreamService) EXPECT() *StreamService_Expecter {
+	return &StreamService_Expecter{mock: &_m.Mock}
+}
+
+// Handle provides a mock function with given fields: ctx, e
+func (_m *StreamService) Handle(ctx context.Context, e domain.Event) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Handle")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Event) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StreamService_Handle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Handle'
+type StreamService_Handle_Call struct {
+	*mock.Call
+}
+
+// Handle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e domain.Event
+func (_e *StreamService_Expecter) Handle(ctx interface{}, e interface{}) *StreamService_Handle_Call {
+	return &StreamService_Handle_Call{Call: _e.mock.On("Handle", ctx, e)}
+}
+
+func (_c *StreamService_Handle_Call) Run(run func(ctx context.Context, e domain.Event)) *StreamService_Handle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Event))
+	})
+	return _c
+}
+
+func (_c *StreamService_Handle_Call) Return(_a0 error) *StreamService_Handle_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StreamService_Handle_Call) RunAndReturn(run func(context.Context, domain.Event) error) *StreamService_Handle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscribe provides a mock function with given fields: ctx, grant, filter, lastEventID
+func (_m *StreamService) Subscribe(ctx context.Context, grant service.StreamGrant, filter domain.BookmarkFilter, lastEventID string) *service.StreamSubscription {
+	ret := _m.Called(ctx, grant, filter, lastEventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 *service.StreamSubscription
+	if rf, ok := ret.Get(0).(func(context.Context, service.StreamGrant, domain.BookmarkFilter, string) *service.StreamSubscription); ok {
+		r0 = rf(ctx, grant, filter, lastEventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.StreamSubscription)
+		}
+	}
+
+	return r0
+}
+
+// StreamService_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type StreamService_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - grant service.StreamGrant
+//   - filter domain.BookmarkFilter
+//   - lastEventID string
+func (_e *StreamService_Expecter) Subscribe(ctx interface{}, grant interface{}, filter interface{}, lastEventID interface{}) *StreamService_Subscribe_Call {
+	return &StreamService_Subscribe_Call{Call: _e.mock.On("Subscribe", ctx, grant, filter, lastEventID)}
+}
+
+func (_c *StreamService_Subscribe_Call) Run(run func(ctx context.Context, grant service.StreamGrant, filter domain.BookmarkFilter, lastEventID string)) *StreamService_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(service.StreamGrant), args[2].(domain.BookmarkFilter), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *StreamService_Subscribe_Call) Return(_a0 *service.StreamSubscription) *StreamService_Subscribe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StreamService_Subscribe_Call) RunAndReturn(run func(context.Context, service.StreamGrant, domain.BookmarkFilter, string) *service.StreamSubscription) *StreamService_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewStreamService creates a new instance of StreamService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStreamService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StreamService {
+	mock := &StreamService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,249 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SyncService is an autogenerated mock type for the SyncService type
+type SyncService struct {
+	mock.Mock
+}
+
+type SyncService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SyncService) EXPECT() *SyncService_Expecter {
+	return &SyncService_Expecter{mock: &_m.Mock}
+}
+
+// Handle provides a mock function with given fields: ctx, e
+func (_m *SyncService) Handle(ctx context.Context, e domain.Event) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Handle")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Event) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SyncService_Handle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Handle'
+type SyncService_Handle_Call struct {
+	*mock.Call
+}
+
+// Handle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e domain.Event
+func (_e *SyncService_Expecter) Handle(ctx interface{}, e interface{}) *SyncService_Handle_Call {
+	return &SyncService_Handle_Call{Call: _e.mock.On("Handle", ctx, e)}
+}
+
+func (_c *SyncService_Handle_Call) Run(run func(ctx context.Context, e domain.Event)) *SyncService_Handle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Event))
+	})
+	return _c
+}
+
+func (_c *SyncService_Handle_Call) Return(_a0 error) *SyncService_Handle_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SyncService_Handle_Call) RunAndReturn(run func(context.Context, domain.Event) error) *SyncService_Handle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Pull provides a mock function with given fields: ctx, token, limit
+func (_m *SyncService) Pull(ctx context.Context, token string, limit int) (*domain.SyncPage, error) {
+	ret := _m.Called(ctx, token, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pull")
+	}
+
+	var r0 *domain.SyncPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*domain.SyncPage, error)); ok {
+		return rf(ctx, token, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *domain.SyncPage); ok {
+		r0 = rf(ctx, token, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SyncPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, token, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncService_Pull_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pull'
+type SyncService_Pull_Call struct {
+	*mock.Call
+}
+
+// Pull is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+//   - limit int
+func (_e *SyncService_Expecter) Pull(ctx interface{}, token interface{}, limit interface{}) *SyncService_Pull_Call {
+	return &SyncService_Pull_Call{Call: _e.mock.On("Pull", ctx, token, limit)}
+}
+
+func (_c *SyncService_Pull_Call) Run(run func(ctx context.Context, token string, limit int)) *SyncService_Pull_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *SyncService_Pull_Call) Return(_a0 *domain.SyncPage, _a1 error) *SyncService_Pull_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SyncService_Pull_Call) RunAndReturn(run func(context.Context, string, int) (*domain.SyncPage, error)) *SyncService_Pull_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Push provides a mock function with given fields: ctx, changes
+func (_m *SyncService) Push(ctx context.Context, changes []domain.SyncChange) ([]domain.SyncResult, error) {
+	ret := _m.Called(ctx, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Push")
+	}
+
+	var r0 []domain.SyncResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.SyncChange) ([]domain.SyncResult, error)); ok {
+		return rf(ctx, changes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.SyncChange) []domain.SyncResult); ok {
+		r0 = rf(ctx, changes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SyncResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.SyncChange) error); ok {
+		r1 = rf(ctx, changes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncService_Push_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Push'
+type SyncService_Push_Call struct {
+	*mock.Call
+}
+
+// Push is a helper method to define mock.On call
+//   - ctx context.Context
+//   - changes []domain.SyncChange
+func (_e *SyncService_Expecter) Push(ctx interface{}, changes interface{}) *SyncService_Push_Call {
+	return &SyncService_Push_Call{Call: _e.mock.On("Push", ctx, changes)}
+}
+
+func (_c *SyncService_Push_Call) Run(run func(ctx context.Context, changes []domain.SyncChange)) *SyncService_Push_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.SyncChange))
+	})
+	return _c
+}
+
+func (_c *SyncService_Push_Call) Return(_a0 []domain.SyncResult, _a1 error) *SyncService_Push_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SyncService_Push_Call) RunAndReturn(run func(context.Context, []domain.SyncChange) ([]domain.SyncResult, error)) *SyncService_Push_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *SyncService) Run(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Run")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SyncService_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type SyncService_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *SyncService_Expecter) Run(ctx interface{}) *SyncService_Run_Call {
+	return &SyncService_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *SyncService_Run_Call) Run(run func(ctx context.Context)) *SyncService_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *SyncService_Run_Call) Return(_a0 error) *SyncService_Run_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SyncService_Run_Call) RunAndReturn(run func(context.Context) error) *SyncService_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSyncService creates a new instance of SyncService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSyncService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SyncService {
+	mock := &SyncService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,105 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	image "image"
+
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Thumbnailer is an autogenerated mock type for the Thumbnailer type
+type Thumbnailer struct {
+	mock.Mock
+}
+
+type Thumbnailer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Thumbnailer) EXPECT() *Thumbnailer_Expecter {
+	return &Thumbnailer_Expecter{mock: &_m.Mock}
+}
+
+// Thumbnail provides a mock function with given fields: ctx, w, r
+func (_m *Thumbnailer) Thumbnail(ctx context.Context, w io.Writer, r io.Reader) (string, image.Point, error) {
+	ret := _m.Called(ctx, w, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Thumbnail")
+	}
+
+	var r0 string
+	var r1 image.Point
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Writer, io.Reader) (string, image.Point, error)); ok {
+		return rf(ctx, w, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, io.Writer, io.Reader) string); ok {
+		r0 = rf(ctx, w, r)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, io.Writer, io.Reader) image.Point); ok {
+		r1 = rf(ctx, w, r)
+	} else {
+		r1 = ret.Get(1).(image.Point)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, io.Writer, io.Reader) error); ok {
+		r2 = rf(ctx, w, r)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Thumbnailer_Thumbnail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Thumbnail'
+type Thumbnailer_Thumbnail_Call struct {
+	*mock.Call
+}
+
+// Thumbnail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - w io.Writer
+//   - r io.Reader
+func (_e *Thumbnailer_Expecter) Thumbnail(ctx interface{}, w interface{}, r interface{}) *Thumbnailer_Thumbnail_Call {
+	return &Thumbnailer_Thumbnail_Call{Call: _e.mock.On("Thumbnail", ctx, w, r)}
+}
+
+func (_c *Thumbnailer_Thumbnail_Call) Run(run func(ctx context.Context, w io.Writer, r io.Reader)) *Thumbnailer_Thumbnail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(io.Writer), args[2].(io.Reader))
+	})
+	return _c
+}
+
+func (_c *Thumbnailer_Thumbnail_Call) Return(contentType string, size image.Point, err error) *Thumbnailer_Thumbnail_Call {
+	_c.Call.Return(contentType, size, err)
+	return _c
+}
+
+func (_c *Thumbnailer_Thumbnail_Call) RunAndReturn(run func(context.Context, io.Writer, io.Reader) (string, image.Point, error)) *Thumbnailer_Thumbnail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewThumbnailer creates a new instance of Thumbnailer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewThumbnailer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Thumbnailer {
+	mock := &Thumbnailer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
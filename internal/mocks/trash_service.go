@@ -0,0 +1,138 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TrashService is an autogenerated mock type for the TrashService type
+type TrashService struct {
+	mock.Mock
+}
+
+type TrashService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TrashService) EXPECT() *TrashService_Expecter {
+	return &TrashService_Expecter{mock: &_m.Mock}
+}
+
+// PurgeExpired provides a mock function with given fields: ctx
+func (_m *TrashService) PurgeExpired(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeExpired")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TrashService_PurgeExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeExpired'
+type TrashService_PurgeExpired_Call struct {
+	*mock.Call
+}
+
+// PurgeExpired is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *TrashService_Expecter) PurgeExpired(ctx interface{}) *TrashService_PurgeExpired_Call {
+	return &TrashService_PurgeExpired_Call{Call: _e.mock.On("PurgeExpired", ctx)}
+}
+
+func (_c *TrashService_PurgeExpired_Call) Run(run func(ctx context.Context)) *TrashService_PurgeExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *TrashService_PurgeExpired_Call) Return(_a0 int, _a1 error) *TrashService_PurgeExpired_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TrashService_PurgeExpired_Call) RunAndReturn(run func(context.Context) (int, error)) *TrashService_PurgeExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *TrashService) Run(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Run")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TrashService_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type TrashService_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *TrashService_Expecter) Run(ctx interface{}) *TrashService_Run_Call {
+	return &TrashService_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *TrashService_Run_Call) Run(run func(ctx context.Context)) *TrashService_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *TrashService_Run_Call) Return(_a0 error) *TrashService_Run_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TrashService_Run_Call) RunAndReturn(run func(context.Context) error) *TrashService_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTrashService creates a new instance of TrashService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTrashService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TrashService {
+	mock := &TrashService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,407 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// WebhookDeliveryRepository is an autogenerated mock type for the WebhookDeliveryRepository type
+type WebhookDeliveryRepository struct {
+	mock.Mock
+}
+
+type WebhookDeliveryRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookDeliveryRepository) EXPECT() *WebhookDeliveryRepository_Expecter {
+	return &WebhookDeliveryRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, d
+func (_m *WebhookDeliveryRepository) Create(ctx context.Context, d *domain.WebhookDelivery) error {
+	ret := _m.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.WebhookDelivery) error); ok {
+		r0 = rf(ctx, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type WebhookDeliveryRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - d *domain.WebhookDelivery
+func (_e *WebhookDeliveryRepository_Expecter) Create(ctx interface{}, d interface{}) *WebhookDeliveryRepository_Create_Call {
+	return &WebhookDeliveryRepository_Create_Call{Call: _e.mock.On("Create", ctx, d)}
+}
+
+func (_c *WebhookDeliveryRepository_Create_Call) Run(run func(ctx context.Context, d *domain.WebhookDelivery)) *WebhookDeliveryRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.WebhookDelivery))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_Create_Call) Return(_a0 error) *WebhookDeliveryRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_Create_Call) RunAndReturn(run func(context.Context, *domain.WebhookDelivery) error) *WebhookDeliveryRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByWebhook provides a mock function with given fields: ctx, webhookID
+func (_m *WebhookDeliveryRepository) DeleteByWebhook(ctx context.Context, webhookID string) error {
+	ret := _m.Called(ctx, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, webhookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryRepository_DeleteByWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByWebhook'
+type WebhookDeliveryRepository_DeleteByWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteByWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+func (_e *WebhookDeliveryRepository_Expecter) DeleteByWebhook(ctx interface{}, webhookID interface{}) *WebhookDeliveryRepository_DeleteByWebhook_Call {
+	return &WebhookDeliveryRepository_DeleteByWebhook_Call{Call: _e.mock.On("DeleteByWebhook", ctx, webhookID)}
+}
+
+func (_c *WebhookDeliveryRepository_DeleteByWebhook_Call) Run(run func(ctx context.Context, webhookID string)) *WebhookDeliveryRepository_DeleteByWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_DeleteByWebhook_Call) Return(_a0 error) *WebhookDeliveryRepository_DeleteByWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_DeleteByWebhook_Call) RunAndReturn(run func(context.Context, string) error) *WebhookDeliveryRepository_DeleteByWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Due provides a mock function with given fields: ctx, now, limit
+func (_m *WebhookDeliveryRepository) Due(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Due")
+	}
+
+	var r0 []*domain.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]*domain.WebhookDelivery, error)); ok {
+		return rf(ctx, now, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []*domain.WebhookDelivery); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookDeliveryRepository_Due_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Due'
+type WebhookDeliveryRepository_Due_Call struct {
+	*mock.Call
+}
+
+// Due is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit int
+func (_e *WebhookDeliveryRepository_Expecter) Due(ctx interface{}, now interface{}, limit interface{}) *WebhookDeliveryRepository_Due_Call {
+	return &WebhookDeliveryRepository_Due_Call{Call: _e.mock.On("Due", ctx, now, limit)}
+}
+
+func (_c *WebhookDeliveryRepository_Due_Call) Run(run func(ctx context.Context, now time.Time, limit int)) *WebhookDeliveryRepository_Due_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_Due_Call) Return(_a0 []*domain.WebhookDelivery, _a1 error) *WebhookDeliveryRepository_Due_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_Due_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]*domain.WebhookDelivery, error)) *WebhookDeliveryRepository_Due_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, webhookID, id
+func (_m *WebhookDeliveryRepository) GetByID(ctx context.Context, webhookID string, id string) (*domain.WebhookDelivery, error) {
+	ret := _m.Called(ctx, webhookID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.WebhookDelivery, error)); ok {
+		return rf(ctx, webhookID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.WebhookDelivery); ok {
+		r0 = rf(ctx, webhookID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, webhookID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookDeliveryRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type WebhookDeliveryRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+//   - id string
+func (_e *WebhookDeliveryRepository_Expecter) GetByID(ctx interface{}, webhookID interface{}, id interface{}) *WebhookDeliveryRepository_GetByID_Call {
+	return &WebhookDeliveryRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, webhookID, id)}
+}
+
+func (_c *WebhookDeliveryRepository_GetByID_Call) Run(run func(ctx context.Context, webhookID string, id string)) *WebhookDeliveryRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_GetByID_Call) Return(_a0 *domain.WebhookDelivery, _a1 error) *WebhookDeliveryRepository_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_GetByID_Call) RunAndReturn(run func(context.Context, string, string) (*domain.WebhookDelivery, error)) *WebhookDeliveryRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByWebhook provides a mock function with given fields: ctx, webhookID
+func (_m *WebhookDeliveryRepository) ListByWebhook(ctx context.Context, webhookID string) ([]*domain.WebhookDelivery, error) {
+	ret := _m.Called(ctx, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByWebhook")
+	}
+
+	var r0 []*domain.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.WebhookDelivery, error)); ok {
+		return rf(ctx, webhookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.WebhookDelivery); ok {
+		r0 = rf(ctx, webhookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookDeliveryRepository_ListByWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByWebhook'
+type WebhookDeliveryRepository_ListByWebhook_Call struct {
+	*mock.Call
+}
+
+// ListByWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+func (_e *WebhookDeliveryRepository_Expecter) ListByWebhook(ctx interface{}, webhookID interface{}) *WebhookDeliveryRepository_ListByWebhook_Call {
+	return &WebhookDeliveryRepository_ListByWebhook_Call{Call: _e.mock.On("ListByWebhook", ctx, webhookID)}
+}
+
+func (_c *WebhookDeliveryRepository_ListByWebhook_Call) Run(run func(ctx context.Context, webhookID string)) *WebhookDeliveryRepository_ListByWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_ListByWebhook_Call) Return(_a0 []*domain.WebhookDelivery, _a1 error) *WebhookDeliveryRepository_ListByWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_ListByWebhook_Call) RunAndReturn(run func(context.Context, string) ([]*domain.WebhookDelivery, error)) *WebhookDeliveryRepository_ListByWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Trim provides a mock function with given fields: ctx, webhookID, keep
+func (_m *WebhookDeliveryRepository) Trim(ctx context.Context, webhookID string, keep int) error {
+	ret := _m.Called(ctx, webhookID, keep)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Trim")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, webhookID, keep)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryRepository_Trim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Trim'
+type WebhookDeliveryRepository_Trim_Call struct {
+	*mock.Call
+}
+
+// Trim is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+//   - keep int
+func (_e *WebhookDeliveryRepository_Expecter) Trim(ctx interface{}, webhookID interface{}, keep interface{}) *WebhookDeliveryRepository_Trim_Call {
+	return &WebhookDeliveryRepository_Trim_Call{Call: _e.mock.On("Trim", ctx, webhookID, keep)}
+}
+
+func (_c *WebhookDeliveryRepository_Trim_Call) Run(run func(ctx context.Context, webhookID string, keep int)) *WebhookDeliveryRepository_Trim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_Trim_Call) Return(_a0 error) *WebhookDeliveryRepository_Trim_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_Trim_Call) RunAndReturn(run func(context.Context, string, int) error) *WebhookDeliveryRepository_Trim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, d
+func (_m *WebhookDeliveryRepository) Update(ctx context.Context, d *domain.WebhookDelivery) error {
+	ret := _m.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.WebhookDelivery) error); ok {
+		r0 = rf(ctx, d)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type WebhookDeliveryRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - d *domain.WebhookDelivery
+func (_e *WebhookDeliveryRepository_Expecter) Update(ctx interface{}, d interface{}) *WebhookDeliveryRepository_Update_Call {
+	return &WebhookDeliveryRepository_Update_Call{Call: _e.mock.On("Update", ctx, d)}
+}
+
+func (_c *WebhookDeliveryRepository_Update_Call) Run(run func(ctx context.Context, d *domain.WebhookDelivery)) *WebhookDeliveryRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.WebhookDelivery))
+	})
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_Update_Call) Return(_a0 error) *WebhookDeliveryRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookDeliveryRepository_Update_Call) RunAndReturn(run func(context.Context, *domain.WebhookDelivery) error) *WebhookDeliveryRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookDeliveryRepository creates a new instance of WebhookDeliveryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookDeliveryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookDeliveryRepository {
+	mock := &WebhookDeliveryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
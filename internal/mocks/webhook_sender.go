@@ -0,0 +1,84 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookSender is an autogenerated mock type for the WebhookSender type
+type WebhookSender struct {
+	mock.Mock
+}
+
+type WebhookSender_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookSender) EXPECT() *WebhookSender_Expecter {
+	return &WebhookSender_Expecter{mock: &_m.Mock}
+}
+
+// Send provides a mock function with given fields: ctx, req
+func (_m *WebhookSender) Send(ctx context.Context, req domain.WebhookRequest) domain.WebhookAttempt {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 domain.WebhookAttempt
+	if rf, ok := ret.Get(0).(func(context.Context, domain.WebhookRequest) domain.WebhookAttempt); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(domain.WebhookAttempt)
+	}
+
+	return r0
+}
+
+// WebhookSender_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type WebhookSender_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req domain.WebhookRequest
+func (_e *WebhookSender_Expecter) Send(ctx interface{}, req interface{}) *WebhookSender_Send_Call {
+	return &WebhookSender_Send_Call{Call: _e.mock.On("Send", ctx, req)}
+}
+
+func (_c *WebhookSender_Send_Call) Run(run func(ctx context.Context, req domain.WebhookRequest)) *WebhookSender_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.WebhookRequest))
+	})
+	return _c
+}
+
+func (_c *WebhookSender_Send_Call) Return(_a0 domain.WebhookAttempt) *WebhookSender_Send_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookSender_Send_Call) RunAndReturn(run func(context.Context, domain.WebhookRequest) domain.WebhookAttempt) *WebhookSender_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookSender creates a new instance of WebhookSender. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookSender(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookSender {
+	mock := &WebhookSender{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,579 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/etsrc/goprod/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookService is an autogenerated mock type for the WebhookService type
+type WebhookService struct {
+	mock.Mock
+}
+
+type WebhookService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookService) EXPECT() *WebhookService_Expecter {
+	return &WebhookService_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, w
+func (_m *WebhookService) Create(ctx context.Context, w *domain.Webhook) error {
+	ret := _m.Called(ctx, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Webhook) error); ok {
+		r0 = rf(ctx, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type WebhookService_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - w *domain.Webhook
+func (_e *WebhookService_Expecter) Create(ctx interface{}, w interface{}) *WebhookService_Create_Call {
+	return &WebhookService_Create_Call{Call: _e.mock.On("Create", ctx, w)}
+}
+
+func (_c *WebhookService_Create_Call) Run(run func(ctx context.Context, w *domain.Webhook)) *WebhookService_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Webhook))
+	})
+	return _c
+}
+
+func (_c *WebhookService_Create_Call) Return(_a0 error) *WebhookService_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookService_Create_Call) RunAndReturn(run func(context.Context, *domain.Webhook) error) *WebhookService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *WebhookService) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type WebhookService_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *WebhookService_Expecter) Delete(ctx interface{}, id interface{}) *WebhookService_Delete_Call {
+	return &WebhookService_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *WebhookService_Delete_Call) Run(run func(ctx context.Context, id string)) *WebhookService_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WebhookService_Delete_Call) Return(_a0 error) *WebhookService_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookService_Delete_Call) RunAndReturn(run func(context.Context, string) error) *WebhookService_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Deliveries provides a mock function with given fields: ctx, webhookID
+func (_m *WebhookService) Deliveries(ctx context.Context, webhookID string) ([]*domain.WebhookDelivery, error) {
+	ret := _m.Called(ctx, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Deliveries")
+	}
+
+	var r0 []*domain.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.WebhookDelivery, error)); ok {
+		return rf(ctx, webhookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.WebhookDelivery); ok {
+		r0 = rf(ctx, webhookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookService_Deliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Deliveries'
+type WebhookService_Deliveries_Call struct {
+	*mock.Call
+}
+
+// Deliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+func (_e *WebhookService_Expecter) Deliveries(ctx interface{}, webhookID interface{}) *WebhookService_Deliveries_Call {
+	return &WebhookService_Deliveries_Call{Call: _e.mock.On("Deliveries", ctx, webhookID)}
+}
+
+func (_c *WebhookService_Deliveries_Call) Run(run func(ctx context.Context, webhookID string)) *WebhookService_Deliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WebhookService_Deliveries_Call) Return(_a0 []*domain.WebhookDelivery, _a1 error) *WebhookService_Deliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookService_Deliveries_Call) RunAndReturn(run func(context.Context, string) ([]*domain.WebhookDelivery, error)) *WebhookService_Deliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delivery provides a mock function with given fields: ctx, webhookID, id
+func (_m *WebhookService) Delivery(ctx context.Context, webhookID string, id string) (*domain.WebhookDelivery, error) {
+	ret := _m.Called(ctx, webhookID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delivery")
+	}
+
+	var r0 *domain.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.WebhookDelivery, error)); ok {
+		return rf(ctx, webhookID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.WebhookDelivery); ok {
+		r0 = rf(ctx, webhookID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, webhookID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookService_Delivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delivery'
+type WebhookService_Delivery_Call struct {
+	*mock.Call
+}
+
+// Delivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+//   - id string
+func (_e *WebhookService_Expecter) Delivery(ctx interface{}, webhookID interface{}, id interface{}) *WebhookService_Delivery_Call {
+	return &WebhookService_Delivery_Call{Call: _e.mock.On("Delivery", ctx, webhookID, id)}
+}
+
+func (_c *WebhookService_Delivery_Call) Run(run func(ctx context.Context, webhookID string, id string)) *WebhookService_Delivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *WebhookService_Delivery_Call) Return(_a0 *domain.WebhookDelivery, _a1 error) *WebhookService_Delivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookService_Delivery_Call) RunAndReturn(run func(context.Context, string, string) (*domain.WebhookDelivery, error)) *WebhookService_Delivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *WebhookService) GetByID(ctx context.Context, id string) (*domain.Webhook, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *domain.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Webhook, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Webhook); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookService_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type WebhookService_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *WebhookService_Expecter) GetByID(ctx interface{}, id interface{}) *WebhookService_GetByID_Call {
+	return &WebhookService_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *WebhookService_GetByID_Call) Run(run func(ctx context.Context, id string)) *WebhookService_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WebhookService_GetByID_Call) Return(_a0 *domain.Webhook, _a1 error) *WebhookService_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookService_GetByID_Call) RunAndReturn(run func(context.Context, string) (*domain.Webhook, error)) *WebhookService_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Handle provides a mock function with given fields: ctx, e
+func (_m *WebhookService) Handle(ctx context.Context, e domain.Event) error {
+	ret := _m.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Handle")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Event) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookService_Handle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Handle'
+type WebhookService_Handle_Call struct {
+	*mock.Call
+}
+
+// Handle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e domain.Event
+func (_e *WebhookService_Expecter) Handle(ctx interface{}, e interface{}) *WebhookService_Handle_Call {
+	return &WebhookService_Handle_Call{Call: _e.mock.On("Handle", ctx, e)}
+}
+
+func (_c *WebhookService_Handle_Call) Run(run func(ctx context.Context, e domain.Event)) *WebhookService_Handle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.Event))
+	})
+	return _c
+}
+
+func (_c *WebhookService_Handle_Call) Return(_a0 error) *WebhookService_Handle_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookService_Handle_Call) RunAndReturn(run func(context.Context, domain.Event) error) *WebhookService_Handle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *WebhookService) List(ctx context.Context) ([]*domain.Webhook, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*domain.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.Webhook, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.Webhook); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type WebhookService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *WebhookService_Expecter) List(ctx interface{}) *WebhookService_List_Call {
+	return &WebhookService_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *WebhookService_List_Call) Run(run func(ctx context.Context)) *WebhookService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *WebhookService_List_Call) Return(_a0 []*domain.Webhook, _a1 error) *WebhookService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookService_List_Call) RunAndReturn(run func(context.Context) ([]*domain.Webhook, error)) *WebhookService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Redeliver provides a mock function with given fields: ctx, webhookID, id
+func (_m *WebhookService) Redeliver(ctx context.Context, webhookID string, id string) (*domain.WebhookDelivery, error) {
+	ret := _m.Called(ctx, webhookID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Redeliver")
+	}
+
+	var r0 *domain.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.WebhookDelivery, error)); ok {
+		return rf(ctx, webhookID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.WebhookDelivery); ok {
+		r0 = rf(ctx, webhookID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, webhookID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookService_Redeliver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Redeliver'
+type WebhookService_Redeliver_Call struct {
+	*mock.Call
+}
+
+// Redeliver is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+//   - id string
+func (_e *WebhookService_Expecter) Redeliver(ctx interface{}, webhookID interface{}, id interface{}) *WebhookService_Redeliver_Call {
+	return &WebhookService_Redeliver_Call{Call: _e.mock.On("Redeliver", ctx, webhookID, id)}
+}
+
+func (_c *WebhookService_Redeliver_Call) Run(run func(ctx context.Context, webhookID string, id string)) *WebhookService_Redeliver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *WebhookService_Redeliver_Call) Return(_a0 *domain.WebhookDelivery, _a1 error) *WebhookService_Redeliver_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookService_Redeliver_Call) RunAndReturn(run func(context.Context, string, string) (*domain.WebhookDelivery, error)) *WebhookService_Redeliver_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *WebhookService) Run(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Run")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookService_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type WebhookService_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *WebhookService_Expecter) Run(ctx interface{}) *WebhookService_Run_Call {
+	return &WebhookService_Run_Call{Call: _e.mock.On("Run", ctx)}
+}
+
+func (_c *WebhookService_Run_Call) Run(run func(ctx context.Context)) *WebhookService_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *WebhookService_Run_Call) Return(_a0 error) *WebhookService_Run_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookService_Run_Call) RunAndReturn(run func(context.Context) error) *WebhookService_Run_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, w
+func (_m *WebhookService) Update(ctx context.Context, w *domain.Webhook) (*domain.Webhook, error) {
+	ret := _m.Called(ctx, w)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *domain.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Webhook) (*domain.Webhook, error)); ok {
+		return rf(ctx, w)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Webhook) *domain.Webhook); ok {
+		r0 = rf(ctx, w)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Webhook) error); ok {
+		r1 = rf(ctx, w)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type WebhookService_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - w *domain.Webhook
+func (_e *WebhookService_Expecter) Update(ctx interface{}, w interface{}) *WebhookService_Update_Call {
+	return &WebhookService_Update_Call{Call: _e.mock.On("Update", ctx, w)}
+}
+
+func (_c *WebhookService_Update_Call) Run(run func(ctx context.Context, w *domain.Webhook)) *WebhookService_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Webhook))
+	})
+	return _c
+}
+
+func (_c *WebhookService_Update_Call) Return(_a0 *domain.Webhook, _a1 error) *WebhookService_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookService_Update_Call) RunAndReturn(run func(context.Context, *domain.Webhook) (*domain.Webhook, error)) *WebhookService_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookService creates a new instance of WebhookService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookService {
+	mock := &WebhookService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
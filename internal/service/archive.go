@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// ErrArchiveQueueFull is returned when a capture cannot be scheduled because
+// too many are waiting.
+var ErrArchiveQueueFull = errors.New("archive queue is full")
+
+// ArchiveService captures bookmarked pages in the background and serves the
+// captures.
+type ArchiveService interface {
+	// Enqueue schedules a capture without waiting for it.
+	Enqueue(ctx context.Context, id string)
+	// Request schedules a capture of an existing bookmark, failing when the
+	// bookmark does not exist or the queue is full.
+	Request(ctx context.Context, id string) error
+	// Resource returns a captured resource by its original URL, or the page
+	// when url is empty.
+	Resource(ctx context.Context, id, url string) (*domain.ArchivedResource, error)
+	// Remove deletes a bookmark's archive.
+	Remove(ctx context.Context, id string) error
+	Run(ctx context.Context) error
+}
+
+type ArchiveOptions struct {
+	Workers   int // pages captured concurrently
+	QueueSize int // captures waiting for a worker; more are dropped
+}
+
+type archiveService struct {
+	repo     domain.BookmarkRepository
+	archiver domain.PageArchiver
+	opts     ArchiveOptions
+
+	queue chan string
+}
+
+func NewArchiveService(repo domain.BookmarkRepository, archiver domain.PageArchiver, opts ArchiveOptions) ArchiveService {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 100
+	}
+	return &archiveService{
+		repo:     repo,
+		archiver: archiver,
+		opts:     opts,
+		queue:    make(chan string, opts.QueueSize),
+	}
+}
+
+func (s *archiveService) Enqueue(ctx context.Context, id string) {
+	if !s.tryEnqueue(id) {
+		domain.LoggerFrom(ctx).Warn("Archive queue full, bookmark skipped", "bookmark_id", id)
+	}
+}
+
+func (s *archiveService) Request(ctx context.Context, id string) error {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return fmt.Errorf("service.Request: %w", err)
+	}
+	if !s.tryEnqueue(id) {
+		return fmt.Errorf("service.Request: %w", ErrArchiveQueueFull)
+	}
+	return nil
+}
+
+func (s *archiveService) tryEnqueue(id string) bool {
+	select {
+	case s.queue <- id:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *archiveService) Resource(ctx context.Context, id, url string) (*domain.ArchivedResource, error) {
+	res, err := s.archiver.Resource(ctx, id, url)
+	if err != nil {
+		return nil, fmt.Errorf("service.Resource: %w", err)
+	}
+	return res, nil
+}
+
+func (s *archiveService) Remove(ctx context.Context, id string) error {
+	if err := s.archiver.Delete(ctx, id); err != nil {
+		return fmt.Errorf("service.Remove: %w", err)
+	}
+	return nil
+}
+
+// Run captures queued bookmarks until ctx is done.
+func (s *archiveService) Run(ctx context.Context) error {
+	sem := make(chan struct{}, s.opts.Workers)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case id := <-s.queue:
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return nil
+			}
+			wg.Go(func() {
+				defer func() { <-sem }()
+				s.process(ctx, id)
+			})
+		}
+	}
+}
+
+func (s *archiveService) process(ctx context.Context, id string) {
+	b, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, domain.ErrBookmarkNotFound) {
+			domain.LoggerFrom(ctx).Error("Archiving bookmark failed", "bookmark_id", id, "error", err)
+		}
+		return
+	}
+
+	info, captureErr := s.archiver.Capture(ctx, id, b.URL)
+	if ctx.Err() != nil {
+		return
+	}
+
+	// Re-read so that a slow capture does not overwrite changes made meanwhile.
+	if b, err = s.repo.GetByID(ctx, id); err != nil {
+		return
+	}
+	b = b.Clone()
+	if captureErr != nil {
+		domain.LoggerFrom(ctx).Warn("Archiving bookmark failed", "bookmark_id", id, "error", captureErr)
+		// Keep describing the previous capture, which is still served.
+		if b.Archive == nil {
+			b.Archive = &domain.ArchiveInfo{}
+		}
+		b.Archive.Error = captureErr.Error()
+	} else {
+		b.Archive = info
+	}
+
+	if err := s.repo.Update(ctx, b); err != nil && !errors.Is(err, domain.ErrBookmarkNotFound) {
+		domain.LoggerFrom(ctx).Error("Archiving bookmark failed", "bookmark_id", id, "error", err)
+	}
+}
+
+type archivingBookmarkService struct {
+	BookmarkService
+	archive ArchiveService
+	policy  domain.ArchivePolicy
+}
+
+// WithArchiving decorates svc so that Create schedules a capture of new
+// bookmarks the policy applies to, and Purge removes a bookmark's archive.
+// A bookmark in the trash keeps its archive in case it is restored.
+func WithArchiving(svc BookmarkService, archive ArchiveService, policy domain.ArchivePolicy) BookmarkService {
+	return &archivingBookmarkService{BookmarkService: svc, archive: archive, policy: policy}
+}
+
+func (s *archivingBookmarkService) Create(ctx context.Context, b *domain.Bookmark) error {
+	if err := s.BookmarkService.Create(ctx, b); err != nil {
+		return err
+	}
+	if s.policy.Applies(b) {
+		s.archive.Enqueue(ctx, b.ID)
+	}
+	return nil
+}
+
+func (s *archivingBookmarkService) Purge(ctx context.Context, id string) error {
+	if err := s.BookmarkService.Purge(ctx, id); err != nil {
+		return err
+	}
+	if err := s.archive.Remove(ctx, id); err != nil {
+		domain.LoggerFrom(ctx).Error("Deleting archive failed", "bookmark_id", id, "error", err)
+	}
+	return nil
+}
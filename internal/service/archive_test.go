@@ -0,0 +1,137 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func runArchive(t *testing.T, svc service.ArchiveService) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		svc.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func waitForArchive(t *testing.T, repo domain.BookmarkRepository, id string) *domain.Bookmark {
+	t.Helper()
+
+	var b *domain.Bookmark
+	require.Eventually(t, func() bool {
+		var err error
+		b, err = repo.GetByID(context.Background(), id)
+		require.NoError(t, err)
+		return b.Archive != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	return b
+}
+
+func TestArchiveService(t *testing.T) {
+	t.Parallel()
+
+	captured := time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)
+	policy := domain.ArchivePolicy{Tags: []string{"reference"}}
+
+	t.Run("Archives By Policy", func(t *testing.T) {
+		t.Parallel()
+
+		repo := persistence.NewInMemoryBookmarkRepository()
+		archiver := mocks.NewPageArchiver(t)
+		archiver.On("Capture", mock.Anything, mock.Anything, "https://go.dev/ref/spec").
+			Return(&domain.ArchiveInfo{CapturedAt: captured, Resources: 3, Size: 2048}, nil).Once()
+
+		archive := service.NewArchiveService(repo, archiver, service.ArchiveOptions{})
+		runArchive(t, archive)
+		svc := service.WithArchiving(service.NewBookmarkService(repo), archive, policy)
+
+		b := &domain.Bookmark{URL: "https://go.dev/ref/spec", Title: "Go spec", Tags: []string{"reference"}}
+		require.NoError(t, svc.Create(context.Background(), b))
+
+		got := waitForArchive(t, repo, b.ID)
+		assert.Equal(t, 3, got.Archive.Resources)
+		assert.True(t, got.Archive.CapturedAt.Equal(captured))
+	})
+
+	t.Run("Skips Bookmarks Outside Policy", func(t *testing.T) {
+		t.Parallel()
+
+		repo := persistence.NewInMemoryBookmarkRepository()
+		archiver := mocks.NewPageArchiver(t)
+		archive := service.NewArchiveService(repo, archiver, service.ArchiveOptions{QueueSize: 1})
+		svc := service.WithArchiving(service.NewBookmarkService(repo), archive, policy)
+
+		b := &domain.Bookmark{URL: "https://go.dev", Title: "Go home", ArchiveMode: domain.ArchiveNever, Tags: []string{"reference"}}
+		require.NoError(t, svc.Create(context.Background(), b))
+
+		// Nothing was queued, so the one slot is still free.
+		assert.NoError(t, archive.Request(context.Background(), b.ID))
+		assert.ErrorIs(t, archive.Request(context.Background(), b.ID), service.ErrArchiveQueueFull)
+	})
+
+	t.Run("Failure Keeps Previous Capture", func(t *testing.T) {
+		t.Parallel()
+
+		repo := persistence.NewInMemoryBookmarkRepository()
+		b := &domain.Bookmark{ID: "b1", URL: "https://go.dev/gone", Title: "Gone",
+			Archive: &domain.ArchiveInfo{CapturedAt: captured, Resources: 2}}
+		require.NoError(t, repo.Create(context.Background(), b))
+
+		archiver := mocks.NewPageArchiver(t)
+		archiver.On("Capture", mock.Anything, "b1", mock.Anything).
+			Return(nil, errors.New("status 410")).Once()
+		archive := service.NewArchiveService(repo, archiver, service.ArchiveOptions{})
+		runArchive(t, archive)
+
+		require.NoError(t, archive.Request(context.Background(), "b1"))
+		require.Eventually(t, func() bool {
+			got, err := repo.GetByID(context.Background(), "b1")
+			require.NoError(t, err)
+			return got.Archive.Error != ""
+		}, 5*time.Second, 10*time.Millisecond)
+
+		got, _ := repo.GetByID(context.Background(), "b1")
+		assert.Equal(t, 2, got.Archive.Resources)
+		assert.Contains(t, got.Archive.Error, "status 410")
+	})
+
+	t.Run("Request Unknown Bookmark", func(t *testing.T) {
+		t.Parallel()
+
+		repo := persistence.NewInMemoryBookmarkRepository()
+		archive := service.NewArchiveService(repo, mocks.NewPageArchiver(t), service.ArchiveOptions{})
+		assert.ErrorIs(t, archive.Request(context.Background(), "nope"), domain.ErrBookmarkNotFound)
+	})
+
+	t.Run("Purge Removes Archive", func(t *testing.T) {
+		t.Parallel()
+
+		repo := persistence.NewInMemoryBookmarkRepository()
+		require.NoError(t, repo.Create(context.Background(), &domain.Bookmark{ID: "b1", URL: "https://go.dev", Title: "Go"}))
+		archiver := mocks.NewPageArchiver(t)
+		archiver.On("Delete", mock.Anything, "b1").Return(nil).Once()
+		archive := service.NewArchiveService(repo, archiver, service.ArchiveOptions{})
+		svc := service.WithArchiving(service.NewBookmarkService(repo), archive, policy)
+
+		// The archiver expects one call, so this also checks that moving
+		// the bookmark to the trash keeps its archive.
+		require.NoError(t, svc.Delete(context.Background(), "b1"))
+		require.NoError(t, svc.Purge(context.Background(), "b1"))
+	})
+}
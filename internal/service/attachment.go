@@ -0,0 +1,408 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/google/uuid"
+)
+
+// AttachmentService keeps files with bookmarks.
+type AttachmentService interface {
+	// Upload stores what is read from r as an attachment of a bookmark. The
+	// content type is sniffed from the content rather than taken from the
+	// client.
+	Upload(ctx context.Context, bookmarkID, filename string, r io.Reader) (*domain.Attachment, error)
+	List(ctx context.Context, bookmarkID string) ([]*domain.Attachment, error)
+	// Open returns an attachment with its content, which can seek for range
+	// requests. The caller closes it.
+	Open(ctx context.Context, bookmarkID, id string) (*domain.Attachment, io.ReadSeekCloser, error)
+	// OpenThumbnail returns an attachment with its thumbnail, failing with
+	// domain.ErrAttachmentNotFound when it has none.
+	OpenThumbnail(ctx context.Context, bookmarkID, id string) (*domain.Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, bookmarkID, id string) error
+	// DeleteAll removes every attachment of a bookmark.
+	DeleteAll(ctx context.Context, bookmarkID string) error
+}
+
+type AttachmentOptions struct {
+	MaxBytes int64 // largest single attachment
+	// QuotaBytes bounds the total size of the attachments each principal
+	// has uploaded. Requests that authenticated as no one share one quota,
+	// since the actor they name is not checked.
+	QuotaBytes int64
+	// TotalBytes bounds the size of all attachments together, whoever
+	// uploaded them.
+	TotalBytes int64
+	// AllowedTypes lists the media types that may be uploaded, such as
+	// "application/pdf", or "image/*" for every image. Empty allows all.
+	AllowedTypes []string
+}
+
+type attachmentService struct {
+	repo        domain.BookmarkRepository
+	attachments domain.AttachmentRepository
+	store       domain.BlobStore
+	thumbnailer domain.Thumbnailer
+	opts        AttachmentOptions
+
+	// mu makes checking a quota and recording an upload one step.
+	mu sync.Mutex
+}
+
+// NewAttachmentService makes thumbnails of images unless thumbnailer is nil.
+func NewAttachmentService(repo domain.BookmarkRepository, attachments domain.AttachmentRepository, store domain.BlobStore, thumbnailer domain.Thumbnailer, opts AttachmentOptions) AttachmentService {
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = 25 << 20
+	}
+	if opts.QuotaBytes <= 0 {
+		opts.QuotaBytes = 1 << 30
+	}
+	if opts.TotalBytes <= 0 {
+		opts.TotalBytes = 10 << 30
+	}
+	return &attachmentService{
+		repo:        repo,
+		attachments: attachments,
+		store:       store,
+		thumbnailer: thumbnailer,
+		opts:        opts,
+	}
+}
+
+func (s *attachmentService) Upload(ctx context.Context, bookmarkID, filename string, r io.Reader) (*domain.Attachment, error) {
+	if _, err := s.repo.GetByID(ctx, bookmarkID); err != nil {
+		return nil, fmt.Errorf("service.Upload: %w", err)
+	}
+
+	br := bufio.NewReaderSize(r, 512)
+	head, err := br.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, fmt.Errorf("service.Upload: %w", err)
+	}
+	contentType := sniffContentType(head, filename)
+	if !s.allowed(contentType) {
+		return nil, fmt.Errorf("service.Upload: %w: %s", domain.ErrContentTypeDenied, contentType)
+	}
+
+	owner := domain.PrincipalFrom(ctx)
+	if owner == "" {
+		owner = "anonymous"
+	}
+	remaining, err := s.remaining(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("service.Upload: %w", err)
+	}
+	// Stop reading as soon as any limit is passed, rather than storing a
+	// blob only to refuse it.
+	limit, limitErr := s.opts.MaxBytes, domain.ErrAttachmentTooLarge
+	if remaining < limit {
+		limit, limitErr = max(remaining, 0), domain.ErrQuotaExceeded
+	}
+	info, err := s.store.Put(ctx, &limitReader{r: br, n: limit, err: limitErr})
+	if err != nil {
+		return nil, fmt.Errorf("service.Upload: %w", err)
+	}
+
+	a := &domain.Attachment{
+		ID:          uuid.NewString(),
+		BookmarkID:  bookmarkID,
+		Filename:    cleanFilename(filename),
+		ContentType: contentType,
+		Size:        info.Size,
+		BlobKey:     info.Key,
+		CreatedAt:   time.Now(),
+		Owner:       owner,
+	}
+	if strings.HasPrefix(contentType, "image/") && s.thumbnailer != nil {
+		a.Thumbnail = s.thumbnail(ctx, info.Key)
+	}
+
+	// Uploads run concurrently, so the quota is checked again against what
+	// has been recorded meanwhile.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if remaining, err = s.remaining(ctx, owner); err != nil {
+		return nil, fmt.Errorf("service.Upload: %w", err)
+	}
+	if a.Size > remaining {
+		return nil, fmt.Errorf("service.Upload: %w", domain.ErrQuotaExceeded)
+	}
+	if err := s.attachments.Create(ctx, a); err != nil {
+		return nil, fmt.Errorf("service.Upload: %w", err)
+	}
+	return a, nil
+}
+
+// remaining is how many bytes owner may still upload, within both their
+// quota and the total.
+func (s *attachmentService) remaining(ctx context.Context, owner string) (int64, error) {
+	used, err := s.attachments.SizeByOwner(ctx, owner)
+	if err != nil {
+		return 0, err
+	}
+	total, err := s.attachments.TotalSize(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return min(s.opts.QuotaBytes-used, s.opts.TotalBytes-total), nil
+}
+
+// thumbnail makes and stores a preview of an image blob. An image that cannot
+// be previewed is still attached, without a thumbnail.
+func (s *attachmentService) thumbnail(ctx context.Context, key string) *domain.Thumbnail {
+	src, err := s.store.Get(ctx, key)
+	if err != nil {
+		domain.LoggerFrom(ctx).Error("Making thumbnail failed", "blob", key, "error", err)
+		return nil
+	}
+	defer src.Close()
+
+	var buf bytes.Buffer
+	contentType, size, err := s.thumbnailer.Thumbnail(ctx, &buf, src)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotAnImage) {
+			domain.LoggerFrom(ctx).Error("Making thumbnail failed", "blob", key, "error", err)
+		}
+		return nil
+	}
+	info, err := s.store.Put(ctx, &buf)
+	if err != nil {
+		domain.LoggerFrom(ctx).Error("Making thumbnail failed", "blob", key, "error", err)
+		return nil
+	}
+	return &domain.Thumbnail{BlobKey: info.Key, ContentType: contentType, Width: size.X, Height: size.Y}
+}
+
+func (s *attachmentService) allowed(contentType string) bool {
+	if len(s.opts.AllowedTypes) == 0 {
+		return true
+	}
+	for _, pattern := range s.opts.AllowedTypes {
+		if pattern == contentType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok && strings.HasPrefix(contentType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *attachmentService) List(ctx context.Context, bookmarkID string) ([]*domain.Attachment, error) {
+	if _, err := s.repo.GetByID(ctx, bookmarkID); err != nil {
+		return nil, fmt.Errorf("service.List: %w", err)
+	}
+	list, err := s.attachments.ListByBookmark(ctx, bookmarkID)
+	if err != nil {
+		return nil, fmt.Errorf("service.List: %w", err)
+	}
+	return list, nil
+}
+
+// Open, like List, acts as if a bookmark in the trash had no attachments.
+func (s *attachmentService) Open(ctx context.Context, bookmarkID, id string) (*domain.Attachment, io.ReadSeekCloser, error) {
+	if _, err := s.repo.GetByID(ctx, bookmarkID); err != nil {
+		return nil, nil, fmt.Errorf("service.Open: %w", err)
+	}
+	a, err := s.attachments.GetByID(ctx, bookmarkID, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("service.Open: %w", err)
+	}
+	return a, &blobReader{ctx: ctx, store: s.store, key: a.BlobKey, size: a.Size}, nil
+}
+
+func (s *attachmentService) OpenThumbnail(ctx context.Context, bookmarkID, id string) (*domain.Attachment, io.ReadCloser, error) {
+	if _, err := s.repo.GetByID(ctx, bookmarkID); err != nil {
+		return nil, nil, fmt.Errorf("service.OpenThumbnail: %w", err)
+	}
+	a, err := s.attachments.GetByID(ctx, bookmarkID, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("service.OpenThumbnail: %w", err)
+	}
+	if a.Thumbnail == nil {
+		return nil, nil, fmt.Errorf("service.OpenThumbnail: %w: no thumbnail", domain.ErrAttachmentNotFound)
+	}
+	body, err := s.store.Get(ctx, a.Thumbnail.BlobKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("service.OpenThumbnail: %w", err)
+	}
+	return a, body, nil
+}
+
+// Delete leaves the blobs to garbage collection, since other attachments may
+// share their content.
+func (s *attachmentService) Delete(ctx context.Context, bookmarkID, id string) error {
+	if _, err := s.repo.GetByID(ctx, bookmarkID); err != nil {
+		return fmt.Errorf("service.Delete: %w", err)
+	}
+	if err := s.attachments.Delete(ctx, bookmarkID, id); err != nil {
+		return fmt.Errorf("service.Delete: %w", err)
+	}
+	return nil
+}
+
+func (s *attachmentService) DeleteAll(ctx context.Context, bookmarkID string) error {
+	if err := s.attachments.DeleteByBookmark(ctx, bookmarkID); err != nil {
+		return fmt.Errorf("service.DeleteAll: %w", err)
+	}
+	return nil
+}
+
+// sniffContentType decides an upload's type from its first bytes. Where
+// sniffing can only tell that the content is text, a zip file or some binary,
+// the file name's extension may refine it to a type consistent with that.
+func sniffContentType(head []byte, filename string) string {
+	sniffed := http.DetectContentType(head)
+	mediaType, params, _ := mime.ParseMediaType(sniffed)
+	byName, _, _ := mime.ParseMediaType(mime.TypeByExtension(path.Ext(filename)))
+	if byName == "" || activeContentTypes[byName] {
+		return sniffed
+	}
+
+	switch mediaType {
+	case "text/plain":
+		if strings.HasPrefix(byName, "text/") || byName == "application/json" {
+			return mime.FormatMediaType(byName, params)
+		}
+	case "application/zip":
+		// Office documents and EPUB books are zip files.
+		if strings.HasSuffix(byName, "+zip") || strings.Contains(byName, "officedocument") || strings.Contains(byName, "opendocument") {
+			return byName
+		}
+	case "application/octet-stream":
+		return byName
+	}
+	return sniffed
+}
+
+// activeContentTypes are never taken from a file name: a browser would run
+// scripts in them.
+var activeContentTypes = map[string]bool{
+	"text/html":              true,
+	"application/xhtml+xml":  true,
+	"image/svg+xml":          true,
+	"text/xml":               true,
+	"application/xml":        true,
+	"text/javascript":        true,
+	"application/javascript": true,
+}
+
+// cleanFilename keeps the last element of a client's file name, which may be
+// a full path.
+func cleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	if name == "." || name == "/" {
+		return "attachment"
+	}
+	return name
+}
+
+// limitReader fails with err once more than n bytes have been read.
+type limitReader struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, l.err
+	}
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n, l.err
+	}
+	return n, err
+}
+
+// blobReader reads a blob from wherever it was last seeked to, opening a
+// range of it on the first read after each seek.
+type blobReader struct {
+	ctx    context.Context
+	store  domain.BlobStore
+	key    string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+func (b *blobReader) Read(p []byte) (int, error) {
+	if b.body == nil {
+		if b.offset >= b.size {
+			return 0, io.EOF
+		}
+		body, err := b.store.GetRange(b.ctx, b.key, b.offset, -1)
+		if err != nil {
+			return 0, err
+		}
+		b.body = body
+	}
+	n, err := b.body.Read(p)
+	b.offset += int64(n)
+	return n, err
+}
+
+func (b *blobReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += b.offset
+	case io.SeekEnd:
+		offset += b.size
+	}
+	if offset < 0 {
+		return 0, errors.New("seek before start of blob")
+	}
+	if offset != b.offset {
+		b.Close()
+		b.offset = offset
+	}
+	return offset, nil
+}
+
+func (b *blobReader) Close() error {
+	if b.body == nil {
+		return nil
+	}
+	err := b.body.Close()
+	b.body = nil
+	return err
+}
+
+// attachingBookmarkService deletes a bookmark's attachments when it is
+// purged. A bookmark in the trash keeps them in case it is restored.
+type attachingBookmarkService struct {
+	BookmarkService
+	attachments AttachmentService
+}
+
+// WithAttachments decorates svc so that Purge removes the bookmark's
+// attachments.
+func WithAttachments(svc BookmarkService, attachments AttachmentService) BookmarkService {
+	return &attachingBookmarkService{BookmarkService: svc, attachments: attachments}
+}
+
+func (s *attachingBookmarkService) Purge(ctx context.Context, id string) error {
+	if err := s.BookmarkService.Purge(ctx, id); err != nil {
+		return err
+	}
+	if err := s.attachments.DeleteAll(ctx, id); err != nil {
+		domain.LoggerFrom(ctx).Error("Deleting attachments failed", "bookmark_id", id, "error", err)
+	}
+	return nil
+}
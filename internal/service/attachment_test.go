@@ -0,0 +1,240 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/blob"
+	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/etsrc/goprod/internal/infra/thumbnail"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type attachmentFixture struct {
+	bookmarks   service.BookmarkService
+	attachments *persistence.InMemoryAttachmentRepository
+	svc         service.AttachmentService
+	bookmarkID  string
+}
+
+func newAttachmentFixture(t *testing.T, opts service.AttachmentOptions) *attachmentFixture {
+	t.Helper()
+
+	store, err := blob.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	repo := persistence.NewInMemoryBookmarkRepository()
+	attachments := persistence.NewInMemoryAttachmentRepository()
+	svc := service.NewAttachmentService(repo, attachments, store, thumbnail.New(thumbnail.Options{MaxSize: 64}), opts)
+	bookmarks := service.WithAttachments(service.NewBookmarkService(repo), svc)
+
+	b := &domain.Bookmark{URL: "https://example.com/paper", Title: "A paper"}
+	require.NoError(t, bookmarks.Create(context.Background(), b))
+	return &attachmentFixture{bookmarks: bookmarks, attachments: attachments, svc: svc, bookmarkID: b.ID}
+}
+
+func pngImage(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := range img.Pix {
+		img.Pix[i] = 200
+	}
+	img.Set(0, 0, color.RGBA{255, 0, 0, 255})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestAttachmentService_Upload(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		filename      string
+		content       string
+		wantFilename  string
+		wantType      string
+		wantThumbnail bool
+	}{
+		{"PDF", "paper.pdf", "%PDF-1.7\n...", "paper.pdf", "application/pdf", false},
+		{"Sniffed Over Name", "paper.pdf", "<!DOCTYPE html><p>hi", "paper.pdf", "text/html; charset=utf-8", false},
+		{"Name Refines Text", "notes.md", "# Notes\n\nSome text.", "notes.md", "text/markdown; charset=utf-8", false},
+		{"Name Cannot Make Text Active", "page.html", "just text", "page.html", "text/plain; charset=utf-8", false},
+		{"Client Path Dropped", `C:\Users\me\scan.txt`, "scanned", "scan.txt", "text/plain; charset=utf-8", false},
+		{"Image Gets Thumbnail", "shot.png", string(pngImage(t, 200, 100)), "shot.png", "image/png", true},
+		{"Broken Image Kept", "shot.png", "\x89PNG\r\n\x1a\nbroken", "shot.png", "image/png", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			f := newAttachmentFixture(t, service.AttachmentOptions{})
+
+			a, err := f.svc.Upload(ctx, f.bookmarkID, tt.filename, strings.NewReader(tt.content))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFilename, a.Filename)
+			assert.Equal(t, tt.wantType, a.ContentType)
+			assert.Equal(t, int64(len(tt.content)), a.Size)
+			assert.Equal(t, f.bookmarkID, a.BookmarkID)
+
+			_, body, err := f.svc.Open(ctx, f.bookmarkID, a.ID)
+			require.NoError(t, err)
+			got, err := io.ReadAll(body)
+			require.NoError(t, err)
+			require.NoError(t, body.Close())
+			assert.Equal(t, tt.content, string(got))
+
+			_, thumb, err := f.svc.OpenThumbnail(ctx, f.bookmarkID, a.ID)
+			if !tt.wantThumbnail {
+				assert.Nil(t, a.Thumbnail)
+				assert.ErrorIs(t, err, domain.ErrAttachmentNotFound)
+				return
+			}
+			require.NoError(t, err)
+			defer thumb.Close()
+			img, _, err := image.Decode(thumb)
+			require.NoError(t, err)
+			assert.Equal(t, image.Pt(64, 32), img.Bounds().Size())
+			assert.Equal(t, 64, a.Thumbnail.Width)
+		})
+	}
+}
+
+func TestAttachmentService_Limits(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	f := newAttachmentFixture(t, service.AttachmentOptions{
+		MaxBytes:     100,
+		QuotaBytes:   150,
+		AllowedTypes: []string{"text/*", "application/pdf"},
+	})
+
+	_, err := f.svc.Upload(ctx, f.bookmarkID, "big.txt", strings.NewReader(strings.Repeat("a", 101)))
+	assert.ErrorIs(t, err, domain.ErrAttachmentTooLarge)
+
+	_, err = f.svc.Upload(ctx, f.bookmarkID, "shot.png", bytes.NewReader(pngImage(t, 1, 1)))
+	assert.ErrorIs(t, err, domain.ErrContentTypeDenied)
+
+	_, err = f.svc.Upload(ctx, "missing", "a.txt", strings.NewReader("a"))
+	assert.ErrorIs(t, err, domain.ErrBookmarkNotFound)
+
+	// Naming another actor does not get a caller a fresh quota.
+	alice, bob := domain.WithActor(ctx, "alice"), domain.WithActor(ctx, "bob")
+	_, err = f.svc.Upload(alice, f.bookmarkID, "first.txt", strings.NewReader(strings.Repeat("a", 100)))
+	require.NoError(t, err)
+	_, err = f.svc.Upload(bob, f.bookmarkID, "second.txt", strings.NewReader(strings.Repeat("b", 51)))
+	assert.ErrorIs(t, err, domain.ErrQuotaExceeded)
+	a, err := f.svc.Upload(bob, f.bookmarkID, "second.txt", strings.NewReader(strings.Repeat("b", 50)))
+	require.NoError(t, err, "exactly filling the quota is fine")
+	assert.Equal(t, "anonymous", a.Owner)
+
+	// An authenticated principal has a quota of their own.
+	admin := domain.WithPrincipal(ctx, "admin")
+	a, err = f.svc.Upload(admin, f.bookmarkID, "third.txt", strings.NewReader(strings.Repeat("c", 100)))
+	require.NoError(t, err)
+	assert.Equal(t, "admin", a.Owner)
+
+	used, err := f.attachments.SizeByOwner(ctx, "anonymous")
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), used)
+}
+
+func TestAttachmentService_TotalLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	f := newAttachmentFixture(t, service.AttachmentOptions{QuotaBytes: 1000, TotalBytes: 150})
+
+	// Two X-Actor values, as IdentifyActor would put them in the context.
+	alice, bob := domain.WithActor(ctx, "alice"), domain.WithActor(ctx, "bob")
+	_, err := f.svc.Upload(alice, f.bookmarkID, "first.txt", strings.NewReader(strings.Repeat("a", 100)))
+	require.NoError(t, err)
+	_, err = f.svc.Upload(bob, f.bookmarkID, "second.txt", strings.NewReader(strings.Repeat("b", 100)))
+	assert.ErrorIs(t, err, domain.ErrQuotaExceeded)
+
+	// Nor does authenticating get past the total.
+	_, err = f.svc.Upload(domain.WithPrincipal(ctx, "admin"), f.bookmarkID, "third.txt", strings.NewReader(strings.Repeat("c", 51)))
+	assert.ErrorIs(t, err, domain.ErrQuotaExceeded)
+
+	total, err := f.attachments.TotalSize(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), total)
+}
+
+func TestAttachmentService_Seek(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	f := newAttachmentFixture(t, service.AttachmentOptions{})
+	a, err := f.svc.Upload(ctx, f.bookmarkID, "abc.txt", strings.NewReader("abcdefghijklmnopqrstuvwxyz"))
+	require.NoError(t, err)
+
+	_, body, err := f.svc.Open(ctx, f.bookmarkID, a.ID)
+	require.NoError(t, err)
+	defer body.Close()
+
+	size, err := body.Seek(0, io.SeekEnd)
+	require.NoError(t, err)
+	assert.Equal(t, int64(26), size)
+
+	buf := make([]byte, 3)
+	_, err = body.Seek(10, io.SeekStart)
+	require.NoError(t, err)
+	_, err = io.ReadFull(body, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "klm", string(buf))
+
+	_, err = body.Seek(-3, io.SeekEnd)
+	require.NoError(t, err)
+	rest, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "xyz", string(rest))
+}
+
+func TestAttachmentService_Delete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	f := newAttachmentFixture(t, service.AttachmentOptions{})
+	first, err := f.svc.Upload(ctx, f.bookmarkID, "a.txt", strings.NewReader("a"))
+	require.NoError(t, err)
+	_, err = f.svc.Upload(ctx, f.bookmarkID, "b.txt", strings.NewReader("b"))
+	require.NoError(t, err)
+
+	other := &domain.Bookmark{URL: "https://example.com/other", Title: "Another paper"}
+	require.NoError(t, f.bookmarks.Create(ctx, other))
+	_, _, err = f.svc.Open(ctx, other.ID, first.ID)
+	assert.ErrorIs(t, err, domain.ErrAttachmentNotFound, "attachments are reached only through their bookmark")
+
+	require.NoError(t, f.svc.Delete(ctx, f.bookmarkID, first.ID))
+	assert.ErrorIs(t, f.svc.Delete(ctx, f.bookmarkID, first.ID), domain.ErrAttachmentNotFound)
+	list, err := f.svc.List(ctx, f.bookmarkID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "b.txt", list[0].Filename)
+
+	// A bookmark in the trash keeps its attachments, out of reach until it
+	// is restored; purging it takes the rest with it.
+	require.NoError(t, f.bookmarks.Delete(ctx, f.bookmarkID))
+	list, err = f.attachments.ListByBookmark(ctx, f.bookmarkID)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+	_, _, err = f.svc.Open(ctx, f.bookmarkID, list[0].ID)
+	assert.ErrorIs(t, err, domain.ErrBookmarkNotFound)
+	require.NoError(t, f.bookmarks.Purge(ctx, f.bookmarkID))
+	list, err = f.attachments.ListByBookmark(ctx, f.bookmarkID)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// AuditService keeps the audit log: who changed what, when and from where,
+// and who was refused access.
+type AuditService interface {
+	// Record appends e to the log, stamped with the current time.
+	Record(ctx context.Context, e *domain.AuditEntry) error
+	// Query returns a page of the entries matching filter, newest first.
+	Query(ctx context.Context, filter domain.AuditFilter) (*domain.AuditPage, error)
+	// Verify checks the hash chain of the whole log.
+	Verify(ctx context.Context) (*domain.AuditVerification, error)
+}
+
+type AuditOptions struct {
+	PageSize    int // entries returned when the filter sets no limit
+	MaxPageSize int
+}
+
+type auditService struct {
+	log  domain.AuditLog
+	opts AuditOptions
+}
+
+func NewAuditService(log domain.AuditLog, opts AuditOptions) AuditService {
+	if opts.PageSize <= 0 {
+		opts.PageSize = 100
+	}
+	if opts.MaxPageSize <= 0 {
+		opts.MaxPageSize = 1000
+	}
+	return &auditService{log: log, opts: opts}
+}
+
+func (s *auditService) Record(ctx context.Context, e *domain.AuditEntry) error {
+	// Stored to the precision the hash covers, so that entries read back
+	// from any log hash the same.
+	e.At = time.Now().UTC().Truncate(time.Microsecond)
+	if err := s.log.Append(ctx, e); err != nil {
+		return fmt.Errorf("service.Record: %w", err)
+	}
+	return nil
+}
+
+func (s *auditService) Query(ctx context.Context, filter domain.AuditFilter) (*domain.AuditPage, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = s.opts.PageSize
+	}
+	limit := min(filter.Limit, s.opts.MaxPageSize)
+	filter.Limit = limit + 1
+
+	entries, err := s.log.Query(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("service.Query: %w", err)
+	}
+	page := &domain.AuditPage{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		page.Next = page.Entries[limit-1].Seq
+	}
+	return page, nil
+}
+
+func (s *auditService) Verify(ctx context.Context) (*domain.AuditVerification, error) {
+	result := &domain.AuditVerification{Valid: true}
+	var last int64
+	var prev string
+	for {
+		entries, err := s.log.Range(ctx, last, s.opts.MaxPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("service.Verify: %w", err)
+		}
+		for _, e := range entries {
+			// A gap in Seq means entries were removed.
+			if e.Seq != last+1 || e.PrevHash != prev || e.Hash != e.Digest() {
+				result.Valid, result.BrokenAt = false, e.Seq
+				return result, nil
+			}
+			result.Checked++
+			last, prev = e.Seq, e.Hash
+		}
+		if len(entries) < s.opts.MaxPageSize {
+			return result, nil
+		}
+	}
+}
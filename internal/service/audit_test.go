@@ -0,0 +1,136 @@
+package service_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tamperedAuditLog hands out entries as someone who edited the store would
+// have left them.
+type tamperedAuditLog struct {
+	*persistence.InMemoryAuditLog
+	tamper func(entries []*domain.AuditEntry) []*domain.AuditEntry
+}
+
+func (l *tamperedAuditLog) Range(ctx context.Context, after int64, limit int) ([]*domain.AuditEntry, error) {
+	all, err := l.InMemoryAuditLog.Range(ctx, 0, math.MaxInt)
+	if err != nil {
+		return nil, err
+	}
+	entries := []*domain.AuditEntry{}
+	for _, e := range l.tamper(all) {
+		if e.Seq > after && len(entries) < limit {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+func recordAudit(t *testing.T, svc service.AuditService, actors ...string) {
+	t.Helper()
+	for _, actor := range actors {
+		e := &domain.AuditEntry{Actor: actor, Action: "POST /bookmarks", Target: "/bookmarks", Outcome: domain.AuditSuccess, Status: 201}
+		require.NoError(t, svc.Record(context.Background(), e))
+	}
+}
+
+func TestAuditService_Query(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := service.NewAuditService(persistence.NewInMemoryAuditLog(), service.AuditOptions{PageSize: 2, MaxPageSize: 3})
+	recordAudit(t, svc, "alice", "bob", "alice", "alice", "alice")
+
+	page, err := svc.Query(ctx, domain.AuditFilter{Actor: "alice"})
+	require.NoError(t, err)
+	require.Len(t, page.Entries, 2)
+	assert.Equal(t, int64(5), page.Entries[0].Seq)
+	assert.Equal(t, int64(4), page.Next)
+
+	page, err = svc.Query(ctx, domain.AuditFilter{Actor: "alice", Before: page.Next})
+	require.NoError(t, err)
+	require.Len(t, page.Entries, 2)
+	assert.Equal(t, int64(1), page.Entries[1].Seq)
+	assert.Zero(t, page.Next, "the last page has no next")
+
+	page, err = svc.Query(ctx, domain.AuditFilter{Limit: 100})
+	require.NoError(t, err)
+	assert.Len(t, page.Entries, 3, "the limit is capped at MaxPageSize")
+}
+
+func TestAuditService_Verify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		tamper func(entries []*domain.AuditEntry) []*domain.AuditEntry
+		want   domain.AuditVerification
+	}{
+		{
+			name:   "Intact",
+			tamper: func(entries []*domain.AuditEntry) []*domain.AuditEntry { return entries },
+			want:   domain.AuditVerification{Valid: true, Checked: 5},
+		},
+		{
+			name: "Changed",
+			tamper: func(entries []*domain.AuditEntry) []*domain.AuditEntry {
+				for _, e := range entries {
+					if e.Seq == 3 {
+						e.Actor = "mallory"
+					}
+				}
+				return entries
+			},
+			want: domain.AuditVerification{Checked: 2, BrokenAt: 3},
+		},
+		{
+			name: "Resealed",
+			tamper: func(entries []*domain.AuditEntry) []*domain.AuditEntry {
+				for _, e := range entries {
+					if e.Seq == 3 {
+						e.Actor = "mallory"
+						e.Seal(e.PrevHash)
+					}
+				}
+				return entries
+			},
+			want: domain.AuditVerification{Checked: 3, BrokenAt: 4},
+		},
+		{
+			name: "Removed",
+			tamper: func(entries []*domain.AuditEntry) []*domain.AuditEntry {
+				var kept []*domain.AuditEntry
+				for _, e := range entries {
+					if e.Seq != 2 {
+						kept = append(kept, e)
+					}
+				}
+				return kept
+			},
+			want: domain.AuditVerification{Checked: 1, BrokenAt: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			log := &tamperedAuditLog{InMemoryAuditLog: persistence.NewInMemoryAuditLog(), tamper: tt.tamper}
+			// Pages of two check that the chain is followed across pages.
+			svc := service.NewAuditService(log, service.AuditOptions{MaxPageSize: 2})
+			recordAudit(t, svc, "alice", "bob", "alice", "bob", "alice")
+
+			got, err := svc.Verify(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *got)
+		})
+	}
+}
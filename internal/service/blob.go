@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// BlobGCService deletes blobs that nothing refers to any more.
+type BlobGCService interface {
+	// Collect makes one pass over the store.
+	Collect(ctx context.Context) (*domain.BlobGCRun, error)
+	// Run calls Collect every Interval until ctx is done.
+	Run(ctx context.Context) error
+}
+
+type BlobGCOptions struct {
+	Interval time.Duration // time between the starts of two passes
+	// Grace spares blobs put less than this long ago, whose references may
+	// not have been recorded yet.
+	Grace time.Duration
+}
+
+type blobGCService struct {
+	store     domain.BlobStore
+	referrers []domain.BlobReferrer
+	opts      BlobGCOptions
+}
+
+func NewBlobGCService(store domain.BlobStore, referrers []domain.BlobReferrer, opts BlobGCOptions) BlobGCService {
+	if opts.Interval <= 0 {
+		opts.Interval = 24 * time.Hour
+	}
+	if opts.Grace <= 0 {
+		opts.Grace = time.Hour
+	}
+	return &blobGCService{store: store, referrers: referrers, opts: opts}
+}
+
+func (s *blobGCService) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		run, err := s.Collect(ctx)
+		if err != nil && ctx.Err() == nil {
+			domain.LoggerFrom(ctx).Error("Blob garbage collection failed", "error", err)
+			continue
+		}
+		if run != nil && run.Deleted > 0 {
+			domain.LoggerFrom(ctx).Info("Collected blobs", "deleted", run.Deleted, "scanned", run.Scanned, "freed_bytes", run.FreedBytes)
+		}
+	}
+}
+
+// Collect reads every reference before it deletes anything, and deletes
+// nothing if any referrer fails: a missed reference would lose content.
+func (s *blobGCService) Collect(ctx context.Context) (*domain.BlobGCRun, error) {
+	// Blobs put after this point are younger than the grace period, so the
+	// references read below need not include them.
+	cutoff := time.Now().Add(-s.opts.Grace)
+
+	referenced := make(map[string]struct{})
+	for _, r := range s.referrers {
+		err := r.ReferencedBlobs(ctx, func(key string) error {
+			referenced[key] = struct{}{}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("service.Collect: %w", err)
+		}
+	}
+
+	run := &domain.BlobGCRun{}
+	err := s.store.Walk(ctx, func(info domain.BlobInfo) error {
+		run.Scanned++
+		if _, ok := referenced[info.Key]; ok || !info.ModTime.Before(cutoff) {
+			return nil
+		}
+		if err := s.store.Delete(ctx, info.Key); err != nil {
+			return err
+		}
+		run.Deleted++
+		run.FreedBytes += info.Size
+		return nil
+	})
+	if err != nil {
+		return run, fmt.Errorf("service.Collect: %w", err)
+	}
+	return run, nil
+}
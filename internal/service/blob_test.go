@@ -0,0 +1,72 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/infra/blob"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobGCService_Collect(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := blob.NewFileStore(dir)
+	require.NoError(t, err)
+
+	put := func(content string, age time.Duration) string {
+		info, err := store.Put(ctx, strings.NewReader(content))
+		require.NoError(t, err)
+		old := time.Now().Add(-age)
+		path := filepath.Join(dir, info.Key[:2], info.Key[2:4], info.Key)
+		require.NoError(t, os.Chtimes(path, old, old))
+		return info.Key
+	}
+	kept := put("referenced", 48*time.Hour)
+	orphan := put("orphan", 48*time.Hour)
+	fresh := put("just put", time.Minute)
+
+	referrer := mocks.NewBlobReferrer(t)
+	referrer.EXPECT().ReferencedBlobs(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, fn func(string) error) error {
+			return fn(kept)
+		})
+
+	gc := service.NewBlobGCService(store, []domain.BlobReferrer{referrer}, service.BlobGCOptions{Grace: time.Hour})
+	run, err := gc.Collect(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &domain.BlobGCRun{Scanned: 3, Deleted: 1, FreedBytes: int64(len("orphan"))}, run)
+
+	_, err = store.Stat(ctx, orphan)
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+	for _, key := range []string{kept, fresh} {
+		_, err := store.Stat(ctx, key)
+		assert.NoError(t, err)
+	}
+}
+
+func TestBlobGCService_CollectReferrerFails(t *testing.T) {
+	t.Parallel()
+
+	store := mocks.NewBlobStore(t)
+	referrer := mocks.NewBlobReferrer(t)
+	referrer.EXPECT().ReferencedBlobs(mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+	gc := service.NewBlobGCService(store, []domain.BlobReferrer{referrer}, service.BlobGCOptions{})
+	_, err := gc.Collect(context.Background())
+	assert.Error(t, err)
+	// The store is never walked, so nothing is deleted.
+	store.AssertNotCalled(t, "Walk", mock.Anything, mock.Anything)
+}
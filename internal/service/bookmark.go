@@ -7,13 +7,25 @@ import (
 
 	"github.com/etsrc/goprod/internal/domain"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type BookmarkService interface {
+	// Create stores a new bookmark, giving it an ID and creation time
+	// unless it already has them.
 	Create(ctx context.Context, b *domain.Bookmark) error
 	GetByID(ctx context.Context, id string) (*domain.Bookmark, error)
-	List(ctx context.Context) ([]*domain.Bookmark, error)
+	List(ctx context.Context, filter domain.BookmarkFilter) ([]*domain.Bookmark, error)
+	Stream(ctx context.Context, filter domain.BookmarkFilter, fn func(*domain.Bookmark) error) error
+	Recent(ctx context.Context, filter domain.BookmarkFilter, limit int) ([]*domain.Bookmark, error)
+	// Delete moves a bookmark to the trash, from which it can be restored
+	// until it is purged.
 	Delete(ctx context.Context, id string) error
+	Trash(ctx context.Context) ([]*domain.Bookmark, error)
+	Restore(ctx context.Context, id string) (*domain.Bookmark, error)
+	// Purge removes a bookmark in the trash for good, with everything kept
+	// for it.
+	Purge(ctx context.Context, id string) error
 }
 
 type bookmarkService struct {
@@ -26,10 +38,22 @@ func NewBookmarkService(repo domain.BookmarkRepository) BookmarkService {
 	}
 }
 
-func (s *bookmarkService) Create(ctx context.Context, b *domain.Bookmark) error {
-	b.ID = uuid.NewString()
-	b.CreatedAt = time.Now()
-	b.UpdatedAt = time.Now()
+func (s *bookmarkService) Create(ctx context.Context, b *domain.Bookmark) (err error) {
+	ctx, span := startSpan(ctx, "BookmarkService.Create")
+	defer func() { endSpan(span, err) }()
+
+	// A bookmark synced from a client comes with the ID and times it was
+	// given there.
+	if b.ID == "" {
+		b.ID = uuid.NewString()
+	}
+	now := time.Now()
+	if b.CreatedAt.IsZero() {
+		b.CreatedAt = now
+	}
+	if b.UpdatedAt.IsZero() {
+		b.UpdatedAt = now
+	}
 
 	if err := b.Validate(); err != nil {
 		return fmt.Errorf("service.Create: %w", err)
@@ -42,7 +66,10 @@ func (s *bookmarkService) Create(ctx context.Context, b *domain.Bookmark) error
 	return nil
 }
 
-func (s *bookmarkService) GetByID(ctx context.Context, id string) (*domain.Bookmark, error) {
+func (s *bookmarkService) GetByID(ctx context.Context, id string) (_ *domain.Bookmark, err error) {
+	ctx, span := startSpan(ctx, "BookmarkService.GetByID", attribute.String("bookmark.id", id))
+	defer func() { endSpan(span, err) }()
+
 	if id == "" {
 		return nil, fmt.Errorf("service.GetByID: id is required")
 	}
@@ -55,8 +82,15 @@ func (s *bookmarkService) GetByID(ctx context.Context, id string) (*domain.Bookm
 	return bookmark, nil
 }
 
-func (s *bookmarkService) List(ctx context.Context) ([]*domain.Bookmark, error) {
-	bookmarks, err := s.repo.GetAll(ctx)
+func (s *bookmarkService) List(ctx context.Context, filter domain.BookmarkFilter) (_ []*domain.Bookmark, err error) {
+	ctx, span := startSpan(ctx, "BookmarkService.List")
+	defer func() { endSpan(span, err) }()
+
+	bookmarks := []*domain.Bookmark{}
+	err = s.repo.Walk(ctx, filter, func(b *domain.Bookmark) error {
+		bookmarks = append(bookmarks, b)
+		return nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("service.List: %w", err)
 	}
@@ -64,7 +98,57 @@ func (s *bookmarkService) List(ctx context.Context) ([]*domain.Bookmark, error)
 	return bookmarks, nil
 }
 
-func (s *bookmarkService) Delete(ctx context.Context, id string) error {
+// Stream hands matching bookmarks to fn one at a time, for callers such as
+// exports that must not hold the whole collection in memory.
+func (s *bookmarkService) Stream(ctx context.Context, filter domain.BookmarkFilter, fn func(*domain.Bookmark) error) (err error) {
+	ctx, span := startSpan(ctx, "BookmarkService.Stream")
+	defer func() { endSpan(span, err) }()
+
+	if err := s.repo.Walk(ctx, filter, fn); err != nil {
+		return fmt.Errorf("service.Stream: %w", err)
+	}
+
+	return nil
+}
+
+// Recent returns the limit most recently created bookmarks matching filter,
+// newest first. Only limit bookmarks are held at a time.
+func (s *bookmarkService) Recent(ctx context.Context, filter domain.BookmarkFilter, limit int) (_ []*domain.Bookmark, err error) {
+	ctx, span := startSpan(ctx, "BookmarkService.Recent")
+	defer func() { endSpan(span, err) }()
+
+	if limit <= 0 {
+		return nil, fmt.Errorf("service.Recent: limit must be positive")
+	}
+
+	// Walk yields oldest first, so keep a ring of the last limit bookmarks.
+	ring := make([]*domain.Bookmark, 0, limit)
+	next := 0
+	err = s.repo.Walk(ctx, filter, func(b *domain.Bookmark) error {
+		if len(ring) < limit {
+			ring = append(ring, b)
+			return nil
+		}
+		ring[next] = b
+		next = (next + 1) % limit
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service.Recent: %w", err)
+	}
+
+	recent := make([]*domain.Bookmark, 0, len(ring))
+	for i := len(ring) - 1; i >= 0; i-- {
+		recent = append(recent, ring[(next+i)%len(ring)])
+	}
+
+	return recent, nil
+}
+
+func (s *bookmarkService) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "BookmarkService.Delete", attribute.String("bookmark.id", id))
+	defer func() { endSpan(span, err) }()
+
 	if id == "" {
 		return fmt.Errorf("service.Delete: id is required")
 	}
@@ -75,3 +159,49 @@ func (s *bookmarkService) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (s *bookmarkService) Trash(ctx context.Context) (_ []*domain.Bookmark, err error) {
+	ctx, span := startSpan(ctx, "BookmarkService.Trash")
+	defer func() { endSpan(span, err) }()
+
+	bookmarks, err := s.repo.Trash(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("service.Trash: %w", err)
+	}
+
+	return bookmarks, nil
+}
+
+func (s *bookmarkService) Restore(ctx context.Context, id string) (_ *domain.Bookmark, err error) {
+	ctx, span := startSpan(ctx, "BookmarkService.Restore", attribute.String("bookmark.id", id))
+	defer func() { endSpan(span, err) }()
+
+	if id == "" {
+		return nil, fmt.Errorf("service.Restore: id is required")
+	}
+
+	if err := s.repo.Restore(ctx, id); err != nil {
+		return nil, fmt.Errorf("service.Restore: %w", err)
+	}
+	bookmark, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.Restore: %w", err)
+	}
+
+	return bookmark, nil
+}
+
+func (s *bookmarkService) Purge(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "BookmarkService.Purge", attribute.String("bookmark.id", id))
+	defer func() { endSpan(span, err) }()
+
+	if id == "" {
+		return fmt.Errorf("service.Purge: id is required")
+	}
+
+	if err := s.repo.Purge(ctx, id); err != nil {
+		return fmt.Errorf("service.Purge: %w", err)
+	}
+
+	return nil
+}
@@ -2,7 +2,9 @@ package service_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/etsrc/goprod/internal/domain"
 	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
@@ -36,7 +38,7 @@ func TestBookmarkIntegration(t *testing.T) {
 		assert.Equal(t, newBookmark.Title, fetched.Title)
 
 		// 3. List
-		all, err := svc.List(ctx)
+		all, err := svc.List(ctx, domain.BookmarkFilter{})
 		require.NoError(t, err)
 		assert.Len(t, all, 1)
 
@@ -49,3 +51,46 @@ func TestBookmarkIntegration(t *testing.T) {
 		assert.ErrorIs(t, err, domain.ErrBookmarkNotFound)
 	})
 }
+
+func TestBookmarkService_Recent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := persistence.NewInMemoryBookmarkRepository()
+	svc := service.NewBookmarkService(repo)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 5 {
+		b := domain.NewBookmark(fmt.Sprintf("https://example.com/%d", i), fmt.Sprintf("Bookmark %d", i), "", nil)
+		b.ID = fmt.Sprintf("id-%d", i)
+		b.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		require.NoError(t, repo.Create(ctx, b))
+	}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  []string
+	}{
+		{name: "Fewer Than Stored", limit: 3, want: []string{"id-4", "id-3", "id-2"}},
+		{name: "More Than Stored", limit: 10, want: []string{"id-4", "id-3", "id-2", "id-1", "id-0"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := svc.Recent(ctx, domain.BookmarkFilter{}, tt.limit)
+			require.NoError(t, err)
+			ids := make([]string, 0, len(got))
+			for _, b := range got {
+				ids = append(ids, b.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+
+	_, err := svc.Recent(ctx, domain.BookmarkFilter{}, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// ContentService extracts the readable text of bookmarked pages in the
+// background and indexes it for search.
+type ContentService interface {
+	// Enqueue schedules a bookmark for extraction without waiting for it.
+	Enqueue(ctx context.Context, id string)
+	Run(ctx context.Context) error
+}
+
+type ContentOptions struct {
+	Workers   int // pages fetched concurrently
+	QueueSize int // bookmarks waiting for a worker; more are dropped
+}
+
+type contentService struct {
+	repo      domain.BookmarkRepository
+	extractor domain.ContentExtractor
+	index     domain.SearchIndex
+	opts      ContentOptions
+
+	queue chan string
+}
+
+func NewContentService(repo domain.BookmarkRepository, extractor domain.ContentExtractor, index domain.SearchIndex, opts ContentOptions) ContentService {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 1000
+	}
+	return &contentService{
+		repo:      repo,
+		extractor: extractor,
+		index:     index,
+		opts:      opts,
+		queue:     make(chan string, opts.QueueSize),
+	}
+}
+
+func (s *contentService) Enqueue(ctx context.Context, id string) {
+	select {
+	case s.queue <- id:
+	default:
+		domain.LoggerFrom(ctx).Warn("Content queue full, bookmark skipped", "bookmark_id", id)
+	}
+}
+
+// Run extracts queued bookmarks until ctx is done.
+func (s *contentService) Run(ctx context.Context) error {
+	sem := make(chan struct{}, s.opts.Workers)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case id := <-s.queue:
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return nil
+			}
+			wg.Go(func() {
+				defer func() { <-sem }()
+				s.process(ctx, id)
+			})
+		}
+	}
+}
+
+func (s *contentService) process(ctx context.Context, id string) {
+	b, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, domain.ErrBookmarkNotFound) {
+			domain.LoggerFrom(ctx).Error("Extracting content failed", "bookmark_id", id, "error", err)
+		}
+		return
+	}
+
+	content, extractErr := s.extractor.Extract(ctx, b.URL)
+	if ctx.Err() != nil {
+		return
+	}
+
+	// Re-read so that a slow fetch does not overwrite changes made meanwhile.
+	if b, err = s.repo.GetByID(ctx, id); err != nil {
+		return
+	}
+	if extractErr == nil {
+		extractErr = s.index.IndexContent(ctx, id, content.Text)
+	}
+	b = b.Clone()
+	if extractErr != nil {
+		domain.LoggerFrom(ctx).Warn("Extracting content failed", "bookmark_id", id, "error", extractErr)
+		// Keep describing the previous text, which is still indexed.
+		if b.Content == nil {
+			b.Content = &domain.ContentInfo{}
+		}
+		b.Content.Error = extractErr.Error()
+	} else {
+		b.Content = &domain.ContentInfo{
+			WordCount:      content.WordCount,
+			ReadingMinutes: domain.ReadingMinutes(content.WordCount),
+			ExtractedAt:    time.Now().UTC(),
+		}
+	}
+
+	err = s.repo.Update(ctx, b)
+	if errors.Is(err, domain.ErrBookmarkNotFound) {
+		// Deleted while the page was being indexed.
+		err = s.index.Remove(ctx, id)
+	}
+	if err != nil {
+		domain.LoggerFrom(ctx).Error("Extracting content failed", "bookmark_id", id, "error", err)
+	}
+}
+
+// extractingBookmarkService queues every created or restored bookmark for
+// extraction.
+type extractingBookmarkService struct {
+	BookmarkService
+	content ContentService
+}
+
+// WithContentExtraction decorates svc so that Create schedules the new
+// bookmark's page for extraction once it is saved, and Restore schedules it
+// again, since deletion takes a bookmark's text out of the search index.
+func WithContentExtraction(svc BookmarkService, content ContentService) BookmarkService {
+	return &extractingBookmarkService{BookmarkService: svc, content: content}
+}
+
+func (s *extractingBookmarkService) Create(ctx context.Context, b *domain.Bookmark) error {
+	if err := s.BookmarkService.Create(ctx, b); err != nil {
+		return err
+	}
+	s.content.Enqueue(ctx, b.ID)
+	return nil
+}
+
+func (s *extractingBookmarkService) Restore(ctx context.Context, id string) (*domain.Bookmark, error) {
+	b, err := s.BookmarkService.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.content.Enqueue(ctx, b.ID)
+	return b, nil
+}
@@ -0,0 +1,142 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/etsrc/goprod/internal/infra/search"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func runContent(t *testing.T, svc service.ContentService) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		svc.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func waitForContent(t *testing.T, repo domain.BookmarkRepository, id string) *domain.Bookmark {
+	t.Helper()
+
+	var b *domain.Bookmark
+	require.Eventually(t, func() bool {
+		var err error
+		b, err = repo.GetByID(context.Background(), id)
+		require.NoError(t, err)
+		return b.Content != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	return b
+}
+
+func TestContentService(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Extracts And Indexes New Bookmarks", func(t *testing.T) {
+		t.Parallel()
+
+		index := search.NewIndex()
+		repo := service.WithSearchIndex(persistence.NewInMemoryBookmarkRepository(), index)
+		extractor := mocks.NewContentExtractor(t)
+		extractor.On("Extract", mock.Anything, "https://go.dev/blog/pipelines").
+			Return(&domain.PageContent{Text: "A pipeline is a series of stages connected by channels.", WordCount: 500}, nil).Once()
+
+		content := service.NewContentService(repo, extractor, index, service.ContentOptions{})
+		runContent(t, content)
+		svc := service.WithContentExtraction(service.NewBookmarkService(repo), content)
+
+		b := &domain.Bookmark{URL: "https://go.dev/blog/pipelines", Title: "Pipelines"}
+		require.NoError(t, svc.Create(context.Background(), b))
+
+		got := waitForContent(t, repo, b.ID)
+		assert.Equal(t, 500, got.Content.WordCount)
+		assert.Equal(t, 3, got.Content.ReadingMinutes)
+		assert.Empty(t, got.Content.Error)
+
+		results, err := service.NewSearchService(repo, index).Search(context.Background(), "stages pipelines", 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, b.ID, results[0].Bookmark.ID)
+		assert.Len(t, results[0].Snippets, 3, "the title, URL and content matched")
+	})
+
+	t.Run("Failure Keeps Previous Text", func(t *testing.T) {
+		t.Parallel()
+
+		index := search.NewIndex()
+		repo := service.WithSearchIndex(persistence.NewInMemoryBookmarkRepository(), index)
+		extracted := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+		b := &domain.Bookmark{ID: "b1", URL: "https://go.dev/gone", Title: "Gone",
+			Content: &domain.ContentInfo{WordCount: 120, ReadingMinutes: 1, ExtractedAt: extracted}}
+		require.NoError(t, repo.Create(context.Background(), b))
+		require.NoError(t, index.IndexContent(context.Background(), "b1", "earlier text"))
+
+		extractor := mocks.NewContentExtractor(t)
+		extractor.On("Extract", mock.Anything, "https://go.dev/gone").
+			Return(nil, errors.New("status 410")).Once()
+		content := service.NewContentService(repo, extractor, index, service.ContentOptions{})
+		runContent(t, content)
+
+		content.Enqueue(context.Background(), "b1")
+		require.Eventually(t, func() bool {
+			got, err := repo.GetByID(context.Background(), "b1")
+			require.NoError(t, err)
+			return got.Content.Error != ""
+		}, 5*time.Second, 10*time.Millisecond)
+
+		got, _ := repo.GetByID(context.Background(), "b1")
+		assert.Equal(t, 120, got.Content.WordCount)
+		assert.True(t, got.Content.ExtractedAt.Equal(extracted))
+		hits, err := index.Search(context.Background(), "earlier", 0)
+		require.NoError(t, err)
+		assert.Len(t, hits, 1)
+	})
+}
+
+func TestWithSearchIndex(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	index := search.NewIndex()
+	repo := service.WithSearchIndex(persistence.NewInMemoryBookmarkRepository(), index)
+	svc := service.NewSearchService(repo, index)
+	found := func(q string) []string {
+		results, err := svc.Search(ctx, q, 0)
+		require.NoError(t, err)
+		ids := []string{}
+		for _, r := range results {
+			ids = append(ids, r.Bookmark.ID)
+		}
+		return ids
+	}
+
+	require.NoError(t, repo.Create(ctx, &domain.Bookmark{ID: "b1", URL: "https://go.dev", Title: "Go home"}))
+	require.NoError(t, repo.CreateBatch(ctx, []*domain.Bookmark{
+		{ID: "b2", URL: "https://pkg.go.dev", Title: "Go packages"},
+	}))
+	assert.Equal(t, []string{"b1"}, found("home"))
+	assert.Equal(t, []string{"b2"}, found("packages"))
+
+	require.NoError(t, index.IndexContent(ctx, "b1", "Build simple, secure, scalable systems"))
+	require.NoError(t, repo.Update(ctx, &domain.Bookmark{ID: "b1", URL: "https://go.dev", Title: "The Go website"}))
+	assert.Empty(t, found("home"))
+	assert.Equal(t, []string{"b1"}, found("website scalable"), "updating metadata keeps the content")
+
+	require.NoError(t, repo.Delete(ctx, "b1"))
+	assert.Empty(t, found("scalable"))
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// EnrichmentService fills in bookmarks from the metadata of their pages in the
+// background.
+type EnrichmentService interface {
+	// Enqueue schedules a bookmark for enrichment without waiting for it.
+	Enqueue(ctx context.Context, id string)
+	Run(ctx context.Context) error
+}
+
+type EnrichmentOptions struct {
+	Workers     int           // pages fetched concurrently
+	QueueSize   int           // bookmarks waiting for a worker; more are dropped
+	MaxAttempts int           // tries per bookmark, including the first
+	Backoff     time.Duration // delay before the first retry, doubled for each one after
+}
+
+type enrichmentService struct {
+	repo      domain.BookmarkRepository
+	inspector domain.PageInspector
+	opts      EnrichmentOptions
+
+	queue chan string
+}
+
+func NewEnrichmentService(repo domain.BookmarkRepository, inspector domain.PageInspector, opts EnrichmentOptions) EnrichmentService {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 1000
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 1
+	}
+	return &enrichmentService{
+		repo:      repo,
+		inspector: inspector,
+		opts:      opts,
+		queue:     make(chan string, opts.QueueSize),
+	}
+}
+
+// Enqueue never blocks the caller, which is usually serving a request. When the
+// queue is full the bookmark is skipped; it keeps what the user entered.
+func (s *enrichmentService) Enqueue(ctx context.Context, id string) {
+	select {
+	case s.queue <- id:
+	default:
+		domain.LoggerFrom(ctx).Warn("Enrichment queue full, bookmark skipped", "bookmark_id", id)
+	}
+}
+
+// Run enriches queued bookmarks until ctx is done. A bookmark holds its worker
+// while it waits to be retried, which keeps the number of requests in flight
+// bounded by Workers.
+func (s *enrichmentService) Run(ctx context.Context) error {
+	sem := make(chan struct{}, s.opts.Workers)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case id := <-s.queue:
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return nil
+			}
+			wg.Go(func() {
+				defer func() { <-sem }()
+				s.process(ctx, id)
+			})
+		}
+	}
+}
+
+func (s *enrichmentService) process(ctx context.Context, id string) {
+	b, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, domain.ErrBookmarkNotFound) {
+			domain.LoggerFrom(ctx).Error("Enriching bookmark failed", "bookmark_id", id, "error", err)
+		}
+		return
+	}
+
+	page, inspectErr := s.inspect(ctx, b.URL)
+	if ctx.Err() != nil {
+		return
+	}
+
+	// Re-read so that a slow fetch does not overwrite changes made meanwhile.
+	if b, err = s.repo.GetByID(ctx, id); err != nil {
+		return
+	}
+	b = b.Clone()
+	if b.Metadata == nil {
+		b.Metadata = make(map[string]string)
+	}
+	if inspectErr != nil {
+		domain.LoggerFrom(ctx).Warn("Enriching bookmark failed", "bookmark_id", id, "error", inspectErr)
+		b.Metadata[domain.MetaEnrichError] = inspectErr.Error()
+	} else {
+		applyPage(b, page)
+	}
+	b.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, b); err != nil && !errors.Is(err, domain.ErrBookmarkNotFound) {
+		domain.LoggerFrom(ctx).Error("Enriching bookmark failed", "bookmark_id", id, "error", err)
+	}
+}
+
+// inspect calls the inspector until it succeeds, fails permanently or runs out
+// of attempts, backing off exponentially between tries.
+func (s *enrichmentService) inspect(ctx context.Context, url string) (*domain.PageMetadata, error) {
+	delay := s.opts.Backoff
+	for attempt := 1; ; attempt++ {
+		page, err := s.inspector.Inspect(ctx, url)
+		if err == nil {
+			return page, nil
+		}
+		if errors.Is(err, domain.ErrPageUnavailable) || attempt >= s.opts.MaxAttempts {
+			return nil, fmt.Errorf("service.enrich: after %d attempt(s): %w", attempt, err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// applyPage fills the fields the user left empty and records everything else
+// as metadata. Values the bookmark already has are never overwritten.
+func applyPage(b *domain.Bookmark, page *domain.PageMetadata) {
+	if page.Title != "" && b.HasPlaceholderTitle() {
+		b.Title = page.Title
+	}
+	if b.Description == "" {
+		b.Description = page.Description
+	}
+
+	setMeta := func(key, value string) {
+		if _, ok := b.Metadata[key]; !ok && value != "" {
+			b.Metadata[key] = value
+		}
+	}
+	setMeta(domain.MetaAuthor, page.Author)
+	setMeta(domain.MetaPublisher, page.SiteName)
+	setMeta(domain.MetaCanonical, page.Canonical)
+	setMeta(domain.MetaLanguage, page.Language)
+	setMeta(domain.MetaImage, page.Image)
+	for key, value := range page.Properties {
+		setMeta(key, value)
+	}
+
+	delete(b.Metadata, domain.MetaEnrichError)
+	b.Metadata[domain.MetaEnrichedAt] = time.Now().UTC().Format(time.RFC3339)
+}
+
+// enrichingBookmarkService queues every created bookmark for enrichment.
+type enrichingBookmarkService struct {
+	BookmarkService
+	enrichment EnrichmentService
+}
+
+// WithEnrichment decorates svc so that Create schedules the new bookmark for
+// enrichment once it is saved.
+func WithEnrichment(svc BookmarkService, enrichment EnrichmentService) BookmarkService {
+	return &enrichingBookmarkService{BookmarkService: svc, enrichment: enrichment}
+}
+
+func (s *enrichingBookmarkService) Create(ctx context.Context, b *domain.Bookmark) error {
+	if err := s.BookmarkService.Create(ctx, b); err != nil {
+		return err
+	}
+	s.enrichment.Enqueue(ctx, b.ID)
+	return nil
+}
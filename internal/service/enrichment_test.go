@@ -0,0 +1,179 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// runEnrichment starts svc.Run and stops it when the test ends.
+func runEnrichment(t *testing.T, svc service.EnrichmentService) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		svc.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func waitForEnrichment(t *testing.T, repo domain.BookmarkRepository, id string) *domain.Bookmark {
+	t.Helper()
+
+	var b *domain.Bookmark
+	require.Eventually(t, func() bool {
+		var err error
+		b, err = repo.GetByID(context.Background(), id)
+		require.NoError(t, err)
+		return b.Metadata[domain.MetaEnrichedAt] != "" || b.Metadata[domain.MetaEnrichError] != ""
+	}, 5*time.Second, 10*time.Millisecond)
+	return b
+}
+
+func TestEnrichmentService(t *testing.T) {
+	t.Parallel()
+
+	page := &domain.PageMetadata{
+		Title:       "Go Concurrency Patterns: Pipelines",
+		Description: "How to build streaming pipelines.",
+		Author:      "Sameer Ajmani",
+		SiteName:    "The Go Blog",
+		Canonical:   "https://go.dev/blog/pipelines",
+		Language:    "en",
+		Properties:  map[string]string{"og:type": "article", "og:title": "Pipelines"},
+	}
+
+	tests := []struct {
+		name         string
+		bookmark     *domain.Bookmark
+		mockBehavior func(m *mocks.PageInspector)
+		check        func(t *testing.T, b *domain.Bookmark)
+	}{
+		{
+			name:     "Fills Placeholder Title And Metadata",
+			bookmark: &domain.Bookmark{URL: "https://go.dev/blog/pipelines", Title: "go.dev/blog/pipelines"},
+			mockBehavior: func(m *mocks.PageInspector) {
+				m.On("Inspect", mock.Anything, "https://go.dev/blog/pipelines").Return(page, nil).Once()
+			},
+			check: func(t *testing.T, b *domain.Bookmark) {
+				assert.Equal(t, "Go Concurrency Patterns: Pipelines", b.Title)
+				assert.Equal(t, "How to build streaming pipelines.", b.Description)
+				assert.Equal(t, "Sameer Ajmani", b.Metadata[domain.MetaAuthor])
+				assert.Equal(t, "The Go Blog", b.Metadata[domain.MetaPublisher])
+				assert.Equal(t, "https://go.dev/blog/pipelines", b.Metadata[domain.MetaCanonical])
+				assert.Equal(t, "en", b.Metadata[domain.MetaLanguage])
+				assert.Equal(t, "article", b.Metadata["og:type"])
+				assert.True(t, b.UpdatedAt.After(b.CreatedAt))
+			},
+		},
+		{
+			name: "Keeps What The User Entered",
+			bookmark: &domain.Bookmark{
+				URL:         "https://go.dev/blog/pipelines",
+				Title:       "Pipelines post",
+				Description: "my notes",
+				Metadata:    map[string]string{domain.MetaAuthor: "Ajmani"},
+			},
+			mockBehavior: func(m *mocks.PageInspector) {
+				m.On("Inspect", mock.Anything, mock.Anything).Return(page, nil).Once()
+			},
+			check: func(t *testing.T, b *domain.Bookmark) {
+				assert.Equal(t, "Pipelines post", b.Title)
+				assert.Equal(t, "my notes", b.Description)
+				assert.Equal(t, "Ajmani", b.Metadata[domain.MetaAuthor])
+				assert.Equal(t, "The Go Blog", b.Metadata[domain.MetaPublisher])
+			},
+		},
+		{
+			name:     "Retries Transient Errors",
+			bookmark: &domain.Bookmark{URL: "https://go.dev/blog/pipelines", Title: "https://go.dev/blog/pipelines"},
+			mockBehavior: func(m *mocks.PageInspector) {
+				m.On("Inspect", mock.Anything, mock.Anything).Return(nil, errors.New("status 503")).Twice()
+				m.On("Inspect", mock.Anything, mock.Anything).Return(page, nil).Once()
+			},
+			check: func(t *testing.T, b *domain.Bookmark) {
+				assert.Equal(t, "Go Concurrency Patterns: Pipelines", b.Title)
+				assert.NotContains(t, b.Metadata, domain.MetaEnrichError)
+			},
+		},
+		{
+			name:     "Gives Up After Max Attempts",
+			bookmark: &domain.Bookmark{URL: "https://go.dev/down", Title: "Down page"},
+			mockBehavior: func(m *mocks.PageInspector) {
+				m.On("Inspect", mock.Anything, mock.Anything).Return(nil, errors.New("status 503")).Times(3)
+			},
+			check: func(t *testing.T, b *domain.Bookmark) {
+				assert.Contains(t, b.Metadata[domain.MetaEnrichError], "after 3 attempt(s)")
+				assert.Equal(t, "Down page", b.Title)
+			},
+		},
+		{
+			name:     "Does Not Retry Permanent Errors",
+			bookmark: &domain.Bookmark{URL: "https://go.dev/gone", Title: "Gone page"},
+			mockBehavior: func(m *mocks.PageInspector) {
+				err := fmt.Errorf("%w: status 404", domain.ErrPageUnavailable)
+				m.On("Inspect", mock.Anything, mock.Anything).Return(nil, err).Once()
+			},
+			check: func(t *testing.T, b *domain.Bookmark) {
+				assert.Contains(t, b.Metadata[domain.MetaEnrichError], "after 1 attempt(s)")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := persistence.NewInMemoryBookmarkRepository()
+			inspector := mocks.NewPageInspector(t)
+			tt.mockBehavior(inspector)
+
+			enrichment := service.NewEnrichmentService(repo, inspector, service.EnrichmentOptions{
+				Workers:     2,
+				MaxAttempts: 3,
+				Backoff:     time.Millisecond,
+			})
+			runEnrichment(t, enrichment)
+			svc := service.WithEnrichment(service.NewBookmarkService(repo), enrichment)
+
+			require.NoError(t, svc.Create(context.Background(), tt.bookmark))
+			tt.check(t, waitForEnrichment(t, repo, tt.bookmark.ID))
+		})
+	}
+}
+
+func TestEnrichmentService_QueueFull(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil)).With("request_id", "req-1")
+	ctx := domain.WithLogger(context.Background(), logger)
+
+	// Not running, so the second bookmark finds the queue full.
+	svc := service.NewEnrichmentService(persistence.NewInMemoryBookmarkRepository(), mocks.NewPageInspector(t), service.EnrichmentOptions{QueueSize: 1})
+	svc.Enqueue(ctx, "b1")
+	svc.Enqueue(ctx, "b2")
+
+	// Logged for the request that created the bookmark.
+	assert.Contains(t, logs.String(), `"msg":"Enrichment queue full, bookmark skipped"`)
+	assert.Contains(t, logs.String(), `"request_id":"req-1"`)
+	assert.Contains(t, logs.String(), `"bookmark_id":"b2"`)
+}
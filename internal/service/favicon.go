@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"golang.org/x/net/idna"
+)
+
+// FaviconService serves sites' icons, fetching them on first use and
+// refreshing them once they are stale.
+type FaviconService interface {
+	// Icon returns host's icon at the smallest of domain.FaviconSizes that is
+	// at least size. A host without a usable icon gets a generated one.
+	// Invalid host names fail with domain.ErrInvalidHost.
+	Icon(ctx context.Context, host string, size int) (*FaviconIcon, error)
+}
+
+// FaviconIcon is an icon ready to serve.
+type FaviconIcon struct {
+	PNG  []byte
+	Size int
+	// Fallback is set for generated icons, which are replaced as soon as
+	// the site gets one of its own and so should be cached briefly.
+	Fallback bool
+	// ModTime is when the icon was fetched, zero for generated ones.
+	ModTime time.Time
+}
+
+type FaviconOptions struct {
+	// TTL is how long a fetched icon is served before it is fetched again.
+	TTL time.Duration
+	// RetryAfter is how long a failed fetch is remembered before it is tried
+	// again. The previous icon, if any, is served meanwhile.
+	RetryAfter time.Duration
+}
+
+type faviconService struct {
+	source domain.FaviconSource
+	store  domain.FaviconStore
+	opts   FaviconOptions
+
+	mu       sync.Mutex
+	inflight map[string]*faviconFetch // by host
+}
+
+// faviconFetch is a refresh shared by every request for the same host.
+type faviconFetch struct {
+	done chan struct{}
+	icon *domain.Favicon
+}
+
+func NewFaviconService(source domain.FaviconSource, store domain.FaviconStore, opts FaviconOptions) FaviconService {
+	if opts.TTL <= 0 {
+		opts.TTL = 7 * 24 * time.Hour
+	}
+	if opts.RetryAfter <= 0 {
+		opts.RetryAfter = 24 * time.Hour
+	}
+	return &faviconService{
+		source:   source,
+		store:    store,
+		opts:     opts,
+		inflight: make(map[string]*faviconFetch),
+	}
+}
+
+func (s *faviconService) Icon(ctx context.Context, host string, size int) (*FaviconIcon, error) {
+	host, err := normalizeHost(host)
+	if err != nil {
+		return nil, fmt.Errorf("service.Icon: %w", err)
+	}
+	size = domain.FaviconSize(size)
+
+	icon, err := s.store.Get(ctx, host)
+	if err != nil && !errors.Is(err, domain.ErrFaviconNotFound) {
+		// The icon is fetched again rather than the request failed.
+		domain.LoggerFrom(ctx).Error("Reading cached favicon failed", "host", host, "error", err)
+	}
+	if icon == nil || s.stale(icon) {
+		icon = s.refresh(ctx, host, icon)
+	}
+
+	if icon != nil {
+		if data, ok := icon.Icons[size]; ok {
+			return &FaviconIcon{PNG: data, Size: size, ModTime: icon.FetchedAt}, nil
+		}
+	}
+	return &FaviconIcon{PNG: s.source.Fallback(host, size), Size: size, Fallback: true}, nil
+}
+
+func (s *faviconService) stale(icon *domain.Favicon) bool {
+	ttl := s.opts.TTL
+	if icon.Error != "" {
+		ttl = s.opts.RetryAfter
+	}
+	return time.Since(icon.CheckedAt) > ttl
+}
+
+// refresh fetches host's icon and stores the result, keeping prev's icons
+// when the fetch fails. Concurrent requests for a host share one fetch, which
+// goes on when they give up so that its result is not wasted. Those that give
+// up get prev.
+func (s *faviconService) refresh(ctx context.Context, host string, prev *domain.Favicon) *domain.Favicon {
+	s.mu.Lock()
+	f, ok := s.inflight[host]
+	if !ok {
+		f = &faviconFetch{done: make(chan struct{})}
+		s.inflight[host] = f
+		go s.fetch(context.WithoutCancel(ctx), host, prev, f)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.icon
+	case <-ctx.Done():
+		return prev
+	}
+}
+
+func (s *faviconService) fetch(ctx context.Context, host string, prev *domain.Favicon, f *faviconFetch) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.inflight, host)
+		s.mu.Unlock()
+		close(f.done)
+	}()
+
+	now := time.Now()
+	icons, err := s.source.Fetch(ctx, host)
+	if err == nil {
+		f.icon = &domain.Favicon{Host: host, Icons: icons, FetchedAt: now, CheckedAt: now}
+	} else {
+		if prev != nil {
+			f.icon = prev.Clone()
+		} else {
+			f.icon = &domain.Favicon{Host: host}
+		}
+		f.icon.CheckedAt = now
+		f.icon.Error = err.Error()
+		if !errors.Is(err, domain.ErrFaviconNotFound) {
+			domain.LoggerFrom(ctx).Warn("Fetching favicon failed", "host", host, "error", err)
+		}
+	}
+	if err := s.store.Put(ctx, f.icon); err != nil {
+		domain.LoggerFrom(ctx).Error("Caching favicon failed", "host", host, "error", err)
+	}
+}
+
+// normalizeHost lower-cases host and converts international names to their
+// ASCII form, so that one site maps to one cache entry. It accepts a port but
+// not IP addresses, which sites are not known by.
+func normalizeHost(host string) (string, error) {
+	host = strings.ToLower(strings.TrimSpace(host))
+	name, port := host, ""
+	if h, p, err := net.SplitHostPort(host); err == nil {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 1 || n > 65535 {
+			return "", fmt.Errorf("%w: %q", domain.ErrInvalidHost, host)
+		}
+		name, port = h, ":"+strconv.Itoa(n)
+	}
+	name = strings.TrimSuffix(name, ".")
+	if net.ParseIP(name) != nil {
+		return "", fmt.Errorf("%w: %q is an IP address", domain.ErrInvalidHost, host)
+	}
+
+	ascii, err := idna.Lookup.ToASCII(name)
+	if err != nil || !strings.Contains(ascii, ".") || len(ascii) > 253 {
+		return "", fmt.Errorf("%w: %q", domain.ErrInvalidHost, host)
+	}
+	for _, label := range strings.Split(ascii, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") ||
+			strings.Trim(label, "abcdefghijklmnopqrstuvwxyz0123456789-") != "" {
+			return "", fmt.Errorf("%w: %q", domain.ErrInvalidHost, host)
+		}
+	}
+	return ascii + port, nil
+}
@@ -0,0 +1,171 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func fetchedIcons(label string) map[int][]byte {
+	icons := make(map[int][]byte)
+	for _, size := range domain.FaviconSizes {
+		icons[size] = []byte(label)
+	}
+	return icons
+}
+
+func TestFaviconService_Icon(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	old := time.Now().Add(-30 * 24 * time.Hour)
+
+	tests := []struct {
+		name         string
+		host         string
+		cached       *domain.Favicon
+		mockBehavior func(m *mocks.FaviconSource)
+		wantPNG      string
+		wantFallback bool
+		wantErr      error
+	}{
+		{
+			name: "Fetched On First Use",
+			host: "Example.COM.",
+			mockBehavior: func(m *mocks.FaviconSource) {
+				m.On("Fetch", mock.Anything, "example.com").Return(fetchedIcons("new"), nil).Once()
+			},
+			wantPNG: "new",
+		},
+		{
+			name:         "Fresh Cache Served",
+			host:         "example.com",
+			cached:       &domain.Favicon{Host: "example.com", Icons: fetchedIcons("cached"), CheckedAt: time.Now()},
+			mockBehavior: func(m *mocks.FaviconSource) {},
+			wantPNG:      "cached",
+		},
+		{
+			name:   "Stale Cache Refreshed",
+			host:   "example.com",
+			cached: &domain.Favicon{Host: "example.com", Icons: fetchedIcons("cached"), CheckedAt: old},
+			mockBehavior: func(m *mocks.FaviconSource) {
+				m.On("Fetch", mock.Anything, "example.com").Return(fetchedIcons("new"), nil).Once()
+			},
+			wantPNG: "new",
+		},
+		{
+			name:   "Failed Refresh Keeps Icon",
+			host:   "example.com",
+			cached: &domain.Favicon{Host: "example.com", Icons: fetchedIcons("cached"), CheckedAt: old},
+			mockBehavior: func(m *mocks.FaviconSource) {
+				m.On("Fetch", mock.Anything, "example.com").Return(nil, errors.New("connection refused")).Once()
+			},
+			wantPNG: "cached",
+		},
+		{
+			name:   "Recent Failure Not Retried",
+			host:   "example.com",
+			cached: &domain.Favicon{Host: "example.com", CheckedAt: time.Now(), Error: "favicon not found"},
+			mockBehavior: func(m *mocks.FaviconSource) {
+				m.On("Fallback", "example.com", 32).Return([]byte("generated")).Once()
+			},
+			wantPNG:      "generated",
+			wantFallback: true,
+		},
+		{
+			name: "No Icon Falls Back",
+			host: "bücher.example",
+			mockBehavior: func(m *mocks.FaviconSource) {
+				m.On("Fetch", mock.Anything, "xn--bcher-kva.example").Return(nil, domain.ErrFaviconNotFound).Once()
+				m.On("Fallback", "xn--bcher-kva.example", 32).Return([]byte("generated")).Once()
+			},
+			wantPNG:      "generated",
+			wantFallback: true,
+		},
+		{"IP Address", "127.0.0.1", nil, func(m *mocks.FaviconSource) {}, "", false, domain.ErrInvalidHost},
+		{"Path", "example.com/x", nil, func(m *mocks.FaviconSource) {}, "", false, domain.ErrInvalidHost},
+		{"Single Label", "localhost", nil, func(m *mocks.FaviconSource) {}, "", false, domain.ErrInvalidHost},
+		{"Bad Port", "example.com:99999", nil, func(m *mocks.FaviconSource) {}, "", false, domain.ErrInvalidHost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := persistence.NewInMemoryFaviconStore()
+			if tt.cached != nil {
+				require.NoError(t, store.Put(ctx, tt.cached))
+			}
+			source := mocks.NewFaviconSource(t)
+			tt.mockBehavior(source)
+			svc := service.NewFaviconService(source, store, service.FaviconOptions{})
+
+			icon, err := svc.Icon(ctx, tt.host, 20)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPNG, string(icon.PNG))
+			assert.Equal(t, tt.wantFallback, icon.Fallback)
+			assert.Equal(t, 32, icon.Size)
+		})
+	}
+}
+
+func TestFaviconService_RecordsFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := persistence.NewInMemoryFaviconStore()
+	source := mocks.NewFaviconSource(t)
+	source.On("Fetch", mock.Anything, "example.com").Return(nil, domain.ErrFaviconNotFound).Once()
+	source.On("Fallback", "example.com", 16).Return([]byte("generated")).Twice()
+	svc := service.NewFaviconService(source, store, service.FaviconOptions{})
+
+	// The second request is served from the recorded failure.
+	for range 2 {
+		_, err := svc.Icon(ctx, "example.com", 16)
+		require.NoError(t, err)
+	}
+	stored, err := store.Get(ctx, "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ErrFaviconNotFound.Error(), stored.Error)
+	assert.Empty(t, stored.Icons)
+}
+
+func TestFaviconService_SharesFetch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	release := make(chan time.Time)
+	source := mocks.NewFaviconSource(t)
+	source.On("Fetch", mock.Anything, "example.com").
+		WaitUntil(release).
+		Return(fetchedIcons("new"), nil).Once()
+	svc := service.NewFaviconService(source, persistence.NewInMemoryFaviconStore(), service.FaviconOptions{})
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			icon, err := svc.Icon(ctx, "example.com", 64)
+			assert.NoError(t, err)
+			assert.Equal(t, "new", string(icon.PNG))
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// HealthService checks the components the application depends on and
+// tracks whether it is shutting down.
+type HealthService interface {
+	// Register adds a component to every check. Components are registered
+	// before requests are served.
+	Register(name string, c domain.HealthChecker)
+	// Check checks every component at once.
+	Check(ctx context.Context) *domain.HealthReport
+	// Drain marks the application as shutting down, so that it stops being
+	// ready while it still serves requests.
+	Drain()
+	Draining() bool
+}
+
+type HealthOptions struct {
+	Timeout time.Duration // time a component has to answer
+}
+
+type component struct {
+	name    string
+	checker domain.HealthChecker
+}
+
+type healthService struct {
+	opts       HealthOptions
+	components []component
+	draining   atomic.Bool
+}
+
+func NewHealthService(opts HealthOptions) HealthService {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 2 * time.Second
+	}
+	return &healthService{opts: opts}
+}
+
+func (s *healthService) Register(name string, c domain.HealthChecker) {
+	s.components = append(s.components, component{name: name, checker: c})
+}
+
+func (s *healthService) Drain() {
+	s.draining.Store(true)
+}
+
+func (s *healthService) Draining() bool {
+	return s.draining.Load()
+}
+
+func (s *healthService) Check(ctx context.Context) *domain.HealthReport {
+	report := &domain.HealthReport{
+		Status:     domain.HealthUp,
+		Draining:   s.Draining(),
+		Components: make([]domain.ComponentHealth, len(s.components)),
+	}
+	var wg sync.WaitGroup
+	for i, c := range s.components {
+		wg.Go(func() {
+			report.Components[i] = s.check(ctx, c)
+		})
+	}
+	wg.Wait()
+
+	for _, c := range report.Components {
+		if c.Status != domain.HealthUp {
+			report.Status = domain.HealthDown
+		}
+	}
+	report.Ready = report.Status == domain.HealthUp && !report.Draining
+	return report
+}
+
+// check gives up on a component that does not answer within the timeout,
+// even one that ignores ctx, such as one waiting for a lock.
+func (s *healthService) check(ctx context.Context, c component) domain.ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- c.checker.CheckHealth(ctx) }()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	health := domain.ComponentHealth{
+		Name:      c.name,
+		Status:    domain.HealthUp,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		health.Status = domain.HealthDown
+		health.Error = err.Error()
+		if errors.Is(err, context.DeadlineExceeded) {
+			health.Error = "timed out"
+		}
+		domain.LoggerFrom(ctx).Warn("Health check failed", "component", c.name, "error", err)
+	}
+	return health
+}
@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthService_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		mockBehavior func(m *mocks.HealthChecker)
+		drain        bool
+		wantStatus   domain.HealthStatus
+		wantReady    bool
+		wantError    string
+	}{
+		{
+			name: "Up",
+			mockBehavior: func(m *mocks.HealthChecker) {
+				m.On("CheckHealth", mock.Anything).Return(nil).Once()
+			},
+			wantStatus: domain.HealthUp,
+			wantReady:  true,
+		},
+		{
+			name: "Down",
+			mockBehavior: func(m *mocks.HealthChecker) {
+				m.On("CheckHealth", mock.Anything).Return(errors.New("connection refused")).Once()
+			},
+			wantStatus: domain.HealthDown,
+			wantError:  "connection refused",
+		},
+		{
+			name: "Timed Out",
+			mockBehavior: func(m *mocks.HealthChecker) {
+				m.On("CheckHealth", mock.Anything).After(time.Second).Return(nil).Once()
+			},
+			wantStatus: domain.HealthDown,
+			wantError:  "timed out",
+		},
+		{
+			name: "Draining",
+			mockBehavior: func(m *mocks.HealthChecker) {
+				m.On("CheckHealth", mock.Anything).Return(nil).Once()
+			},
+			drain:      true,
+			wantStatus: domain.HealthUp,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := mocks.NewHealthChecker(t)
+			tt.mockBehavior(checker)
+			svc := service.NewHealthService(service.HealthOptions{Timeout: 50 * time.Millisecond})
+			svc.Register("bookmark repository", persistence.NewInMemoryBookmarkRepository())
+			svc.Register("database", checker)
+			if tt.drain {
+				svc.Drain()
+			}
+
+			report := svc.Check(context.Background())
+			assert.Equal(t, tt.wantStatus, report.Status)
+			assert.Equal(t, tt.wantReady, report.Ready)
+			assert.Equal(t, tt.drain, report.Draining)
+			require.Len(t, report.Components, 2)
+			assert.Equal(t, domain.HealthUp, report.Components[0].Status)
+			assert.Equal(t, "database", report.Components[1].Name)
+			assert.Equal(t, tt.wantError, report.Components[1].Error)
+			if tt.wantError == "timed out" {
+				assert.Less(t, report.Components[1].LatencyMS, 500.0, "a slow component is given up on")
+			}
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/google/uuid"
+)
+
+type ImportService interface {
+	Submit(ctx context.Context, items []*domain.Bookmark) (*domain.ImportJob, error)
+	GetByID(ctx context.Context, id string) (*domain.ImportJob, error)
+	Cancel(ctx context.Context, id string) (*domain.ImportJob, error)
+	Run(ctx context.Context) error
+}
+
+type ImportOptions struct {
+	BatchSize int // bookmarks written per repository call and per checkpoint
+	Workers   int // jobs processed concurrently
+}
+
+type importService struct {
+	repo domain.BookmarkRepository
+	jobs domain.ImportJobRepository
+	opts ImportOptions
+
+	queue chan string
+	// deferred is set when a job did not fit in queue. Run then looks for
+	// unfinished jobs again once a worker is free.
+	deferred atomic.Bool
+
+	mu      sync.Mutex
+	running map[string]context.CancelCauseFunc
+}
+
+func NewImportService(repo domain.BookmarkRepository, jobs domain.ImportJobRepository, opts ImportOptions) ImportService {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 500
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	return &importService{
+		repo:    repo,
+		jobs:    jobs,
+		opts:    opts,
+		queue:   make(chan string, 64),
+		running: make(map[string]context.CancelCauseFunc),
+	}
+}
+
+// Submit stores the job and its payload, then hands it to Run. IDs are assigned
+// here rather than at write time so that replaying a batch after a crash hits
+// the same IDs instead of creating duplicates. Fields the server maintains,
+// such as the link health or the trash state, are dropped: an export carries
+// them, but they describe the bookmarks where they were exported from.
+func (s *importService) Submit(ctx context.Context, items []*domain.Bookmark) (*domain.ImportJob, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("service.Submit: %w", domain.ErrImportEmpty)
+	}
+
+	now := time.Now()
+	for _, b := range items {
+		b.Version, b.DeletedAt = 0, nil
+		b.Health, b.Archive, b.Content = nil, nil, nil
+		if b.ID == "" {
+			b.ID = uuid.NewString()
+		}
+		if b.CreatedAt.IsZero() {
+			b.CreatedAt = now
+		}
+		if b.UpdatedAt.IsZero() {
+			b.UpdatedAt = b.CreatedAt
+		}
+	}
+
+	job := domain.NewImportJob(uuid.NewString(), len(items))
+	if err := s.jobs.Create(ctx, job, items); err != nil {
+		return nil, fmt.Errorf("service.Submit: failed to save job: %w", err)
+	}
+
+	// A full queue is not an error: the job is already persisted as pending and
+	// Run picks it up once a worker is free.
+	select {
+	case s.queue <- job.ID:
+	default:
+		s.deferred.Store(true)
+		domain.LoggerFrom(ctx).Warn("Import queue full, job deferred", "job_id", job.ID)
+	}
+
+	return job, nil
+}
+
+func (s *importService) GetByID(ctx context.Context, id string) (*domain.ImportJob, error) {
+	if id == "" {
+		return nil, fmt.Errorf("service.GetByID: id is required")
+	}
+
+	job, err := s.jobs.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetByID: %w", err)
+	}
+
+	return job, nil
+}
+
+// Cancel stops a running job after its current batch, or finishes a pending one
+// straight away. Bookmarks already written are kept.
+func (s *importService) Cancel(ctx context.Context, id string) (*domain.ImportJob, error) {
+	// The job is read and updated under mu, so that process can neither pick
+	// up a pending job nor finish a running one between the check and the
+	// write.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.jobs.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.Cancel: %w", err)
+	}
+	if job.Finished() {
+		return nil, fmt.Errorf("service.Cancel: %w", domain.ErrImportJobFinished)
+	}
+
+	if stop, running := s.running[id]; running {
+		stop(domain.ErrImportJobCanceled)
+		job.Status = domain.ImportJobCanceled
+		return job, nil
+	}
+
+	job.Finish(domain.ImportJobCanceled)
+	if err := s.jobs.Update(ctx, job); err != nil {
+		return nil, fmt.Errorf("service.Cancel: %w", err)
+	}
+	return job, nil
+}
+
+// Run resumes every unfinished job left over from a previous process and then
+// processes newly submitted jobs until ctx is done. Jobs that did not fit in
+// the queue are looked up again whenever a worker is free. Jobs interrupted by
+// shutdown keep their checkpoint and continue on the next Run.
+func (s *importService) Run(ctx context.Context) error {
+	sem := make(chan struct{}, s.opts.Workers)
+	freed := make(chan struct{}, 1)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	start := func(id string) {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				<-sem
+				select {
+				case freed <- struct{}{}:
+				default:
+				}
+			}()
+			s.process(ctx, id)
+		}()
+	}
+
+	pending, err := s.jobs.ListUnfinished(ctx)
+	if err != nil {
+		return fmt.Errorf("service.Run: %w", err)
+	}
+	for _, job := range pending {
+		domain.LoggerFrom(ctx).Info("Resuming import job", "job_id", job.ID, "processed", job.Processed, "total", job.Total)
+		start(job.ID)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case id := <-s.queue:
+			start(id)
+		case <-freed:
+			if !s.deferred.Swap(false) {
+				continue
+			}
+			// Jobs already queued or running are listed too; claim skips
+			// those that another worker has taken.
+			pending, err := s.jobs.ListUnfinished(ctx)
+			if err != nil {
+				s.deferred.Store(true)
+				domain.LoggerFrom(ctx).Error("Listing deferred import jobs failed", "error", err)
+				continue
+			}
+			for _, job := range pending {
+				start(job.ID)
+			}
+		}
+	}
+}
+
+func (s *importService) process(parent context.Context, id string) {
+	ctx, stop := context.WithCancelCause(parent)
+	defer stop(nil)
+
+	// Checkpoints are written with a context detached from cancellation so that
+	// the final state is recorded even while the job is being stopped.
+	saveCtx := context.WithoutCancel(ctx)
+
+	job, ok := s.claim(ctx, id, stop)
+	if !ok {
+		return
+	}
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, id)
+		s.mu.Unlock()
+	}()
+
+	job.Status = domain.ImportJobRunning
+	job.UpdatedAt = time.Now()
+	if err := s.jobs.Update(saveCtx, job); err != nil {
+		domain.LoggerFrom(ctx).Error("Import job failed", "job_id", id, "error", err)
+		return
+	}
+
+	failed := false
+	for job.Processed < job.Total {
+		if ctx.Err() != nil {
+			break
+		}
+
+		items, err := s.jobs.Items(ctx, id, job.Processed, s.opts.BatchSize)
+		if err != nil || len(items) == 0 {
+			if ctx.Err() != nil {
+				break
+			}
+			domain.LoggerFrom(ctx).Error("Loading import items failed", "job_id", id, "error", err)
+			failed = true
+			break
+		}
+
+		s.writeBatch(ctx, job, items)
+		job.Processed += len(items)
+		job.UpdatedAt = time.Now()
+		s.checkpoint(saveCtx, job)
+	}
+
+	// The outcome is decided and saved under mu, so that Cancel finds the
+	// job either still running or finished.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case failed:
+		job.Finish(domain.ImportJobFailed)
+	case errors.Is(context.Cause(ctx), domain.ErrImportJobCanceled):
+		job.Finish(domain.ImportJobCanceled)
+	case ctx.Err() != nil:
+		// Shutting down: leave the job running so the next Run resumes it.
+		return
+	default:
+		job.Finish(domain.ImportJobCompleted)
+	}
+	s.checkpoint(saveCtx, job)
+}
+
+// claim registers the job as running so that Cancel can reach it. It returns
+// false if the job is already running or has finished in the meantime.
+func (s *importService) claim(ctx context.Context, id string, stop context.CancelCauseFunc) (*domain.ImportJob, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, dup := s.running[id]; dup {
+		return nil, false
+	}
+	job, err := s.jobs.GetByID(ctx, id)
+	if err != nil {
+		domain.LoggerFrom(ctx).Error("Import job failed", "job_id", id, "error", err)
+		return nil, false
+	}
+	if job.Finished() {
+		return nil, false
+	}
+	s.running[id] = stop
+	return job, true
+}
+
+// writeBatch validates items and writes the valid ones in a single repository
+// call. If the batch is rejected it falls back to one item at a time so that a
+// single bad row only fails itself.
+func (s *importService) writeBatch(ctx context.Context, job *domain.ImportJob, items []*domain.Bookmark) {
+	valid := make([]*domain.Bookmark, 0, len(items))
+	index := make(map[*domain.Bookmark]int, len(items))
+	for i, b := range items {
+		if err := b.Validate(); err != nil {
+			job.RecordError(job.Processed+i, b.URL, err)
+			continue
+		}
+		valid = append(valid, b)
+		index[b] = job.Processed + i
+	}
+	if len(valid) == 0 {
+		return
+	}
+
+	if err := s.repo.CreateBatch(ctx, valid); err == nil {
+		job.Imported += len(valid)
+		return
+	}
+
+	for _, b := range valid {
+		err := s.repo.CreateBatch(ctx, []*domain.Bookmark{b})
+		if errors.Is(err, domain.ErrBookmarkExists) && s.alreadyImported(ctx, b) {
+			err = nil
+		}
+		if err != nil {
+			job.RecordError(index[b], b.URL, err)
+			continue
+		}
+		job.Imported++
+	}
+}
+
+// alreadyImported reports whether b was written by an earlier attempt of this
+// batch that crashed before its checkpoint was saved.
+func (s *importService) alreadyImported(ctx context.Context, b *domain.Bookmark) bool {
+	existing, err := s.repo.GetByID(ctx, b.ID)
+	return err == nil && existing.URL == b.URL && existing.Title == b.Title
+}
+
+func (s *importService) checkpoint(ctx context.Context, job *domain.ImportJob) {
+	if err := s.jobs.Update(ctx, job); err != nil {
+		domain.LoggerFrom(ctx).Error("Import checkpoint failed", "job_id", job.ID, "error", err)
+	}
+}
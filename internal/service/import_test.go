@@ -0,0 +1,272 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func importItems(n int) []*domain.Bookmark {
+	items := make([]*domain.Bookmark, 0, n)
+	for i := range n {
+		items = append(items, &domain.Bookmark{
+			URL:   fmt.Sprintf("https://example.com/%d", i),
+			Title: fmt.Sprintf("Bookmark %d", i),
+		})
+	}
+	return items
+}
+
+func waitForImport(t *testing.T, svc service.ImportService, id string) *domain.ImportJob {
+	t.Helper()
+
+	var job *domain.ImportJob
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = svc.GetByID(context.Background(), id)
+		require.NoError(t, err)
+		return job.Finished()
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestImportService_Run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		items        func() []*domain.Bookmark
+		wantImported int
+		wantFailed   int
+	}{
+		{
+			name:         "All Valid Across Several Batches",
+			items:        func() []*domain.Bookmark { return importItems(25) },
+			wantImported: 25,
+		},
+		{
+			name: "Invalid Items Are Reported",
+			items: func() []*domain.Bookmark {
+				items := importItems(5)
+				items[1].Title = "x"
+				items[3].URL = "not a url"
+				return items
+			},
+			wantImported: 3,
+			wantFailed:   2,
+		},
+		{
+			name: "Duplicate ID Only Fails Itself",
+			items: func() []*domain.Bookmark {
+				items := importItems(4)
+				items[0].ID = "same-id"
+				items[2].ID = "same-id"
+				return items
+			},
+			wantImported: 3,
+			wantFailed:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			repo := persistence.NewInMemoryBookmarkRepository()
+			svc := service.NewImportService(repo, persistence.NewInMemoryImportJobRepository(), service.ImportOptions{BatchSize: 10})
+			go svc.Run(ctx)
+
+			job, err := svc.Submit(ctx, tt.items())
+			require.NoError(t, err)
+
+			job = waitForImport(t, svc, job.ID)
+			assert.Equal(t, domain.ImportJobCompleted, job.Status)
+			assert.Equal(t, job.Total, job.Processed)
+			assert.Equal(t, tt.wantImported, job.Imported)
+			assert.Equal(t, tt.wantFailed, job.Failed)
+			assert.Len(t, job.Errors, tt.wantFailed)
+
+			all, err := repo.GetAll(ctx)
+			require.NoError(t, err)
+			assert.Len(t, all, tt.wantImported)
+		})
+	}
+}
+
+func TestImportService_ResumesFromCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := persistence.NewInMemoryBookmarkRepository()
+	jobs := persistence.NewInMemoryImportJobRepository()
+
+	// Simulate a process that wrote the first batch and checkpointed, then
+	// wrote part of the second batch and crashed before checkpointing.
+	items := importItems(30)
+	for _, b := range items {
+		b.ID = b.URL
+	}
+	job := domain.NewImportJob("job-1", len(items))
+	job.Status = domain.ImportJobRunning
+	job.Processed = 10
+	job.Imported = 10
+	require.NoError(t, jobs.Create(ctx, job, items))
+	require.NoError(t, repo.CreateBatch(ctx, items[:15]))
+
+	svc := service.NewImportService(repo, jobs, service.ImportOptions{BatchSize: 10})
+	go svc.Run(ctx)
+
+	job = waitForImport(t, svc, "job-1")
+	assert.Equal(t, domain.ImportJobCompleted, job.Status)
+	assert.Equal(t, 30, job.Imported)
+	assert.Zero(t, job.Failed)
+
+	all, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 30)
+}
+
+func TestImportService_DropsServerFields(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := persistence.NewInMemoryBookmarkRepository()
+	svc := service.NewImportService(repo, persistence.NewInMemoryImportJobRepository(), service.ImportOptions{})
+	go svc.Run(ctx)
+
+	deleted := time.Now()
+	items := importItems(1)
+	items[0].ID = "b1"
+	items[0].Version = 7
+	items[0].DeletedAt = &deleted
+	items[0].Health = &domain.LinkHealth{Status: domain.LinkBroken}
+	items[0].Archive = &domain.ArchiveInfo{}
+	items[0].Content = &domain.ContentInfo{}
+	items[0].ArchiveMode = domain.ArchiveNever
+	job, err := svc.Submit(ctx, items)
+	require.NoError(t, err)
+	waitForImport(t, svc, job.ID)
+
+	b, err := repo.GetByID(ctx, "b1")
+	require.NoError(t, err, "the bookmark is not imported into the trash")
+	assert.Equal(t, int64(1), b.Version)
+	assert.Nil(t, b.Health)
+	assert.Nil(t, b.Archive)
+	assert.Nil(t, b.Content)
+	assert.Equal(t, domain.ArchiveNever, b.ArchiveMode, "the archive mode is the user's own")
+}
+
+// gatedRepository holds every write until release is closed.
+type gatedRepository struct {
+	*persistence.InMemoryBookmarkRepository
+	release chan struct{}
+}
+
+func (r *gatedRepository) CreateBatch(ctx context.Context, items []*domain.Bookmark) error {
+	<-r.release
+	return r.InMemoryBookmarkRepository.CreateBatch(ctx, items)
+}
+
+func TestImportService_DeferredJobs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := &gatedRepository{InMemoryBookmarkRepository: persistence.NewInMemoryBookmarkRepository(), release: make(chan struct{})}
+	svc := service.NewImportService(repo, persistence.NewInMemoryImportJobRepository(), service.ImportOptions{Workers: 1})
+	go svc.Run(ctx)
+
+	// The first job holds the only worker and Run waits to start the second,
+	// so the queue fills up and the last jobs are deferred.
+	first, err := svc.Submit(ctx, importItems(1))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		job, err := svc.GetByID(ctx, first.ID)
+		require.NoError(t, err)
+		return job.Status == domain.ImportJobRunning
+	}, 5*time.Second, 10*time.Millisecond)
+
+	var ids []string
+	for range 70 {
+		job, err := svc.Submit(ctx, importItems(1))
+		require.NoError(t, err)
+		ids = append(ids, job.ID)
+	}
+	close(repo.release)
+
+	for _, id := range ids {
+		job := waitForImport(t, svc, id)
+		assert.Equal(t, domain.ImportJobCompleted, job.Status)
+	}
+}
+
+func TestImportService_Cancel(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := service.NewImportService(persistence.NewInMemoryBookmarkRepository(), persistence.NewInMemoryImportJobRepository(), service.ImportOptions{})
+
+	t.Run("Pending Job", func(t *testing.T) {
+		t.Parallel()
+
+		// Run is not started, so the job stays pending until canceled.
+		job, err := svc.Submit(ctx, importItems(1))
+		require.NoError(t, err)
+
+		job, err = svc.Cancel(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ImportJobCanceled, job.Status)
+
+		_, err = svc.Cancel(ctx, job.ID)
+		assert.ErrorIs(t, err, domain.ErrImportJobFinished)
+	})
+
+	t.Run("Completed Job", func(t *testing.T) {
+		t.Parallel()
+
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		svc := service.NewImportService(persistence.NewInMemoryBookmarkRepository(), persistence.NewInMemoryImportJobRepository(), service.ImportOptions{})
+		go svc.Run(runCtx)
+
+		job, err := svc.Submit(ctx, importItems(1))
+		require.NoError(t, err)
+		waitForImport(t, svc, job.ID)
+
+		_, err = svc.Cancel(ctx, job.ID)
+		assert.ErrorIs(t, err, domain.ErrImportJobFinished)
+		job, err = svc.GetByID(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ImportJobCompleted, job.Status, "a completed job stays completed")
+	})
+
+	t.Run("Unknown Job", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := svc.Cancel(ctx, "missing")
+		assert.ErrorIs(t, err, domain.ErrImportJobNotFound)
+	})
+
+	t.Run("Empty Import", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := svc.Submit(ctx, nil)
+		assert.ErrorIs(t, err, domain.ErrImportEmpty)
+	})
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// LinkCheckService finds bookmarks whose links have stopped working.
+type LinkCheckService interface {
+	// CheckAll checks every bookmark once and records the result on each.
+	CheckAll(ctx context.Context) (*domain.LinkCheckRun, error)
+	// Run calls CheckAll every Interval until ctx is done.
+	Run(ctx context.Context) error
+	Report(ctx context.Context) (*domain.LinkHealthReport, error)
+}
+
+type LinkCheckOptions struct {
+	Interval    time.Duration // time between the starts of two passes
+	Workers     int           // links checked concurrently
+	BrokenAfter int           // consecutive failures before a link counts as broken
+	// FollowPermanentRedirects replaces a bookmark's URL with the target of
+	// a permanent redirect that leads to a working page.
+	FollowPermanentRedirects bool
+}
+
+type linkCheckService struct {
+	repo    domain.BookmarkRepository
+	checker domain.LinkChecker
+	opts    LinkCheckOptions
+
+	mu      sync.Mutex
+	lastRun *domain.LinkCheckRun
+}
+
+func NewLinkCheckService(repo domain.BookmarkRepository, checker domain.LinkChecker, opts LinkCheckOptions) LinkCheckService {
+	if opts.Interval <= 0 {
+		opts.Interval = 24 * time.Hour
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	if opts.BrokenAfter <= 0 {
+		opts.BrokenAfter = 1
+	}
+	return &linkCheckService{repo: repo, checker: checker, opts: opts}
+}
+
+// Run starts with a pass right away, so a restart does not postpone checking
+// by a full interval.
+func (s *linkCheckService) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.CheckAll(ctx); err != nil && ctx.Err() == nil {
+			domain.LoggerFrom(ctx).Error("Link check failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *linkCheckService) CheckAll(ctx context.Context) (*domain.LinkCheckRun, error) {
+	run := &domain.LinkCheckRun{StartedAt: time.Now()}
+	s.mu.Lock()
+	s.lastRun = run
+	s.mu.Unlock()
+
+	sem := make(chan struct{}, s.opts.Workers)
+	var wg sync.WaitGroup
+	err := s.repo.Walk(ctx, domain.BookmarkFilter{}, func(b *domain.Bookmark) error {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			s.check(ctx, run, b.ID, b.URL)
+		})
+		return nil
+	})
+	wg.Wait()
+
+	s.mu.Lock()
+	finished := time.Now()
+	run.FinishedAt = &finished
+	result := *run
+	s.mu.Unlock()
+
+	if err != nil {
+		return &result, fmt.Errorf("service.CheckAll: %w", err)
+	}
+	return &result, nil
+}
+
+func (s *linkCheckService) check(ctx context.Context, run *domain.LinkCheckRun, id, url string) {
+	result := s.checker.Check(ctx, url)
+	if ctx.Err() != nil {
+		return
+	}
+
+	// Re-read so that a slow check does not overwrite changes made meanwhile,
+	// and drop the result if the URL itself was edited.
+	b, err := s.repo.GetByID(ctx, id)
+	if err != nil || b.URL != url {
+		return
+	}
+	b = b.Clone()
+	moved := s.applyCheck(b, result, time.Now())
+
+	if err := s.repo.Update(ctx, b); err != nil {
+		if !errors.Is(err, domain.ErrBookmarkNotFound) {
+			domain.LoggerFrom(ctx).Error("Saving link check failed", "bookmark_id", id, "error", err)
+		}
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run.Checked++
+	if !result.OK() && !result.Inconclusive() {
+		run.Failed++
+	}
+	if moved {
+		run.Moved++
+	}
+}
+
+// applyCheck records result on b and reports whether b's URL was replaced.
+// An inconclusive result updates the details but leaves the status and the
+// failure count alone.
+func (s *linkCheckService) applyCheck(b *domain.Bookmark, result domain.LinkCheck, now time.Time) bool {
+	h := b.Health
+	if h == nil {
+		h = &domain.LinkHealth{Status: domain.LinkUnchecked}
+		b.Health = h
+	}
+	h.CheckedAt = now
+	h.StatusCode = result.StatusCode
+	h.FinalURL = result.FinalURL
+	h.LatencyMS = result.Latency.Milliseconds()
+	h.Error = ""
+	if result.Err != nil {
+		h.Error = result.Err.Error()
+	}
+
+	switch {
+	case result.Inconclusive():
+		return false
+	case !result.OK():
+		h.ConsecutiveFailures++
+		h.Status = domain.LinkFailing
+		if h.ConsecutiveFailures >= s.opts.BrokenAfter {
+			h.Status = domain.LinkBroken
+		}
+		return false
+	}
+
+	h.ConsecutiveFailures = 0
+	h.Status = domain.LinkOK
+	if result.FinalURL == "" || result.FinalURL == b.URL {
+		return false
+	}
+	if !s.opts.FollowPermanentRedirects || !result.PermanentRedirect {
+		h.Status = domain.LinkRedirected
+		return false
+	}
+	h.PreviousURL = b.URL
+	b.URL = result.FinalURL
+	b.UpdatedAt = now
+	return true
+}
+
+func (s *linkCheckService) Report(ctx context.Context) (*domain.LinkHealthReport, error) {
+	report := &domain.LinkHealthReport{
+		ByStatus:     make(map[domain.LinkStatus]int),
+		ByStatusCode: make(map[string]int),
+		Broken:       []*domain.Bookmark{},
+	}
+	err := s.repo.Walk(ctx, domain.BookmarkFilter{}, func(b *domain.Bookmark) error {
+		report.Total++
+		report.ByStatus[b.LinkStatus()]++
+		switch {
+		case b.Health == nil:
+		case b.Health.StatusCode == 0:
+			report.ByStatusCode["error"]++
+		default:
+			report.ByStatusCode[strconv.Itoa(b.Health.StatusCode)]++
+		}
+		if b.LinkStatus() == domain.LinkBroken {
+			report.Broken = append(report.Broken, b)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service.Report: %w", err)
+	}
+
+	slices.SortStableFunc(report.Broken, func(a, b *domain.Bookmark) int {
+		return b.Health.ConsecutiveFailures - a.Health.ConsecutiveFailures
+	})
+
+	s.mu.Lock()
+	if s.lastRun != nil {
+		run := *s.lastRun
+		report.LastRun = &run
+	}
+	s.mu.Unlock()
+
+	return report, nil
+}
@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/etsrc/goprod/internal/mocks"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkCheckService_CheckAll(t *testing.T) {
+	t.Parallel()
+
+	const (
+		url    = "https://example.com/post"
+		newURL = "https://example.com/posts/1"
+	)
+	ok := domain.LinkCheck{StatusCode: 200, FinalURL: url, Latency: 120 * time.Millisecond}
+	notFound := domain.LinkCheck{StatusCode: 404, FinalURL: url}
+	refused := domain.LinkCheck{Err: errors.New("connection refused")}
+	moved := domain.LinkCheck{StatusCode: 200, FinalURL: newURL, PermanentRedirect: true}
+
+	tests := []struct {
+		name            string
+		follow          bool
+		checks          []domain.LinkCheck // one per pass
+		wantStatus      domain.LinkStatus
+		wantFailures    int
+		wantURL         string
+		wantPreviousURL string
+	}{
+		{
+			name:       "Working Link",
+			checks:     []domain.LinkCheck{ok},
+			wantStatus: domain.LinkOK,
+			wantURL:    url,
+		},
+		{
+			name:         "Failing Until Broken",
+			checks:       []domain.LinkCheck{notFound, refused},
+			wantStatus:   domain.LinkFailing,
+			wantFailures: 2,
+			wantURL:      url,
+		},
+		{
+			name:         "Broken After Threshold",
+			checks:       []domain.LinkCheck{notFound, refused, notFound},
+			wantStatus:   domain.LinkBroken,
+			wantFailures: 3,
+			wantURL:      url,
+		},
+		{
+			name:       "Success Resets Failures",
+			checks:     []domain.LinkCheck{notFound, notFound, notFound, ok},
+			wantStatus: domain.LinkOK,
+			wantURL:    url,
+		},
+		{
+			name:         "Rate Limit Is Inconclusive",
+			checks:       []domain.LinkCheck{notFound, {StatusCode: 429, FinalURL: url}},
+			wantStatus:   domain.LinkFailing,
+			wantFailures: 1,
+			wantURL:      url,
+		},
+		{
+			name:       "Permanent Redirect Reported",
+			checks:     []domain.LinkCheck{moved},
+			wantStatus: domain.LinkRedirected,
+			wantURL:    url,
+		},
+		{
+			name:            "Permanent Redirect Followed",
+			follow:          true,
+			checks:          []domain.LinkCheck{moved},
+			wantStatus:      domain.LinkOK,
+			wantURL:         newURL,
+			wantPreviousURL: url,
+		},
+		{
+			name:       "Temporary Redirect Not Followed",
+			follow:     true,
+			checks:     []domain.LinkCheck{{StatusCode: 200, FinalURL: newURL}},
+			wantStatus: domain.LinkRedirected,
+			wantURL:    url,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := persistence.NewInMemoryBookmarkRepository()
+			b := &domain.Bookmark{ID: "b1", URL: url, Title: "Post", CreatedAt: time.Now()}
+			require.NoError(t, repo.Create(ctx, b))
+
+			checker := mocks.NewLinkChecker(t)
+			for _, c := range tt.checks {
+				checker.On("Check", mock.Anything, mock.Anything).Return(c).Once()
+			}
+			svc := service.NewLinkCheckService(repo, checker, service.LinkCheckOptions{
+				BrokenAfter:              3,
+				FollowPermanentRedirects: tt.follow,
+			})
+
+			for range tt.checks {
+				run, err := svc.CheckAll(ctx)
+				require.NoError(t, err)
+				assert.Equal(t, 1, run.Checked)
+				assert.NotNil(t, run.FinishedAt)
+			}
+
+			got, err := repo.GetByID(ctx, b.ID)
+			require.NoError(t, err)
+			require.NotNil(t, got.Health)
+			assert.Equal(t, tt.wantStatus, got.Health.Status)
+			assert.Equal(t, tt.wantFailures, got.Health.ConsecutiveFailures)
+			assert.Equal(t, tt.wantURL, got.URL)
+			assert.Equal(t, tt.wantPreviousURL, got.Health.PreviousURL)
+			assert.False(t, got.Health.CheckedAt.IsZero())
+		})
+	}
+}
+
+func TestLinkCheckService_Report(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := persistence.NewInMemoryBookmarkRepository()
+	now := time.Now()
+	for _, b := range []*domain.Bookmark{
+		{ID: "ok", URL: "https://a.example", CreatedAt: now,
+			Health: &domain.LinkHealth{Status: domain.LinkOK, StatusCode: 200}},
+		{ID: "broken-2", URL: "https://b.example", CreatedAt: now.Add(time.Second),
+			Health: &domain.LinkHealth{Status: domain.LinkBroken, StatusCode: 404, ConsecutiveFailures: 2}},
+		{ID: "broken-5", URL: "https://c.example", CreatedAt: now.Add(2 * time.Second),
+			Health: &domain.LinkHealth{Status: domain.LinkBroken, Error: "no such host", ConsecutiveFailures: 5}},
+		{ID: "new", URL: "https://d.example", CreatedAt: now.Add(3 * time.Second)},
+	} {
+		require.NoError(t, repo.Create(ctx, b))
+	}
+
+	checker := mocks.NewLinkChecker(t)
+	svc := service.NewLinkCheckService(repo, checker, service.LinkCheckOptions{})
+
+	report, err := svc.Report(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, map[domain.LinkStatus]int{domain.LinkOK: 1, domain.LinkBroken: 2, domain.LinkUnchecked: 1}, report.ByStatus)
+	assert.Equal(t, map[string]int{"200": 1, "404": 1, "error": 1}, report.ByStatusCode)
+	require.Len(t, report.Broken, 2)
+	assert.Equal(t, "broken-5", report.Broken[0].ID)
+	assert.Nil(t, report.LastRun, "no pass has run yet")
+}
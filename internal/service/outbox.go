@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/google/uuid"
+)
+
+// outboxRepository stores the events for every write in the same transaction
+// as the write, and leaves publishing them to an OutboxRelay.
+type outboxRepository struct {
+	domain.AtomicBookmarkRepository
+	relay OutboxRelay
+}
+
+// WithOutbox decorates repo so that creating, updating, deleting and
+// restoring bookmarks records BookmarkCreated, BookmarkUpdated (followed by
+// TagAdded and TagRemoved when the tags changed), BookmarkDeleted and
+// BookmarkRestored events. They are written to repo's outbox atomically with
+// the change they describe, so a crash between the two cannot lose them.
+// relay is notified after each commit and publishes the events. Purging a
+// bookmark already deleted records nothing.
+func WithOutbox(repo domain.AtomicBookmarkRepository, relay OutboxRelay) domain.BookmarkRepository {
+	return &outboxRepository{AtomicBookmarkRepository: repo, relay: relay}
+}
+
+func (r *outboxRepository) Create(ctx context.Context, b *domain.Bookmark) error {
+	return r.atomically(ctx, func(tx domain.BookmarkTx) error {
+		if err := tx.Create(ctx, b); err != nil {
+			return err
+		}
+		tx.Record(createEvents(ctx, b)...)
+		return nil
+	})
+}
+
+func (r *outboxRepository) CreateBatch(ctx context.Context, bs []*domain.Bookmark) error {
+	return r.atomically(ctx, func(tx domain.BookmarkTx) error {
+		if err := tx.CreateBatch(ctx, bs); err != nil {
+			return err
+		}
+		tx.Record(createEvents(ctx, bs...)...)
+		return nil
+	})
+}
+
+func (r *outboxRepository) Update(ctx context.Context, b *domain.Bookmark) error {
+	return r.atomically(ctx, func(tx domain.BookmarkTx) error {
+		before, _ := tx.GetByID(ctx, b.ID)
+		if err := tx.Update(ctx, b); err != nil {
+			return err
+		}
+		tx.Record(updateEvents(ctx, before, b.Clone())...)
+		return nil
+	})
+}
+
+func (r *outboxRepository) CompareAndUpdate(ctx context.Context, b *domain.Bookmark, version int64) error {
+	return r.atomically(ctx, func(tx domain.BookmarkTx) error {
+		before, _ := tx.GetByID(ctx, b.ID)
+		if err := tx.CompareAndUpdate(ctx, b, version); err != nil {
+			return err
+		}
+		tx.Record(updateEvents(ctx, before, b.Clone())...)
+		return nil
+	})
+}
+
+func (r *outboxRepository) Delete(ctx context.Context, id string) error {
+	return r.atomically(ctx, func(tx domain.BookmarkTx) error {
+		before, err := tx.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(ctx, id); err != nil {
+			return err
+		}
+		tx.Record(domain.BookmarkDeleted{EventMeta: newEventMeta(ctx), Bookmark: before})
+		return nil
+	})
+}
+
+func (r *outboxRepository) CompareAndDelete(ctx context.Context, id string, version int64) error {
+	return r.atomically(ctx, func(tx domain.BookmarkTx) error {
+		before, err := tx.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.CompareAndDelete(ctx, id, version); err != nil {
+			return err
+		}
+		tx.Record(domain.BookmarkDeleted{EventMeta: newEventMeta(ctx), Bookmark: before})
+		return nil
+	})
+}
+
+func (r *outboxRepository) Restore(ctx context.Context, id string) error {
+	return r.atomically(ctx, func(tx domain.BookmarkTx) error {
+		if err := tx.Restore(ctx, id); err != nil {
+			return err
+		}
+		restored, err := tx.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		tx.Record(domain.BookmarkRestored{EventMeta: newEventMeta(ctx), Bookmark: restored.Clone()})
+		return nil
+	})
+}
+
+func (r *outboxRepository) atomically(ctx context.Context, fn func(tx domain.BookmarkTx) error) error {
+	if err := r.Atomically(ctx, fn); err != nil {
+		return err
+	}
+	r.relay.Notify()
+	return nil
+}
+
+// OutboxRelay publishes the events stored in an outbox, in the order they
+// were stored, and removes them once published.
+//
+// Delivery is at least once: an event is published again when a synchronous
+// subscriber fails to handle it, or when the relay stops between publishing
+// and acknowledging it. Subscribers that must not act twice on an event
+// deduplicate by its EventID.
+type OutboxRelay interface {
+	// Notify wakes the relay up, without waiting for the next poll.
+	Notify()
+	// Run dispatches pending events every PollInterval, or when notified,
+	// until ctx is done.
+	Run(ctx context.Context) error
+}
+
+type OutboxOptions struct {
+	PollInterval time.Duration // time between two looks at the outbox when not notified
+	BatchSize    int           // records read from the outbox at once
+	// DedupWindow is how many recently published event IDs the relay
+	// remembers, so that events it failed to acknowledge are not published
+	// twice by the same process.
+	DedupWindow int
+}
+
+type outboxRelay struct {
+	outbox    domain.Outbox
+	publisher domain.EventPublisher
+	opts      OutboxOptions
+	notify    chan struct{}
+
+	// recent holds the IDs of the last DedupWindow published events; seen
+	// indexes it.
+	recent []string
+	next   int
+	seen   map[string]struct{}
+}
+
+func NewOutboxRelay(outbox domain.Outbox, publisher domain.EventPublisher, opts OutboxOptions) OutboxRelay {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.DedupWindow <= 0 {
+		opts.DedupWindow = 10000
+	}
+	return &outboxRelay{
+		outbox:    outbox,
+		publisher: publisher,
+		opts:      opts,
+		notify:    make(chan struct{}, 1),
+		recent:    make([]string, opts.DedupWindow),
+		seen:      make(map[string]struct{}, opts.DedupWindow),
+	}
+}
+
+func (r *outboxRelay) Notify() {
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run makes a last pass once ctx is done, so that writes made during
+// shutdown are still published.
+func (r *outboxRelay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.dispatch(context.WithoutCancel(ctx))
+			return nil
+		case <-ticker.C:
+		case <-r.notify:
+		}
+		r.dispatch(ctx)
+	}
+}
+
+// dispatch publishes pending records until the outbox is empty, cannot be
+// read or acknowledged, or a synchronous subscriber fails; what is left is
+// retried on the next pass.
+func (r *outboxRelay) dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		records, err := r.outbox.Pending(ctx, r.opts.BatchSize)
+		if err != nil {
+			domain.LoggerFrom(ctx).Error("Outbox relay failed", "error", err)
+			return
+		}
+		if len(records) == 0 {
+			return
+		}
+
+		// Records are acknowledged up to the first that a subscriber failed
+		// to handle, which stays in the outbox, holding back those after it
+		// so that they are still published in order.
+		ids := make([]string, 0, len(records))
+		var failed error
+		for _, rec := range records {
+			id := rec.Event.EventID()
+			if _, dup := r.seen[id]; !dup {
+				if failed = r.publisher.Publish(ctx, rec.Event); failed != nil {
+					domain.LoggerFrom(ctx).Warn("Outbox event not delivered, will retry", "event_type", rec.Event.EventType(), "event_id", id, "error", failed)
+					break
+				}
+				r.remember(id)
+			}
+			ids = append(ids, id)
+		}
+		if len(ids) > 0 {
+			if err := r.outbox.Ack(ctx, ids...); err != nil {
+				domain.LoggerFrom(ctx).Error("Outbox relay failed", "error", err)
+				return
+			}
+		}
+		if failed != nil {
+			return
+		}
+		if len(records) < r.opts.BatchSize {
+			return
+		}
+	}
+}
+
+func (r *outboxRelay) remember(id string) {
+	if old := r.recent[r.next]; old != "" {
+		delete(r.seen, old)
+	}
+	r.recent[r.next] = id
+	r.seen[id] = struct{}{}
+	r.next = (r.next + 1) % len(r.recent)
+}
+
+// createEvents describes the creation of bs.
+func createEvents(ctx context.Context, bs ...*domain.Bookmark) []domain.Event {
+	events := make([]domain.Event, len(bs))
+	for i, b := range bs {
+		events[i] = domain.BookmarkCreated{EventMeta: newEventMeta(ctx), Bookmark: b.Clone()}
+	}
+	return events
+}
+
+// updateEvents describes the replacement of before by after: a
+// BookmarkUpdated, followed by a TagAdded or TagRemoved per changed tag.
+func updateEvents(ctx context.Context, before, after *domain.Bookmark) []domain.Event {
+	events := []domain.Event{domain.BookmarkUpdated{EventMeta: newEventMeta(ctx), Before: before, Bookmark: after}}
+	if before == nil {
+		return events
+	}
+	added, removed := domain.DiffTags(before.Tags, after.Tags)
+	for _, tag := range added {
+		events = append(events, domain.TagAdded{EventMeta: newEventMeta(ctx), BookmarkID: after.ID, Tag: tag})
+	}
+	for _, tag := range removed {
+		events = append(events, domain.TagRemoved{EventMeta: newEventMeta(ctx), BookmarkID: after.ID, Tag: tag})
+	}
+	return events
+}
+
+// newEventMeta attributes the event to the actor of ctx, the context of the
+// write it describes.
+func newEventMeta(ctx context.Context) domain.EventMeta {
+	return domain.EventMeta{ID: uuid.NewString(), At: time.Now(), Actor: domain.ActorFrom(ctx)}
+}
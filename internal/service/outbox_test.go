@@ -0,0 +1,247 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPublisher struct {
+	events []domain.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, events ...domain.Event) error {
+	p.events = append(p.events, events...)
+	return nil
+}
+
+func (p *recordingPublisher) types() []domain.EventType {
+	var types []domain.EventType
+	for _, e := range p.events {
+		types = append(types, e.EventType())
+	}
+	return types
+}
+
+// immediateRelay dispatches on every notification, so the events of a write
+// are published before the write returns.
+type immediateRelay struct {
+	service.OutboxRelay
+}
+
+func (r immediateRelay) Notify() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = r.Run(ctx)
+}
+
+// withImmediateOutbox returns an in-memory repository whose events reach
+// events as soon as each write commits.
+func withImmediateOutbox(events domain.EventPublisher) domain.BookmarkRepository {
+	store := persistence.NewInMemoryBookmarkRepository()
+	return service.WithOutbox(store, immediateRelay{service.NewOutboxRelay(store, events, service.OutboxOptions{})})
+}
+
+// drain runs the relay once: Run makes a last pass when ctx is already done.
+func drain(t *testing.T, relay service.OutboxRelay) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, relay.Run(ctx))
+}
+
+func TestWithOutbox(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := persistence.NewInMemoryBookmarkRepository()
+	events := &recordingPublisher{}
+	relay := service.NewOutboxRelay(store, events, service.OutboxOptions{BatchSize: 2})
+	repo := service.WithOutbox(store, relay)
+	svc := service.NewBookmarkService(repo)
+
+	b := &domain.Bookmark{URL: "https://go.dev", Title: "The Go language", Tags: []string{"go", "lang"}}
+	require.NoError(t, svc.Create(ctx, b))
+	updated := b.Clone()
+	updated.Tags = []string{"go", "docs"}
+	require.NoError(t, repo.Update(ctx, updated))
+	require.NoError(t, svc.Delete(ctx, b.ID))
+
+	// Nothing is published until the relay runs; the events wait in the
+	// outbox.
+	assert.Empty(t, events.events)
+	pending, err := store.Pending(ctx, 10)
+	require.NoError(t, err)
+	assert.Len(t, pending, 5)
+
+	drain(t, relay)
+	assert.Equal(t, []domain.EventType{
+		domain.EventBookmarkCreated,
+		domain.EventBookmarkUpdated,
+		domain.EventTagAdded,
+		domain.EventTagRemoved,
+		domain.EventBookmarkDeleted,
+	}, events.types())
+
+	for _, e := range events.events {
+		assert.Equal(t, b.ID, e.AggregateID())
+		assert.NotEmpty(t, e.EventID())
+	}
+	update := events.events[1].(domain.BookmarkUpdated)
+	assert.Equal(t, []string{"go", "lang"}, update.Before.Tags)
+	assert.Equal(t, []string{"go", "docs"}, update.Bookmark.Tags)
+	assert.Equal(t, "docs", events.events[2].(domain.TagAdded).Tag)
+	assert.Equal(t, "lang", events.events[3].(domain.TagRemoved).Tag)
+	assert.Equal(t, "The Go language", events.events[4].(domain.BookmarkDeleted).Bookmark.Title)
+
+	// The recorded bookmark is a copy, so later changes to the caller's
+	// value do not reach subscribers.
+	b.Title = "Changed"
+	assert.Equal(t, "The Go language", events.events[0].(domain.BookmarkCreated).Bookmark.Title)
+
+	pending, err = store.Pending(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestWithOutbox_Restore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	events := &recordingPublisher{}
+	svc := service.NewBookmarkService(withImmediateOutbox(events))
+
+	b := &domain.Bookmark{URL: "https://go.dev", Title: "The Go language"}
+	require.NoError(t, svc.Create(ctx, b))
+	require.NoError(t, svc.Delete(ctx, b.ID))
+	_, err := svc.Restore(ctx, b.ID)
+	require.NoError(t, err)
+	require.NoError(t, svc.Delete(ctx, b.ID))
+	require.NoError(t, svc.Purge(ctx, b.ID))
+
+	assert.Equal(t, []domain.EventType{
+		domain.EventBookmarkCreated,
+		domain.EventBookmarkDeleted,
+		domain.EventBookmarkRestored,
+		domain.EventBookmarkDeleted,
+	}, events.types())
+	restored := events.events[2].(domain.BookmarkRestored)
+	assert.Nil(t, restored.Bookmark.DeletedAt)
+	assert.Equal(t, int64(2), restored.Bookmark.Version)
+}
+
+func TestWithOutbox_FailedWriteRecordsNothing(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := persistence.NewInMemoryBookmarkRepository()
+	repo := service.WithOutbox(store, service.NewOutboxRelay(store, &recordingPublisher{}, service.OutboxOptions{}))
+
+	assert.ErrorIs(t, repo.Update(ctx, &domain.Bookmark{ID: "missing"}), domain.ErrBookmarkNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, "missing"), domain.ErrBookmarkNotFound)
+
+	pending, err := store.Pending(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+// flakyOutbox fails to acknowledge the first time.
+type flakyOutbox struct {
+	domain.Outbox
+	failed bool
+}
+
+func (o *flakyOutbox) Ack(ctx context.Context, ids ...string) error {
+	if !o.failed {
+		o.failed = true
+		return errors.New("connection reset")
+	}
+	return o.Outbox.Ack(ctx, ids...)
+}
+
+func TestOutboxRelay_DeduplicatesUnacknowledged(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := persistence.NewInMemoryBookmarkRepository()
+	events := &recordingPublisher{}
+	relay := service.NewOutboxRelay(&flakyOutbox{Outbox: store}, events, service.OutboxOptions{})
+	repo := service.WithOutbox(store, relay)
+
+	require.NoError(t, repo.Create(ctx, &domain.Bookmark{ID: "a", URL: "https://go.dev"}))
+
+	// The first pass publishes but fails to acknowledge, so the second
+	// finds the event again and only acknowledges it.
+	drain(t, relay)
+	drain(t, relay)
+	assert.Len(t, events.events, 1)
+
+	pending, err := store.Pending(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+// failingPublisher fails to publish the event with the given ID the first
+// time, as a bus does when a synchronous subscriber fails.
+type failingPublisher struct {
+	recordingPublisher
+	failID string
+	failed bool
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, events ...domain.Event) error {
+	for _, e := range events {
+		if e.EventID() == p.failID && !p.failed {
+			p.failed = true
+			return errors.New("subscriber sync: change log unavailable")
+		}
+	}
+	return p.recordingPublisher.Publish(ctx, events...)
+}
+
+func TestOutboxRelay_RetriesFailedDelivery(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := persistence.NewInMemoryBookmarkRepository()
+	events := &failingPublisher{}
+	relay := service.NewOutboxRelay(store, events, service.OutboxOptions{})
+	repo := service.WithOutbox(store, relay)
+
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, repo.Create(ctx, &domain.Bookmark{ID: id, URL: "https://go.dev/" + id}))
+	}
+	pending, err := store.Pending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 3)
+	events.failID = pending[1].Event.EventID()
+
+	// The failed event and those after it stay in the outbox; the one
+	// before it is acknowledged.
+	drain(t, relay)
+	assert.Equal(t, []string{"a"}, aggregateIDs(events.events))
+	pending, err = store.Pending(ctx, 10)
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+
+	// The next pass delivers them, in order.
+	drain(t, relay)
+	assert.Equal(t, []string{"a", "b", "c"}, aggregateIDs(events.events))
+	pending, err = store.Pending(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func aggregateIDs(events []domain.Event) []string {
+	ids := make([]string, len(events))
+	for i, e := range events {
+		ids[i] = e.AggregateID()
+	}
+	return ids
+}
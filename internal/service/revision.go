@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// RevisionService keeps a revision of a bookmark for every change to the
+// fields people edit, so that an unwanted change can be seen and undone.
+type RevisionService interface {
+	// List returns a bookmark's revisions, newest first.
+	List(ctx context.Context, bookmarkID string) ([]*domain.Revision, error)
+	// Diff compares two of a bookmark's revisions. A to of 0 means the
+	// latest revision.
+	Diff(ctx context.Context, bookmarkID string, from, to int64) (*domain.RevisionDiff, error)
+	// Revert gives a bookmark the domain.RevisionFields of one of its
+	// revisions. The change makes a new revision; no revision is removed.
+	Revert(ctx context.Context, bookmarkID string, number int64) (*domain.Bookmark, error)
+	// DeleteAll removes a bookmark's revisions.
+	DeleteAll(ctx context.Context, bookmarkID string) error
+	// Handle records a revision for a change to a bookmark. It is meant to
+	// be subscribed to the event bus synchronously, so that revisions are
+	// numbered in the order changes were made.
+	Handle(ctx context.Context, e domain.Event) error
+	// Run prunes revisions beyond retention every PruneInterval until ctx
+	// is done. It returns at once when retention is unlimited.
+	Run(ctx context.Context) error
+}
+
+// RevisionOptions sets how long revisions are kept. A revision is kept while
+// it is one of the Keep newest of its bookmark or is younger than MaxAge;
+// with neither set, revisions are kept forever. The newest revision of a
+// bookmark is always kept.
+type RevisionOptions struct {
+	Keep          int
+	MaxAge        time.Duration
+	PruneInterval time.Duration
+}
+
+type revisionService struct {
+	repo      domain.BookmarkRepository
+	revisions domain.RevisionRepository
+	opts      RevisionOptions
+
+	// mu makes comparing a change with the latest revision and adding the
+	// next one a single step.
+	mu sync.Mutex
+}
+
+func NewRevisionService(repo domain.BookmarkRepository, revisions domain.RevisionRepository, opts RevisionOptions) RevisionService {
+	if opts.PruneInterval <= 0 {
+		opts.PruneInterval = time.Hour
+	}
+	return &revisionService{repo: repo, revisions: revisions, opts: opts}
+}
+
+func (s *revisionService) List(ctx context.Context, bookmarkID string) ([]*domain.Revision, error) {
+	revisions, err := s.revisions.List(ctx, bookmarkID)
+	if err != nil {
+		return nil, fmt.Errorf("service.List: %w", err)
+	}
+	if len(revisions) == 0 {
+		// A bookmark made before revisions were kept has none.
+		if _, err := s.repo.GetByID(ctx, bookmarkID); err != nil {
+			return nil, fmt.Errorf("service.List: %w", err)
+		}
+	}
+	return revisions, nil
+}
+
+func (s *revisionService) Diff(ctx context.Context, bookmarkID string, from, to int64) (*domain.RevisionDiff, error) {
+	fromRev, err := s.revisions.Get(ctx, bookmarkID, from)
+	if err != nil {
+		return nil, fmt.Errorf("service.Diff: %w", err)
+	}
+	var toRev *domain.Revision
+	if to == 0 {
+		revisions, err := s.revisions.List(ctx, bookmarkID)
+		if err != nil {
+			return nil, fmt.Errorf("service.Diff: %w", err)
+		}
+		toRev = revisions[0]
+	} else if toRev, err = s.revisions.Get(ctx, bookmarkID, to); err != nil {
+		return nil, fmt.Errorf("service.Diff: %w", err)
+	}
+	return &domain.RevisionDiff{
+		BookmarkID: bookmarkID,
+		From:       fromRev.Number,
+		To:         toRev.Number,
+		Changes:    domain.DiffBookmarks(fromRev.Bookmark, toRev.Bookmark),
+	}, nil
+}
+
+func (s *revisionService) Revert(ctx context.Context, bookmarkID string, number int64) (*domain.Bookmark, error) {
+	rev, err := s.revisions.Get(ctx, bookmarkID, number)
+	if err != nil {
+		return nil, fmt.Errorf("service.Revert: %w", err)
+	}
+	current, err := s.repo.GetByID(ctx, bookmarkID)
+	if err != nil {
+		return nil, fmt.Errorf("service.Revert: %w", err)
+	}
+	if len(domain.DiffBookmarks(current, rev.Bookmark)) == 0 {
+		return current, nil
+	}
+
+	b := current.Clone()
+	b.URL, b.Title, b.Description = rev.Bookmark.URL, rev.Bookmark.Title, rev.Bookmark.Description
+	b.Tags, b.ArchiveMode = slices.Clone(rev.Bookmark.Tags), rev.Bookmark.ArchiveMode
+	b.UpdatedAt = time.Now()
+	if err := b.Validate(); err != nil {
+		return nil, fmt.Errorf("service.Revert: %w", err)
+	}
+	if err := s.repo.Update(ctx, b); err != nil {
+		return nil, fmt.Errorf("service.Revert: %w", err)
+	}
+	return b, nil
+}
+
+func (s *revisionService) DeleteAll(ctx context.Context, bookmarkID string) error {
+	if err := s.revisions.DeleteByBookmark(ctx, bookmarkID); err != nil {
+		return fmt.Errorf("service.DeleteAll: %w", err)
+	}
+	return nil
+}
+
+func (s *revisionService) Handle(ctx context.Context, e domain.Event) error {
+	rev := &domain.Revision{BookmarkID: e.AggregateID(), Actor: e.EventActor(), At: e.OccurredAt(), EventID: e.EventID()}
+	var before *domain.Bookmark
+	switch e := e.(type) {
+	case domain.BookmarkCreated:
+		rev.Change, rev.Bookmark = domain.RevisionCreated, e.Bookmark
+	case domain.BookmarkUpdated:
+		rev.Change, rev.Bookmark, before = domain.RevisionUpdated, e.Bookmark, e.Before
+	case domain.BookmarkDeleted:
+		rev.Change, rev.Bookmark, rev.Fields = domain.RevisionDeleted, e.Bookmark, []string{}
+	case domain.BookmarkRestored:
+		rev.Change, rev.Bookmark = domain.RevisionRestored, e.Bookmark
+	default:
+		// Tag changes are part of the update that made them.
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rev.Fields == nil {
+		// Compared with the latest revision when there is one, so that the
+		// fields add up to what the revisions show.
+		latest, err := s.revisions.List(ctx, rev.BookmarkID)
+		if err != nil {
+			return fmt.Errorf("service.Handle: %w", err)
+		}
+		if len(latest) > 0 {
+			before = latest[0].Bookmark
+		}
+		rev.Fields = slices.Clone(domain.RevisionFields)
+		if before != nil {
+			rev.Fields = rev.Fields[:0]
+			for _, c := range domain.DiffBookmarks(before, rev.Bookmark) {
+				rev.Fields = append(rev.Fields, c.Field)
+			}
+		}
+		if rev.Change == domain.RevisionUpdated && len(rev.Fields) == 0 {
+			// Only fields the application maintains changed.
+			return nil
+		}
+	}
+	if err := s.revisions.Add(ctx, rev); err != nil {
+		return fmt.Errorf("service.Handle: %w", err)
+	}
+	return nil
+}
+
+func (s *revisionService) Run(ctx context.Context) error {
+	if s.opts.Keep <= 0 && s.opts.MaxAge <= 0 {
+		return nil
+	}
+	ticker := time.NewTicker(s.opts.PruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		// Without a MaxAge, age keeps no revision beyond the Keep newest.
+		before := time.Now()
+		if s.opts.MaxAge > 0 {
+			before = before.Add(-s.opts.MaxAge)
+		}
+		pruned, err := s.revisions.Prune(ctx, s.opts.Keep, before)
+		if err != nil && ctx.Err() == nil {
+			domain.LoggerFrom(ctx).Error("Pruning revisions failed", "error", err)
+			continue
+		}
+		if pruned > 0 {
+			domain.LoggerFrom(ctx).Info("Pruned revisions", "revisions", pruned)
+		}
+	}
+}
+
+type revisingBookmarkService struct {
+	BookmarkService
+	revisions RevisionService
+}
+
+// WithRevisions decorates svc so that Purge removes the bookmark's
+// revisions.
+func WithRevisions(svc BookmarkService, revisions RevisionService) BookmarkService {
+	return &revisingBookmarkService{BookmarkService: svc, revisions: revisions}
+}
+
+func (s *revisingBookmarkService) Purge(ctx context.Context, id string) error {
+	if err := s.BookmarkService.Purge(ctx, id); err != nil {
+		return err
+	}
+	if err := s.revisions.DeleteAll(ctx, id); err != nil {
+		domain.LoggerFrom(ctx).Error("Deleting revisions failed", "bookmark_id", id, "error", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRevisionTest() (domain.BookmarkRepository, service.RevisionService) {
+	events := &handlerPublisher{}
+	repo := withImmediateOutbox(events)
+	svc := service.NewRevisionService(repo, persistence.NewInMemoryRevisionRepository(), service.RevisionOptions{})
+	events.handle = svc.Handle
+	return repo, svc
+}
+
+func TestRevisionService_Handle(t *testing.T) {
+	t.Parallel()
+
+	ctx := domain.WithActor(context.Background(), "alice")
+	repo, svc := newRevisionTest()
+	b := syncBookmark(t, repo, "Original")
+
+	edited := b.Clone()
+	edited.Description, edited.Tags = "Carefully written", []string{"go"}
+	require.NoError(t, repo.Update(ctx, edited))
+	checked := edited.Clone()
+	checked.Health = &domain.LinkHealth{Status: domain.LinkOK}
+	require.NoError(t, repo.Update(ctx, checked))
+	require.NoError(t, repo.Delete(ctx, b.ID))
+	require.NoError(t, repo.Restore(ctx, b.ID))
+
+	revisions, err := svc.List(ctx, b.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 4, "a change to fields the application maintains makes no revision")
+	assert.Equal(t, domain.RevisionRestored, revisions[0].Change)
+	assert.Empty(t, revisions[0].Fields)
+	assert.Equal(t, domain.RevisionDeleted, revisions[1].Change)
+	assert.Equal(t, domain.RevisionUpdated, revisions[2].Change)
+	assert.Equal(t, []string{"description", "tags"}, revisions[2].Fields)
+	assert.Equal(t, "alice", revisions[2].Actor)
+	assert.Equal(t, "Carefully written", revisions[2].Bookmark.Description)
+	assert.Equal(t, domain.RevisionCreated, revisions[3].Change)
+	assert.Equal(t, int64(1), revisions[3].Number)
+	assert.Equal(t, domain.RevisionFields, revisions[3].Fields)
+	assert.Empty(t, revisions[3].Actor)
+
+	_, err = svc.List(ctx, "unknown")
+	assert.ErrorIs(t, err, domain.ErrBookmarkNotFound)
+}
+
+func TestRevisionService_DiffAndRevert(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo, svc := newRevisionTest()
+	b := syncBookmark(t, repo, "Original")
+	b.Description = "Carefully written"
+	require.NoError(t, repo.Update(ctx, b.Clone()))
+	b.Title, b.Description = "Overwritten", ""
+	require.NoError(t, repo.Update(ctx, b.Clone()))
+
+	diff, err := svc.Diff(ctx, b.ID, 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), diff.To)
+	assert.Equal(t, []domain.FieldChange{
+		{Field: "title", From: "Original", To: "Overwritten"},
+		{Field: "description", From: "Carefully written", To: ""},
+	}, diff.Changes)
+	_, err = svc.Diff(ctx, b.ID, 1, 9)
+	assert.ErrorIs(t, err, domain.ErrRevisionNotFound)
+
+	reverted, err := svc.Revert(ctx, b.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "Original", reverted.Title)
+	assert.Equal(t, "Carefully written", reverted.Description)
+	stored, err := repo.GetByID(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Carefully written", stored.Description)
+
+	revisions, err := svc.List(ctx, b.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 4, "a revert is a revision of its own")
+	assert.Equal(t, []string{"title", "description"}, revisions[0].Fields)
+
+	// Reverting to what the bookmark already is changes nothing.
+	_, err = svc.Revert(ctx, b.ID, 4)
+	require.NoError(t, err)
+	revisions, err = svc.List(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Len(t, revisions, 4)
+}
+
+func TestWithRevisions_Purge(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo, revisions := newRevisionTest()
+	svc := service.WithRevisions(service.NewBookmarkService(repo), revisions)
+	b := &domain.Bookmark{URL: "https://go.dev", Title: "The Go language"}
+	require.NoError(t, svc.Create(ctx, b))
+	require.NoError(t, svc.Delete(ctx, b.ID))
+
+	require.NoError(t, svc.Purge(ctx, b.ID))
+	_, err := revisions.Diff(ctx, b.ID, 1, 0)
+	assert.ErrorIs(t, err, domain.ErrRevisionNotFound)
+}
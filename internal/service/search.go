@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// SearchService runs full-text queries over bookmarks' metadata and page
+// content.
+type SearchService interface {
+	// Search returns up to limit bookmarks matching every word of query, best
+	// first.
+	Search(ctx context.Context, query string, limit int) ([]domain.SearchResult, error)
+}
+
+type searchService struct {
+	repo  domain.BookmarkRepository
+	index domain.SearchIndex
+}
+
+func NewSearchService(repo domain.BookmarkRepository, index domain.SearchIndex) SearchService {
+	return &searchService{repo: repo, index: index}
+}
+
+func (s *searchService) Search(ctx context.Context, query string, limit int) ([]domain.SearchResult, error) {
+	hits, err := s.index.Search(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("service.Search: %w", err)
+	}
+
+	results := make([]domain.SearchResult, 0, len(hits))
+	for _, hit := range hits {
+		b, err := s.repo.GetByID(ctx, hit.ID)
+		if errors.Is(err, domain.ErrBookmarkNotFound) {
+			// Deleted since the query ran.
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("service.Search: %w", err)
+		}
+		results = append(results, domain.SearchResult{Bookmark: b, Score: hit.Score, Snippets: hit.Snippets})
+	}
+	return results, nil
+}
+
+// indexedRepository keeps a search index's metadata in step with every write
+// to the repository, whichever service makes it.
+type indexedRepository struct {
+	domain.BookmarkRepository
+	index domain.SearchIndex
+}
+
+// WithSearchIndex decorates repo so that every bookmark it stores has its
+// metadata indexed, and every bookmark it deletes is dropped from the index.
+// Page content is indexed separately by the ContentService.
+func WithSearchIndex(repo domain.BookmarkRepository, index domain.SearchIndex) domain.BookmarkRepository {
+	return &indexedRepository{BookmarkRepository: repo, index: index}
+}
+
+func (r *indexedRepository) Create(ctx context.Context, b *domain.Bookmark) error {
+	if err := r.BookmarkRepository.Create(ctx, b); err != nil {
+		return err
+	}
+	r.indexMetadata(ctx, b)
+	return nil
+}
+
+func (r *indexedRepository) CreateBatch(ctx context.Context, bs []*domain.Bookmark) error {
+	if err := r.BookmarkRepository.CreateBatch(ctx, bs); err != nil {
+		return err
+	}
+	for _, b := range bs {
+		r.indexMetadata(ctx, b)
+	}
+	return nil
+}
+
+func (r *indexedRepository) Update(ctx context.Context, b *domain.Bookmark) error {
+	if err := r.BookmarkRepository.Update(ctx, b); err != nil {
+		return err
+	}
+	r.indexMetadata(ctx, b)
+	return nil
+}
+
+func (r *indexedRepository) CompareAndUpdate(ctx context.Context, b *domain.Bookmark, version int64) error {
+	if err := r.BookmarkRepository.CompareAndUpdate(ctx, b, version); err != nil {
+		return err
+	}
+	r.indexMetadata(ctx, b)
+	return nil
+}
+
+func (r *indexedRepository) Delete(ctx context.Context, id string) error {
+	if err := r.BookmarkRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	if err := r.index.Remove(ctx, id); err != nil {
+		domain.LoggerFrom(ctx).Error("Removing bookmark from search index failed", "bookmark_id", id, "error", err)
+	}
+	return nil
+}
+
+func (r *indexedRepository) CompareAndDelete(ctx context.Context, id string, version int64) error {
+	if err := r.BookmarkRepository.CompareAndDelete(ctx, id, version); err != nil {
+		return err
+	}
+	if err := r.index.Remove(ctx, id); err != nil {
+		domain.LoggerFrom(ctx).Error("Removing bookmark from search index failed", "bookmark_id", id, "error", err)
+	}
+	return nil
+}
+
+func (r *indexedRepository) Restore(ctx context.Context, id string) error {
+	if err := r.BookmarkRepository.Restore(ctx, id); err != nil {
+		return err
+	}
+	if b, err := r.BookmarkRepository.GetByID(ctx, id); err == nil {
+		r.indexMetadata(ctx, b)
+	}
+	return nil
+}
+
+// indexMetadata logs rather than fails: the bookmark is already stored, and a
+// stale index entry is corrected by its next write.
+func (r *indexedRepository) indexMetadata(ctx context.Context, b *domain.Bookmark) {
+	if err := r.index.IndexMetadata(ctx, b); err != nil {
+		domain.LoggerFrom(ctx).Error("Indexing bookmark failed", "bookmark_id", b.ID, "error", err)
+	}
+}
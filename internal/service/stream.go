@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// StreamBookmarkRemoved is sent to a stream client instead of an update when
+// the update takes a bookmark out of what the client sees, for example by
+// removing the tag it filters on. Its bookmark holds only the ID.
+const StreamBookmarkRemoved domain.EventType = "bookmark.removed"
+
+// StreamEvent is a change to a bookmark as sent to stream clients.
+type StreamEvent struct {
+	// ID orders events in the stream. A client resumes after the last ID it
+	// saw.
+	ID       string
+	Type     domain.EventType
+	Bookmark *domain.Bookmark
+}
+
+// StreamGrant is what a stream client is authorized to see: bookmarks with
+// any of Tags, or every bookmark when Tags is empty.
+type StreamGrant struct {
+	Tags []string
+}
+
+func (g StreamGrant) Allows(b *domain.Bookmark) bool {
+	return len(g.Tags) == 0 || slices.ContainsFunc(g.Tags, func(t string) bool {
+		return slices.Contains(b.Tags, t)
+	})
+}
+
+// StreamService relays bookmark changes to live clients, such as dashboards.
+type StreamService interface {
+	// Subscribe starts receiving changes to bookmarks that grant allows and
+	// filter matches. With a lastEventID, buffered events after it are
+	// replayed first.
+	Subscribe(ctx context.Context, grant StreamGrant, filter domain.BookmarkFilter, lastEventID string) *StreamSubscription
+	// Handle adds a change to the stream. It is meant to be subscribed to
+	// the event bus, and never waits for clients.
+	Handle(ctx context.Context, e domain.Event) error
+}
+
+type StreamOptions struct {
+	// ReplaySize is how many recent events are kept for clients that
+	// reconnect.
+	ReplaySize int
+	// ClientBuffer is how many events may wait for a client. A client that
+	// falls further behind is disconnected, and catches up from the replay
+	// buffer when it reconnects.
+	ClientBuffer int
+}
+
+// StreamSubscription is one client's view of the stream.
+type StreamSubscription struct {
+	// Replay holds the buffered events after the requested one.
+	Replay []StreamEvent
+	// Reset reports that the requested event is no longer buffered, or was
+	// never part of this stream, so events may have been missed. The client
+	// should reload what it shows.
+	Reset bool
+
+	events  chan StreamEvent
+	visible func(*domain.Bookmark) bool
+	hub     *streamService
+}
+
+// Events delivers live events. It is closed when the client falls behind,
+// and after Close.
+func (s *StreamSubscription) Events() <-chan StreamEvent {
+	return s.events
+}
+
+// Close stops the subscription. It may be called more than once.
+func (s *StreamSubscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.drop(s)
+}
+
+// view is e as this subscription's client sees it, if at all.
+func (s *StreamSubscription) view(e streamChange) (StreamEvent, bool) {
+	switch {
+	case s.visible(e.bookmark):
+		return StreamEvent{ID: e.id, Type: e.typ, Bookmark: e.bookmark}, true
+	case e.typ == domain.EventBookmarkUpdated && e.before != nil && s.visible(e.before):
+		return StreamEvent{ID: e.id, Type: StreamBookmarkRemoved, Bookmark: &domain.Bookmark{ID: e.bookmark.ID}}, true
+	}
+	return StreamEvent{}, false
+}
+
+// streamChange is a buffered change, before it is filtered for clients.
+type streamChange struct {
+	id       string
+	seq      uint64
+	typ      domain.EventType
+	bookmark *domain.Bookmark
+	before   *domain.Bookmark
+}
+
+type streamService struct {
+	opts StreamOptions
+	// epoch tells this process's event IDs from those of an earlier one.
+	epoch string
+
+	mu      sync.Mutex
+	seq     uint64
+	buffer  []streamChange // ring of the last ReplaySize changes
+	start   int            // index of the oldest change in buffer
+	clients map[*StreamSubscription]struct{}
+}
+
+func NewStreamService(opts StreamOptions) StreamService {
+	if opts.ReplaySize <= 0 {
+		opts.ReplaySize = 1000
+	}
+	if opts.ClientBuffer <= 0 {
+		opts.ClientBuffer = 64
+	}
+	return &streamService{
+		opts:    opts,
+		epoch:   strconv.FormatInt(time.Now().UnixNano(), 36),
+		buffer:  make([]streamChange, 0, opts.ReplaySize),
+		clients: make(map[*StreamSubscription]struct{}),
+	}
+}
+
+func (s *streamService) Subscribe(_ context.Context, grant StreamGrant, filter domain.BookmarkFilter, lastEventID string) *StreamSubscription {
+	sub := &StreamSubscription{
+		events: make(chan StreamEvent, s.opts.ClientBuffer),
+		visible: func(b *domain.Bookmark) bool {
+			return grant.Allows(b) && filter.Matches(b)
+		},
+		hub: s,
+	}
+
+	// Replaying and registering under one lock means no event falls
+	// between the two.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if lastEventID != "" {
+		replay, ok := s.since(lastEventID)
+		sub.Reset = !ok
+		for _, c := range replay {
+			if e, ok := sub.view(c); ok {
+				sub.Replay = append(sub.Replay, e)
+			}
+		}
+	}
+	s.clients[sub] = struct{}{}
+	return sub
+}
+
+// since returns the buffered changes after the one with the given ID, and
+// false when that one is not in the buffer, nor the one just before it.
+func (s *streamService) since(id string) ([]streamChange, bool) {
+	epoch, seqText, _ := strings.Cut(id, "-")
+	seq, err := strconv.ParseUint(seqText, 10, 64)
+	if err != nil || epoch != s.epoch || seq > s.seq {
+		return nil, false
+	}
+	if seq == s.seq {
+		return nil, true
+	}
+	oldest := s.seq - uint64(len(s.buffer)) + 1
+	if seq+1 < oldest {
+		return nil, false
+	}
+
+	var changes []streamChange
+	for i := range len(s.buffer) {
+		c := s.buffer[(s.start+i)%len(s.buffer)]
+		if c.seq > seq {
+			changes = append(changes, c)
+		}
+	}
+	return changes, true
+}
+
+func (s *streamService) Handle(_ context.Context, e domain.Event) error {
+	var c streamChange
+	switch e := e.(type) {
+	case domain.BookmarkCreated:
+		c = streamChange{typ: e.EventType(), bookmark: e.Bookmark}
+	case domain.BookmarkUpdated:
+		c = streamChange{typ: e.EventType(), bookmark: e.Bookmark, before: e.Before}
+	case domain.BookmarkDeleted:
+		c = streamChange{typ: e.EventType(), bookmark: e.Bookmark}
+	case domain.BookmarkRestored:
+		c = streamChange{typ: e.EventType(), bookmark: e.Bookmark}
+	default:
+		// Tag changes are part of the update that made them.
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	c.seq = s.seq
+	c.id = s.epoch + "-" + strconv.FormatUint(s.seq, 10)
+	if len(s.buffer) < s.opts.ReplaySize {
+		s.buffer = append(s.buffer, c)
+	} else {
+		s.buffer[s.start] = c
+		s.start = (s.start + 1) % len(s.buffer)
+	}
+
+	for sub := range s.clients {
+		e, ok := sub.view(c)
+		if !ok {
+			continue
+		}
+		select {
+		case sub.events <- e:
+		default:
+			s.drop(sub)
+		}
+	}
+	return nil
+}
+
+// drop unregisters sub and closes its channel. The caller holds mu.
+func (s *streamService) drop(sub *StreamSubscription) {
+	if _, ok := s.clients[sub]; ok {
+		delete(s.clients, sub)
+		close(sub.events)
+	}
+}
@@ -0,0 +1,164 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func created(id, url string, tags ...string) domain.Event {
+	return domain.BookmarkCreated{EventMeta: domain.EventMeta{ID: "e-" + id}, Bookmark: &domain.Bookmark{ID: id, URL: url, Tags: tags}}
+}
+
+// receive returns the events waiting on sub without blocking.
+func receive(sub *service.StreamSubscription) []service.StreamEvent {
+	var events []service.StreamEvent
+	for {
+		select {
+		case e, ok := <-sub.Events():
+			if !ok {
+				return events
+			}
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func bookmarkIDs(events []service.StreamEvent) []string {
+	var ids []string
+	for _, e := range events {
+		ids = append(ids, e.Bookmark.ID)
+	}
+	return ids
+}
+
+func TestStreamService_Filters(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := service.NewStreamService(service.StreamOptions{})
+	all := svc.Subscribe(ctx, service.StreamGrant{}, domain.BookmarkFilter{}, "")
+	byTag := svc.Subscribe(ctx, service.StreamGrant{}, domain.BookmarkFilter{Tag: "go"}, "")
+	byHost := svc.Subscribe(ctx, service.StreamGrant{}, domain.BookmarkFilter{Host: "go.dev"}, "")
+	granted := svc.Subscribe(ctx, service.StreamGrant{Tags: []string{"work"}}, domain.BookmarkFilter{}, "")
+	defer all.Close()
+	defer byTag.Close()
+	defer byHost.Close()
+	defer granted.Close()
+
+	require.NoError(t, svc.Handle(ctx, created("a", "https://go.dev/doc", "go")))
+	require.NoError(t, svc.Handle(ctx, created("b", "https://example.com", "work")))
+	require.NoError(t, svc.Handle(ctx, domain.TagAdded{BookmarkID: "b", Tag: "work"}))
+	require.NoError(t, svc.Handle(ctx, domain.BookmarkDeleted{Bookmark: &domain.Bookmark{ID: "a", URL: "https://go.dev/doc", Tags: []string{"go"}}}))
+
+	assert.Equal(t, []string{"a", "b", "a"}, bookmarkIDs(receive(all)))
+	assert.Equal(t, []string{"a", "a"}, bookmarkIDs(receive(byTag)))
+	assert.Equal(t, []string{"a", "a"}, bookmarkIDs(receive(byHost)))
+	assert.Equal(t, []string{"b"}, bookmarkIDs(receive(granted)))
+}
+
+func TestStreamService_UpdateOutOfView(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := service.NewStreamService(service.StreamOptions{})
+	sub := svc.Subscribe(ctx, service.StreamGrant{Tags: []string{"work"}}, domain.BookmarkFilter{}, "")
+	defer sub.Close()
+
+	before := &domain.Bookmark{ID: "b", Title: "Secret plans", Tags: []string{"work"}}
+	after := &domain.Bookmark{ID: "b", Title: "Secret plans", Tags: []string{"private"}}
+	require.NoError(t, svc.Handle(ctx, domain.BookmarkUpdated{Before: before, Bookmark: after}))
+	require.NoError(t, svc.Handle(ctx, domain.BookmarkUpdated{Before: after, Bookmark: after}))
+
+	events := receive(sub)
+	require.Len(t, events, 1)
+	assert.Equal(t, service.StreamBookmarkRemoved, events[0].Type)
+	assert.Equal(t, &domain.Bookmark{ID: "b"}, events[0].Bookmark)
+}
+
+func TestStreamService_Replay(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := service.NewStreamService(service.StreamOptions{ReplaySize: 3})
+	first := svc.Subscribe(ctx, service.StreamGrant{}, domain.BookmarkFilter{}, "")
+	defer first.Close()
+	for _, id := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, svc.Handle(ctx, created(id, "https://example.com/"+id)))
+	}
+	seen := receive(first)
+	require.Len(t, seen, 4)
+
+	resumed := svc.Subscribe(ctx, service.StreamGrant{}, domain.BookmarkFilter{}, seen[1].ID)
+	defer resumed.Close()
+	assert.False(t, resumed.Reset)
+	assert.Equal(t, []string{"c", "d"}, bookmarkIDs(resumed.Replay))
+	assert.Equal(t, seen[2].ID, resumed.Replay[0].ID)
+
+	upToDate := svc.Subscribe(ctx, service.StreamGrant{}, domain.BookmarkFilter{}, seen[3].ID)
+	defer upToDate.Close()
+	assert.False(t, upToDate.Reset)
+	assert.Empty(t, upToDate.Replay)
+
+	// An ID from another process, such as one before a restart, cannot be
+	// resumed from.
+	tooOld := svc.Subscribe(ctx, service.StreamGrant{}, domain.BookmarkFilter{}, "x"+seen[0].ID)
+	defer tooOld.Close()
+	assert.True(t, tooOld.Reset)
+
+	evicted := service.NewStreamService(service.StreamOptions{ReplaySize: 1})
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, evicted.Handle(ctx, created(id, "https://example.com/"+id)))
+	}
+	sub := evicted.Subscribe(ctx, service.StreamGrant{}, domain.BookmarkFilter{}, "")
+	require.NoError(t, evicted.Handle(ctx, created("d", "https://example.com/d")))
+	d := receive(sub)
+	require.Len(t, d, 1)
+	sub.Close()
+	// IDs from before d's predecessor are gone; the one right before the
+	// oldest buffered event is still a valid place to resume.
+	gone := evicted.Subscribe(ctx, service.StreamGrant{}, domain.BookmarkFilter{}, d[0].ID[:len(d[0].ID)-1]+"1")
+	assert.True(t, gone.Reset)
+	gone.Close()
+	justBefore := evicted.Subscribe(ctx, service.StreamGrant{}, domain.BookmarkFilter{}, d[0].ID[:len(d[0].ID)-1]+"3")
+	assert.False(t, justBefore.Reset)
+	assert.Equal(t, []string{"d"}, bookmarkIDs(justBefore.Replay))
+	justBefore.Close()
+}
+
+func TestStreamService_SlowClientIsDropped(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := service.NewStreamService(service.StreamOptions{ClientBuffer: 2})
+	slow := svc.Subscribe(ctx, service.StreamGrant{}, domain.BookmarkFilter{}, "")
+	fast := svc.Subscribe(ctx, service.StreamGrant{}, domain.BookmarkFilter{}, "")
+	defer fast.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, id := range []string{"a", "b", "c"} {
+			svc.Handle(ctx, created(id, "https://example.com/"+id))
+			receive(fast)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Handle blocked on a slow client")
+	}
+
+	// The slow client got what fitted, then its channel was closed.
+	assert.Equal(t, []string{"a", "b"}, bookmarkIDs(receive(slow)))
+	_, open := <-slow.Events()
+	assert.False(t, open)
+	slow.Close()
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/google/uuid"
+)
+
+// SyncService lets clients keep a local copy of the bookmarks, pulling only
+// what changed since they last synced and pushing the changes they made
+// offline.
+type SyncService interface {
+	// Pull returns the changes since token, at most limit of them. An empty
+	// token returns every bookmark. A token whose changes are no longer
+	// known fails with domain.ErrChangesExpired, after which the client
+	// pulls again without one.
+	Pull(ctx context.Context, token string, limit int) (*domain.SyncPage, error)
+	// Push applies changes in order, returning a result for each.
+	Push(ctx context.Context, changes []domain.SyncChange) ([]domain.SyncResult, error)
+	// Handle records a change in the change log. It is meant to be
+	// subscribed to the event bus synchronously, so that changes are logged
+	// in the order they were made.
+	Handle(ctx context.Context, e domain.Event) error
+	// Run prunes old deletions from the change log every PruneInterval until
+	// ctx is done.
+	Run(ctx context.Context) error
+}
+
+type SyncOptions struct {
+	PageSize int // most changes returned by one pull
+	MaxPush  int // most changes accepted by one push
+	// TombstoneTTL is how long deletions are kept. A client that has not
+	// synced for longer must pull everything again.
+	TombstoneTTL  time.Duration
+	PruneInterval time.Duration
+}
+
+type syncService struct {
+	repo      domain.BookmarkRepository
+	bookmarks BookmarkService
+	changes   domain.ChangeLog
+	opts      SyncOptions
+
+	// pushMu applies pushes one at a time, in the order they arrive. Writes
+	// made other than by pushing are caught by the repository's
+	// CompareAndUpdate and CompareAndDelete.
+	pushMu sync.Mutex
+}
+
+// NewSyncService creates and restores pushed bookmarks through bookmarks, so
+// that they are enriched and archived like those made through the API, and
+// updates and deletes them in repo, comparing versions.
+func NewSyncService(repo domain.BookmarkRepository, bookmarks BookmarkService, changes domain.ChangeLog, opts SyncOptions) SyncService {
+	if opts.PageSize <= 0 {
+		opts.PageSize = 500
+	}
+	if opts.MaxPush <= 0 {
+		opts.MaxPush = 500
+	}
+	if opts.TombstoneTTL <= 0 {
+		opts.TombstoneTTL = 30 * 24 * time.Hour
+	}
+	if opts.PruneInterval <= 0 {
+		opts.PruneInterval = time.Hour
+	}
+	return &syncService{repo: repo, bookmarks: bookmarks, changes: changes, opts: opts}
+}
+
+func (s *syncService) Pull(ctx context.Context, token string, limit int) (*domain.SyncPage, error) {
+	if limit <= 0 || limit > s.opts.PageSize {
+		limit = s.opts.PageSize
+	}
+	epoch, err := s.changes.Epoch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("service.Pull: %w", err)
+	}
+	if token == "" {
+		page, err := s.snapshot(ctx, epoch)
+		if err != nil {
+			return nil, fmt.Errorf("service.Pull: %w", err)
+		}
+		return page, nil
+	}
+
+	tokenEpoch, seqText, _ := strings.Cut(token, "-")
+	seq, err := strconv.ParseInt(seqText, 10, 64)
+	if err != nil || tokenEpoch != epoch {
+		return nil, fmt.Errorf("service.Pull: %w", domain.ErrChangesExpired)
+	}
+	changes, err := s.changes.Since(ctx, seq, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("service.Pull: %w", err)
+	}
+
+	page := &domain.SyncPage{Upserts: []*domain.Bookmark{}, Tombstones: []domain.Tombstone{}}
+	if len(changes) > limit {
+		changes, page.More = changes[:limit], true
+	}
+	for _, c := range changes {
+		seq = c.Seq
+		if c.Deleted {
+			page.Tombstones = append(page.Tombstones, domain.Tombstone{ID: c.BookmarkID, DeletedAt: c.At})
+			continue
+		}
+		// The bookmark as it is now, which may be newer than the change.
+		b, err := s.repo.GetByID(ctx, c.BookmarkID)
+		switch {
+		case errors.Is(err, domain.ErrBookmarkNotFound):
+			// Deleted since; the deletion is logged shortly, if not yet.
+			page.Tombstones = append(page.Tombstones, domain.Tombstone{ID: c.BookmarkID, DeletedAt: c.At})
+		case err != nil:
+			return nil, fmt.Errorf("service.Pull: %w", err)
+		default:
+			page.Upserts = append(page.Upserts, b)
+		}
+	}
+	page.Token = syncToken(epoch, seq)
+	return page, nil
+}
+
+// snapshot returns every bookmark, with a token from before they were read.
+// Changes made while reading are sent again by the next pull, which does no
+// harm, rather than missed.
+func (s *syncService) snapshot(ctx context.Context, epoch string) (*domain.SyncPage, error) {
+	seq, err := s.changes.Latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page := &domain.SyncPage{Token: syncToken(epoch, seq), Full: true, Upserts: []*domain.Bookmark{}, Tombstones: []domain.Tombstone{}}
+	err = s.repo.Walk(ctx, domain.BookmarkFilter{}, func(b *domain.Bookmark) error {
+		page.Upserts = append(page.Upserts, b)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func syncToken(epoch string, seq int64) string {
+	return epoch + "-" + strconv.FormatInt(seq, 10)
+}
+
+func (s *syncService) Push(ctx context.Context, changes []domain.SyncChange) ([]domain.SyncResult, error) {
+	if len(changes) > s.opts.MaxPush {
+		return nil, fmt.Errorf("service.Push: %w: at most %d changes may be pushed at once", domain.ErrInvalidSync, s.opts.MaxPush)
+	}
+
+	s.pushMu.Lock()
+	defer s.pushMu.Unlock()
+
+	results := make([]domain.SyncResult, 0, len(changes))
+	for _, c := range changes {
+		results = append(results, s.apply(ctx, c))
+	}
+	return results, nil
+}
+
+// apply stores one change unless someone else changed the bookmark since
+// the client read it. A change made to any version but the stored one is a
+// conflict, whatever the clocks say; UpdatedAt only decides the time the
+// stored bookmark is given, which never goes back.
+func (s *syncService) apply(ctx context.Context, c domain.SyncChange) domain.SyncResult {
+	result := domain.SyncResult{ID: c.ID}
+	invalid := func(msg string) domain.SyncResult {
+		result.Status, result.Error = domain.SyncInvalid, msg
+		return result
+	}
+	failed := func(err error) domain.SyncResult {
+		domain.LoggerFrom(ctx).Error("Applying sync change failed", "bookmark_id", c.ID, "error", err)
+		result.Status, result.Error = domain.SyncFailed, "the change could not be stored"
+		return result
+	}
+
+	if c.UpdatedAt.IsZero() {
+		return invalid("updated_at is required")
+	}
+	if c.ID == "" {
+		if c.Deleted || c.BaseVersion != 0 {
+			return invalid("id is required")
+		}
+		c.ID = uuid.NewString()
+		result.ID = c.ID
+	} else if _, err := uuid.Parse(c.ID); err != nil {
+		return invalid("id must be a UUID")
+	}
+	// A client's clock may be ahead; a change cannot have been made later
+	// than it arrived.
+	at := c.UpdatedAt
+	if now := time.Now(); at.After(now) {
+		at = now
+	}
+
+	current, err := s.repo.GetByID(ctx, c.ID)
+	if errors.Is(err, domain.ErrBookmarkNotFound) {
+		switch {
+		case c.Deleted:
+			result.Status = domain.SyncApplied
+		case c.BaseVersion != 0:
+			// Deleted here while the client was changing it.
+			result.Status = domain.SyncConflict
+		default:
+			b := &domain.Bookmark{
+				ID:          c.ID,
+				URL:         c.URL,
+				Title:       c.Title,
+				Description: c.Description,
+				Tags:        c.Tags,
+				CreatedAt:   at,
+				UpdatedAt:   at,
+			}
+			if err := b.Validate(); err != nil {
+				return invalid(err.Error())
+			}
+			// The ID may be in the trash, from which the client brings it
+			// back as it has it.
+			restored, err := s.restore(ctx, b)
+			if errors.Is(err, domain.ErrBookmarkNotFound) {
+				err = s.bookmarks.Create(ctx, b)
+			} else if err == nil {
+				b = restored
+			}
+			if err != nil {
+				return failed(err)
+			}
+			result.Status, result.Bookmark = domain.SyncApplied, b
+		}
+		return result
+	}
+	if err != nil {
+		return failed(err)
+	}
+
+	if !c.Deleted && syncUnchanged(current, c) {
+		// Typically a push sent again after its response was lost.
+		result.Status, result.Bookmark = domain.SyncApplied, current
+		return result
+	}
+	conflict := func() domain.SyncResult {
+		result.Status, result.Bookmark = domain.SyncConflict, current
+		return result
+	}
+	// lost reports the bookmark as it is now, after another write got to it
+	// since it was read.
+	lost := func() domain.SyncResult {
+		current, err = s.repo.GetByID(ctx, c.ID)
+		if errors.Is(err, domain.ErrBookmarkNotFound) {
+			current, err = nil, nil
+		}
+		if err != nil {
+			return failed(err)
+		}
+		return conflict()
+	}
+	if c.BaseVersion != current.Version {
+		return conflict()
+	}
+
+	if c.Deleted {
+		err := s.repo.CompareAndDelete(ctx, c.ID, current.Version)
+		if errors.Is(err, domain.ErrVersionConflict) {
+			return lost()
+		}
+		if err != nil && !errors.Is(err, domain.ErrBookmarkNotFound) {
+			return failed(err)
+		}
+		result.Status = domain.SyncApplied
+		return result
+	}
+	b := current.Clone()
+	b.URL, b.Title, b.Description, b.Tags = c.URL, c.Title, c.Description, c.Tags
+	if at.After(b.UpdatedAt) {
+		b.UpdatedAt = at
+	}
+	if err := b.Validate(); err != nil {
+		return invalid(err.Error())
+	}
+	err = s.repo.CompareAndUpdate(ctx, b, current.Version)
+	if errors.Is(err, domain.ErrVersionConflict) || errors.Is(err, domain.ErrBookmarkNotFound) {
+		// Written, or deleted, since it was read.
+		return lost()
+	}
+	if err != nil {
+		return failed(err)
+	}
+	result.Status, result.Bookmark = domain.SyncApplied, b
+	return result
+}
+
+// restore takes b out of the trash with the fields a client gave it.
+func (s *syncService) restore(ctx context.Context, b *domain.Bookmark) (*domain.Bookmark, error) {
+	restored, err := s.bookmarks.Restore(ctx, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	restored = restored.Clone()
+	restored.URL, restored.Title, restored.Description, restored.Tags = b.URL, b.Title, b.Description, b.Tags
+	restored.UpdatedAt = b.UpdatedAt
+	if err := s.repo.Update(ctx, restored); err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// syncUnchanged reports whether applying c would leave b as it is.
+func syncUnchanged(b *domain.Bookmark, c domain.SyncChange) bool {
+	return b.URL == c.URL && b.Title == c.Title && b.Description == c.Description && slices.Equal(b.Tags, c.Tags)
+}
+
+func (s *syncService) Handle(ctx context.Context, e domain.Event) error {
+	var c domain.Change
+	switch e := e.(type) {
+	case domain.BookmarkCreated, domain.BookmarkUpdated, domain.BookmarkRestored:
+		c = domain.Change{BookmarkID: e.AggregateID(), At: e.OccurredAt()}
+	case domain.BookmarkDeleted:
+		c = domain.Change{BookmarkID: e.AggregateID(), Deleted: true, At: e.OccurredAt()}
+	default:
+		// Tag changes are part of the update that made them.
+		return nil
+	}
+	if err := s.changes.Append(ctx, c); err != nil {
+		return fmt.Errorf("service.Handle: %w", err)
+	}
+	return nil
+}
+
+func (s *syncService) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.opts.PruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		dropped, err := s.changes.Prune(ctx, time.Now().Add(-s.opts.TombstoneTTL))
+		if err != nil && ctx.Err() == nil {
+			domain.LoggerFrom(ctx).Error("Pruning sync change log failed", "error", err)
+			continue
+		}
+		if dropped > 0 {
+			domain.LoggerFrom(ctx).Info("Pruned sync deletions", "deletions", dropped)
+		}
+	}
+}
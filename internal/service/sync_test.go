@@ -0,0 +1,341 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// handlerPublisher hands events straight to a handler, as a synchronous bus
+// subscriber would get them.
+type handlerPublisher struct {
+	handle domain.EventHandler
+}
+
+func (p *handlerPublisher) Publish(ctx context.Context, events ...domain.Event) error {
+	var errs []error
+	for _, e := range events {
+		errs = append(errs, p.handle(ctx, e))
+	}
+	return errors.Join(errs...)
+}
+
+func newSyncTest(opts service.SyncOptions) (domain.BookmarkRepository, service.SyncService) {
+	events := &handlerPublisher{}
+	repo := withImmediateOutbox(events)
+	svc := service.NewSyncService(repo, service.NewBookmarkService(repo), persistence.NewInMemoryChangeLog(), opts)
+	events.handle = svc.Handle
+	return repo, svc
+}
+
+func syncBookmark(t *testing.T, repo domain.BookmarkRepository, title string) *domain.Bookmark {
+	t.Helper()
+	now := time.Now()
+	b := &domain.Bookmark{ID: uuid.NewString(), URL: "https://example.com/" + title, Title: title, CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, repo.Create(context.Background(), b))
+	return b
+}
+
+func upsertIDs(page *domain.SyncPage) []string {
+	var ids []string
+	for _, b := range page.Upserts {
+		ids = append(ids, b.ID)
+	}
+	return ids
+}
+
+func TestSyncService_Pull(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo, svc := newSyncTest(service.SyncOptions{})
+	kept := syncBookmark(t, repo, "Kept")
+	deleted := syncBookmark(t, repo, "Deleted")
+
+	full, err := svc.Pull(ctx, "", 0)
+	require.NoError(t, err)
+	assert.True(t, full.Full)
+	assert.ElementsMatch(t, []string{kept.ID, deleted.ID}, upsertIDs(full))
+
+	// Nothing changed yet.
+	page, err := svc.Pull(ctx, full.Token, 0)
+	require.NoError(t, err)
+	assert.False(t, page.Full)
+	assert.Empty(t, page.Upserts)
+	assert.Empty(t, page.Tombstones)
+	assert.Equal(t, full.Token, page.Token)
+
+	updated := kept.Clone()
+	updated.Title = "Kept and renamed"
+	require.NoError(t, repo.Update(ctx, updated))
+	require.NoError(t, repo.Delete(ctx, deleted.ID))
+	added := syncBookmark(t, repo, "Added")
+
+	page, err = svc.Pull(ctx, full.Token, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{kept.ID, added.ID}, upsertIDs(page))
+	assert.Equal(t, "Kept and renamed", page.Upserts[0].Title)
+	assert.Equal(t, int64(2), page.Upserts[0].Version)
+	require.Len(t, page.Tombstones, 1)
+	assert.Equal(t, deleted.ID, page.Tombstones[0].ID)
+
+	again, err := svc.Pull(ctx, page.Token, 0)
+	require.NoError(t, err)
+	assert.Empty(t, again.Upserts)
+	assert.Empty(t, again.Tombstones)
+}
+
+func TestSyncService_PullPages(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo, svc := newSyncTest(service.SyncOptions{})
+	full, err := svc.Pull(ctx, "", 0)
+	require.NoError(t, err)
+	var want []string
+	for _, title := range []string{"One", "Two", "Three"} {
+		want = append(want, syncBookmark(t, repo, title).ID)
+	}
+
+	var got []string
+	token := full.Token
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3)
+		page, err := svc.Pull(ctx, token, 2)
+		require.NoError(t, err)
+		got = append(got, upsertIDs(page)...)
+		token = page.Token
+		if !page.More {
+			break
+		}
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestSyncService_PullExpired(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, svc := newSyncTest(service.SyncOptions{})
+
+	for _, token := range []string{"garbage", "otherepoch-0"} {
+		_, err := svc.Pull(ctx, token, 0)
+		assert.ErrorIs(t, err, domain.ErrChangesExpired, token)
+	}
+}
+
+func TestSyncService_Push(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo, svc := newSyncTest(service.SyncOptions{MaxPush: 10})
+	existing := syncBookmark(t, repo, "Existing")
+	later := time.Now().Add(time.Minute)
+	earlier := existing.UpdatedAt.Add(-time.Minute)
+
+	// Someone else renames it, making version 2.
+	renamed := existing.Clone()
+	renamed.Title = "Renamed here"
+	renamed.UpdatedAt = time.Now()
+	require.NoError(t, repo.Update(ctx, renamed))
+
+	newID := uuid.NewString()
+	results, err := svc.Push(ctx, []domain.SyncChange{
+		{ID: newID, UpdatedAt: earlier, URL: "https://example.com/new", Title: "Made offline"},
+		{ID: existing.ID, BaseVersion: 1, UpdatedAt: earlier, URL: existing.URL, Title: "Stale edit"},
+		{ID: uuid.NewString(), UpdatedAt: earlier, URL: "not a url", Title: "Invalid"},
+		{ID: newID, UpdatedAt: earlier, URL: "https://example.com/new", Title: "Made offline"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	assert.Equal(t, domain.SyncApplied, results[0].Status)
+	assert.Equal(t, int64(1), results[0].Bookmark.Version)
+	assert.Equal(t, domain.SyncConflict, results[1].Status)
+	assert.Equal(t, "Renamed here", results[1].Bookmark.Title)
+	assert.Equal(t, domain.SyncInvalid, results[2].Status)
+	assert.NotEmpty(t, results[2].Error)
+	// Pushing the same change twice is not a conflict.
+	assert.Equal(t, domain.SyncApplied, results[3].Status)
+
+	// A change made to an old version conflicts even when the client's
+	// clock says it was made later; one made to the current version
+	// applies, whenever it was made.
+	results, err = svc.Push(ctx, []domain.SyncChange{
+		{ID: existing.ID, BaseVersion: 1, UpdatedAt: later, URL: existing.URL, Title: "Later edit"},
+		{ID: existing.ID, BaseVersion: 2, UpdatedAt: later, URL: existing.URL, Title: "Merged edit"},
+		{ID: newID, BaseVersion: 1, UpdatedAt: earlier, Deleted: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.SyncConflict, results[0].Status)
+	assert.Equal(t, "Renamed here", results[0].Bookmark.Title)
+	assert.Equal(t, domain.SyncApplied, results[1].Status)
+	assert.Equal(t, int64(3), results[1].Bookmark.Version)
+	assert.Equal(t, domain.SyncApplied, results[2].Status)
+
+	stored, err := repo.GetByID(ctx, existing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Merged edit", stored.Title)
+	assert.False(t, stored.UpdatedAt.After(time.Now()), "UpdatedAt is not in the future")
+	_, err = repo.GetByID(ctx, newID)
+	assert.ErrorIs(t, err, domain.ErrBookmarkNotFound)
+
+	_, err = svc.Push(ctx, make([]domain.SyncChange, 11))
+	assert.ErrorIs(t, err, domain.ErrInvalidSync)
+}
+
+// racingRepository updates a bookmark right after it is read, as a request
+// made other than by pushing might.
+type racingRepository struct {
+	domain.BookmarkRepository
+	race func(b *domain.Bookmark)
+}
+
+func (r *racingRepository) GetByID(ctx context.Context, id string) (*domain.Bookmark, error) {
+	b, err := r.BookmarkRepository.GetByID(ctx, id)
+	if err == nil && r.race != nil {
+		race := r.race
+		r.race = nil
+		race(b)
+	}
+	return b, err
+}
+
+func TestSyncService_PushRacingUpdate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := &racingRepository{BookmarkRepository: persistence.NewInMemoryBookmarkRepository()}
+	svc := service.NewSyncService(repo, service.NewBookmarkService(repo), persistence.NewInMemoryChangeLog(), service.SyncOptions{})
+	b := syncBookmark(t, repo, "Raced")
+	repo.race = func(read *domain.Bookmark) {
+		renamed := read.Clone()
+		renamed.Title = "Renamed meanwhile"
+		require.NoError(t, repo.BookmarkRepository.Update(ctx, renamed))
+	}
+
+	results, err := svc.Push(ctx, []domain.SyncChange{
+		{ID: b.ID, BaseVersion: 1, UpdatedAt: time.Now(), URL: b.URL, Title: "Edited offline"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.SyncConflict, results[0].Status)
+	require.NotNil(t, results[0].Bookmark)
+	assert.Equal(t, "Renamed meanwhile", results[0].Bookmark.Title)
+	stored, err := repo.GetByID(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed meanwhile", stored.Title)
+}
+
+func TestSyncService_PushRacingDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := &racingRepository{BookmarkRepository: persistence.NewInMemoryBookmarkRepository()}
+	svc := service.NewSyncService(repo, service.NewBookmarkService(repo), persistence.NewInMemoryChangeLog(), service.SyncOptions{})
+	b := syncBookmark(t, repo, "Raced")
+	repo.race = func(read *domain.Bookmark) {
+		renamed := read.Clone()
+		renamed.Title = "Renamed meanwhile"
+		require.NoError(t, repo.BookmarkRepository.Update(ctx, renamed))
+	}
+
+	results, err := svc.Push(ctx, []domain.SyncChange{
+		{ID: b.ID, BaseVersion: 1, UpdatedAt: time.Now(), Deleted: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.SyncConflict, results[0].Status)
+	require.NotNil(t, results[0].Bookmark)
+	assert.Equal(t, "Renamed meanwhile", results[0].Bookmark.Title)
+	stored, err := repo.GetByID(ctx, b.ID)
+	require.NoError(t, err, "the bookmark written meanwhile is not deleted")
+	assert.Equal(t, "Renamed meanwhile", stored.Title)
+}
+
+// recordingBookmarkService records the bookmarks created and restored
+// through it, as the decorators that enrich and archive them see them.
+type recordingBookmarkService struct {
+	service.BookmarkService
+	created, restored []string
+}
+
+func (s *recordingBookmarkService) Create(ctx context.Context, b *domain.Bookmark) error {
+	if err := s.BookmarkService.Create(ctx, b); err != nil {
+		return err
+	}
+	s.created = append(s.created, b.ID)
+	return nil
+}
+
+func (s *recordingBookmarkService) Restore(ctx context.Context, id string) (*domain.Bookmark, error) {
+	b, err := s.BookmarkService.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.restored = append(s.restored, id)
+	return b, nil
+}
+
+func TestSyncService_PushThroughBookmarkService(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := persistence.NewInMemoryBookmarkRepository()
+	bookmarks := &recordingBookmarkService{BookmarkService: service.NewBookmarkService(repo)}
+	svc := service.NewSyncService(repo, bookmarks, persistence.NewInMemoryChangeLog(), service.SyncOptions{})
+	trashed := syncBookmark(t, repo, "Trashed")
+	require.NoError(t, repo.Delete(ctx, trashed.ID))
+
+	madeAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	newID := uuid.NewString()
+	results, err := svc.Push(ctx, []domain.SyncChange{
+		{ID: newID, UpdatedAt: madeAt, URL: "https://example.com/new", Title: "Made offline"},
+		{ID: trashed.ID, UpdatedAt: madeAt, URL: trashed.URL, Title: "Brought back"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.SyncApplied, results[0].Status)
+	assert.Equal(t, domain.SyncApplied, results[1].Status)
+	assert.Equal(t, []string{newID}, bookmarks.created)
+	assert.Equal(t, []string{trashed.ID}, bookmarks.restored)
+
+	// Created with the ID and time the client gave it.
+	created, err := repo.GetByID(ctx, newID)
+	require.NoError(t, err)
+	assert.True(t, created.CreatedAt.Equal(madeAt))
+	assert.True(t, created.UpdatedAt.Equal(madeAt))
+}
+
+func TestSyncService_PushEditToDeleted(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo, svc := newSyncTest(service.SyncOptions{})
+	b := syncBookmark(t, repo, "Gone")
+	require.NoError(t, repo.Delete(ctx, b.ID))
+
+	results, err := svc.Push(ctx, []domain.SyncChange{
+		{ID: b.ID, BaseVersion: 1, UpdatedAt: time.Now(), URL: b.URL, Title: "Edited offline"},
+		{ID: b.ID, BaseVersion: 1, UpdatedAt: time.Now(), Deleted: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.SyncConflict, results[0].Status)
+	assert.Nil(t, results[0].Bookmark)
+	assert.Equal(t, domain.SyncApplied, results[1].Status)
+
+	// Pushed again as new, it comes back out of the trash.
+	results, err = svc.Push(ctx, []domain.SyncChange{
+		{ID: b.ID, UpdatedAt: time.Now(), URL: b.URL, Title: "Brought back"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.SyncApplied, results[0].Status)
+	stored, err := repo.GetByID(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Brought back", stored.Title)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/etsrc/goprod/internal/service"
+
+// startSpan starts a span for a service method, named like
+// "BookmarkService.Create".
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan ends span, marking it failed when err is one the caller did not
+// cause. Bookmarks that are not found or not valid are the caller's doing.
+func endSpan(span trace.Span, err error) {
+	switch {
+	case err == nil,
+		errors.Is(err, domain.ErrBookmarkNotFound),
+		errors.Is(err, domain.ErrInvalidURL),
+		errors.Is(err, domain.ErrTitleTooShort):
+	default:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
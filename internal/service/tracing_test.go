@@ -0,0 +1,46 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/etsrc/goprod/internal/domain"
+	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestBookmarkService_Tracing installs a global tracer provider, so it does
+// not run in parallel. Spans of other tests are told apart by their trace.
+func TestBookmarkService_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	svc := service.NewBookmarkService(persistence.NewInMemoryBookmarkRepository())
+	ctx, root := otel.Tracer("test").Start(context.Background(), "request")
+	b := domain.NewBookmark("https://example.com", "Example Domain", "", nil)
+	require.NoError(t, svc.Create(ctx, b))
+	_, err := svc.GetByID(ctx, "missing")
+	require.ErrorIs(t, err, domain.ErrBookmarkNotFound)
+	root.End()
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, s := range recorder.Ended() {
+		if s.SpanContext().TraceID() == root.SpanContext().TraceID() {
+			spans[s.Name()] = s
+		}
+	}
+	require.Contains(t, spans, "BookmarkService.Create")
+	require.Contains(t, spans, "InMemoryBookmarkRepository.Create")
+	require.Contains(t, spans, "BookmarkService.GetByID")
+
+	assert.Equal(t, root.SpanContext().SpanID(), spans["BookmarkService.Create"].Parent().SpanID())
+	assert.Equal(t, spans["BookmarkService.Create"].SpanContext().SpanID(), spans["InMemoryBookmarkRepository.Create"].Parent().SpanID(),
+		"repository spans nest under the service's")
+	assert.Equal(t, codes.Unset, spans["BookmarkService.GetByID"].Status().Code, "a missing bookmark is not a failure")
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+)
+
+// TrashService empties the trash of bookmarks deleted longer ago than the
+// retention period.
+type TrashService interface {
+	// PurgeExpired purges the expired bookmarks and reports how many.
+	PurgeExpired(ctx context.Context) (int, error)
+	// Run calls PurgeExpired every Interval until ctx is done.
+	Run(ctx context.Context) error
+}
+
+type TrashOptions struct {
+	Retention time.Duration // how long a deleted bookmark can be restored
+	Interval  time.Duration // time between two purges
+}
+
+type trashService struct {
+	bookmarks BookmarkService
+	opts      TrashOptions
+}
+
+// NewTrashService purges through bookmarks, so that whatever its decorators
+// keep for a bookmark, such as attachments, is removed with it.
+func NewTrashService(bookmarks BookmarkService, opts TrashOptions) TrashService {
+	if opts.Retention <= 0 {
+		opts.Retention = 30 * 24 * time.Hour
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = time.Hour
+	}
+	return &trashService{bookmarks: bookmarks, opts: opts}
+}
+
+func (s *trashService) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		purged, err := s.PurgeExpired(ctx)
+		if err != nil && ctx.Err() == nil {
+			domain.LoggerFrom(ctx).Error("Purging trash failed", "error", err)
+		}
+		if purged > 0 {
+			domain.LoggerFrom(ctx).Info("Purged trash", "bookmarks", purged)
+		}
+	}
+}
+
+// PurgeExpired carries on past a bookmark it fails to purge, which is tried
+// again next time.
+func (s *trashService) PurgeExpired(ctx context.Context) (int, error) {
+	trash, err := s.bookmarks.Trash(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("service.PurgeExpired: %w", err)
+	}
+
+	cutoff := time.Now().Add(-s.opts.Retention)
+	purged := 0
+	var errs []error
+	for _, b := range trash {
+		if ctx.Err() != nil {
+			break
+		}
+		if !b.DeletedAt.Before(cutoff) {
+			continue
+		}
+		err := s.bookmarks.Purge(ctx, b.ID)
+		switch {
+		case err == nil:
+			purged++
+		case !errors.Is(err, domain.ErrBookmarkNotFound):
+			// Not found means restored or purged since the trash was read.
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return purged, fmt.Errorf("service.PurgeExpired: %w", err)
+	}
+	return purged, nil
+}
@@ -0,0 +1,70 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	persistence "github.com/etsrc/goprod/internal/infra/persistence/inmem"
+	"github.com/etsrc/goprod/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookmarkService_Trash(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := service.NewBookmarkService(persistence.NewInMemoryBookmarkRepository())
+	b := &domain.Bookmark{URL: "https://go.dev", Title: "The Go language"}
+	require.NoError(t, svc.Create(ctx, b))
+
+	require.NoError(t, svc.Delete(ctx, b.ID))
+	_, err := svc.GetByID(ctx, b.ID)
+	assert.ErrorIs(t, err, domain.ErrBookmarkNotFound)
+	list, err := svc.List(ctx, domain.BookmarkFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, list)
+	trash, err := svc.Trash(ctx)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	assert.NotNil(t, trash[0].DeletedAt)
+
+	restored, err := svc.Restore(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	_, err = svc.GetByID(ctx, b.ID)
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, svc.Purge(ctx, b.ID), domain.ErrBookmarkNotFound, "only bookmarks in the trash can be purged")
+	require.NoError(t, svc.Delete(ctx, b.ID))
+	require.NoError(t, svc.Purge(ctx, b.ID))
+	_, err = svc.Restore(ctx, b.ID)
+	assert.ErrorIs(t, err, domain.ErrBookmarkNotFound)
+}
+
+func TestTrashService_PurgeExpired(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	bookmarks := service.NewBookmarkService(persistence.NewInMemoryBookmarkRepository())
+	for _, title := range []string{"Expired", "Recent", "Live"} {
+		require.NoError(t, bookmarks.Create(ctx, &domain.Bookmark{URL: "https://example.com/" + title, Title: title}))
+	}
+	list, err := bookmarks.List(ctx, domain.BookmarkFilter{})
+	require.NoError(t, err)
+	require.NoError(t, bookmarks.Delete(ctx, list[0].ID))
+	time.Sleep(200 * time.Millisecond)
+	require.NoError(t, bookmarks.Delete(ctx, list[1].ID))
+
+	trash := service.NewTrashService(bookmarks, service.TrashOptions{Retention: 100 * time.Millisecond})
+	purged, err := trash.PurgeExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	left, err := bookmarks.Trash(ctx)
+	require.NoError(t, err)
+	require.Len(t, left, 1)
+	assert.Equal(t, "Recent", left[0].Title)
+}
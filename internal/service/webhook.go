@@ -0,0 +1,464 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/etsrc/goprod/internal/domain"
+	"github.com/google/uuid"
+)
+
+// WebhookService manages webhook subscriptions and sends them events.
+type WebhookService interface {
+	// Create validates w and stores it active. A secret is generated when w
+	// has none; either way w.Secret holds it afterwards.
+	Create(ctx context.Context, w *domain.Webhook) error
+	GetByID(ctx context.Context, id string) (*domain.Webhook, error)
+	List(ctx context.Context) ([]*domain.Webhook, error)
+	// Update replaces a webhook's URL, event filter and active flag, and its
+	// secret when w has one. Activating a disabled webhook clears its
+	// failures.
+	Update(ctx context.Context, w *domain.Webhook) (*domain.Webhook, error)
+	// Delete removes a webhook and its deliveries.
+	Delete(ctx context.Context, id string) error
+
+	// Deliveries returns a webhook's recent deliveries, newest first.
+	Deliveries(ctx context.Context, webhookID string) ([]*domain.WebhookDelivery, error)
+	Delivery(ctx context.Context, webhookID, id string) (*domain.WebhookDelivery, error)
+	// Redeliver sends a delivery's payload again, as a new delivery.
+	Redeliver(ctx context.Context, webhookID, id string) (*domain.WebhookDelivery, error)
+
+	// Handle queues a delivery of e to every active webhook that accepts
+	// it. It is meant to be subscribed to the event bus.
+	Handle(ctx context.Context, e domain.Event) error
+	// Run sends due deliveries, and retries failed ones, until ctx is done.
+	Run(ctx context.Context) error
+}
+
+type WebhookOptions struct {
+	Workers     int // deliveries sent concurrently
+	MaxAttempts int // attempts per delivery before it fails
+	// RetryDelay is the wait after a delivery's first failed attempt. It
+	// doubles after each further failure, up to MaxRetryDelay.
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+	// DisableAfter is the number of consecutive failed attempts, across
+	// deliveries, after which a webhook is disabled.
+	DisableAfter int
+	History      int           // deliveries kept per webhook
+	PollInterval time.Duration // time between two looks for due retries
+}
+
+// minSecretLength keeps secrets long enough that signatures cannot be
+// forged by guessing them.
+const minSecretLength = 16
+
+type webhookService struct {
+	webhooks   domain.WebhookRepository
+	deliveries domain.WebhookDeliveryRepository
+	sender     domain.WebhookSender
+	opts       WebhookOptions
+	wake       chan struct{}
+
+	// mu serializes changes to webhooks' failure counts, which concurrent
+	// deliveries update.
+	mu sync.Mutex
+}
+
+func NewWebhookService(webhooks domain.WebhookRepository, deliveries domain.WebhookDeliveryRepository, sender domain.WebhookSender, opts WebhookOptions) WebhookService {
+	if opts.Workers <= 0 {
+		opts.Workers = 4
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 8
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = 30 * time.Second
+	}
+	if opts.MaxRetryDelay <= 0 {
+		opts.MaxRetryDelay = time.Hour
+	}
+	if opts.DisableAfter <= 0 {
+		opts.DisableAfter = 20
+	}
+	if opts.History <= 0 {
+		opts.History = 100
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+	return &webhookService{
+		webhooks:   webhooks,
+		deliveries: deliveries,
+		sender:     sender,
+		opts:       opts,
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+func (s *webhookService) Create(ctx context.Context, w *domain.Webhook) error {
+	if w.Secret == "" {
+		w.Secret = rand.Text()
+	}
+	if err := validateWebhook(w); err != nil {
+		return fmt.Errorf("service.Create: %w", err)
+	}
+
+	now := time.Now()
+	w.ID = uuid.NewString()
+	w.Active = true
+	w.Failures = 0
+	w.DisabledAt = nil
+	w.DisabledReason = ""
+	w.CreatedAt = now
+	w.UpdatedAt = now
+	if err := s.webhooks.Create(ctx, w); err != nil {
+		return fmt.Errorf("service.Create: %w", err)
+	}
+	return nil
+}
+
+func (s *webhookService) GetByID(ctx context.Context, id string) (*domain.Webhook, error) {
+	w, err := s.webhooks.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetByID: %w", err)
+	}
+	return w, nil
+}
+
+func (s *webhookService) List(ctx context.Context) ([]*domain.Webhook, error) {
+	list, err := s.webhooks.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("service.List: %w", err)
+	}
+	return list, nil
+}
+
+func (s *webhookService) Update(ctx context.Context, w *domain.Webhook) (*domain.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.webhooks.GetByID(ctx, w.ID)
+	if err != nil {
+		return nil, fmt.Errorf("service.Update: %w", err)
+	}
+	current.URL = w.URL
+	current.Events = w.Events
+	if w.Secret != "" {
+		current.Secret = w.Secret
+	}
+	if w.Active && !current.Active {
+		current.Failures = 0
+		current.DisabledAt = nil
+		current.DisabledReason = ""
+	}
+	current.Active = w.Active
+	if err := validateWebhook(current); err != nil {
+		return nil, fmt.Errorf("service.Update: %w", err)
+	}
+
+	current.UpdatedAt = time.Now()
+	if err := s.webhooks.Update(ctx, current); err != nil {
+		return nil, fmt.Errorf("service.Update: %w", err)
+	}
+	return current, nil
+}
+
+func (s *webhookService) Delete(ctx context.Context, id string) error {
+	if err := s.webhooks.Delete(ctx, id); err != nil {
+		return fmt.Errorf("service.Delete: %w", err)
+	}
+	if err := s.deliveries.DeleteByWebhook(ctx, id); err != nil {
+		return fmt.Errorf("service.Delete: %w", err)
+	}
+	return nil
+}
+
+func validateWebhook(w *domain.Webhook) error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", domain.ErrInvalidWebhook)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: url must not contain credentials", domain.ErrInvalidWebhook)
+	}
+	for _, pattern := range w.Events {
+		if !validEventPattern(pattern) {
+			return fmt.Errorf("%w: unknown event %q", domain.ErrInvalidWebhook, pattern)
+		}
+	}
+	if len(w.Secret) < minSecretLength {
+		return fmt.Errorf("%w: secret must be at least %d characters", domain.ErrInvalidWebhook, minSecretLength)
+	}
+	return nil
+}
+
+// validEventPattern accepts an event type, "*", or a prefix ending in "*"
+// that matches at least one event type.
+func validEventPattern(pattern string) bool {
+	prefix, wildcard := strings.CutSuffix(pattern, "*")
+	return slices.ContainsFunc(domain.EventTypes, func(t domain.EventType) bool {
+		if wildcard {
+			return strings.HasPrefix(string(t), prefix)
+		}
+		return string(t) == pattern
+	})
+}
+
+func (s *webhookService) Deliveries(ctx context.Context, webhookID string) ([]*domain.WebhookDelivery, error) {
+	if _, err := s.webhooks.GetByID(ctx, webhookID); err != nil {
+		return nil, fmt.Errorf("service.Deliveries: %w", err)
+	}
+	list, err := s.deliveries.ListByWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("service.Deliveries: %w", err)
+	}
+	return list, nil
+}
+
+func (s *webhookService) Delivery(ctx context.Context, webhookID, id string) (*domain.WebhookDelivery, error) {
+	d, err := s.deliveries.GetByID(ctx, webhookID, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.Delivery: %w", err)
+	}
+	return d, nil
+}
+
+func (s *webhookService) Redeliver(ctx context.Context, webhookID, id string) (*domain.WebhookDelivery, error) {
+	w, err := s.webhooks.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("service.Redeliver: %w", err)
+	}
+	if !w.Active {
+		return nil, fmt.Errorf("service.Redeliver: %w", domain.ErrWebhookDisabled)
+	}
+	orig, err := s.deliveries.GetByID(ctx, webhookID, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.Redeliver: %w", err)
+	}
+
+	d := newDelivery(webhookID, orig.EventID, orig.EventType, orig.Payload)
+	d.RedeliveryOf = orig.ID
+	if err := s.enqueue(ctx, d); err != nil {
+		return nil, fmt.Errorf("service.Redeliver: %w", err)
+	}
+	s.notify()
+	return d, nil
+}
+
+// webhookPayload is the body of every delivery.
+type webhookPayload struct {
+	ID         string           `json:"id"`
+	Type       domain.EventType `json:"type"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Data       domain.Event     `json:"data"`
+}
+
+func (s *webhookService) Handle(ctx context.Context, e domain.Event) error {
+	hooks, err := s.webhooks.List(ctx)
+	if err != nil {
+		return fmt.Errorf("service.Handle: %w", err)
+	}
+
+	var payload []byte
+	var errs []error
+	for _, w := range hooks {
+		if !w.Active || !w.Accepts(e.EventType()) {
+			continue
+		}
+		if payload == nil {
+			payload, err = json.Marshal(webhookPayload{ID: e.EventID(), Type: e.EventType(), OccurredAt: e.OccurredAt(), Data: e})
+			if err != nil {
+				return fmt.Errorf("service.Handle: %w", err)
+			}
+		}
+		if err := s.enqueue(ctx, newDelivery(w.ID, e.EventID(), e.EventType(), payload)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if payload != nil {
+		s.notify()
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("service.Handle: %w", err)
+	}
+	return nil
+}
+
+func newDelivery(webhookID, eventID string, t domain.EventType, payload []byte) *domain.WebhookDelivery {
+	now := time.Now()
+	return &domain.WebhookDelivery{
+		ID:            uuid.NewString(),
+		WebhookID:     webhookID,
+		EventID:       eventID,
+		EventType:     t,
+		Payload:       payload,
+		Status:        domain.DeliveryPending,
+		Attempts:      []domain.WebhookAttempt{},
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+	}
+}
+
+// enqueue stores a new delivery, dropping the oldest beyond History.
+func (s *webhookService) enqueue(ctx context.Context, d *domain.WebhookDelivery) error {
+	if err := s.deliveries.Create(ctx, d); err != nil {
+		return err
+	}
+	return s.deliveries.Trim(ctx, d.WebhookID, s.opts.History)
+}
+
+func (s *webhookService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run looks for due deliveries every PollInterval, and as soon as new ones
+// are queued. A pass sends everything due before the next one starts.
+func (s *webhookService) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.sendDue(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+func (s *webhookService) sendDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		due, err := s.deliveries.Due(ctx, time.Now(), s.opts.Workers*4)
+		if err != nil {
+			domain.LoggerFrom(ctx).Error("Loading webhook deliveries failed", "error", err)
+			return
+		}
+		if len(due) == 0 {
+			return
+		}
+
+		sem := make(chan struct{}, s.opts.Workers)
+		var wg sync.WaitGroup
+		for _, d := range due {
+			sem <- struct{}{}
+			wg.Go(func() {
+				defer func() { <-sem }()
+				if err := s.send(ctx, d); err != nil && ctx.Err() == nil {
+					domain.LoggerFrom(ctx).Error("Webhook delivery failed", "delivery_id", d.ID, "error", err)
+				}
+			})
+		}
+		wg.Wait()
+	}
+}
+
+// send makes one attempt at d and records it on d and its webhook. An
+// attempt cut short by shutdown is not recorded, so it is made again after
+// a restart.
+func (s *webhookService) send(ctx context.Context, d *domain.WebhookDelivery) error {
+	w, err := s.webhooks.GetByID(ctx, d.WebhookID)
+	if errors.Is(err, domain.ErrWebhookNotFound) {
+		return s.deliveries.DeleteByWebhook(ctx, d.WebhookID)
+	}
+	if err != nil {
+		return err
+	}
+	if !w.Active {
+		d.Status = domain.DeliveryFailed
+		d.NextAttemptAt = nil
+		return s.deliveries.Update(ctx, d)
+	}
+
+	attempt := s.sender.Send(ctx, domain.WebhookRequest{
+		URL:        w.URL,
+		Secret:     w.Secret,
+		DeliveryID: d.ID,
+		EventType:  d.EventType,
+		Payload:    d.Payload,
+	})
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	d.Attempts = append(d.Attempts, attempt)
+	switch {
+	case attempt.OK():
+		d.Status = domain.DeliverySucceeded
+		d.NextAttemptAt = nil
+	case len(d.Attempts) >= s.opts.MaxAttempts:
+		d.Status = domain.DeliveryFailed
+		d.NextAttemptAt = nil
+	default:
+		next := attempt.At.Add(s.retryDelay(len(d.Attempts)))
+		d.NextAttemptAt = &next
+	}
+	if err := s.deliveries.Update(ctx, d); err != nil {
+		return err
+	}
+	return s.recordAttempt(ctx, d.WebhookID, attempt)
+}
+
+// retryDelay is the wait after the nth failed attempt.
+func (s *webhookService) retryDelay(n int) time.Duration {
+	delay := s.opts.RetryDelay
+	for range n - 1 {
+		delay *= 2
+		if delay >= s.opts.MaxRetryDelay {
+			return s.opts.MaxRetryDelay
+		}
+	}
+	return delay
+}
+
+// recordAttempt resets a webhook's failure count after a success, and
+// disables it after DisableAfter failures in a row.
+func (s *webhookService) recordAttempt(ctx context.Context, webhookID string, attempt domain.WebhookAttempt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, err := s.webhooks.GetByID(ctx, webhookID)
+	if err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			return nil
+		}
+		return err
+	}
+	if attempt.OK() {
+		if w.Failures == 0 {
+			return nil
+		}
+		w.Failures = 0
+	} else {
+		w.Failures++
+		if w.Active && w.Failures >= s.opts.DisableAfter {
+			now := time.Now()
+			w.Active = false
+			w.DisabledAt = &now
+			w.DisabledReason = fmt.Sprintf("%d consecutive failed attempts, the last: %s", w.Failures, describeAttempt(attempt))
+			domain.LoggerFrom(ctx).Warn("Webhook disabled", "webhook_id", w.ID, "reason", w.DisabledReason)
+		}
+	}
+	w.UpdatedAt = time.Now()
+	return s.webhooks.Update(ctx, w)
+}
+
+func describeAttempt(a domain.WebhookAttempt) string {
+	if a.Error != "" {
+		return a.Error
+	}
+	return fmt.Sprintf("status %d", a.StatusCode)
+}